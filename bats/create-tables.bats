@@ -112,6 +112,32 @@ teardown() {
     [ "${#lines[@]}" -eq 6 ]
 }
 
+@test "import data from csv infers the column types and primary key" {
+    cat <<DELIM > people.csv
+name,id,age,score
+alice,1,30,1.5
+bob,2,,2
+alice,3,25,3.25
+DELIM
+    run dolt table import -c --dry-run test people.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`id\` int not null comment 'tag:1'" ]] || false
+    [[ "$output" =~ "\`age\` int comment 'tag:2'" ]] || false
+    [[ "$output" =~ "\`score\` float not null comment 'tag:3'" ]] || false
+    [[ "$output" =~ "primary key (\`id\`)" ]] || false
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "test" ]] || false
+    run dolt table import -c --pk=name test people.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "duplicate value (alice)" ]] || false
+    run dolt table import -c test people.csv
+    [ "$status" -eq 0 ]
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`id\` int not null comment 'tag:1'" ]] || false
+}
+
 @test "try to create a table with a bad csv" {
     run dolt table import -c --pk=pk test `batshelper bad.csv`
     [ "$status" -eq 1 ]
@@ -279,7 +305,7 @@ teardown() {
     [ "$status" -eq 0 ]
     [[ "$output" =~ "CREATE TABLE \`test\`" ]] || false
     [[ "$output" =~ "\`pk\` int not null comment 'tag:0'" ]] || false
-    [[ "$output" =~ "\`c1\` varchar(1024) comment 'tag:1'" ]] || false
+    [[ "$output" =~ "\`c1\` longtext comment 'tag:1'" ]] || false
     [[ "$output" =~ "primary key (\`pk\`)" ]] || false
 }
 
@@ -336,3 +362,16 @@ teardown() {
     [ "${lines[0]}" = "diff --dolt a/test b/test" ]
     [ "${lines[1]}" = "added table" ]
 }
+
+@test "import a table from a sql dump" {
+    run dolt table import -c test `batshelper 1pk5col-ints.sql`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt schema show test
+    [[ "$output" =~ "\`pk\` int not null comment 'tag:0'" ]] || false
+    run dolt sql -q "select * from test"
+    [[ "$output" =~ "| 0  | 1  | 2  | 3  | 4  | 5  |" ]] || false
+    run dolt table import -c other `batshelper 1pk5col-ints.sql`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no CREATE TABLE statement" ]] || false
+}
@@ -1,71 +1,120 @@
-#!/usr/bin/env bats
-
-setup() {
-    load $BATS_TEST_DIRNAME/helper/common.bash
-    export PATH=$PATH:~/go/bin
-    export NOMS_VERSION_NEXT=1
-    cd $BATS_TMPDIR
-    mkdir "dolt-repo-$$"
-    cd "dolt-repo-$$"
-    dolt init
-}
-
-teardown() {
-    rm -rf "$BATS_TMPDIR/dolt-repo-$$"
-}
-
-@test "update table using csv" {
-    run dolt table create -s `batshelper 1pk5col-ints.schema` test
-    [ "$status" -eq 0 ]
-    run dolt table import -u test `batshelper 1pk5col-ints.csv`
-    [ "$status" -eq 0 ]
-    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
-    [[ "$output" =~ "Import completed successfully." ]] || false
-}
-
-@test "update table using schema with csv" {
-    run dolt table create -s `batshelper 1pk5col-ints.schema` test
-    [ "$status" -eq 0 ]
-    run dolt table import -u -s `batshelper 1pk5col-ints.schema` test `batshelper 1pk5col-ints.csv`
-    [ "$status" -eq 1 ]
-    [[ "$output" =~ "schema is not supported for update operations" ]] || false
-}
-
-@test "update table using csv with newlines" {
-    skip "We currently fail on CSV imports with newlines"
-    run dolt table create -s `batshelper 1pk5col-strings.schema` test
-    [ "$status" -eq 0 ]
-    run dolt table import -u test `batshelper 1pk5col-strings-newlines.csv`
-    [ "$status" -eq 0 ]
-}
-
-@test "update table using json" {
-    run dolt table create -s `batshelper employees-sch.json` employees
-    [ "$status" -eq 0 ]
-    run dolt table import -u employees `batshelper employees-tbl.json`
-    [ "$status" -eq 0 ]
-    [[ "$output" =~ "Rows Processed: 3, Additions: 3, Modifications: 0, Had No Effect: 0" ]] || false
-    [[ "$output" =~ "Import completed successfully." ]] || false
-}
-
-@test "update table using wrong json" {
-    run dolt table create -s `batshelper employees-sch-wrong.json` employees
-    [ "$status" -eq 0 ]
-    run dolt table import -u employees `batshelper employees-tbl.json`
-    [ "$status" -eq 0 ]
-    [[ "$output" =~ "Rows Processed: 0, Additions: 0, Modifications: 0, Had No Effect: 0" ]] || false
-}
-
-@test "update table using schema with json" {
-    run dolt table create -s `batshelper employees-sch-wrong.json` employees
-    [ "$status" -eq 0 ]
-    run dolt table import -u -s `batshelper employees-sch.json` employees `batshelper employees-tbl.json`
-    [ "$status" -eq 1 ]
-    [[ "$output" =~ "schema is not supported for update operations" ]] || false
-}
-
-@test "update table with json when table does not exist" {
-    run dolt table import -u employees `batshelper employees-tbl.json`
-    [ "$status" -eq 1 ]
-    [[ "$output" =~ "The following table could not be found:" ]] || false
-}
\ No newline at end of file
+#!/usr/bin/env bats
+
+setup() {
+    load $BATS_TEST_DIRNAME/helper/common.bash
+    export PATH=$PATH:~/go/bin
+    export NOMS_VERSION_NEXT=1
+    cd $BATS_TMPDIR
+    mkdir "dolt-repo-$$"
+    cd "dolt-repo-$$"
+    dolt init
+}
+
+teardown() {
+    rm -rf "$BATS_TMPDIR/dolt-repo-$$"
+}
+
+@test "update table using csv" {
+    run dolt table create -s `batshelper 1pk5col-ints.schema` test
+    [ "$status" -eq 0 ]
+    run dolt table import -u test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+}
+
+@test "update table using schema with csv" {
+    run dolt table create -s `batshelper 1pk5col-ints.schema` test
+    [ "$status" -eq 0 ]
+    run dolt table import -u -s `batshelper 1pk5col-ints.schema` test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "schema is not supported for update operations" ]] || false
+}
+
+@test "update table using csv with newlines" {
+    skip "We currently fail on CSV imports with newlines"
+    run dolt table create -s `batshelper 1pk5col-strings.schema` test
+    [ "$status" -eq 0 ]
+    run dolt table import -u test `batshelper 1pk5col-strings-newlines.csv`
+    [ "$status" -eq 0 ]
+}
+
+@test "update table using json" {
+    run dolt table create -s `batshelper employees-sch.json` employees
+    [ "$status" -eq 0 ]
+    run dolt table import -u employees `batshelper employees-tbl.json`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 3, Modifications: 0, Had No Effect: 0" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+}
+
+@test "update table using wrong json" {
+    run dolt table create -s `batshelper employees-sch-wrong.json` employees
+    [ "$status" -eq 0 ]
+    run dolt table import -u employees `batshelper employees-tbl.json`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 0, Additions: 0, Modifications: 0, Had No Effect: 0" ]] || false
+}
+
+@test "update table using schema with json" {
+    run dolt table create -s `batshelper employees-sch-wrong.json` employees
+    [ "$status" -eq 0 ]
+    run dolt table import -u -s `batshelper employees-sch.json` employees `batshelper employees-tbl.json`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "schema is not supported for update operations" ]] || false
+}
+
+@test "update table with json when table does not exist" {
+    run dolt table import -u employees `batshelper employees-tbl.json`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "The following table could not be found:" ]] || false
+}
+@test "replace table using csv" {
+    dolt table create -s `batshelper 1pk5col-ints.schema` test
+    dolt sql -q "insert into test (pk, c1, c2, c3, c4, c5) values (1, 1, 2, 3, 4, 5), (2, 2, 2, 2, 2, 2), (3, 3, 3, 3, 3, 3)"
+    dolt add test
+    dolt commit -m "added rows"
+    run dolt table import -r test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 1, Modifications: 0, Had No Effect: 1, Deletions: 2" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "+  | 0" ]] || false
+    [[ "$output" =~ "-  | 2" ]] || false
+    [[ "$output" =~ "-  | 3" ]] || false
+    [[ ! "$output" =~ "| 1  | 1" ]] || false
+    run dolt table import --replace test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 0, Modifications: 0, Had No Effect: 2" ]] || false
+}
+
+@test "replace table using schema with csv" {
+    dolt table create -s `batshelper 1pk5col-ints.schema` test
+    run dolt table import -r -s `batshelper 1pk5col-ints.schema` test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "schema is not supported for replace operations" ]] || false
+    run dolt table import -r -u test `batshelper 1pk5col-ints.csv`
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Only one of '-c', '-u' and '-r' may be given." ]] || false
+}
+
+@test "update table using json lines" {
+    dolt sql -q "create table people (id bigint primary key, name varchar(100), city varchar(100))"
+    printf '{"id": 1, "name": "Bill", "address": {"city": "Seattle"}}\n{"id": 2, "name": "Rob"}\n' > people.jsonl
+    echo '{"id": "id", "name": "name", "address.city": "city"}' > map.json
+    run dolt table import -u -m map.json people people.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
+    run dolt sql -q "select city from people where id = 1"
+    [[ "$output" =~ "Seattle" ]] || false
+    run bash -c "printf '{\"id\": 3, \"name\": \"John\"}\n[1]\n' | dolt table import -u people --file-type jsonl"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "row 2 is not a JSON object" ]] || false
+    run bash -c "printf '{\"id\": 3, \"name\": \"John\"}\n[1]\n' | dolt table import -u --continue people --file-type jsonl"
+    [ "$status" -eq 0 ]
+    run dolt table export people --file-type jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"city":"Seattle","id":1,"name":"Bill"}' ]] || false
+    [[ "$output" =~ '{"id":3,"name":"John"}' ]] || false
+}
@@ -0,0 +1,198 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var blameShortDesc = "Show what revision and author last modified each row of a table"
+var blameLongDesc = "Annotates each row in the given table with information from the commit which last modified the " +
+	"row. Rows are identified by their primary key, and are annotated with the commit's hash, author, date and " +
+	"message.\n" +
+	"\n" +
+	"History is followed through the first parent of each commit, so a row changed on a branch that was merged in is " +
+	"attributed to the merge commit. A row that was deleted and inserted again is attributed to the commit that " +
+	"inserted it again. If a commit is given, the table is annotated as of that commit rather than HEAD."
+var blameSynopsis = []string{
+	"[<commit>] <table>",
+}
+
+var blameColNames = []string{"commit", "author", "date", "message"}
+
+func Blame(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp["table"] = "The table to annotate."
+	help, usage := cli.HelpAndUsagePrinters(commandStr, blameShortDesc, blameLongDesc, blameSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 || apr.NArg() > 2 {
+		usage()
+		return 1
+	}
+
+	cSpecStr, tblName := "HEAD", apr.Arg(0)
+	if apr.NArg() == 2 {
+		cSpecStr, tblName = apr.Arg(0), apr.Arg(1)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr, dEnv.RepoState.CWBHeadRef().String())
+
+	if verr == nil {
+		verr = printBlame(ctx, dEnv, cm, tblName)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func printBlame(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, tblName string) errhand.VerboseError {
+	blame, err := actions.BlameTable(ctx, dEnv.DoltDB, cm, tblName)
+
+	if err == doltdb.ErrTableNotFound {
+		return errhand.BuildDError("error: unknown table '%s'", tblName).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to blame table '%s'", tblName).AddCause(err).Build()
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	tbl, _, err := root.GetTable(ctx, tblName)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get table '%s'", tblName).AddCause(err).Build()
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get schema of table '%s'", tblName).AddCause(err).Build()
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get rows of table '%s'", tblName).AddCause(err).Build()
+	}
+
+	pkCols := sch.GetPKCols().GetColumns()
+	var colNames []string
+	for _, col := range pkCols {
+		colNames = append(colNames, col.Name)
+	}
+
+	_, untypedSch := untyped.NewUntypedSchema(append(colNames, blameColNames...)...)
+	nbf := rows.Format()
+
+	rowChannel := make(chan row.Row)
+	p := pipeline.NewPartialPipeline(pipeline.InFuncForChannel(rowChannel))
+
+	var iterErr error
+	go func() {
+		defer close(rowChannel)
+		iterErr = rows.IterAll(ctx, func(key, value types.Value) error {
+			r, err := blameRow(nbf, sch, untypedSch, pkCols, key, value, blame)
+
+			if err != nil {
+				return err
+			}
+
+			rowChannel <- r
+			return nil
+		})
+	}()
+
+	err = runPrintingPipeline(ctx, nbf, p, untypedSch)
+
+	if err == nil {
+		err = iterErr
+	}
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to print blame").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// blameRow returns the row to print for the row of the table with the key and value given: the primary key columns of
+// the row followed by the commit which last modified it.
+func blameRow(nbf *types.NomsBinFormat, sch, untypedSch schema.Schema, pkCols []schema.Column, key, value types.Value, blame map[hash.Hash]*doltdb.Commit) (row.Row, error) {
+	r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	taggedVals := make(row.TaggedValues)
+	for i, col := range pkCols {
+		val, _ := r.GetColVal(col.Tag)
+		if types.IsNull(val) {
+			continue
+		}
+
+		strVal, err := doltcore.GetConvFunc(val.Kind(), types.StringKind)(val)
+
+		if err != nil {
+			return nil, err
+		}
+
+		taggedVals[uint64(i)] = strVal
+	}
+
+	h, err := key.Hash(nbf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm := blame[h]
+	cmHash, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg := strings.SplitN(meta.Description, "\n", 2)[0]
+	for i, val := range []string{cmHash.String(), meta.Name, meta.FormatTS(), msg} {
+		taggedVals[uint64(len(pkCols)+i)] = types.String(val)
+	}
+
+	return row.New(nbf, untypedSch, taggedVals)
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestBlame(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1), (2, 2), (3, 3)")
+	commitQueries(t, dEnv, "update 2", "update tbl set v = 20 where id = 2")
+	commitQueries(t, dEnv, "delete 3", "delete from tbl where id = 3")
+	commitQueries(t, dEnv, "insert 3 again", "insert into tbl (id, v) values (3, 3)")
+	commitQueries(t, dEnv, "insert 4", "insert into tbl (id, v) values (4, 4)")
+
+	head, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+	require.NoError(t, err)
+	blame, err := actions.BlameTable(ctx, dEnv.DoltDB, head, "tbl")
+	require.NoError(t, err)
+
+	expected := map[int64]string{1: "base", 2: "update 2", 3: "insert 3 again", 4: "insert 4"}
+	assert.Len(t, blame, len(expected))
+	for id, msg := range expected {
+		key, err := types.NewTuple(types.Format_7_18, types.Uint(0), types.Int(id))
+		require.NoError(t, err)
+		h, err := key.Hash(types.Format_7_18)
+		require.NoError(t, err)
+		require.Contains(t, blame, h)
+		meta, err := blame[h].GetCommitMeta()
+		require.NoError(t, err)
+		assert.Equal(t, msg, meta.Description, "row %d", id)
+	}
+
+	_, err = actions.BlameTable(ctx, dEnv.DoltDB, head, "unknown")
+	assert.Equal(t, doltdb.ErrTableNotFound, err)
+
+	assert.Equal(t, 0, Blame(ctx, "dolt blame", []string{"tbl"}, dEnv))
+	assert.Equal(t, 0, Blame(ctx, "dolt blame", []string{"HEAD~2", "tbl"}, dEnv))
+	assert.Equal(t, 1, Blame(ctx, "dolt blame", []string{"unknown"}, dEnv))
+}
@@ -107,7 +107,7 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	verbose := apr.Contains(verboseFlag)
 	printAll := apr.Contains(allParam)
 
-	branches, err := dEnv.DoltDB.GetRefs(ctx)
+	branches, err := dEnv.DoltDB.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.BranchRefType: {}, ref.RemoteRefType: {}})
 
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read refs from db").AddCause(err).Build(), nil)
@@ -0,0 +1,144 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	continueParam = "continue"
+)
+
+var cherryPickShortDesc = "Apply the changes introduced by an existing commit"
+var cherryPickLongDesc = "Applies the changes introduced by the named commit to the current branch, and records a new " +
+	"commit with the author and message of the original. The changes are the differences between the commit and its " +
+	"first parent, and are merged into the current branch the same way dolt merge merges tables.\n" +
+	"\n" +
+	"If applying the changes results in conflicts, the cherry-pick stops so that the conflicts can be resolved with " +
+	"<b>dolt conflicts</b>. Once the resolved tables have been staged with <b>dolt add</b>, " +
+	"<b>dolt cherry-pick --continue</b> records the commit. <b>dolt cherry-pick --abort</b> returns the branch to the " +
+	"state it was in before the cherry-pick started."
+var cherryPickSynopsis = []string{
+	"<commit>",
+	"--continue",
+	"--abort",
+}
+
+func CherryPick(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(continueParam, "", "Record the commit whose conflicts have been resolved, and continue.")
+	ap.SupportsFlag(abortParam, "", "Abort the cherry-pick and return the branch to its state before the cherry-pick started.")
+	help, usage := cli.HelpAndUsagePrinters(commandStr, cherryPickShortDesc, cherryPickLongDesc, cherryPickSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if verr := checkReplayOperation(dEnv, actions.CherryPickOperation, apr); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	var verr errhand.VerboseError
+	if apr.Contains(abortParam) {
+		verr = abortReplay(ctx, dEnv)
+	} else if apr.Contains(continueParam) {
+		tblToStats, err := actions.ContinueReplay(ctx, dEnv)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		var cm *doltdb.Commit
+		cm, verr = ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		tblToStats, err := actions.CherryPick(ctx, dEnv, cm)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+// checkReplayOperation returns an error if --continue or --abort was given for a different operation than the one in
+// progress, or if neither was given but an operation is in progress.
+func checkReplayOperation(dEnv *env.DoltEnv, operation string, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.Contains(continueParam) || apr.Contains(abortParam) {
+		if !dEnv.IsReplayActive() || dEnv.RepoState.Replay.Operation != operation {
+			return errhand.BuildDError("fatal: no %s in progress", operation).Build()
+		}
+	} else if dEnv.IsReplayActive() {
+		return errhand.BuildDError("error: a %s is in progress", dEnv.RepoState.Replay.Operation).
+			AddDetails("hint: use 'dolt %[1]s --continue' or 'dolt %[1]s --abort'", dEnv.RepoState.Replay.Operation).
+			Build()
+	}
+
+	return nil
+}
+
+func abortReplay(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	err := actions.AbortReplay(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to restore the branch").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func handleReplayErr(dEnv *env.DoltEnv, tblToStats map[string]*merge.MergeStats, err error) errhand.VerboseError {
+	switch {
+	case err == nil:
+		return nil
+	case err == actions.ErrReplayConflicts:
+		printConflicts(tblToStats)
+		operation := dEnv.RepoState.Replay.Operation
+		return errhand.BuildDError("error: could not apply %s", dEnv.RepoState.Replay.Commits[0]).
+			AddDetails("hint: after resolving the conflicts, mark the corrected tables with 'dolt add <table>'").
+			AddDetails("hint: and run 'dolt %[1]s --continue', or run 'dolt %[1]s --abort' to give up", operation).
+			Build()
+	case err == actions.ErrMergeActive:
+		return errhand.BuildDError("error: a merge is in progress").
+			AddDetails("hint: commit the merge or abort it with 'dolt merge --abort'").Build()
+	case err == actions.ErrLocalChanges:
+		return errhand.BuildDError("error: Your local changes would be overwritten.").
+			AddDetails("Please commit your changes before you continue.").Build()
+	case err == actions.ErrUnstagedChanges:
+		return errhand.BuildDError("error: you have unstaged changes").
+			AddDetails("hint: stage them with 'dolt add <table>' before continuing").Build()
+	case err == actions.ErrNoParent:
+		return errhand.BuildDError("error: the initial commit of a repository has no parent to compare it to").Build()
+	case err == doltdb.ErrUpToDate:
+		cli.Println("Current branch is up to date.")
+		return nil
+	case actions.IsTblInConflict(err):
+		tbls := actions.GetTablesForError(err)
+		return errhand.BuildDError("error: the following tables are still in conflict: %s", strings.Join(tbls, ", ")).
+			AddDetails("hint: resolve them with 'dolt conflicts resolve' and stage them with 'dolt add <table>'").Build()
+	default:
+		return errhand.BuildDError("error: failed to replay commits").AddCause(err).Build()
+	}
+}
@@ -201,7 +201,13 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		}
 	}
 
-	return cloneAllBranchRefs(branches, srcDB, ctx, remoteName, dEnv)
+	verr := cloneAllBranchRefs(branches, srcDB, ctx, remoteName, dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	return fetchTags(ctx, remoteName, srcDB, dEnv.DoltDB)
 }
 
 func cloneAllBranchRefs(branches []ref.DoltRef, srcDB *doltdb.DoltDB, ctx context.Context, remoteName string, dEnv *env.DoltEnv) errhand.VerboseError {
@@ -26,6 +26,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	dsql "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/fwt"
@@ -110,7 +111,11 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 			tbl, _, err := root.GetTable(ctx, tblName)
 
 			if err != nil {
+				return errhand.BuildDError("error: unable to read table '%s'", tblName).AddCause(err).Build()
+			}
 
+			if err := printSchemaConflicts(ctx, tblName, tbl); err != nil {
+				return errhand.BuildDError("failed to read schema conflicts").AddCause(err).Build()
 			}
 
 			cnfRd, err := merge.NewConflictReader(ctx, tbl)
@@ -166,3 +171,31 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 
 	return nil
 }
+
+// printSchemaConflicts prints each column of the table whose changes couldn't be merged, as it is in the merge base and
+// in each commit.
+func printSchemaConflicts(ctx context.Context, tblName string, tbl *doltdb.Table) error {
+	conflicts, err := tbl.GetSchemaConflicts(ctx)
+
+	if err != nil || len(conflicts) == 0 {
+		return err
+	}
+
+	cli.Printf("Schema conflicts in %s:\n", tblName)
+	for _, cnf := range conflicts {
+		cli.Println(schemaConflictCol("base:  ", cnf.Base))
+		cli.Println(schemaConflictCol("ours:  ", cnf.Ours))
+		cli.Println(schemaConflictCol("theirs:", cnf.Theirs))
+		cli.Println()
+	}
+
+	return nil
+}
+
+func schemaConflictCol(label string, col schema.Column) string {
+	if col.Tag == schema.InvalidTag {
+		return "    " + label + " (none)"
+	}
+
+	return "    " + label + " " + dsql.FmtCol(0, 0, 0, col)
+}
@@ -0,0 +1,467 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/nullprinter"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// The choices offered when resolving a conflict interactively.
+const (
+	chooseOurs   = "o"
+	chooseTheirs = "t"
+	chooseBase   = "b"
+	chooseEdit   = "e"
+	chooseSkip   = "s"
+	chooseQuit   = "q"
+)
+
+// nullInput is entered to set a column to NULL when editing its value.
+const nullInput = "NULL"
+
+// conflictPrompter reads the user's choices for resolving conflicts.
+type conflictPrompter struct {
+	in *bufio.Reader
+}
+
+// tableConflict is a conflicted row of a table, with its values in the merge base, the working set and the commit
+// merged in. A side which doesn't have the row is nil.
+type tableConflict struct {
+	key    types.Value
+	base   row.Row
+	ours   row.Row
+	theirs row.Row
+}
+
+// resolveInteractively walks the conflicts of each of the tables given, showing each conflicted row and asking how it
+// should be resolved. Rows are written to the root given as they are resolved. Returns the updated root, the number of
+// conflicts resolved, and whether the user quit before all of the conflicts were seen.
+func resolveInteractively(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, tblNames []string, in io.Reader) (*doltdb.RootValue, int, bool, error) {
+	p := &conflictPrompter{in: bufio.NewReader(in)}
+
+	resolved := 0
+	for _, tblName := range tblNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, 0, false, err
+		} else if !ok {
+			return nil, 0, false, fmt.Errorf("table '%s' not found", tblName)
+		}
+
+		updatedTbl, n, quit, err := p.resolveTable(ctx, tblName, tbl)
+
+		if err != nil {
+			return nil, 0, false, err
+		}
+
+		resolved += n
+		if n > 0 {
+			root, err = root.PutTable(ctx, ddb, tblName, updatedTbl)
+
+			if err != nil {
+				return nil, 0, false, err
+			}
+		}
+
+		if quit {
+			return root, resolved, true, nil
+		}
+	}
+
+	return root, resolved, false, nil
+}
+
+// resolveTable prompts for the resolution of each conflict of the table given, and returns the table with the resolved
+// rows written and their conflicts removed.
+func (p *conflictPrompter) resolveTable(ctx context.Context, tblName string, tbl *doltdb.Table) (*doltdb.Table, int, bool, error) {
+	conflicts, err := readConflicts(ctx, tbl)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	schemas, conflictData, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	rowEd := rowData.Edit()
+	conflictEd := conflictData.Edit()
+
+	resolved := 0
+	quit := false
+	for i, cnf := range conflicts {
+		cli.Println(fmt.Sprintf("Conflict %d of %d in table '%s':", i+1, len(conflicts), tblName))
+		printConflict(sch, cnf)
+
+		r, choice, err := p.resolveConflict(tbl.Format(), sch, cnf)
+
+		if err != nil {
+			return nil, 0, false, err
+		}
+
+		if choice == chooseQuit {
+			quit = true
+			break
+		} else if choice == chooseSkip {
+			continue
+		}
+
+		if r == nil {
+			rowEd.Remove(cnf.key)
+		} else {
+			rowEd.Set(cnf.key, r.NomsMapValue(sch))
+		}
+
+		conflictEd.Remove(cnf.key)
+		resolved++
+	}
+
+	if resolved == 0 {
+		return tbl, 0, quit, nil
+	}
+
+	rowData, err = rowEd.Map(ctx)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	conflictData, err = conflictEd.Map(ctx)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	tbl, err = tbl.UpdateRows(ctx, rowData)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	tbl, err = tbl.SetConflicts(ctx, schemas, conflictData)
+
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	return tbl, resolved, quit, nil
+}
+
+// resolveConflict asks how the conflict given should be resolved, and returns the resolved row, which is nil if the
+// row should be removed. If the user skips the conflict or quits, their choice is returned instead. If one side removed
+// the row, the choice is between the two sides. Otherwise, columns changed on only one side take the changed value, and
+// the value of each column changed on both sides is chosen separately.
+func (p *conflictPrompter) resolveConflict(nbf *types.NomsBinFormat, sch schema.Schema, cnf tableConflict) (row.Row, string, error) {
+	if cnf.ours == nil || cnf.theirs == nil {
+		choice, err := p.choose("Keep [o]urs, take [t]heirs, [s]kip or [q]uit? ", chooseOurs, chooseTheirs, chooseSkip, chooseQuit)
+
+		if err != nil || choice == chooseSkip || choice == chooseQuit {
+			return nil, choice, err
+		} else if choice == chooseOurs {
+			return cnf.ours, "", nil
+		}
+
+		return cnf.theirs, "", nil
+	}
+
+	for {
+		vals := make(row.TaggedValues)
+		err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			base, ours, theirs := colVal(cnf.base, tag), colVal(cnf.ours, tag), colVal(cnf.theirs, tag)
+
+			var val types.Value
+			switch {
+			case ours.Equals(theirs) || theirs.Equals(base):
+				val = ours
+			case ours.Equals(base):
+				val = theirs
+			default:
+				val, err = p.chooseValue(col, base, ours, theirs)
+			}
+
+			if !types.IsNull(val) {
+				vals[tag] = val
+			}
+
+			return err != nil, err
+		})
+
+		if err == errSkip {
+			return nil, chooseSkip, nil
+		} else if err == errQuit {
+			return nil, chooseQuit, nil
+		} else if err != nil {
+			return nil, "", err
+		}
+
+		r, err := row.New(nbf, sch, vals)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if isValid, err := row.IsValid(r, sch); err != nil {
+			return nil, "", err
+		} else if isValid {
+			return r, "", nil
+		}
+
+		col, constraint, err := row.GetInvalidConstraint(r, sch)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		cli.PrintErrln(fmt.Sprintf("The value of column '%s' fails the constraint %v. Choose again.", col.Name, constraint))
+	}
+}
+
+// errSkip and errQuit stop the iteration over the columns of a conflict when the user skips it or quits.
+var errSkip = errors.New("skip")
+var errQuit = errors.New("quit")
+
+// chooseValue asks which value the column given should take.
+func (p *conflictPrompter) chooseValue(col schema.Column, base, ours, theirs types.Value) (types.Value, error) {
+	prompt := fmt.Sprintf("%s: [o]urs, [t]heirs, [b]ase, [e]dit, [s]kip row or [q]uit? ", col.Name)
+
+	choice, err := p.choose(prompt, chooseOurs, chooseTheirs, chooseBase, chooseEdit, chooseSkip, chooseQuit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch choice {
+	case chooseOurs:
+		return ours, nil
+	case chooseTheirs:
+		return theirs, nil
+	case chooseBase:
+		return base, nil
+	case chooseSkip:
+		return nil, errSkip
+	case chooseQuit:
+		return nil, errQuit
+	}
+
+	return p.editValue(col)
+}
+
+// editValue reads a new value for the column given until a valid one is entered.
+func (p *conflictPrompter) editValue(col schema.Column) (types.Value, error) {
+	for {
+		line, err := p.readLine(fmt.Sprintf("New value for %s (%s for null): ", col.Name, nullInput))
+
+		if err == io.EOF {
+			return nil, errQuit
+		} else if err != nil {
+			return nil, err
+		}
+
+		if line == nullInput {
+			return types.NullValue, nil
+		}
+
+		val, err := doltcore.StringToValue(line, col.Kind)
+
+		if err == nil {
+			return val, nil
+		}
+
+		cli.PrintErrln(fmt.Sprintf("'%s' is not a valid %s.", line, col.KindString()))
+	}
+}
+
+// choose prints the prompt given until the user enters one of the choices given. Input ending is treated as quitting.
+func (p *conflictPrompter) choose(prompt string, choices ...string) (string, error) {
+	for {
+		line, err := p.readLine(prompt)
+
+		if err == io.EOF {
+			return chooseQuit, nil
+		} else if err != nil {
+			return "", err
+		}
+
+		for _, choice := range choices {
+			if strings.EqualFold(line, choice) {
+				return choice, nil
+			}
+		}
+	}
+}
+
+// readLine prints the prompt given and reads a line of input, without its line ending.
+func (p *conflictPrompter) readLine(prompt string) (string, error) {
+	cli.Print(prompt)
+	line, err := p.in.ReadString('\n')
+
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+
+	if err != nil {
+		if err == io.EOF {
+			cli.Println()
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// readConflicts returns the conflicts of the table given.
+func readConflicts(ctx context.Context, tbl *doltdb.Table) ([]tableConflict, error) {
+	baseSch, sch, theirSch, err := tbl.GetConflictSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, conflictData, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []tableConflict
+	err = conflictData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		cnf, err := doltdb.ConflictFromTuple(value.(types.Tuple))
+
+		if err != nil {
+			return true, err
+		}
+
+		ours, _, err := rowData.MaybeGet(ctx, key)
+
+		if err != nil {
+			return true, err
+		}
+
+		tc := tableConflict{key: key}
+		if tc.base, err = doltdb.ConflictRow(key, cnf.Base, baseSch); err == nil {
+			if tc.ours, err = doltdb.ConflictRow(key, ours, sch); err == nil {
+				tc.theirs, err = doltdb.ConflictRow(key, cnf.MergeValue, theirSch)
+			}
+		}
+
+		conflicts = append(conflicts, tc)
+		return err != nil, err
+	})
+
+	return conflicts, err
+}
+
+// printConflict prints the values of each column of the conflicted row given on each side of the merge. Columns
+// changed on both sides are marked.
+func printConflict(sch schema.Schema, cnf tableConflict) {
+	lines := [][]string{{"", "base", "ours", "theirs", ""}}
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		base, ours, theirs := colVal(cnf.base, tag), colVal(cnf.ours, tag), colVal(cnf.theirs, tag)
+
+		marker := ""
+		if cnf.ours != nil && cnf.theirs != nil && !ours.Equals(theirs) && !ours.Equals(base) && !theirs.Equals(base) {
+			marker = "*"
+		}
+
+		lines = append(lines, []string{col.Name, sideValString(cnf.base, base), sideValString(cnf.ours, ours), sideValString(cnf.theirs, theirs), marker})
+		return false, nil
+	})
+
+	widths := make([]int, len(lines[0]))
+	for _, line := range lines {
+		for i, s := range line {
+			if len(s) > widths[i] {
+				widths[i] = len(s)
+			}
+		}
+	}
+
+	for _, line := range lines {
+		var sb strings.Builder
+		for i, s := range line {
+			sb.WriteString(fmt.Sprintf("  %-*s", widths[i], s))
+		}
+
+		cli.Println(strings.TrimRight(sb.String(), " "))
+	}
+}
+
+// colVal returns the value of the column given in the row given, or null if the row is nil or doesn't have a value.
+func colVal(r row.Row, tag uint64) types.Value {
+	if r == nil {
+		return types.NullValue
+	}
+
+	if val, ok := r.GetColVal(tag); ok && val != nil {
+		return val
+	}
+
+	return types.NullValue
+}
+
+// sideValString returns the string shown for a value of one side of a conflict. Nothing is shown for a side which
+// doesn't have the row.
+func sideValString(r row.Row, val types.Value) string {
+	if r == nil {
+		return ""
+	} else if types.IsNull(val) {
+		return nullprinter.PRINTED_NULL
+	} else if str, ok := val.(types.String); ok {
+		return string(str)
+	}
+
+	str, err := types.EncodedValue(context.Background(), val)
+
+	if err != nil {
+		return "<" + err.Error() + ">"
+	}
+
+	return str
+}
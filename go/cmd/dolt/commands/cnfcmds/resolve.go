@@ -16,6 +16,13 @@ package cnfcmds
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"vitess.io/vitess/go/vt/sqlparser"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
@@ -24,6 +31,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 )
 
@@ -36,15 +44,35 @@ var resLongDesc = "When a merge operation finds conflicting changes, the rows wi
 	"the conflicts whose keys are provided.\n" +
 	"\n" +
 	"In it's second form <b>dolt conflicts resolve --ours|--theirs <table>...</b>, resolve runs in auto resolve mode. " +
-	"where conflicts are resolved using a rule to determine which version of a row should be used."
+	"where conflicts are resolved using a rule to determine which version of a row should be used. Schema conflicts, " +
+	"where the two branches changed a column in ways that can't be merged, are resolved the same way: --ours keeps " +
+	"our definition of each column in conflict, and --theirs changes it to theirs, converting its values.\n" +
+	"\n" +
+	"In it's third form <b>dolt conflicts resolve --interactive [<table>...]</b>, resolve walks each conflicted row of " +
+	"the tables given, or of every table in conflict, showing its values in the merge base, in our branch and in " +
+	"their branch. Columns changed on only one side take the changed value. For each column changed on both sides, " +
+	"our value, their value or the base value can be chosen, or a new value entered.\n" +
+	"\n" +
+	"In it's fourth form <b>dolt conflicts resolve --sql <query></b>, resolve runs the UPDATE, DELETE and REPLACE " +
+	"statements given against the working set. The conflicts of each table <table> are in the table " +
+	"<b>dolt_conflicts_<table></b>, which has the columns base_<column>, our_<column> and their_<column> for each " +
+	"column of the table. Updating the our_ columns of a conflict writes the row to the working set, and setting " +
+	"them all to NULL removes it. Deleting a conflict marks it resolved."
 var resSynopsis = []string{
 	"<table> [<key_definition>] <key>...",
 	"--ours|--theirs <table>...",
+	"--interactive [<table>...]",
+	"--sql <query>",
 }
 
 const (
-	oursFlag   = "ours"
-	theirsFlag = "theirs"
+	oursFlag        = "ours"
+	theirsFlag      = "theirs"
+	interactiveFlag = "interactive"
+	sqlParam        = "sql"
+
+	// resolveDbName is the name of the database that the queries given with --sql run against
+	resolveDbName = "dolt"
 )
 
 var autoResolvers = map[string]merge.AutoResolver{
@@ -67,12 +95,18 @@ func Resolve(ctx context.Context, commandStr string, args []string, dEnv *env.Do
 	ap.ArgListHelp["key"] = "key(s) of rows within a table whose conflicts have been resolved"
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "Fol all conflicts, take the version from our branch and resolve the conflict")
+	ap.SupportsFlag(interactiveFlag, "i", "Walk each conflicted row, choosing the value of each column changed on both sides")
+	ap.SupportsString(sqlParam, "q", "query", "Resolve conflicts by running the UPDATE, DELETE and REPLACE statements given against the dolt_conflicts_<table> tables")
 	help, usage := cli.HelpAndUsagePrinters(commandStr, resShortDesc, resLongDesc, resSynopsis, ap)
 	apr := cli.ParseArgs(ap, args, help)
 
 	var verr errhand.VerboseError
 	if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(ctx, apr, dEnv)
+	} else if apr.Contains(interactiveFlag) {
+		verr = interactiveResolve(ctx, apr, dEnv)
+	} else if query, ok := apr.GetValue(sqlParam); ok {
+		verr = sqlResolve(ctx, query, dEnv)
 	} else {
 		verr = manualResolve(ctx, apr, dEnv)
 	}
@@ -96,17 +130,27 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 	tbls := apr.Args()
 	if len(tbls) == 1 && tbls[0] == "." {
 		err = actions.AutoResolveAll(ctx, dEnv, autoResolveFunc)
+		tbls = nil
 	} else {
 		err = actions.AutoResolveTables(ctx, dEnv, autoResolveFunc, tbls)
 	}
 
-	if err != nil {
-		if err == doltdb.ErrNoConflicts {
+	rowsResolved := err == nil
+	if err != nil && err != doltdb.ErrNoConflicts {
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+
+	// Row conflicts are resolved first, as taking their column definitions converts the values of the table's rows
+	err = actions.ResolveSchemaConflicts(ctx, dEnv, tbls, autoResolveFlag == theirsFlag)
+
+	if err == doltdb.ErrNoConflicts {
+		if !rowsResolved {
 			cli.Println("no conflicts to resolve.")
-			return nil
 		}
 
-		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to resolve schema conflicts").AddCause(err).Build()
 	}
 
 	return nil
@@ -163,19 +207,7 @@ func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 			cli.Println(key, "is not the primary key of a conflicting row")
 		}
 
-		updatedHash, err := updatedTbl.HashOf()
-
-		if err != nil {
-			return errhand.BuildDError("error: failed to get table hash").AddCause(err).Build()
-		}
-
-		hash, err := tbl.HashOf()
-
-		if err != nil {
-			return errhand.BuildDError("error: failed to get table hash").AddCause(err).Build()
-		}
-
-		if hash == updatedHash {
+		if updatedTbl != nil {
 			root, err := root.PutTable(ctx, dEnv.DoltDB, tblName, updatedTbl)
 
 			if err != nil {
@@ -195,3 +227,128 @@ func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 
 	return nil
 }
+
+func interactiveResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, verr := commands.GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	tbls := apr.Args()
+	if len(tbls) == 0 {
+		var err error
+		tbls, err = root.TablesInConflict(ctx)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read tables").AddCause(err).Build()
+		}
+	}
+
+	if len(tbls) == 0 {
+		cli.Println("no conflicts to resolve.")
+		return nil
+	}
+
+	root, resolved, _, err := resolveInteractively(ctx, dEnv.DoltDB, root, tbls, os.Stdin)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+
+	if resolved > 0 {
+		verr = commands.UpdateWorkingWithVErr(dEnv, root)
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	cli.Println(resolved, "rows resolved successfully")
+
+	return nil
+}
+
+func sqlResolve(ctx context.Context, query string, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, verr := commands.GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	root, err := executeResolveQueries(ctx, dEnv, root, query)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+
+	return commands.UpdateWorkingWithVErr(dEnv, root)
+}
+
+// executeResolveQueries runs the UPDATE, DELETE and REPLACE statements given against the root given, which has the
+// dolt_conflicts_<table> system tables for resolving conflicts, and returns the resulting root.
+func executeResolveQueries(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, queries string) (*doltdb.RootValue, error) {
+	db := dsqle.NewDatabase(resolveDbName, root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, dsqle.NewAnalyzer(catalog), nil)
+	engine.AddDatabase(db)
+	engine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(db))
+
+	if err := engine.Init(); err != nil {
+		return nil, err
+	}
+
+	pieces, err := sqlparser.SplitStatementToPieces(queries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlCtx := sql.NewContext(ctx)
+	for _, query := range pieces {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+
+		stmt, err := sqlparser.Parse(query)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch s := stmt.(type) {
+		case *sqlparser.Update:
+			n, err := dsqle.ExecuteUpdate(sqlCtx, engine, db, s)
+
+			if err != nil {
+				return nil, err
+			}
+
+			cli.Println(fmt.Sprintf("Rows updated: %v", n))
+		case *sqlparser.Delete:
+			n, err := dsqle.ExecuteDelete(sqlCtx, engine, db, s)
+
+			if err != nil {
+				return nil, err
+			}
+
+			cli.Println(fmt.Sprintf("Rows deleted: %v", n))
+		case *sqlparser.Insert:
+			if s.Action != sqlparser.ReplaceStr {
+				return nil, fmt.Errorf("unsupported statement for resolving conflicts: %s", query)
+			}
+
+			n, err := dsqle.ExecuteReplace(sqlCtx, engine, db, s)
+
+			if err != nil {
+				return nil, err
+			}
+
+			cli.Println(fmt.Sprintf("Rows replaced: %v", n))
+		default:
+			return nil, fmt.Errorf("unsupported statement for resolving conflicts: %s", query)
+		}
+	}
+
+	return db.Root(), nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// The rows of the table people after merging the branch other into master, which leaves all three rows in conflict.
+// Row 1 has name changed on master, city changed on other, and age changed on both. Row 2 has name changed on both.
+// Row 3 is changed on master and removed on other.
+var mergedPeople = map[int64]string{
+	1: "robert 31 nyc",
+	2: "suzy 40 sf",
+	3: "al 51 la",
+}
+
+func TestResolveInteractively(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             string
+		expectedRows      map[int64]string
+		expectedResolved  int
+		expectedQuit      bool
+		expectedConflicts uint64
+	}{
+		{
+			name:              "choose each side",
+			input:             "t\no\nt\n",
+			expectedRows:      map[int64]string{1: "robert 32 boston", 2: "suzy 40 sf"},
+			expectedResolved:  3,
+			expectedConflicts: 0,
+		},
+		{
+			name:              "edit and base",
+			input:             "x\ne\nabc\n35\nb\no\n",
+			expectedRows:      map[int64]string{1: "robert 35 boston", 2: "sue 40 sf", 3: "al 51 la"},
+			expectedResolved:  3,
+			expectedConflicts: 0,
+		},
+		{
+			name:              "skip",
+			input:             "s\nt\ns\n",
+			expectedRows:      map[int64]string{1: "robert 31 nyc", 2: "susan 40 sf", 3: "al 51 la"},
+			expectedResolved:  1,
+			expectedConflicts: 2,
+		},
+		{
+			name:              "quit",
+			input:             "o\nq\n",
+			expectedRows:      map[int64]string{1: "robert 31 boston", 2: "suzy 40 sf", 3: "al 51 la"},
+			expectedResolved:  1,
+			expectedQuit:      true,
+			expectedConflicts: 2,
+		},
+		{
+			name:              "input ends",
+			input:             "o\n",
+			expectedRows:      map[int64]string{1: "robert 31 boston", 2: "suzy 40 sf", 3: "al 51 la"},
+			expectedResolved:  1,
+			expectedQuit:      true,
+			expectedConflicts: 2,
+		},
+		{
+			name:              "null violating constraint",
+			input:             "t\ne\nNULL\nt\nt\n",
+			expectedRows:      map[int64]string{1: "robert 32 boston", 2: "susan 40 sf"},
+			expectedResolved:  3,
+			expectedConflicts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := createConflicts(t)
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+
+			root, resolved, quit, err := resolveInteractively(ctx, dEnv.DoltDB, root, []string{"people"}, strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResolved, resolved)
+			assert.Equal(t, tt.expectedQuit, quit)
+
+			rows, numConflicts := readPeople(t, root)
+			assert.Equal(t, tt.expectedRows, rows)
+			assert.Equal(t, tt.expectedConflicts, numConflicts)
+		})
+	}
+}
+
+func TestExecuteResolveQueries(t *testing.T) {
+	tests := []struct {
+		name              string
+		queries           string
+		expectedErr       bool
+		expectedRows      map[int64]string
+		expectedConflicts uint64
+	}{
+		{
+			name:              "update and resolve",
+			queries:           "update dolt_conflicts_people set our_age = their_age where base_id = 1; delete from dolt_conflicts_people where base_id = 1",
+			expectedRows:      map[int64]string{1: "robert 32 nyc", 2: "suzy 40 sf", 3: "al 51 la"},
+			expectedConflicts: 2,
+		},
+		{
+			name:              "take theirs",
+			queries:           "delete from people where id = 3; delete from dolt_conflicts_people",
+			expectedRows:      map[int64]string{1: "robert 31 nyc", 2: "suzy 40 sf"},
+			expectedConflicts: 0,
+		},
+		{
+			name:        "update theirs",
+			queries:     "update dolt_conflicts_people set their_age = 1",
+			expectedErr: true,
+		},
+		{
+			name:        "select",
+			queries:     "select * from dolt_conflicts_people",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := createConflicts(t)
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+
+			root, err = executeResolveQueries(ctx, dEnv, root, tt.queries)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			rows, numConflicts := readPeople(t, root)
+			assert.Equal(t, tt.expectedRows, rows)
+			assert.Equal(t, tt.expectedConflicts, numConflicts)
+		})
+	}
+}
+
+func TestAutoResolveSchemaConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		flag         string
+		expectedKind types.NomsKind
+		expectedRows map[int64]string
+	}{
+		{
+			name:         "ours",
+			flag:         "--ours",
+			expectedKind: types.FloatKind,
+			expectedRows: map[int64]string{1: "bob 31.5 nyc", 2: "sue 40 sf", 3: "al 50 la"},
+		},
+		{
+			name:         "theirs",
+			flag:         "--theirs",
+			expectedKind: types.UintKind,
+			expectedRows: map[int64]string{1: "bob 32 nyc", 2: "sue 40 sf", 3: "al 50 la"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtestutils.CreateTestEnv()
+			commitQueries(t, dEnv, "base",
+				"create table people (id bigint primary key, name varchar(20) not null, age bigint, city varchar(20))",
+				"insert into people (id, name, age, city) values (1, 'bob', 30, 'nyc'), (2, 'sue', 40, 'sf'), (3, 'al', 50, 'la')")
+
+			require.Equal(t, 0, commands.Checkout(ctx, "dolt checkout", []string{"-b", "other"}, dEnv))
+			commitQueries(t, dEnv, "other",
+				"alter table people modify column age bigint unsigned",
+				"update people set age = 32 where id = 1")
+
+			require.Equal(t, 0, commands.Checkout(ctx, "dolt checkout", []string{"master"}, dEnv))
+			commitQueries(t, dEnv, "master",
+				"alter table people modify column age double",
+				"update people set age = 31.5 where id = 1")
+
+			require.Equal(t, 0, commands.Merge(ctx, "dolt merge", []string{"other"}, dEnv))
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			inConflict, err := root.TablesInConflict(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{"people"}, inConflict)
+
+			require.Equal(t, 0, Resolve(ctx, "dolt conflicts resolve", []string{tt.flag, "."}, dEnv))
+			root, err = dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			rows, numConflicts := readPeople(t, root)
+			assert.Equal(t, tt.expectedRows, rows)
+			assert.Equal(t, uint64(0), numConflicts)
+
+			tbl, _, err := root.GetTable(ctx, "people")
+			require.NoError(t, err)
+			sch, err := tbl.GetSchema(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedKind, sch.GetAllCols().NameToCol["age"].Kind)
+			hasSchConflicts, err := tbl.HasSchemaConflicts()
+			require.NoError(t, err)
+			assert.False(t, hasSchConflicts)
+		})
+	}
+}
+
+// createConflicts merges the branch other into master, leaving the rows of the table people in conflict as described
+// by mergedPeople.
+func createConflicts(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base",
+		"create table people (id bigint primary key, name varchar(20) not null, age bigint, city varchar(20))",
+		"insert into people (id, name, age, city) values (1, 'bob', 30, 'nyc'), (2, 'sue', 40, 'sf'), (3, 'al', 50, 'la')")
+
+	require.Equal(t, 0, commands.Checkout(ctx, "dolt checkout", []string{"-b", "other"}, dEnv))
+	commitQueries(t, dEnv, "other",
+		"update people set age = 32, city = 'boston' where id = 1",
+		"update people set name = 'susan' where id = 2",
+		"delete from people where id = 3")
+
+	require.Equal(t, 0, commands.Checkout(ctx, "dolt checkout", []string{"master"}, dEnv))
+	commitQueries(t, dEnv, "master",
+		"update people set age = 31, name = 'robert' where id = 1",
+		"update people set name = 'suzy' where id = 2",
+		"update people set age = 51 where id = 3")
+
+	require.Equal(t, 0, commands.Merge(ctx, "dolt merge", []string{"other"}, dEnv))
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	rows, numConflicts := readPeople(t, root)
+	require.Equal(t, mergedPeople, rows)
+	require.Equal(t, uint64(3), numConflicts)
+
+	return dEnv
+}
+
+func commitQueries(t *testing.T, dEnv *env.DoltEnv, msg string, queries ...string) {
+	ctx := context.Background()
+	for _, query := range queries {
+		require.Equal(t, 0, commands.Sql(ctx, "dolt sql", []string{"-q", query}, dEnv))
+	}
+
+	require.Equal(t, 0, commands.Add(ctx, "dolt add", []string{"."}, dEnv))
+	require.Equal(t, 0, commands.Commit(ctx, "dolt commit", []string{"-m", msg}, dEnv))
+}
+
+// readPeople returns the rows of the table people, keyed by id, and the number of its rows in conflict.
+func readPeople(t *testing.T, root *doltdb.RootValue) (map[int64]string, uint64) {
+	ctx := context.Background()
+	tbl, ok, err := root.GetTable(ctx, "people")
+	require.NoError(t, err)
+	require.True(t, ok)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	rowData, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+
+	rows := make(map[int64]string)
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		require.NoError(t, err)
+
+		var vals []string
+		_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			val, _ := r.GetColVal(tag)
+			vals = append(vals, fmt.Sprint(val))
+			return false, nil
+		})
+
+		id, _ := r.GetColVal(sch.GetAllCols().NameToCol["id"].Tag)
+		rows[int64(id.(types.Int))] = strings.Join(vals[1:], " ")
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	numConflicts, err := tbl.NumRowsInConflict(ctx)
+	require.NoError(t, err)
+
+	return rows, numConflicts
+}
@@ -19,6 +19,8 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 
@@ -28,13 +30,16 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/rowconv"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/fwt"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/nullprinter"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/sqlexport"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/mathutil"
@@ -47,8 +52,13 @@ const (
 	DataOnlyDiff      = 2
 	SchemaAndDataDiff = SchemaOnlyDiff | DataOnlyDiff
 
+	TabularDiffOutput = 1
+	SQLDiffOutput     = 2
+	JSONDiffOutput    = 3
+
 	DataFlag   = "data"
 	SchemaFlag = "schema"
+	FormatFlag = "format"
 )
 
 var diffShortDesc = "Show changes between commits, commit and working tree, etc"
@@ -62,6 +72,8 @@ dolt diff [--options] <commit> [<tables>...]
 
 dolt diff [--options] <commit> <commit> [<tables>...]
    This is to view the changes between two arbitrary <commit>.
+
+The --format option selects how the changes are shown. The default, tabular, shows them as colored tables. sql shows them as the SQL statements which make the changes, so they can be applied to another database. json shows them as a JSON document holding the schema changes and the old and new values of each changed row of each table.
 `
 
 var diffSynopsis = []string{
@@ -73,6 +85,7 @@ func Diff(ctx context.Context, commandStr string, args []string, dEnv *env.DoltE
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(DataFlag, "d", "Show only the data changes, do not show the schema changes (Both shown by default).")
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
 	help, _ := cli.HelpAndUsagePrinters(commandStr, diffShortDesc, diffLongDesc, diffSynopsis, ap)
 	apr := cli.ParseArgs(ap, args, help)
 
@@ -83,10 +96,24 @@ func Diff(ctx context.Context, commandStr string, args []string, dEnv *env.DoltE
 		diffParts = SchemaOnlyDiff
 	}
 
+	diffOutput := TabularDiffOutput
+	if formatStr, ok := apr.GetValue(FormatFlag); ok {
+		switch strings.ToLower(formatStr) {
+		case "tabular":
+		case "sql":
+			diffOutput = SQLDiffOutput
+		case "json":
+			diffOutput = JSONDiffOutput
+		default:
+			cli.PrintErrln(color.RedString("Invalid format %s. Valid values are tabular, sql, json.", formatStr))
+			return 1
+		}
+	}
+
 	r1, r2, tables, verr := getRoots(ctx, apr.Args(), dEnv)
 
 	if verr == nil {
-		verr = diffRoots(ctx, r1, r2, tables, diffParts, dEnv)
+		verr = diffRoots(ctx, r1, r2, tables, diffParts, diffOutput, dEnv)
 	}
 
 	if verr != nil {
@@ -186,7 +213,7 @@ func getRootForCommitSpecStr(ctx context.Context, csStr string, dEnv *env.DoltEn
 	return h.String(), r, nil
 }
 
-func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string, diffParts int, dEnv *env.DoltEnv) errhand.VerboseError {
+func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string, diffParts, diffOutput int, dEnv *env.DoltEnv) errhand.VerboseError {
 	var err error
 	if len(tblNames) == 0 {
 		tblNames, err = actions.AllTables(ctx, r1, r2)
@@ -196,6 +223,15 @@ func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string,
 		return errhand.BuildDError("error: unable to read tables").AddCause(err).Build()
 	}
 
+	var jsonWr *diff.JSONDiffWriter
+	if diffOutput == JSONDiffOutput {
+		jsonWr, err = diff.NewJSONDiffWriter(cli.CliOut)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to write diff").AddCause(err).Build()
+		}
+	}
+
 	for _, tblName := range tblNames {
 		tbl1, ok1, err := r1.GetTable(ctx, tblName)
 
@@ -213,6 +249,10 @@ func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string,
 			bdr := errhand.BuildDError("Table could not be found.")
 			bdr.AddDetails("The table %s does not exist.", tblName)
 			cli.PrintErrln(bdr.Build())
+
+			if diffOutput != TabularDiffOutput {
+				continue
+			}
 		} else if tbl1 != nil && tbl2 != nil {
 			h1, err := tbl1.HashOf()
 
@@ -231,10 +271,12 @@ func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string,
 			}
 		}
 
-		printTableDiffSummary(tblName, tbl1, tbl2)
+		if diffOutput == TabularDiffOutput {
+			printTableDiffSummary(tblName, tbl1, tbl2)
 
-		if tbl1 == nil || tbl2 == nil {
-			continue
+			if tbl1 == nil || tbl2 == nil {
+				continue
+			}
 		}
 
 		var sch1 schema.Schema
@@ -295,14 +337,33 @@ func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string,
 			}
 		}
 
-		var verr errhand.VerboseError
+		// Rows are matched by their primary keys, so if the primary key changed the old rows are re-keyed by the new one in
+		// order to show only the rows whose values changed.
+		rowSch2 := sch2
+		var unkeyedRows []row.Row
+		if ok1 && ok2 && alterschema.PrimaryKeysDiffer(sch1, sch2) {
+			rowSch2, rowData2, unkeyedRows, err = rekeyOldRows(ctx, dEnv.DoltDB.ValueReadWriter(), rowData2, sch1, sch2)
 
-		if diffParts&SchemaOnlyDiff != 0 && sch1Hash != sch2Hash {
-			verr = diffSchemas(tblName, sch2, sch1)
+			if err != nil {
+				return errhand.BuildDError("error: failed to re-key rows by the new primary key").AddCause(err).Build()
+			}
 		}
 
-		if diffParts&DataOnlyDiff != 0 {
-			verr = diffRows(ctx, rowData1, rowData2, sch1, sch2)
+		var verr errhand.VerboseError
+
+		switch diffOutput {
+		case SQLDiffOutput:
+			verr = sqlDiff(ctx, tblName, rowData1, rowData2, sch1, sch2, rowSch2, unkeyedRows, diffParts)
+		case JSONDiffOutput:
+			verr = jsonDiff(ctx, jsonWr, tblName, rowData1, rowData2, sch1, sch2, rowSch2, unkeyedRows, diffParts)
+		default:
+			if diffParts&SchemaOnlyDiff != 0 && sch1Hash != sch2Hash {
+				verr = diffSchemas(tblName, sch2, sch1)
+			}
+
+			if diffParts&DataOnlyDiff != 0 {
+				verr = diffRows(ctx, rowData1, rowData2, sch1, rowSch2, unkeyedRows)
+			}
 		}
 
 		if verr != nil {
@@ -310,6 +371,237 @@ func diffRoots(ctx context.Context, r1, r2 *doltdb.RootValue, tblNames []string,
 		}
 	}
 
+	if jsonWr != nil {
+		if err := jsonWr.Close(); err != nil {
+			return errhand.BuildDError("error: failed to write diff").AddCause(err).Build()
+		}
+	}
+
+	return nil
+}
+
+// rekeyOldRows returns the old rows of a table keyed by the primary key of its new schema, along with the schema they
+// are keyed by. Rows which can't be keyed by the new primary key can't be in the new table, so they are returned
+// separately to be shown as removed. The old rows are returned unchanged if the old schema doesn't have all the new
+// primary key columns.
+func rekeyOldRows(ctx context.Context, vrw types.ValueReadWriter, oldRows types.Map, newSch, oldSch schema.Schema) (schema.Schema, types.Map, []row.Row, error) {
+	rekeyedSch, err := alterschema.RekeySchema(oldSch, newSch.GetPKCols().Tags)
+
+	if err == schema.ErrColNotFound {
+		return oldSch, oldRows, nil, nil
+	} else if err != nil {
+		return nil, types.EmptyMap, nil, err
+	}
+
+	rekeyedRows, unkeyedKeys, err := alterschema.RekeyRowsDroppingErrors(ctx, vrw, oldRows, oldSch, rekeyedSch)
+
+	if err != nil {
+		return nil, types.EmptyMap, nil, err
+	}
+
+	var unkeyedRows []row.Row
+	for _, key := range unkeyedKeys {
+		value, _, err := oldRows.MaybeGet(ctx, key)
+
+		if err != nil {
+			return nil, types.EmptyMap, nil, err
+		}
+
+		r, err := row.FromNoms(oldSch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return nil, types.EmptyMap, nil, err
+		}
+
+		unkeyedRows = append(unkeyedRows, r)
+	}
+
+	return rekeyedSch, rekeyedRows, unkeyedRows, nil
+}
+
+// sqlDiff prints the SQL statements which change the table with the name given from its old schema and rows to its new
+// schema and rows. A nil schema means the table doesn't exist. The old rows are keyed by oldRowSch, which differs from
+// the old schema if they were re-keyed by a new primary key, and unkeyedRows holds any old rows that couldn't be.
+func sqlDiff(ctx context.Context, tblName string, newRows, oldRows types.Map, newSch, oldSch, oldRowSch schema.Schema, unkeyedRows []row.Row, diffParts int) errhand.VerboseError {
+	if newSch == nil {
+		if diffParts&SchemaOnlyDiff != 0 {
+			cli.Println(sqlexport.DropTableStatement(tblName))
+		}
+
+		return nil
+	}
+
+	// Rows which can't be keyed by a new primary key must be deleted before the primary key is changed
+	if diffParts&DataOnlyDiff != 0 {
+		for _, r := range unkeyedRows {
+			stmt, err := sqlexport.DeleteStatement(tblName, oldSch, r)
+
+			if err != nil {
+				return errhand.BuildDError("error: failed to diff rows").AddCause(err).Build()
+			}
+
+			cli.Println(stmt)
+		}
+	}
+
+	if diffParts&SchemaOnlyDiff != 0 {
+		if oldSch == nil {
+			cli.Println(sql.SchemaAsCreateStmt(tblName, newSch))
+		} else {
+			stmts, err := alterTableStatements(tblName, oldSch, newSch)
+
+			if err != nil {
+				return errhand.BuildDError("error: failed to diff schemas").AddCause(err).Build()
+			}
+
+			for _, stmt := range stmts {
+				cli.Println(stmt)
+			}
+		}
+	}
+
+	if diffParts&DataOnlyDiff == 0 {
+		return nil
+	}
+
+	err := iterRowDiffs(ctx, newRows, oldRows, newSch, oldRowSch, nil, func(oldR, newR row.Row) error {
+		var stmt string
+		var err error
+		if oldR == nil {
+			stmt, err = sqlexport.InsertStatement(tblName, newSch, newR)
+		} else if newR == nil {
+			stmt, err = sqlexport.DeleteStatement(tblName, oldSch, oldR)
+		} else {
+			stmt, err = sqlexport.UpdateStatement(tblName, newSch, oldR, newR)
+		}
+
+		if err == nil && stmt != "" {
+			cli.Println(stmt)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to diff rows").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// alterTableStatements returns the ALTER TABLE statements which change the schema of a table from oldSch to newSch.
+func alterTableStatements(tblName string, oldSch, newSch schema.Schema) ([]string, error) {
+	diffs, err := diff.DiffSchemas(oldSch, newSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]uint64, 0, len(diffs))
+	for tag := range diffs {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i] < tags[j]
+	})
+
+	var stmts []string
+	for _, tag := range tags {
+		dff := diffs[tag]
+		switch dff.DiffType {
+		case diff.SchDiffColAdded:
+			stmts = append(stmts, sqlexport.AlterTableAddColumnStatement(tblName, *dff.New))
+		case diff.SchDiffColRemoved:
+			stmts = append(stmts, sqlexport.AlterTableDropColumnStatement(tblName, dff.Old.Name))
+		case diff.SchDiffColModified:
+			// Changes to the primary key are made by a separate statement
+			renamed := *dff.Old
+			renamed.Name = dff.New.Name
+			renamed.IsPartOfPK = dff.New.IsPartOfPK
+
+			if !renamed.Equals(*dff.New) {
+				stmts = append(stmts, sqlexport.AlterTableChangeColumnStatement(tblName, dff.Old.Name, *dff.New))
+			} else if dff.Old.Name != dff.New.Name {
+				stmts = append(stmts, sqlexport.AlterTableRenameColumnStatement(tblName, dff.Old.Name, dff.New.Name))
+			}
+		}
+	}
+
+	if alterschema.PrimaryKeysDiffer(oldSch, newSch) {
+		stmts = append(stmts, sqlexport.AlterTablePrimaryKeyStatement(tblName, newSch))
+	}
+
+	return stmts, nil
+}
+
+// jsonDiff writes the schema changes and row changes of the table with the name given to the JSON diff writer given.
+// A nil schema means the table doesn't exist. The old rows are keyed by oldRowSch, as for sqlDiff.
+func jsonDiff(ctx context.Context, wr *diff.JSONDiffWriter, tblName string, newRows, oldRows types.Map, newSch, oldSch, oldRowSch schema.Schema, unkeyedRows []row.Row, diffParts int) errhand.VerboseError {
+	err := wr.BeginTable(tblName, oldSch, newSch, diffParts&SchemaOnlyDiff != 0)
+
+	if err == nil && diffParts&DataOnlyDiff != 0 {
+		err = iterRowDiffs(ctx, newRows, oldRows, newSch, oldRowSch, unkeyedRows, wr.WriteRowDiff)
+	}
+
+	if err == nil {
+		err = wr.EndTable()
+	}
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write diff").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// iterRowDiffs calls the function given with the old and new versions of each row which differs between oldRows and
+// newRows, after calling it for each of the removed rows given. The old row is nil for added rows, and the new row is
+// nil for removed rows.
+func iterRowDiffs(ctx context.Context, newRows, oldRows types.Map, newSch, oldSch schema.Schema, removedRows []row.Row, cb func(oldR, newR row.Row) error) error {
+	for _, r := range removedRows {
+		if err := cb(r, nil); err != nil {
+			return err
+		}
+	}
+
+	ad := diff.NewAsyncDiffer(1024)
+	ad.Start(ctx, newRows, oldRows)
+	defer ad.Close()
+
+	for !ad.IsDone() {
+		diffs, err := ad.GetDiffs(1024, time.Second)
+
+		if err != nil {
+			return err
+		}
+
+		for _, d := range diffs {
+			var oldR, newR row.Row
+			if d.OldValue != nil {
+				oldR, err = row.FromNoms(oldSch, d.KeyValue.(types.Tuple), d.OldValue.(types.Tuple))
+
+				if err != nil {
+					return err
+				}
+			}
+
+			if d.NewValue != nil {
+				newR, err = row.FromNoms(newSch, d.KeyValue.(types.Tuple), d.NewValue.(types.Tuple))
+
+				if err != nil {
+					return err
+				}
+			}
+
+			err = cb(oldR, newR)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -344,8 +636,8 @@ func diffSchemas(tableName string, sch1 schema.Schema, sch2 schema.Schema) errha
 			cli.Println(color.RedString("- " + sql.FmtCol(2, 0, 0, *dff.Old)))
 		case diff.SchDiffColModified:
 			// changed in sch2
-			n0, t0 := dff.Old.Name, sql.DoltToSQLType[dff.Old.Kind]
-			n1, t1 := dff.New.Name, sql.DoltToSQLType[dff.New.Kind]
+			n0, t0 := dff.Old.Name, sql.ColumnSQLType(*dff.Old)
+			n1, t1 := dff.New.Name, sql.ColumnSQLType(*dff.New)
 
 			nameLen := 0
 			typeLen := 0
@@ -367,6 +659,11 @@ func diffSchemas(tableName string, sch1 schema.Schema, sch2 schema.Schema) errha
 		}
 	}
 
+	if alterschema.PrimaryKeysDiffer(sch1, sch2) {
+		cli.Println("< " + color.YellowString(sql.FmtPrimaryKey(sch1)))
+		cli.Println("> " + color.YellowString(sql.FmtPrimaryKey(sch2)))
+	}
+
 	cli.Println("  );")
 	cli.Println()
 
@@ -378,7 +675,10 @@ func dumbDownSchema(in schema.Schema) (schema.Schema, error) {
 
 	dumbCols := make([]schema.Column, 0, allCols.Size())
 	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		// Rows are shown untyped, so only the tags of the columns need to match for their schemas to be unioned
 		col.Name = strconv.FormatUint(tag, 10)
+		col.Kind = types.StringKind
+		col.TypeParams = nil
 		col.Constraints = nil
 		dumbCols = append(dumbCols, col)
 
@@ -394,7 +694,7 @@ func dumbDownSchema(in schema.Schema) (schema.Schema, error) {
 	return schema.SchemaFromCols(dumbColColl), nil
 }
 
-func diffRows(ctx context.Context, newRows, oldRows types.Map, newSch, oldSch schema.Schema) errhand.VerboseError {
+func diffRows(ctx context.Context, newRows, oldRows types.Map, newSch, oldSch schema.Schema, removedRows []row.Row) errhand.VerboseError {
 	dumbNewSch, err := dumbDownSchema(newSch)
 
 	if err != nil {
@@ -442,6 +742,10 @@ func diffRows(ctx context.Context, newRows, oldRows types.Map, newSch, oldSch sc
 	src := diff.NewRowDiffSource(ad, oldToUnionConv, newToUnionConv, untypedUnionSch)
 	defer src.Close()
 
+	if err := src.AddRemovedRows(removedRows...); err != nil {
+		return errhand.BuildDError("error: failed to diff rows").AddCause(err).Build()
+	}
+
 	oldColNames := make(map[uint64]string)
 	newColNames := make(map[uint64]string)
 	err = untypedUnionSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
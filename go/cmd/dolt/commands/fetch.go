@@ -29,7 +29,7 @@ import (
 
 var fetchShortDesc = "Download objects and refs from another repository"
 var fetchLongDesc = "Fetch refs, along with the objects necessary to complete their histories and update " +
-	"remote-tracking branches." +
+	"remote-tracking branches.  Tags in the remote that don't exist locally are fetched as well." +
 	"\n" +
 	"\n By default dolt will attempt to fetch from a remote named 'origin'.  The <remote> parameter allows you to " +
 	"specify the name of a different remote you wish to pull from by the remote's name." +
@@ -128,12 +128,13 @@ func mapRefspecsToRemotes(refSpecs []ref.RemoteRefSpec, dEnv *env.DoltEnv) (map[
 }
 
 func fetchRefSpecs(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, refSpecs []ref.RemoteRefSpec) errhand.VerboseError {
-	for _, rs := range refSpecs {
-		srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
 
-		if err != nil {
-			return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
-		}
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	for _, rs := range refSpecs {
 
 		branchRefs, err := srcDB.GetRefs(ctx)
 
@@ -154,6 +155,35 @@ func fetchRefSpecs(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, refSp
 		}
 	}
 
+	return fetchTags(ctx, rem.Name, srcDB, dEnv.DoltDB)
+}
+
+// fetchTags copies the tags in srcDB that don't exist in destDB. Tags that exist in both with different values are not
+// updated, as tags can't be moved.
+func fetchTags(ctx context.Context, remoteName string, srcDB, destDB *doltdb.DoltDB) errhand.VerboseError {
+	tags, err := actions.GetTags(ctx, srcDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read tags from '%s'", remoteName).AddCause(err).Build()
+	}
+
+	for _, tag := range tags {
+		progChan := make(chan datas.PullProgress)
+		stopChan := make(chan struct{})
+		go progFunc(progChan, stopChan)
+
+		err = actions.FetchTag(ctx, srcDB, destDB, tag, progChan)
+
+		close(progChan)
+		<-stopChan
+
+		if err == doltdb.ErrTagExists {
+			cli.Printf("! [rejected]          %s -> %s (would clobber existing tag)\n", tag.Name(), tag.Name())
+		} else if err != nil && err != doltdb.ErrUpToDate {
+			return errhand.BuildDError("error: failed to fetch tag '%s'", tag.Name()).AddCause(err).Build()
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,58 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+)
+
+var gcShortDesc = "Cleans up unreferenced data from the repository."
+var gcLongDesc = "Searches the repository for data that is no longer referenced by any branch, tag, remote ref, the " +
+	"working set, the staged set or a merge, cherry-pick, rebase or revert in progress, and removes it from disk. " +
+	"Data that was written and then discarded, for example by reset or by a failed or aborted merge, is only " +
+	"reclaimed by gc. Files which are no longer used are kept for an hour, for other processes still reading the " +
+	"repository, and are removed by a later gc."
+var gcSynopsis = []string{
+	"",
+}
+
+func GarbageCollection(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	help, usage := cli.HelpAndUsagePrinters(commandStr, gcShortDesc, gcLongDesc, gcSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	err := actions.GarbageCollect(ctx, dEnv)
+
+	if err == chunks.ErrUnsupportedOperation {
+		verr = errhand.BuildDError("error: this repository's storage doesn't support garbage collection").Build()
+	} else if err != nil {
+		verr = errhand.BuildDError("error: failed to collect garbage").AddCause(err).Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
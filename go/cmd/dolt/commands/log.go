@@ -15,133 +15,665 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/fatih/color"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/store/hash"
-
-	"context"
-	"strings"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 const (
 	numLinesParam = "number"
+	oneLineParam  = "oneline"
+	graphParam    = "graph"
+	authorParam   = "author"
+	sinceParam    = "since"
+	untilParam    = "until"
 )
 
 var logShortDesc = `Show commit logs`
-var logLongDesc = "Shows the commit logs.\n" +
-	"\n" +
-	"The command takes options to control what is shown and how."
+var logLongDesc = `Shows the commit logs.
+
+The command takes options to control what is shown and how. By default the commits reachable from the current branch's head, or from the <commit> given, are shown. A revision range can be given instead:
+
+dolt log <commit1>..<commit2>
+   Shows the commits reachable from <commit2> but not from <commit1>. Either side may be left out, defaulting to HEAD.
+
+dolt log <commit1>...<commit2>
+   Shows the commits reachable from either <commit1> or <commit2>, but not from both.
+
+Tables given after -- limit the log to the commits which changed at least one of them.
+
+The --format option selects how the commits are shown. The default, text, shows them as readable text. json shows them as a JSON array holding the hash, parents, author, email, date and message of each commit.
+`
 
 var logSynopsis = []string{
-	"[-n <num_commits>] [<commit>]",
+	"[-n <num_commits>] [--oneline] [--graph] [--author <pattern>] [--since <date>] [--until <date>] [--format <format>] [<revision range>] [-- <table>...]",
 }
 
-type commitLoggerFunc func(*doltdb.CommitMeta, []hash.Hash, hash.Hash)
+// commitFormatter returns the lines which describe a commit in the log.
+type commitFormatter func(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) []string
 
-func logToStdOutFunc(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) {
-	cli.Println(color.YellowString("commit %s", ch.String()))
+func formatCommit(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) []string {
+	lines := []string{color.YellowString("commit %s", ch.String())}
 
 	if len(parentHashes) > 1 {
-		printMerge(parentHashes)
+		lines = append(lines, formatMerge(parentHashes))
+	}
+
+	lines = append(lines, "Author: "+cm.Name+" <"+cm.Email+">")
+	lines = append(lines, "Date:   "+cm.FormatTS())
+	lines = append(lines, "")
+	for _, descLine := range strings.Split(cm.Description, "\n") {
+		lines = append(lines, "\t"+descLine)
 	}
 
-	printAuthor(cm)
-	printDate(cm)
-	printDesc(cm)
+	return append(lines, "")
 }
 
-func printMerge(hashes []hash.Hash) {
-	cli.Print("Merge:")
+func formatMerge(hashes []hash.Hash) string {
+	mergeStr := "Merge:"
 	for _, h := range hashes {
-		cli.Print(" " + h.String())
+		mergeStr += " " + h.String()
 	}
-	cli.Println()
+
+	return mergeStr
 }
 
-func printAuthor(cm *doltdb.CommitMeta) {
-	cli.Printf("Author: %s <%s>\n", cm.Name, cm.Email)
+func formatCommitOneLine(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) []string {
+	summary := strings.SplitN(cm.Description, "\n", 2)[0]
+	return []string{color.YellowString(ch.String()) + " " + summary}
 }
 
-func printDate(cm *doltdb.CommitMeta) {
-	timeStr := cm.FormatTS()
-	cli.Println("Date:  ", timeStr)
+// logFilter selects the commits shown by the log. Unset fields don't filter out any commits.
+type logFilter struct {
+	author *regexp.Regexp
+	since  *time.Time
+	until  *time.Time
+	tables []string
 }
 
-func printDesc(cm *doltdb.CommitMeta) {
-	formattedDesc := "\n\t" + strings.Replace(cm.Description, "\n", "\n\t", -1) + "\n"
-	cli.Println(formattedDesc)
+func (f logFilter) isEmpty() bool {
+	return f.author == nil && f.since == nil && f.until == nil && len(f.tables) == 0
 }
 
-func Log(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
-	return logWithLoggerFunc(ctx, commandStr, args, dEnv, logToStdOutFunc)
+// jsonCommit is the JSON representation of a commit written by dolt log --format json.
+type jsonCommit struct {
+	Hash    string   `json:"hash"`
+	Parents []string `json:"parents"`
+	Author  string   `json:"author"`
+	Email   string   `json:"email"`
+	Date    string   `json:"date"`
+	Message string   `json:"message"`
 }
 
-func logWithLoggerFunc(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, loggerFunc commitLoggerFunc) int {
+func Log(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := argparser.NewArgParser()
 	ap.SupportsInt(numLinesParam, "n", "num_commits", "Limit the number of commits to output")
+	ap.SupportsFlag(oneLineParam, "", "Shows each commit on a single line, as its hash and the first line of its message.")
+	ap.SupportsFlag(graphParam, "", "Draws an ASCII graph of the commit history, showing where branches were merged, next to the commits.")
+	ap.SupportsString(authorParam, "", "pattern", "Limit the commits to those whose author name and email, as in \"name <email>\", match the regular expression given.")
+	ap.SupportsString(sinceParam, "", "date", "Limit the commits to those made at or after the date given, such as 2019-08-01 or \"2019-08-01 12:30:00\".")
+	ap.SupportsString(untilParam, "", "date", "Limit the commits to those made at or before the date given, such as 2019-08-01 or \"2019-08-01 12:30:00\".")
+	ap.SupportsString(FormatFlag, "", "format", "How to format the log output. Valid values are text, json. Defaults to text.")
 	help, usage := cli.HelpAndUsagePrinters(commandStr, logShortDesc, logLongDesc, logSynopsis, ap)
+
+	args, tables := splitTableArgs(args)
 	apr := cli.ParseArgs(ap, args, help)
 
-	var cs *doltdb.CommitSpec
-	if apr.NArg() == 0 {
-		cs = dEnv.RepoState.CWBHeadSpec()
-	} else if apr.NArg() == 1 {
-		var err error
-		comSpecStr := apr.Arg(0)
-		cs, err = doltdb.NewCommitSpec(comSpecStr, dEnv.RepoState.Head.Ref.String())
+	if apr.NArg() > 1 {
+		usage()
+		return 1
+	}
+
+	verr := logCommits(ctx, dEnv, apr, tables)
+
+	if verr != nil {
+		cli.PrintErrln(verr.Verbose())
+		return 1
+	}
+
+	return 0
+}
+
+// splitTableArgs splits the arguments given at --, returning the arguments before it and the tables after it.
+func splitTableArgs(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+
+	return args, nil
+}
+
+func logCommits(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, tables []string) errhand.VerboseError {
+	jsonOutput := false
+	if formatStr, ok := apr.GetValue(FormatFlag); ok {
+		switch strings.ToLower(formatStr) {
+		case "text":
+		case "json":
+			jsonOutput = true
+		default:
+			return errhand.BuildDError("Invalid format %s. Valid values are text, json.", formatStr).Build()
+		}
+	}
+
+	graph := apr.Contains(graphParam)
+	if jsonOutput && (graph || apr.Contains(oneLineParam)) {
+		return errhand.BuildDError("--format json can't be used with --graph or --oneline").Build()
+	}
+
+	filter, verr := parseLogFilter(apr, tables)
+
+	if verr != nil {
+		return verr
+	}
+
+	revisions := ""
+	if apr.NArg() == 1 {
+		revisions = apr.Arg(0)
+	}
+
+	n := apr.GetIntOrDefault(numLinesParam, -1)
+	commits, verr := getLogCommits(ctx, dEnv, revisions, graph, filter, n)
+
+	if verr != nil {
+		return verr
+	}
+
+	if jsonOutput {
+		return printJSONLog(ctx, commits)
+	}
+
+	formatter := formatCommit
+	if apr.Contains(oneLineParam) {
+		formatter = formatCommitOneLine
+	}
+
+	var g *logGraph
+	if graph {
+		parents, err := shownParents(ctx, dEnv.DoltDB, commits)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read commit").AddCause(err).Build()
+		}
+
+		g = newLogGraph(parents)
+	}
+
+	for _, cm := range commits {
+		lines, err := commitLogLines(ctx, cm, formatter, g)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read commit").AddCause(err).Build()
+		}
+
+		for _, line := range lines {
+			cli.Println(line)
+		}
+	}
+
+	return nil
+}
+
+func parseLogFilter(apr *argparser.ArgParseResults, tables []string) (logFilter, errhand.VerboseError) {
+	filter := logFilter{tables: tables}
+
+	if authorStr, ok := apr.GetValue(authorParam); ok {
+		author, err := regexp.Compile(authorStr)
 
 		if err != nil {
-			cli.PrintErrf("Invalid commit %s\n", comSpecStr)
-			return 1
+			return logFilter{}, errhand.BuildDError("error: invalid author pattern '%s'", authorStr).AddCause(err).Build()
+		}
+
+		filter.author = author
+	}
+
+	for _, param := range []string{sinceParam, untilParam} {
+		if dateStr, ok := apr.GetValue(param); ok {
+			ts, err := types.ParseTimestamp(dateStr)
+
+			if err != nil {
+				return logFilter{}, errhand.BuildDError("error: invalid --%s date '%s'", param, dateStr).Build()
+			}
+
+			t := time.Time(ts)
+			if param == sinceParam {
+				filter.since = &t
+			} else {
+				filter.until = &t
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// getLogCommits returns the commits in the revision range given, which are selected by the filter given, in the order
+// they should be shown. Commits are sorted by time, or in topological order if they're being drawn as a graph. At most
+// n commits are returned, unless n is negative.
+func getLogCommits(ctx context.Context, dEnv *env.DoltEnv, revisions string, graph bool, filter logFilter, n int) ([]*doltdb.Commit, errhand.VerboseError) {
+	limit := n
+	if !filter.isEmpty() {
+		limit = -1
+	}
+
+	var commits []*doltdb.Commit
+	var err error
+	if strings.Contains(revisions, "...") {
+		commits, err = symmetricDifferenceCommits(ctx, dEnv, strings.SplitN(revisions, "...", 2))
+	} else if strings.Contains(revisions, "..") {
+		var hashes []hash.Hash
+		if hashes, err = resolveCommitHashes(ctx, dEnv, strings.SplitN(revisions, "..", 2)); err == nil {
+			commits, err = commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, hashes[1], hashes[0], limit)
 		}
 	} else {
-		usage()
-		return 1
+		var cm *doltdb.Commit
+		if cm, err = resolveLogCommit(ctx, dEnv, revisions); err == nil {
+			if graph {
+				var h hash.Hash
+				if h, err = cm.HashOf(); err == nil {
+					commits, err = commitwalk.GetTopologicalOrderCommits(ctx, dEnv.DoltDB, h)
+				}
+			} else {
+				commits, err = actions.TimeSortedCommits(ctx, dEnv.DoltDB, cm, limit)
+			}
+		}
 	}
 
-	commit, err := dEnv.DoltDB.Resolve(ctx, cs)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get the commits in '%s'", revisions).AddCause(err).Build()
+	}
+
+	filtered, err := filterCommits(ctx, dEnv.DoltDB, commits, filter)
 
 	if err != nil {
-		cli.PrintErrln(color.HiRedString("Fatal error: cannot get HEAD commit for current branch."))
-		return 1
+		return nil, errhand.BuildDError("error: failed to filter commits").AddCause(err).Build()
 	}
 
-	n := apr.GetIntOrDefault(numLinesParam, -1)
-	commits, err := actions.TimeSortedCommits(ctx, dEnv.DoltDB, commit, n)
+	if n >= 0 && len(filtered) > n {
+		filtered = filtered[:n]
+	}
+
+	return filtered, nil
+}
+
+// resolveLogCommit resolves the commit spec given, which is HEAD if empty.
+func resolveLogCommit(ctx context.Context, dEnv *env.DoltEnv, csStr string) (*doltdb.Commit, error) {
+	cs := dEnv.RepoState.CWBHeadSpec()
+	if csStr != "" {
+		var err error
+		cs, err = doltdb.NewCommitSpec(csStr, dEnv.RepoState.Head.Ref.String())
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dEnv.DoltDB.Resolve(ctx, cs)
+}
+
+func resolveCommitHashes(ctx context.Context, dEnv *env.DoltEnv, csStrs []string) ([]hash.Hash, error) {
+	hashes := make([]hash.Hash, len(csStrs))
+	for i, csStr := range csStrs {
+		cm, err := resolveLogCommit(ctx, dEnv, csStr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[i], err = cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hashes, nil
+}
+
+// symmetricDifferenceCommits returns the commits reachable from exactly one of the two commit specs given, in
+// topological order.
+func symmetricDifferenceCommits(ctx context.Context, dEnv *env.DoltEnv, csStrs []string) ([]*doltdb.Commit, error) {
+	hashes, err := resolveCommitHashes(ctx, dEnv, csStrs)
 
 	if err != nil {
-		cli.PrintErrln("Error retrieving commit.")
-		return 1
+		return nil, err
+	}
+
+	commits, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, hashes[0], hashes[1], -1)
+
+	if err != nil {
+		return nil, err
 	}
 
-	for _, comm := range commits {
-		meta, err := comm.GetCommitMeta()
+	otherCommits, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, hashes[1], hashes[0], -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits = append(commits, otherCommits...)
+	heights := make([]uint64, len(commits))
+	for i, cm := range commits {
+		if heights[i], err = cm.Height(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Sort(commitsByHeight{commits, heights})
+	return commits, nil
+}
+
+// commitsByHeight sorts commits from the highest to the lowest.
+type commitsByHeight struct {
+	commits []*doltdb.Commit
+	heights []uint64
+}
+
+func (c commitsByHeight) Len() int {
+	return len(c.commits)
+}
+
+func (c commitsByHeight) Less(i, j int) bool {
+	return c.heights[i] > c.heights[j]
+}
+
+func (c commitsByHeight) Swap(i, j int) {
+	c.commits[i], c.commits[j] = c.commits[j], c.commits[i]
+	c.heights[i], c.heights[j] = c.heights[j], c.heights[i]
+}
+
+// filterCommits returns the commits given which are selected by the filter given, in the same order.
+func filterCommits(ctx context.Context, ddb *doltdb.DoltDB, commits []*doltdb.Commit, filter logFilter) ([]*doltdb.Commit, error) {
+	if filter.isEmpty() {
+		return commits, nil
+	}
+
+	var filtered []*doltdb.Commit
+	for _, cm := range commits {
+		meta, err := cm.GetCommitMeta()
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get commit metadata")
-			return 1
+			return nil, err
+		}
+
+		if filter.author != nil && !filter.author.MatchString(meta.Name+" <"+meta.Email+">") {
+			continue
+		}
+
+		if filter.since != nil && meta.Time().Before(*filter.since) {
+			continue
 		}
 
-		pHashes, err := comm.ParentHashes(ctx)
+		if filter.until != nil && meta.Time().After(*filter.until) {
+			continue
+		}
+
+		if len(filter.tables) > 0 {
+			changed, err := changesTables(ctx, ddb, cm, filter.tables)
+
+			if err != nil {
+				return nil, err
+			} else if !changed {
+				continue
+			}
+		}
+
+		filtered = append(filtered, cm)
+	}
+
+	return filtered, nil
+}
+
+// changesTables returns whether the commit given changed any of the tables given. A table is changed by a commit if its
+// hash differs from its hash in each of the commit's parents. A commit without parents changes each table it has.
+func changesTables(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tables []string) (bool, error) {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return false, err
+	}
+
+	parentRoots := make([]*doltdb.RootValue, numParents)
+	for i := range parentRoots {
+		parent, err := ddb.ResolveParent(ctx, cm, i)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get parent hashes")
-			return 1
+			return false, err
+		}
+
+		if parentRoots[i], err = parent.GetRootValue(); err != nil {
+			return false, err
 		}
+	}
 
-		cmHash, err := comm.HashOf()
+	for _, tblName := range tables {
+		h, _, err := root.GetTableHash(ctx, tblName)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get commit hash")
-			return 1
+			return false, err
+		}
+
+		changed := numParents > 0 || !h.IsEmpty()
+		for _, parentRoot := range parentRoots {
+			parentHash, _, err := parentRoot.GetTableHash(ctx, tblName)
+
+			if err != nil {
+				return false, err
+			}
+
+			if parentHash == h {
+				changed = false
+				break
+			}
+		}
+
+		if changed {
+			return true, nil
 		}
-		loggerFunc(meta, pHashes, cmHash)
 	}
 
-	return 0
+	return false, nil
+}
+
+// commitLogLines returns the lines for the commit given, drawn next to the graph given if it's not nil.
+func commitLogLines(ctx context.Context, cm *doltdb.Commit, formatter commitFormatter, g *logGraph) ([]string, error) {
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	pHashes, err := cm.ParentHashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cmHash, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := formatter(meta, pHashes, cmHash)
+
+	if g == nil {
+		return lines, nil
+	}
+
+	return g.commitLines(cmHash, lines), nil
+}
+
+// shownParents returns the parents of each of the commits given among the commits given. A parent which isn't one of
+// the commits given is replaced by its nearest ancestors which are.
+func shownParents(ctx context.Context, ddb *doltdb.DoltDB, shown []*doltdb.Commit) (map[hash.Hash][]hash.Hash, error) {
+	isShown := make(map[hash.Hash]bool)
+	minHeight := ^uint64(0)
+	for _, cm := range shown {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		height, err := cm.Height()
+
+		if err != nil {
+			return nil, err
+		}
+
+		isShown[h] = true
+		if height < minHeight {
+			minHeight = height
+		}
+	}
+
+	// hiddenParents memoizes the shown ancestors which replace each hidden commit
+	hiddenParents := make(map[hash.Hash][]hash.Hash)
+	var resolveParents func(cm *doltdb.Commit) ([]hash.Hash, error)
+	resolveParents = func(cm *doltdb.Commit) ([]hash.Hash, error) {
+		pHashes, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var resolved []hash.Hash
+		for _, p := range pHashes {
+			if isShown[p] {
+				if !containsHash(resolved, p) {
+					resolved = append(resolved, p)
+				}
+
+				continue
+			}
+
+			replacements, ok := hiddenParents[p]
+			if !ok {
+				cs, err := doltdb.NewCommitSpec(p.String(), "")
+
+				if err != nil {
+					return nil, err
+				}
+
+				parent, err := ddb.Resolve(ctx, cs)
+
+				if err != nil {
+					return nil, err
+				}
+
+				// commits lower than every shown commit can't have shown ancestors
+				if height, err := parent.Height(); err != nil {
+					return nil, err
+				} else if height >= minHeight {
+					if replacements, err = resolveParents(parent); err != nil {
+						return nil, err
+					}
+				}
+
+				hiddenParents[p] = replacements
+			}
+
+			for _, r := range replacements {
+				if !containsHash(resolved, r) {
+					resolved = append(resolved, r)
+				}
+			}
+		}
+
+		return resolved, nil
+	}
+
+	parents := make(map[hash.Hash][]hash.Hash)
+	for _, cm := range shown {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if parents[h], err = resolveParents(cm); err != nil {
+			return nil, err
+		}
+	}
+
+	return parents, nil
+}
+
+func containsHash(hashes []hash.Hash, h hash.Hash) bool {
+	for _, other := range hashes {
+		if other == h {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printJSONLog(ctx context.Context, commits []*doltdb.Commit) errhand.VerboseError {
+	jsonCommits := make([]jsonCommit, 0, len(commits))
+	for _, cm := range commits {
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+		}
+
+		pHashes, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get parent hashes").AddCause(err).Build()
+		}
+
+		cmHash, err := cm.HashOf()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build()
+		}
+
+		parents := make([]string, len(pHashes))
+		for i, h := range pHashes {
+			parents[i] = h.String()
+		}
+
+		jsonCommits = append(jsonCommits, jsonCommit{
+			Hash:    cmHash.String(),
+			Parents: parents,
+			Author:  meta.Name,
+			Email:   meta.Email,
+			Date:    meta.Time().Format(time.RFC3339),
+			Message: meta.Description,
+		})
+	}
+
+	data, err := json.MarshalIndent(jsonCommits, "", "  ")
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write json").AddCause(err).Build()
+	}
+
+	cli.Println(string(data))
+	return nil
 }
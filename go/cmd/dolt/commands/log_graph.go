@@ -0,0 +1,175 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// logGraph draws the ASCII graph of the commit history shown by dolt log --graph. Commits must be added in topological
+// order, children first. Each column of the graph is a line of history, and holds the commit expected next on it.
+type logGraph struct {
+	parents map[hash.Hash][]hash.Hash
+	cols    []hash.Hash
+}
+
+// newLogGraph returns a graph of the commits whose parents are given.
+func newLogGraph(parents map[hash.Hash][]hash.Hash) *logGraph {
+	return &logGraph{parents: parents}
+}
+
+// commitLines returns the lines describing the commit given, with the graph drawn to their left, and moves the graph
+// on to the commit's parents.
+func (g *logGraph) commitLines(h hash.Hash, lines []string) []string {
+	var out []string
+
+	idx := -1
+	var merged []int
+	for i, col := range g.cols {
+		if col == h {
+			if idx == -1 {
+				idx = i
+			} else {
+				merged = append(merged, i)
+			}
+		}
+	}
+
+	// several lines of history lead to this commit, so all but the first join it
+	if len(merged) > 0 {
+		out = append(out, g.joinLine(merged, true))
+		g.cols = removeCols(g.cols, merged)
+	}
+
+	if idx == -1 {
+		g.cols = append(g.cols, h)
+		idx = len(g.cols) - 1
+	}
+
+	out = append(out, g.prefix(idx)+lines[0])
+
+	parents := g.parents[h]
+	var afterCommit string
+	if len(parents) == 0 {
+		if idx < len(g.cols)-1 {
+			afterCommit = g.joinLine([]int{idx}, false)
+		}
+
+		g.cols = removeCols(g.cols, []int{idx})
+	} else {
+		g.cols[idx] = parents[0]
+
+		var newCols []hash.Hash
+		for _, p := range parents[1:] {
+			if !containsHash(g.cols, p) && !containsHash(newCols, p) {
+				newCols = append(newCols, p)
+			}
+		}
+
+		if len(newCols) > 0 {
+			cols := make([]hash.Hash, 0, len(g.cols)+len(newCols))
+			cols = append(cols, g.cols[:idx+1]...)
+			cols = append(cols, newCols...)
+			g.cols = append(cols, g.cols[idx+1:]...)
+			afterCommit = g.expandLine(idx + 1)
+		}
+	}
+
+	if afterCommit != "" {
+		out = append(out, afterCommit)
+	}
+
+	for _, line := range lines[1:] {
+		out = append(out, strings.TrimRight(strings.Repeat("| ", len(g.cols))+line, " "))
+	}
+
+	return out
+}
+
+// prefix returns the graph drawn to the left of a commit in the column given.
+func (g *logGraph) prefix(commitCol int) string {
+	var sb strings.Builder
+	for i := range g.cols {
+		if i == commitCol {
+			sb.WriteString("* ")
+		} else {
+			sb.WriteString("| ")
+		}
+	}
+
+	return sb.String()
+}
+
+// joinLine returns the line drawn before the columns given are removed, with the columns after each of them moving
+// left. Removed columns are drawn joining the column to their left if drawRemoved is true.
+func (g *logGraph) joinLine(removed []int, drawRemoved bool) string {
+	line := []rune(strings.Repeat(" ", 2*len(g.cols)))
+
+	shifted := false
+	for i := range g.cols {
+		if containsInt(removed, i) {
+			shifted = true
+
+			if drawRemoved {
+				line[2*i-1] = '/'
+			}
+		} else if shifted {
+			line[2*i-1] = '/'
+		} else {
+			line[2*i] = '|'
+		}
+	}
+
+	return strings.TrimRight(string(line), " ")
+}
+
+// expandLine returns the line drawn after columns are inserted at the index given, with the columns after them moving
+// right.
+func (g *logGraph) expandLine(insertAt int) string {
+	line := []rune(strings.Repeat(" ", 2*len(g.cols)))
+
+	for i := range g.cols {
+		if i < insertAt {
+			line[2*i] = '|'
+		} else {
+			line[2*i-1] = '\\'
+		}
+	}
+
+	return strings.TrimRight(string(line), " ")
+}
+
+func removeCols(cols []hash.Hash, removed []int) []hash.Hash {
+	var remaining []hash.Hash
+	for i, col := range cols {
+		if !containsInt(removed, i) {
+			remaining = append(remaining, col)
+		}
+	}
+
+	return remaining
+}
+
+func containsInt(ints []int, n int) bool {
+	for _, other := range ints {
+		if other == n {
+			return true
+		}
+	}
+
+	return false
+}
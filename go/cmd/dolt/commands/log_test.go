@@ -16,10 +16,20 @@ package commands
 
 import (
 	"context"
+	"regexp"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -36,3 +46,220 @@ func TestLog(t *testing.T) {
 
 	cli.Println(commit)
 }
+
+// createBranchesToLog creates a history in which the branch feature, which changed the table tbl, was merged into
+// master, which changed the table other. The branch premerge points at master before the merge.
+func createBranchesToLog(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "create table other (id bigint primary key)")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"-b", "feature"}, dEnv))
+	commitQueries(t, dEnv, "feature 1", "insert into tbl (id, v) values (1, 1)")
+	commitQueries(t, dEnv, "feature 2", "insert into tbl (id, v) values (2, 2)")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"master"}, dEnv))
+	commitQueries(t, dEnv, "master 1", "insert into other (id) values (1)")
+	require.NoError(t, actions.CreateBranch(ctx, dEnv, "premerge", "master", false))
+	require.Equal(t, 0, Merge(ctx, "dolt merge", []string{"feature"}, dEnv))
+	commitQueries(t, dEnv, "merge")
+	commitQueries(t, dEnv, "master 2", "insert into other (id) values (2)")
+
+	return dEnv
+}
+
+func TestLogCommits(t *testing.T) {
+	dEnv := createBranchesToLog(t)
+	all := []string{"master 2", "merge", "master 1", "feature 2", "feature 1", "base", "Data repository created."}
+
+	tests := []struct {
+		name      string
+		revisions string
+		filter    logFilter
+		n         int
+		expected  []string
+	}{
+		{
+			name:     "all commits",
+			n:        -1,
+			expected: all,
+		},
+		{
+			name:     "limited",
+			n:        2,
+			expected: []string{"master 2", "merge"},
+		},
+		{
+			name:      "commit",
+			revisions: "feature",
+			n:         -1,
+			expected:  []string{"feature 2", "feature 1", "base", "Data repository created."},
+		},
+		{
+			name:      "dot dot range",
+			revisions: "feature..master",
+			n:         -1,
+			expected:  []string{"master 2", "merge", "master 1"},
+		},
+		{
+			name:      "dot dot range defaulting to head",
+			revisions: "feature..",
+			n:         -1,
+			expected:  []string{"master 2", "merge", "master 1"},
+		},
+		{
+			name:      "symmetric difference",
+			revisions: "premerge...feature",
+			n:         -1,
+			expected:  []string{"master 1", "feature 2", "feature 1"},
+		},
+		{
+			name:     "table",
+			filter:   logFilter{tables: []string{"tbl"}},
+			n:        -1,
+			expected: []string{"feature 2", "feature 1", "base"},
+		},
+		{
+			name:     "tables",
+			filter:   logFilter{tables: []string{"tbl", "other"}},
+			n:        -1,
+			expected: []string{"master 2", "master 1", "feature 2", "feature 1", "base"},
+		},
+		{
+			name:     "table limited",
+			filter:   logFilter{tables: []string{"tbl"}},
+			n:        1,
+			expected: []string{"feature 2"},
+		},
+		{
+			name:     "unknown table",
+			filter:   logFilter{tables: []string{"unknown"}},
+			n:        -1,
+			expected: nil,
+		},
+		{
+			name:     "author",
+			filter:   logFilter{author: regexp.MustCompile(`^billy bob <bigbillieb@fake\.horse>$`)},
+			n:        -1,
+			expected: all,
+		},
+		{
+			name:     "other author",
+			filter:   logFilter{author: regexp.MustCompile(`someone else`)},
+			n:        -1,
+			expected: nil,
+		},
+		{
+			name:     "since",
+			filter:   logFilter{since: timePtr(time.Now().Add(-time.Hour))},
+			n:        -1,
+			expected: all,
+		},
+		{
+			name:     "until",
+			filter:   logFilter{until: timePtr(time.Now().Add(-time.Hour))},
+			n:        -1,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, verr := getLogCommits(context.Background(), dEnv, tt.revisions, true, tt.filter, tt.n)
+			require.Nil(t, verr)
+			messages := commitMessages(t, commits)
+
+			// commits of the same height are in no particular order, so only the first n commits are compared in order
+			if tt.n < 0 {
+				assert.ElementsMatch(t, tt.expected, messages)
+			} else {
+				assert.Equal(t, tt.expected, messages)
+			}
+		})
+	}
+}
+
+func TestLogGraph(t *testing.T) {
+	h := make([]hash.Hash, 5)
+	for i := range h {
+		h[i] = hash.Of([]byte{byte(i)})
+	}
+
+	tests := []struct {
+		name     string
+		parents  map[hash.Hash][]hash.Hash
+		expected []string
+	}{
+		{
+			name:     "linear",
+			parents:  map[hash.Hash][]hash.Hash{h[0]: {h[1]}, h[1]: {h[2]}, h[2]: {h[3]}, h[3]: {h[4]}},
+			expected: []string{"* 0", "* 1", "* 2", "* 3", "* 4"},
+		},
+		{
+			name:    "merge",
+			parents: map[hash.Hash][]hash.Hash{h[0]: {h[1], h[2]}, h[1]: {h[3]}, h[2]: {h[3]}, h[3]: {h[4]}},
+			expected: []string{
+				"* 0",
+				"|\\",
+				"* | 1",
+				"| * 2",
+				"|/",
+				"* 3",
+				"* 4",
+			},
+		},
+		{
+			name:    "branches with different roots",
+			parents: map[hash.Hash][]hash.Hash{h[0]: {h[1], h[2]}, h[1]: {h[3]}, h[2]: {h[4]}},
+			expected: []string{
+				"* 0",
+				"|\\",
+				"* | 1",
+				"| * 2",
+				"* | 3",
+				" /",
+				"* 4",
+			},
+		},
+		{
+			name:    "octopus merge",
+			parents: map[hash.Hash][]hash.Hash{h[0]: {h[1], h[2], h[3]}, h[1]: {h[4]}, h[2]: {h[4]}, h[3]: {h[4]}},
+			expected: []string{
+				"* 0",
+				"|\\ \\",
+				"* | | 1",
+				"| * | 2",
+				"| | * 3",
+				"|/ /",
+				"* 4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newLogGraph(tt.parents)
+			var lines []string
+			for i, c := range h {
+				lines = append(lines, g.commitLines(c, []string{strconv.Itoa(i)})...)
+			}
+
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func commitMessages(t *testing.T, commits []*doltdb.Commit) []string {
+	var messages []string
+	for _, cm := range commits {
+		meta, err := cm.GetCommitMeta()
+		require.NoError(t, err)
+		messages = append(messages, meta.Description)
+	}
+
+	return messages
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -33,7 +33,8 @@ import (
 )
 
 const (
-	abortParam = "abort"
+	abortParam    = "abort"
+	strategyParam = "strategy"
 )
 
 var mergeShortDest = "Join two or more development histories together"
@@ -47,9 +48,21 @@ var mergeLongDesc = "Incorporates changes from the named commits (since the time
 	"Therefore: \n" +
 	"\n" +
 	"<b>Warning</b>: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may " +
-	"leave you in a state that is hard to back out of in the case of a conflict."
+	"leave you in a state that is hard to back out of in the case of a conflict.\n" +
+	"\n" +
+	"Rows changed in both histories are merged cell-wise by default: a conflict is recorded if both change the same " +
+	"column of a row to different values, or one removes a row the other modifies. A table can be merged with a " +
+	"different strategy by setting <b>merge.strategy.<table></b> in the config, and <b>--strategy</b> merges every " +
+	"table with the strategy given. The strategies are:\n" +
+	"\n" +
+	"\t<b>cell-wise</b> - the default.\n" +
+	"\t<b>ours</b> - changes that would conflict take the value from the current branch.\n" +
+	"\t<b>theirs</b> - changes that would conflict take the value from the branch being merged.\n" +
+	"\t<b>numeric-additive</b> - numeric columns changed in both histories take the sum of both changes.\n" +
+	"\t<b>last-writer-by-commit-time</b> - changes that would conflict take the value from whichever of the two " +
+	"commits being merged was made last."
 var mergeSynopsis = []string{
-	"<branch>",
+	"[--strategy <strategy>] <branch>",
 	"--abort",
 }
 
@@ -62,6 +75,7 @@ var abortDetails = "Abort the current conflict resolution process, and try to re
 func Merge(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(abortParam, "", abortDetails)
+	ap.SupportsString(strategyParam, "", "strategy", "Merges every table with the strategy given, overriding the strategies set in the config.")
 	help, usage := cli.HelpAndUsagePrinters(commandStr, mergeShortDest, mergeLongDesc, mergeSynopsis, ap)
 	apr := cli.ParseArgs(ap, args, help)
 
@@ -79,6 +93,20 @@ func Merge(ctx context.Context, commandStr string, args []string, dEnv *env.Dolt
 			return 1
 		}
 
+		strategies := actions.ConfigMergeStrategies(dEnv)
+		if name, ok := apr.GetValue(strategyParam); ok {
+			strategy, err := merge.ParseStrategy(name)
+
+			if err != nil {
+				cli.PrintErrln(color.RedString(err.Error()))
+				return 1
+			}
+
+			strategies = func(string) (merge.Strategy, error) {
+				return strategy, nil
+			}
+		}
+
 		branchName := apr.Arg(0)
 		dref, err := dEnv.FindRef(ctx, branchName)
 
@@ -116,7 +144,7 @@ func Merge(ctx context.Context, commandStr string, args []string, dEnv *env.Dolt
 			}
 
 			if verr == nil {
-				verr = mergeBranch(ctx, dEnv, dref)
+				verr = mergeBranch(ctx, dEnv, dref, strategies)
 			}
 		}
 	}
@@ -138,7 +166,7 @@ func abortMerge(ctx context.Context, doltEnv *env.DoltEnv) errhand.VerboseError
 	return errhand.BuildDError("fatal: failed to revert changes").AddCause(err).Build()
 }
 
-func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef) errhand.VerboseError {
+func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef, strategies merge.StrategyForTable) errhand.VerboseError {
 	cm1, verr := ResolveCommitWithVErr(dEnv, "HEAD", dEnv.RepoState.Head.Ref.String())
 
 	if verr != nil {
@@ -176,7 +204,7 @@ func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef) errha
 		cli.Println("Already up to date.")
 		return nil
 	} else {
-		return executeMerge(ctx, dEnv, cm1, cm2, dref)
+		return executeMerge(ctx, dEnv, cm1, cm2, dref, strategies)
 	}
 }
 
@@ -219,8 +247,8 @@ and take the hash for your current branch and use it for the value for "staged"
 	return nil
 }
 
-func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, dref ref.DoltRef) errhand.VerboseError {
-	mergedRoot, tblToStats, err := actions.MergeCommits(ctx, dEnv.DoltDB, cm1, cm2)
+func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, dref ref.DoltRef, strategies merge.StrategyForTable) errhand.VerboseError {
+	mergedRoot, tblToStats, err := actions.MergeCommits(ctx, dEnv.DoltDB, cm1, cm2, strategies)
 
 	if err != nil {
 		switch err {
@@ -260,11 +288,26 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 
 func printSuccessStats(tblToStats map[string]*merge.MergeStats) bool {
 	printModifications(tblToStats)
+	printStrategies(tblToStats)
 	printAdditions(tblToStats)
 	printDeletions(tblToStats)
 	return printConflicts(tblToStats)
 }
 
+func printStrategies(tblToStats map[string]*merge.MergeStats) {
+	var tbls []string
+	for tblName, stats := range tblToStats {
+		if stats.Strategy != "" && stats.Strategy != merge.CellWise {
+			tbls = append(tbls, tblName)
+		}
+	}
+
+	sort.Strings(tbls)
+	for _, tbl := range tbls {
+		cli.Println("Merged", tbl, "with the", tblToStats[tbl].Strategy, "strategy")
+	}
+}
+
 func printAdditions(tblToStats map[string]*merge.MergeStats) {
 	for tblName, stats := range tblToStats {
 		if stats.Operation == merge.TableRemoved {
@@ -290,6 +333,18 @@ func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 
 			hasConflicts = true
 		}
+
+		if stats.SchemaConflicts > 0 {
+			cli.Println("CONFLICT (schema): Merge conflict in schema of", tblName)
+
+			hasConflicts = true
+		}
+
+		if stats.ForeignKeyViolations > 0 {
+			cli.Println("CONFLICT (foreign key): Foreign key violations in", tblName)
+
+			hasConflicts = true
+		}
 	}
 
 	return hasConflicts
@@ -303,7 +358,7 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	rowsChanged := 0
 	var tbls []string
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts == 0 {
+		if stats.Operation == merge.TableModified && stats.Conflicts == 0 && stats.SchemaConflicts == 0 && stats.ForeignKeyViolations == 0 {
 			tbls = append(tbls, tblName)
 			nameLen := len(tblName)
 			modCount := stats.Adds + stats.Modifications + stats.Deletes + stats.Conflicts
@@ -20,6 +20,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 )
@@ -87,5 +88,11 @@ func pullRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, srcR
 		return verr
 	}
 
-	return mergeBranch(ctx, dEnv, destRef)
+	verr = fetchTags(ctx, r.Name, srcDB, dEnv.DoltDB)
+
+	if verr != nil {
+		return verr
+	}
+
+	return mergeBranch(ctx, dEnv, destRef, actions.ConfigMergeStrategies(dEnv))
 }
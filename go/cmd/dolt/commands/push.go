@@ -48,6 +48,9 @@ var pushLongDesc = "Updates remote refs using local refs, while sending objects
 	"\n" +
 	"\nWhen the command line does not specify what to push with <refspec>... then the current branch will be used." +
 	"\n" +
+	"\nTags are pushed with a refspec mapping tags to tags, e.g. refs/tags/v1.0, or refs/tags/*:refs/tags/* to push all " +
+	"tags.  Tags that already exist in the remote with a different value are rejected, as tags can't be moved." +
+	"\n" +
 	"\nWhen neither the command-line does not specify what to push, the default behavior is used, which corresponds to the " +
 	"current branch being pushed to the corresponding upstream branch, but as a safety measure, the push is aborted if " +
 	"the upstream branch does not have the same name as the local one."
@@ -139,6 +142,16 @@ func Push(ctx context.Context, commandStr string, args []string, dEnv *env.DoltE
 		return 1
 	}
 
+	if tagRefSpec, ok := refSpec.(ref.TagToTagRefSpec); ok && verr == nil {
+		if apr.Contains(SetUpstreamFlag) {
+			verr = errhand.BuildDError("error: --set-upstream can't be used when pushing tags.").Build()
+		} else {
+			verr = pushTags(ctx, tagRefSpec, dEnv.DoltDB, remote)
+		}
+
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	if verr == nil {
 		hasRef, err := dEnv.DoltDB.HasRef(ctx, currentBranch)
 
@@ -157,21 +170,7 @@ func Push(ctx context.Context, commandStr string, args []string, dEnv *env.DoltE
 				destDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
 
 				if err != nil {
-					bdr := errhand.BuildDError("error: failed to get remote db").AddCause(err)
-
-					if err == remotestorage.ErrInvalidDoltSpecPath {
-						urlObj, _ := earl.Parse(remote.Url)
-						bdr.AddDetails("For the remote: %s %s", remote.Name, remote.Url)
-
-						path := urlObj.Path
-						if path[0] == '/' {
-							path = path[1:]
-						}
-
-						bdr.AddDetails("'%s' should be in the format 'organization/repo'", path)
-					}
-
-					verr = bdr.Build()
+					verr = remoteDBErr(err, remote)
 				} else if src == ref.EmptyBranchRef {
 					verr = deleteRemoteBranch(ctx, dest, remoteRef, dEnv.DoltDB, destDB, remote)
 				} else {
@@ -201,6 +200,82 @@ func Push(ctx context.Context, commandStr string, args []string, dEnv *env.DoltE
 	return HandleVErrAndExitCode(verr, usage)
 }
 
+func remoteDBErr(err error, remote env.Remote) errhand.VerboseError {
+	bdr := errhand.BuildDError("error: failed to get remote db").AddCause(err)
+
+	if err == remotestorage.ErrInvalidDoltSpecPath {
+		urlObj, _ := earl.Parse(remote.Url)
+		bdr.AddDetails("For the remote: %s %s", remote.Name, remote.Url)
+
+		path := urlObj.Path
+		if path[0] == '/' {
+			path = path[1:]
+		}
+
+		bdr.AddDetails("'%s' should be in the format 'organization/repo'", path)
+	}
+
+	return bdr.Build()
+}
+
+// pushTags pushes every local tag matching the refspec given to the remote. Tags that already exist on the remote with
+// a different value are rejected.
+func pushTags(ctx context.Context, refSpec ref.TagToTagRefSpec, localDB *doltdb.DoltDB, remote env.Remote) errhand.VerboseError {
+	tags, err := actions.GetTags(ctx, localDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read tags from db").AddCause(err).Build()
+	}
+
+	remoteDB, err := remote.GetRemoteDB(ctx, localDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return remoteDBErr(err, remote)
+	}
+
+	matched := false
+	rejected := false
+	for _, tag := range tags {
+		dest := refSpec.DestRef(tag.GetRef())
+
+		if dest == nil {
+			continue
+		}
+
+		matched = true
+		progChan := make(chan datas.PullProgress, 16)
+		stopChan := make(chan struct{})
+		go progFunc(progChan, stopChan)
+
+		err = actions.PushTag(ctx, dest.(ref.TagRef), localDB, remoteDB, tag, progChan)
+
+		close(progChan)
+		<-stopChan
+
+		if err == doltdb.ErrTagExists {
+			if !rejected {
+				cli.Printf("To %s\n", remote.Url)
+			}
+
+			rejected = true
+			cli.Printf("! [rejected]          %s -> %s (already exists)\n", tag.GetRef().String(), dest.String())
+		} else if err != nil && err != doltdb.ErrUpToDate {
+			return errhand.BuildDError("error: failed to push tag '%s'", tag.Name()).AddCause(err).Build()
+		}
+	}
+
+	if !matched {
+		return errhand.BuildDError("error: src refspec does not match any tags.").Build()
+	}
+
+	if rejected {
+		cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
+		cli.Println("hint: Updates were rejected because the tag already exists in the remote.")
+	}
+
+	return nil
+}
+
 func getTrackingRef(branchRef ref.DoltRef, remote env.Remote) (ref.DoltRef, errhand.VerboseError) {
 	for _, fsStr := range remote.FetchSpecs {
 		fs, err := ref.ParseRefSpecForRemote(remote.Name, fsStr)
@@ -0,0 +1,87 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+var rebaseShortDesc = "Reapply commits on top of another branch"
+var rebaseLongDesc = "Replays the commits of the current branch that aren't in the history of <upstream> on top of " +
+	"<upstream>, one at a time and in order, and points the current branch at the last replayed commit. Each commit " +
+	"keeps its author and message. Only the first-parent history of the branch is replayed, so the changes a merge " +
+	"commit brought in are replayed as a single ordinary commit.\n" +
+	"\n" +
+	"If replaying a commit results in conflicts, the rebase stops so that the conflicts can be resolved with " +
+	"<b>dolt conflicts</b>. Once the resolved tables have been staged with <b>dolt add</b>, " +
+	"<b>dolt rebase --continue</b> records the commit and replays the rest. <b>dolt rebase --abort</b> points the " +
+	"branch back at the commit it pointed to before the rebase started."
+var rebaseSynopsis = []string{
+	"<upstream>",
+	"--continue",
+	"--abort",
+}
+
+func Rebase(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(continueParam, "", "Record the commit whose conflicts have been resolved, and replay the rest.")
+	ap.SupportsFlag(abortParam, "", "Abort the rebase and point the branch back at the commit it pointed to before the rebase started.")
+	help, usage := cli.HelpAndUsagePrinters(commandStr, rebaseShortDesc, rebaseLongDesc, rebaseSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if verr := checkReplayOperation(dEnv, actions.RebaseOperation, apr); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	var verr errhand.VerboseError
+	if apr.Contains(abortParam) {
+		verr = abortReplay(ctx, dEnv)
+	} else if apr.Contains(continueParam) {
+		tblToStats, err := actions.ContinueReplay(ctx, dEnv)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+
+		if err == nil {
+			cli.Println("Successfully rebased and updated", dEnv.RepoState.CWBHeadRef().String())
+		}
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		var upstream *doltdb.Commit
+		upstream, verr = ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		tblToStats, err := actions.Rebase(ctx, dEnv, upstream)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+
+		if err == nil {
+			cli.Println("Successfully rebased and updated", dEnv.RepoState.CWBHeadRef().String())
+		}
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
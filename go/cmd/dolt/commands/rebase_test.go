@@ -0,0 +1,198 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// commitQueries runs the queries given and commits the result on the current branch.
+func commitQueries(t *testing.T, dEnv *env.DoltEnv, msg string, queries ...string) {
+	ctx := context.Background()
+	for _, query := range queries {
+		require.Equal(t, 0, Sql(ctx, "dolt sql", []string{"-q", query}, dEnv))
+	}
+
+	require.Equal(t, 0, Add(ctx, "dolt add", []string{"."}, dEnv))
+	require.Equal(t, 0, Commit(ctx, "dolt commit", []string{"-m", msg}, dEnv))
+}
+
+// readValues returns the value of the column v of the table tbl in the working root, keyed by id.
+func readValues(t *testing.T, dEnv *env.DoltEnv) map[int64]int64 {
+	ctx := context.Background()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, ok, err := root.GetTable(ctx, "tbl")
+	require.NoError(t, err)
+	require.True(t, ok)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	rowData, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+
+	idTag := sch.GetAllCols().NameToCol["id"].Tag
+	vTag := sch.GetAllCols().NameToCol["v"].Tag
+	values := make(map[int64]int64)
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		require.NoError(t, err)
+		id, _ := r.GetColVal(idTag)
+		v, _ := r.GetColVal(vTag)
+		values[int64(id.(types.Int))] = int64(v.(types.Int))
+		return nil
+	})
+	require.NoError(t, err)
+
+	return values
+}
+
+func headMessage(t *testing.T, dEnv *env.DoltEnv) string {
+	cm, err := dEnv.DoltDB.Resolve(context.Background(), dEnv.RepoState.CWBHeadSpec())
+	require.NoError(t, err)
+	meta, err := cm.GetCommitMeta()
+	require.NoError(t, err)
+	return meta.Description
+}
+
+func createBranchesToReplay(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1), (2, 2)")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"-b", "feature"}, dEnv))
+	commitQueries(t, dEnv, "add 3", "insert into tbl (id, v) values (3, 3)")
+	commitQueries(t, dEnv, "update 2", "update tbl set v = 20 where id = 2")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"master"}, dEnv))
+	commitQueries(t, dEnv, "update 2 on master", "update tbl set v = 200 where id = 2")
+
+	return dEnv
+}
+
+func TestCherryPick(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createBranchesToReplay(t)
+
+	assert.Equal(t, 0, CherryPick(ctx, "dolt cherry-pick", []string{"feature~1"}, dEnv))
+	assert.Equal(t, "add 3", headMessage(t, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 200, 3: 3}, readValues(t, dEnv))
+
+	// Conflicts leave the cherry-pick in progress until they're resolved
+	assert.Equal(t, 1, CherryPick(ctx, "dolt cherry-pick", []string{"feature"}, dEnv))
+	assert.True(t, dEnv.IsReplayActive())
+	assert.Equal(t, 1, CherryPick(ctx, "dolt cherry-pick", []string{"feature"}, dEnv))
+	assert.Equal(t, 1, Rebase(ctx, "dolt rebase", []string{"--continue"}, dEnv))
+	assert.Equal(t, 1, CherryPick(ctx, "dolt cherry-pick", []string{"--continue"}, dEnv))
+
+	require.NoError(t, actions.AutoResolveTables(ctx, dEnv, merge.Theirs, []string{"tbl"}))
+	require.Equal(t, 0, Add(ctx, "dolt add", []string{"tbl"}, dEnv))
+	assert.Equal(t, 0, CherryPick(ctx, "dolt cherry-pick", []string{"--continue"}, dEnv))
+	assert.False(t, dEnv.IsReplayActive())
+	assert.Equal(t, "update 2", headMessage(t, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 20, 3: 3}, readValues(t, dEnv))
+}
+
+func TestCherryPickAbort(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createBranchesToReplay(t)
+
+	assert.Equal(t, 1, CherryPick(ctx, "dolt cherry-pick", []string{"feature"}, dEnv))
+	assert.Equal(t, 0, CherryPick(ctx, "dolt cherry-pick", []string{"--abort"}, dEnv))
+	assert.False(t, dEnv.IsReplayActive())
+	assert.Equal(t, "update 2 on master", headMessage(t, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 200}, readValues(t, dEnv))
+
+	isUnchanged, err := dEnv.IsUnchangedFromHead(ctx)
+	require.NoError(t, err)
+	assert.True(t, isUnchanged)
+}
+
+func TestRebase(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createBranchesToReplay(t)
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"feature"}, dEnv))
+
+	assert.Equal(t, 1, Rebase(ctx, "dolt rebase", []string{"master"}, dEnv))
+	assert.Equal(t, "add 3", headMessage(t, dEnv))
+	assert.Equal(t, []string{dEnv.RepoState.Replay.Commits[0]}, dEnv.RepoState.Replay.Commits)
+
+	require.NoError(t, actions.AutoResolveTables(ctx, dEnv, merge.Ours, []string{"tbl"}))
+	require.Equal(t, 0, Add(ctx, "dolt add", []string{"tbl"}, dEnv))
+	assert.Equal(t, 0, Rebase(ctx, "dolt rebase", []string{"--continue"}, dEnv))
+	assert.False(t, dEnv.IsReplayActive())
+	assert.Equal(t, map[int64]int64{1: 1, 2: 200, 3: 3}, readValues(t, dEnv))
+
+	// Resolving the conflict in favor of master made the last commit empty, so it's dropped
+	assert.Equal(t, "add 3", headMessage(t, dEnv))
+	cs, _ := doltdb.NewCommitSpec("HEAD~1", dEnv.RepoState.CWBHeadRef().String())
+	parent, err := dEnv.DoltDB.Resolve(ctx, cs)
+	require.NoError(t, err)
+	meta, err := parent.GetCommitMeta()
+	require.NoError(t, err)
+	assert.Equal(t, "update 2 on master", meta.Description)
+
+	assert.Equal(t, 0, Rebase(ctx, "dolt rebase", []string{"master"}, dEnv))
+	assert.Equal(t, "add 3", headMessage(t, dEnv))
+}
+
+func TestRebaseAbort(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createBranchesToReplay(t)
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"feature"}, dEnv))
+
+	assert.Equal(t, 1, Rebase(ctx, "dolt rebase", []string{"master"}, dEnv))
+	assert.Equal(t, 0, Rebase(ctx, "dolt rebase", []string{"--abort"}, dEnv))
+	assert.False(t, dEnv.IsReplayActive())
+	assert.Equal(t, "update 2", headMessage(t, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 20, 3: 3}, readValues(t, dEnv))
+}
+
+func TestRebaseError(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1)")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"-b", "feature"}, dEnv))
+	commitQueries(t, dEnv, "add 2", "insert into tbl (id, v) values (2, 2)")
+	commitQueries(t, dEnv, "add other", "create table other (id bigint primary key)")
+
+	// A table added on both branches can't be merged, so replaying the second commit fails
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"master"}, dEnv))
+	commitQueries(t, dEnv, "add other on master", "create table other (id bigint primary key, v bigint)")
+
+	require.Equal(t, 0, Checkout(ctx, "dolt checkout", []string{"feature"}, dEnv))
+	assert.Equal(t, 1, Rebase(ctx, "dolt rebase", []string{"master"}, dEnv))
+
+	// The branch is left as it was before the rebase, rather than with only the first commit replayed
+	assert.False(t, dEnv.IsReplayActive())
+	assert.Equal(t, "add other", headMessage(t, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 2}, readValues(t, dEnv))
+
+	isUnchanged, err := dEnv.IsUnchangedFromHead(ctx)
+	require.NoError(t, err)
+	assert.True(t, isUnchanged)
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+var revertShortDesc = "Undo the changes introduced by an existing commit"
+var revertLongDesc = "Records a new commit on the current branch that undoes the changes introduced by the named " +
+	"commit, including changes to table schemas. The inverse of the commit's changes is merged into the current " +
+	"branch the same way dolt merge merges tables, and the new commit's message names the reverted commit.\n" +
+	"\n" +
+	"If commits made since the named commit changed the same rows, the revert stops so that the conflicts can be " +
+	"resolved with <b>dolt conflicts</b>. Once the resolved tables have been staged with <b>dolt add</b>, " +
+	"<b>dolt revert --continue</b> records the commit. <b>dolt revert --abort</b> returns the branch to the state it " +
+	"was in before the revert started."
+var revertSynopsis = []string{
+	"<commit>",
+	"--continue",
+	"--abort",
+}
+
+func Revert(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(continueParam, "", "Record the revert once its conflicts have been resolved.")
+	ap.SupportsFlag(abortParam, "", "Abort the revert and return the branch to its state before the revert started.")
+	help, usage := cli.HelpAndUsagePrinters(commandStr, revertShortDesc, revertLongDesc, revertSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if verr := checkReplayOperation(dEnv, actions.RevertOperation, apr); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	var verr errhand.VerboseError
+	if apr.Contains(abortParam) {
+		return HandleVErrAndExitCode(abortReplay(ctx, dEnv), usage)
+	} else if apr.Contains(continueParam) {
+		tblToStats, err := actions.ContinueReplay(ctx, dEnv)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		var cm *doltdb.Commit
+		cm, verr = ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		tblToStats, err := actions.Revert(ctx, dEnv, cm)
+		verr = handleReplayErr(dEnv, tblToStats, err)
+	}
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	return Log(ctx, "log", []string{"-n=1"}, dEnv)
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+)
+
+func TestRevert(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1), (2, 2)")
+	commitQueries(t, dEnv, "update 2", "update tbl set v = 20 where id = 2")
+	commitQueries(t, dEnv, "bad import", "insert into tbl (id, v) values (3, 3)", "update tbl set v = 10 where id = 1")
+	commitQueries(t, dEnv, "fix import", "update tbl set v = 30 where id = 3")
+
+	assert.Equal(t, 0, Revert(ctx, "dolt revert", []string{"HEAD~2"}, dEnv))
+	assert.True(t, strings.HasPrefix(headMessage(t, dEnv), `Revert "update 2"`))
+	assert.Equal(t, map[int64]int64{1: 10, 2: 2, 3: 30}, readValues(t, dEnv))
+
+	// Reverting a commit whose rows were changed since results in conflicts
+	assert.Equal(t, 1, Revert(ctx, "dolt revert", []string{"HEAD~2"}, dEnv))
+	assert.True(t, dEnv.IsReplayActive())
+	require.NoError(t, actions.AutoResolveTables(ctx, dEnv, merge.Theirs, []string{"tbl"}))
+	require.Equal(t, 0, Add(ctx, "dolt add", []string{"tbl"}, dEnv))
+	assert.Equal(t, 0, Revert(ctx, "dolt revert", []string{"--continue"}, dEnv))
+	assert.False(t, dEnv.IsReplayActive())
+	assert.True(t, strings.HasPrefix(headMessage(t, dEnv), `Revert "bad import"`))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 2}, readValues(t, dEnv))
+}
+
+func TestRevertSchemaChange(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1)")
+	commitQueries(t, dEnv, "add w", "alter table tbl add (w bigint comment 'tag:100')", "update tbl set w = 5")
+	commitQueries(t, dEnv, "insert 2", "insert into tbl (id, v, w) values (2, 2, 6)")
+
+	assert.Equal(t, 0, Revert(ctx, "dolt revert", []string{"HEAD~1"}, dEnv))
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, "tbl")
+	require.NoError(t, err)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	_, ok := sch.GetAllCols().GetByTag(100)
+	assert.False(t, ok)
+	assert.Equal(t, map[int64]int64{1: 1, 2: 2}, readValues(t, dEnv))
+}
@@ -41,6 +41,7 @@ const (
 	addFieldFlag    = "add-column"
 	renameFieldFlag = "rename-column"
 	dropFieldFlag   = "drop-column"
+	modifyFieldFlag = "modify-column"
 )
 
 var tblSchemaShortDesc = "Displays and modifies table schemas"
@@ -57,6 +58,10 @@ will be empty.
 dolt schema --rename-column renames a column of the specified table. 
 
 dolt schema --drop-column removes a column of the specified table.
+
+dolt schema --modify-column changes the type of a column of the specified table, and makes it required if --not-null 
+is given or optional otherwise. The column's value in every row is converted to the new type, and the schema is left 
+unchanged if any value can't be converted.
 `
 
 var tblSchemaSynopsis = []string{
@@ -65,6 +70,7 @@ var tblSchemaSynopsis = []string{
 	"--add-column [--default <default_value>] [--not-null] [--tag <tag-number>] <table> <name> <type>",
 	"--rename-column <table> <old> <new>",
 	"--drop-column <table> <column>",
+	"--modify-column [--not-null] <table> <column> <type>",
 }
 
 var bold = color.New(color.Bold)
@@ -80,6 +86,7 @@ func Schema(ctx context.Context, commandStr string, args []string, dEnv *env.Dol
 	ap.SupportsFlag(addFieldFlag, "", "add columm to table schema.")
 	ap.SupportsFlag(renameFieldFlag, "", "rename column for specified table.")
 	ap.SupportsFlag(dropFieldFlag, "", "removes column from specified table.")
+	ap.SupportsFlag(modifyFieldFlag, "", "changes the type of a column of specified table.")
 
 	help, usage := cli.HelpAndUsagePrinters(commandStr, tblSchemaShortDesc, tblSchemaLongDesc, tblSchemaSynopsis, ap)
 	apr := cli.ParseArgs(ap, args, help)
@@ -95,6 +102,8 @@ func Schema(ctx context.Context, commandStr string, args []string, dEnv *env.Dol
 		verr = exportSchemas(ctx, apr, root, dEnv)
 	} else if apr.Contains(dropFieldFlag) {
 		verr = removeColumn(ctx, apr, root, dEnv)
+	} else if apr.Contains(modifyFieldFlag) {
+		verr = modifyColumn(ctx, apr, root, dEnv)
 	} else {
 		verr = printSchemas(ctx, apr, dEnv)
 	}
@@ -182,7 +191,13 @@ func printTblSchema(ctx context.Context, cmStr string, tblName string, tbl *dolt
 		return errhand.BuildDError("unable to get schema").AddCause(err).Build()
 	}
 
-	cli.Println(sql.SchemaAsCreateStmt(tblName, sch))
+	stmt, err := sql.TableAsCreateStmt(ctx, root, tblName, sch)
+
+	if err != nil {
+		return errhand.BuildDError("unable to get foreign keys").AddCause(err).Build()
+	}
+
+	cli.Println(stmt)
 	return nil
 }
 
@@ -374,3 +389,68 @@ func removeColumn(ctx context.Context, apr *argparser.ArgParseResults, root *dol
 
 	return UpdateWorkingWithVErr(dEnv, root)
 }
+
+func modifyColumn(ctx context.Context, apr *argparser.ArgParseResults, root *doltdb.RootValue, dEnv *env.DoltEnv) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("Table name, column name, and new column type must be specified.").SetPrintUsage().Build()
+	}
+
+	tblName := apr.Arg(0)
+	if has, err := root.HasTable(ctx, tblName); err != nil {
+		return errhand.BuildDError("error: failed to read tables from database.").AddCause(err).Build()
+	} else if !has {
+		return errhand.BuildDError("%s not found", tblName).Build()
+	}
+
+	tbl, _, err := root.GetTable(ctx, tblName)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get table '%s'", tblName).AddCause(err).Build()
+	}
+
+	tblSch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get schema of table '%s'", tblName).AddCause(err).Build()
+	}
+
+	colName := apr.Arg(1)
+	oldCol, ok := tblSch.GetAllCols().GetByName(colName)
+
+	if !ok {
+		return errToVerboseErr(colName, "", schema.ErrColNotFound)
+	}
+
+	newType := strings.ToLower(apr.Arg(2))
+	newKind, ok := schema.LwrStrToKind[newType]
+	if !ok {
+		return errhand.BuildDError("%s is not a valid type for this column.", newType).SetPrintUsage().Build()
+	}
+
+	// Type parameters such as the precision of a decimal column are kept if the type doesn't change
+	var typeParams map[string]string
+	if newKind == oldCol.Kind {
+		typeParams = oldCol.TypeParams
+	}
+
+	var constraints []schema.ColConstraint
+	if apr.Contains(notNullFlag) {
+		constraints = append(constraints, schema.NotNullConstraint{})
+	}
+
+	newCol := schema.NewColumnWithTypeParams(colName, oldCol.Tag, newKind, oldCol.IsPartOfPK, typeParams, constraints...)
+	convFn := sql.GetLosslessTypeConversionFn(oldCol.Kind, newKind)
+	newTbl, err := alterschema.ModifyColumn(ctx, dEnv.DoltDB, tbl, newCol, alterschema.ConvFunc(convFn))
+
+	if err != nil {
+		return errToVerboseErr(colName, "", err)
+	}
+
+	root, err = root.PutTable(ctx, dEnv.DoltDB, tblName, newTbl)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write table back to database").AddCause(err).Build()
+	}
+
+	return UpdateWorkingWithVErr(dEnv, root)
+}
@@ -15,14 +15,13 @@
 package commands
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/abiosoft/readline"
 	"github.com/fatih/color"
@@ -51,12 +50,11 @@ import (
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
-// An environment variable to set to get indexed join behavior, currently experimental
-const UseIndexedJoinsEnv = "DOLT_USE_INDEXED_JOINS"
-
 var sqlShortDesc = "Runs a SQL query"
 var sqlLongDesc = `Runs a SQL query you specify. By default, begins an interactive shell to run queries and view the
-results. With the -q option, runs the given query and prints any results, then exits.
+results. With the -q option, runs the given query and prints any results, then exits. When statements are piped to it,
+runs each of them in turn, batching inserts, so that a dump of a MySQL database written by mysqldump can be loaded with
+<b>dolt sql < dump.sql</b>. The SET and LOCK TABLES statements in such dumps are ignored.
 
 THIS FUNCTIONALITY IS EXPERIMENTAL and being intensively developed. Feedback is welcome: 
 dolt-interest@liquidata.co
@@ -65,17 +63,19 @@ Reasonably well supported functionality:
 * SELECT statements, including most kinds of joins
 * CREATE TABLE statements
 * ALTER TABLE / DROP TABLE statements
+* CREATE INDEX / DROP INDEX statements, and ALTER TABLE ADD INDEX / DROP INDEX
 * UPDATE and DELETE statements
 * Table and column aliases
 * Column functions, e.g. CONCAT
 * ORDER BY and LIMIT clauses
 * GROUP BY
 * Aggregate functions, e.g. SUM 
+* Reading tables as of a branch, tag or commit, e.g. SELECT * FROM t AS OF 'feature', or from a revision database,
+  e.g. SELECT * FROM ` + "`dolt/feature`" + `.t
 
 Known limitations:
 * Some expressions in SELECT statements
 * Subqueries
-* Non-primary indexes
 * Foreign keys
 * Column constraints besides NOT NULL
 * VARCHAR columns are unlimited length; FLOAT, INTEGER columns are 64 bit
@@ -87,6 +87,9 @@ var sqlSynopsis = []string{
 }
 
 const (
+	// dbName is the name of the database that queries run against
+	dbName = "dolt"
+
 	queryFlag  = "query"
 	welcomeMsg = `# Welcome to the DoltSQL shell.
 # Statements must be terminated with ';'.
@@ -141,28 +144,9 @@ func Sql(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEn
 	return 0
 }
 
-// ScanStatements is a split function for a Scanner that returns each SQL statement in the input as a token. It doesn't
-// work for strings that contain semi-colons. Supporting that requires implementing a state machine.
-func scanStatements(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	if atEOF && len(data) == 0 {
-		return 0, nil, nil
-	}
-	if i := bytes.IndexByte(data, ';'); i >= 0 {
-		// We have a full ;-terminated line.
-		return i + 1, data[0:i], nil
-	}
-	// If we're at EOF, we have a final, non-terminated line. Return it.
-	if atEOF {
-		return len(data), data, nil
-	}
-	// Request more data.
-	return 0, nil, nil
-}
-
 // runBatchMode processes queries until EOF and returns the resulting root value
 func runBatchMode(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue) *doltdb.RootValue {
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Split(scanStatements)
+	scanner := dsql.NewStatementScanner(os.Stdin)
 
 	batcher := dsql.NewSqlBatcher(dEnv.DoltDB, root)
 
@@ -373,7 +357,12 @@ func prepend(s string, ss []string) []string {
 
 // Processes a single query and returns the new root value of the DB, or an error encountered.
 func processQuery(ctx context.Context, query string, dEnv *env.DoltEnv, root *doltdb.RootValue) (*doltdb.RootValue, error) {
-	sqlStatement, err := sqlparser.Parse(query)
+	query, err := dsqle.RewriteAsOf(query, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStatement, err := dsql.Parse(query)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing SQL: %v.", err.Error())
 	}
@@ -382,7 +371,7 @@ func processQuery(ctx context.Context, query string, dEnv *env.DoltEnv, root *do
 	case *sqlparser.Show:
 		return nil, sqlShow(ctx, root, s)
 	case *sqlparser.Select, *sqlparser.OtherRead:
-		sqlSch, rowIter, err := sqlNewEngine(query, root)
+		sqlSch, rowIter, err := sqlNewEngine(query, root, dEnv)
 		if err == nil {
 			err = prettyPrintResults(ctx, root.VRW().Format(), sqlSch, rowIter)
 		}
@@ -394,11 +383,13 @@ func processQuery(ctx context.Context, query string, dEnv *env.DoltEnv, root *do
 	case *sqlparser.Delete:
 		return sqlDelete(ctx, dEnv, root, s, query)
 	case *sqlparser.DDL:
-		_, err := sqlparser.ParseStrictDDL(query)
+		_, err := dsql.ParseStrictDDL(query)
 		if err != nil {
 			return nil, fmt.Errorf("Error parsing DDL: %v.", err.Error())
 		}
 		return sqlDDL(ctx, dEnv, root, s, query)
+	case *sqlparser.Use:
+		return nil, fmt.Errorf("USE is only supported by sql-server. Query other revisions with AS OF or by qualifying tables, as in `%s%sbranch`.table.", dbName, dsqle.RevisionDelimiter)
 	default:
 		return nil, fmt.Errorf("Unsupported SQL statement: '%v'.", query)
 	}
@@ -406,7 +397,7 @@ func processQuery(ctx context.Context, query string, dEnv *env.DoltEnv, root *do
 
 // Processes a single query in batch mode and returns the result. The RootValue may or may not be changed.
 func processBatchQuery(ctx context.Context, query string, dEnv *env.DoltEnv, root *doltdb.RootValue, batcher *dsql.SqlBatcher) (*doltdb.RootValue, error) {
-	sqlStatement, err := sqlparser.Parse(query)
+	sqlStatement, err := dsql.Parse(query)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing SQL: %v.", err.Error())
 	}
@@ -414,6 +405,9 @@ func processBatchQuery(ctx context.Context, query string, dEnv *env.DoltEnv, roo
 	switch s := sqlStatement.(type) {
 	case *sqlparser.Insert:
 		return sqlInsertBatch(ctx, dEnv, root, s, batcher)
+	case *sqlparser.Set, *sqlparser.OtherAdmin:
+		// Dumps of MySQL databases set session options and lock the tables they load, neither of which are needed here
+		return nil, nil
 	default:
 		// For any other kind of statement, we need to commit whatever batch edit we've accumulated so far before executing
 		// the query
@@ -437,19 +431,26 @@ func processBatchQuery(ctx context.Context, query string, dEnv *env.DoltEnv, roo
 }
 
 // Executes a SQL statement of either SHOW or SELECT and returns values for printing if applicable.
-func sqlNewEngine(query string, root *doltdb.RootValue) (sql.Schema, sql.RowIter, error) {
-	db := dsqle.NewDatabase("dolt", root)
-	engine := sqle.NewDefault()
+func sqlNewEngine(query string, root *doltdb.RootValue, dEnv *env.DoltEnv) (sql.Schema, sql.RowIter, error) {
+	db := dsqle.NewDatabase(dbName, root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, dsqle.NewAnalyzer(catalog), nil)
 	engine.AddDatabase(db)
 	ctx := sql.NewEmptyContext()
 
-	// Indexes are not well tested enough to use in production yet.
-	if _, ok := os.LookupEnv(UseIndexedJoinsEnv); ok {
-		engine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(db))
-		err := engine.Init()
-		if err != nil {
-			return nil, nil, err
-		}
+	revisionDBs, err := dsqle.RevisionDatabases(ctx, db, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, revisionDB := range revisionDBs {
+		engine.AddDatabase(revisionDB)
+	}
+
+	engine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(db))
+	err = engine.Init()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return engine.Query(ctx, query)
@@ -485,7 +486,7 @@ func prettyPrintResults(ctx context.Context, nbf *types.NomsBinFormat, sqlSch sq
 			taggedVals := make(row.TaggedValues)
 			for i, col := range sqlRow {
 				if col != nil {
-					taggedVals[uint64(i)] = types.String(fmt.Sprintf("%v", col))
+					taggedVals[uint64(i)] = types.String(sqlValToString(sqlSch[i].Type, col))
 				}
 			}
 
@@ -550,6 +551,18 @@ func prettyPrintResults(ctx context.Context, nbf *types.NomsBinFormat, sqlSch sq
 	return nil
 }
 
+// sqlValToString returns the string to print for the SQL value of the type given. Times are printed in the format of
+// their SQL type, e.g. without a time of day for dates.
+func sqlValToString(typ sql.Type, val interface{}) string {
+	if _, ok := val.(time.Time); ok {
+		if sqlVal, err := typ.SQL(val); err == nil {
+			return sqlVal.ToString()
+		}
+	}
+
+	return fmt.Sprintf("%v", val)
+}
+
 // Adds some print-handling stages to the pipeline given and runs it, returning any error.
 // Adds null-printing and fixed-width transformers. The schema given is assumed to be untyped (string-typed).
 func runPrintingPipeline(ctx context.Context, nbf *types.NomsBinFormat, p *pipeline.Pipeline, untypedSch schema.Schema) error {
@@ -0,0 +1,388 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/auth"
+	"github.com/src-d/go-mysql-server/server"
+	"github.com/src-d/go-mysql-server/sql"
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	dsql "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+)
+
+// doltHandler is a mysql.Handler that wraps the go-mysql-server handler. It executes the write statements that the
+// engine can't execute on its own, and persists the database's root value whenever a statement changes it. Write
+// statements are serialized so that each one sees the result of the last, and reads run alongside each other but not
+// alongside writes, so that they see the database's root before or after a write and never during it.
+type doltHandler struct {
+	*server.Handler
+	engine  *sqle.Engine
+	sm      *server.SessionManager
+	db      *dsqle.Database
+	load    func(ctx context.Context) (*doltdb.RootValue, error)
+	persist func(ctx context.Context, root *doltdb.RootValue) error
+	mu      sync.RWMutex
+
+	// revisions holds the name of the revision database each connection has switched to with USE, by connection ID
+	revisions map[uint32]string
+	revMu     sync.Mutex
+}
+
+var _ mysql.Handler = (*doltHandler)(nil)
+
+// newDoltHandler returns a new handler for the engine and database given. Before each statement the database's root is
+// set to the root returned by load, so that reads see and writes build on any changes made outside the server, and
+// after each write the new root is given to persist.
+func newDoltHandler(engine *sqle.Engine, sm *server.SessionManager, db *dsqle.Database, load func(context.Context) (*doltdb.RootValue, error), persist func(context.Context, *doltdb.RootValue) error) *doltHandler {
+	return &doltHandler{
+		Handler:   server.NewHandler(engine, sm),
+		engine:    engine,
+		sm:        sm,
+		db:        db,
+		load:      load,
+		persist:   persist,
+		revisions: make(map[uint32]string),
+	}
+}
+
+// ComQuery executes the query given. Reads are delegated to the go-mysql-server handler. Tables selected AS OF a
+// revision are read from revision databases, which are resolved for each query that references them.
+func (h *doltHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	query, err := dsqle.RewriteAsOf(query, h.db.Name())
+	if err != nil {
+		return err
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		// Let the engine report the error for anything it can't parse. The engine parses some statements itself, so
+		// they may still change the database.
+		return h.execEngineStmt(c, query, callback)
+	}
+
+	switch s := stmt.(type) {
+	case *sqlparser.Insert:
+		if s.Action == sqlparser.ReplaceStr {
+			return h.execWrite(c, query, callback, func(ctx *sql.Context, engine *sqle.Engine) (int, error) {
+				return dsqle.ExecuteReplace(ctx, engine, h.db, s)
+			})
+		}
+
+		return h.execEngineWrite(c, query, callback)
+	case *sqlparser.Update:
+		return h.execWrite(c, query, callback, func(ctx *sql.Context, engine *sqle.Engine) (int, error) {
+			return dsqle.ExecuteUpdate(ctx, engine, h.db, s)
+		})
+	case *sqlparser.Delete:
+		return h.execWrite(c, query, callback, func(ctx *sql.Context, engine *sqle.Engine) (int, error) {
+			return dsqle.ExecuteDelete(ctx, engine, h.db, s)
+		})
+	case *sqlparser.DDL:
+		if indexDDL, ok := dsql.ParseIndexDDL(query); ok {
+			return h.execWrite(c, query, callback, func(ctx *sql.Context, engine *sqle.Engine) (int, error) {
+				return 0, dsqle.ExecuteIndexDDL(ctx, engine, h.db, indexDDL)
+			})
+		}
+
+		return h.execEngineWrite(c, query, callback)
+	case *sqlparser.Use:
+		return h.use(c, s.DBName.String(), query, callback)
+	default:
+		return h.execRead(c, query, callback)
+	}
+}
+
+// ConnectionClosed forgets the revision database the connection given switched to, if any.
+func (h *doltHandler) ConnectionClosed(c *mysql.Conn) {
+	h.setRevision(c, "")
+	h.Handler.ConnectionClosed(c)
+}
+
+// use switches the connection given to the database with the name given. Switching to a revision database only
+// changes the database of the connection, so that other connections aren't affected.
+func (h *doltHandler) use(c *mysql.Conn, name, query string, callback func(*sqltypes.Result) error) error {
+	prefix := h.db.Name() + dsqle.RevisionDelimiter
+	if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+		// Resolve the revision now, so that a bad one is reported by USE rather than the queries that follow it
+		revision := name[len(prefix):]
+		if _, err := dsqle.NewRevisionDatabase(context.Background(), h.db, revision); err != nil {
+			return fmt.Errorf("unable to resolve revision '%s': %v", revision, err)
+		}
+
+		h.setRevision(c, name)
+		return callback(&sqltypes.Result{})
+	}
+
+	if strings.EqualFold(name, h.db.Name()) {
+		h.setRevision(c, "")
+	}
+
+	return h.execRead(c, query, callback)
+}
+
+// revision returns the name of the revision database the connection given switched to with USE, or "" if it hasn't.
+func (h *doltHandler) revision(c *mysql.Conn) string {
+	h.revMu.Lock()
+	defer h.revMu.Unlock()
+
+	return h.revisions[c.ConnectionID]
+}
+
+func (h *doltHandler) setRevision(c *mysql.Conn, name string) {
+	h.revMu.Lock()
+	defer h.revMu.Unlock()
+
+	if name == "" {
+		delete(h.revisions, c.ConnectionID)
+	} else {
+		h.revisions[c.ConnectionID] = name
+	}
+}
+
+// queryEngine returns the engine to execute the query given from the connection given with. A query which reads from
+// revision databases, or is made after switching to one, is executed by a new engine whose catalog has the revision
+// databases it reads, resolved for the query, so that the catalog of the server's engine is never changed.
+func (h *doltHandler) queryEngine(c *mysql.Conn, query string) (*sqle.Engine, error) {
+	ctx := context.Background()
+	revisionDBs, err := dsqle.RevisionDatabases(ctx, h.db, query)
+	if err != nil {
+		return nil, err
+	}
+
+	current := h.revision(c)
+	if current != "" {
+		found := false
+		for _, revisionDB := range revisionDBs {
+			found = found || strings.EqualFold(revisionDB.Name(), current)
+		}
+
+		if !found {
+			revision := current[len(h.db.Name()+dsqle.RevisionDelimiter):]
+			revisionDB, err := dsqle.NewRevisionDatabase(ctx, h.db, revision)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve revision '%s': %v", revision, err)
+			}
+
+			revisionDBs = append(revisionDBs, revisionDB)
+		}
+	}
+
+	if len(revisionDBs) == 0 {
+		return h.engine, nil
+	}
+
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, dsqle.NewAnalyzer(catalog), &sqle.Config{Auth: h.engine.Auth})
+	engine.AddDatabase(h.db)
+	for _, revisionDB := range revisionDBs {
+		engine.AddDatabase(revisionDB)
+	}
+
+	engine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(h.db))
+	if current != "" {
+		catalog.SetCurrentDatabase(current)
+	}
+
+	if err := engine.Init(); err != nil {
+		return nil, err
+	}
+
+	return engine, nil
+}
+
+// queryHandler returns the go-mysql-server handler to execute the query given from the connection given with. See
+// queryEngine.
+func (h *doltHandler) queryHandler(c *mysql.Conn, query string) (*server.Handler, error) {
+	engine, err := h.queryEngine(c, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if engine == h.engine {
+		return h.Handler, nil
+	}
+
+	return server.NewHandler(engine, h.sm), nil
+}
+
+// execRead executes a statement that doesn't change the database, against the database's root as reloaded by
+// reloadRoot. Reloading the root updates state shared with other statements, so it's done with the write lock held,
+// and the statement then runs with the read lock. A write may run in between, in which case the statement sees its
+// result.
+func (h *doltHandler) execRead(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	h.mu.Lock()
+	_, err := h.reloadRoot()
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	handler, err := h.queryHandler(c, query)
+	if err != nil {
+		return err
+	}
+
+	return handler.ComQuery(c, query, callback)
+}
+
+// execEngineWrite executes a write statement that the engine supports natively, then persists the result. Results are
+// only returned to the client once the write is persisted.
+func (h *doltHandler) execEngineWrite(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.checkWritable(c); err != nil {
+		return err
+	}
+
+	return h.execEngine(c, query, callback)
+}
+
+// execEngineStmt executes a statement that the engine may or may not write with, persisting the result if it does.
+func (h *doltHandler) execEngineStmt(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.execEngine(c, query, callback)
+}
+
+// execEngine executes a statement with the engine and persists the result. Callers must hold the write lock.
+func (h *doltHandler) execEngine(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	root, err := h.reloadRoot()
+	if err != nil {
+		return err
+	}
+
+	handler, err := h.queryHandler(c, query)
+	if err != nil {
+		return err
+	}
+
+	var results []*sqltypes.Result
+	err = handler.ComQuery(c, query, func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	})
+
+	if err == nil {
+		err = h.db.Flush(context.Background())
+	}
+
+	if err != nil {
+		h.db.SetRoot(root)
+		return err
+	}
+
+	if err := h.persistRoot(root); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if err := callback(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execWrite executes a write statement with the function given, then persists the result.
+func (h *doltHandler) execWrite(c *mysql.Conn, query string, callback func(*sqltypes.Result) error, write func(*sql.Context, *sqle.Engine) (int, error)) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := h.sm.NewContextWithQuery(c, query)
+	if err := h.engine.Auth.Allowed(ctx, auth.ReadPerm|auth.WritePerm); err != nil {
+		return err
+	}
+
+	if err := h.checkWritable(c); err != nil {
+		return err
+	}
+
+	root, err := h.reloadRoot()
+	if err != nil {
+		return err
+	}
+
+	engine, err := h.queryEngine(c, query)
+	if err != nil {
+		return err
+	}
+
+	n, err := write(ctx, engine)
+	if err != nil {
+		h.db.SetRoot(root)
+		return err
+	}
+
+	if err := h.persistRoot(root); err != nil {
+		return err
+	}
+
+	return callback(&sqltypes.Result{RowsAffected: uint64(n)})
+}
+
+// reloadRoot sets the database's root to the root that writes are persisted to, which may have been changed outside
+// the server, and returns it. Callers must hold the write lock.
+func (h *doltHandler) reloadRoot() (*doltdb.RootValue, error) {
+	root, err := h.load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	h.db.SetRoot(root)
+	return root, nil
+}
+
+// checkWritable returns an error if the connection given has switched to a revision database with USE, since revision
+// databases are read-only.
+func (h *doltHandler) checkWritable(c *mysql.Conn) error {
+	if current := h.revision(c); current != "" {
+		return fmt.Errorf("database '%s' is read-only", current)
+	}
+
+	return nil
+}
+
+// persistRoot persists the database's current root if it differs from the previous root given. If it can't be
+// persisted, the database's root is reset to the previous root.
+func (h *doltHandler) persistRoot(prevRoot *doltdb.RootValue) error {
+	root := h.db.Root()
+	if root == prevRoot {
+		return nil
+	}
+
+	if err := h.persist(context.Background(), root); err != nil {
+		h.db.SetRoot(prevRoot)
+		return err
+	}
+
+	return nil
+}
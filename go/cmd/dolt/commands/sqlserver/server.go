@@ -20,6 +20,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	sqle "github.com/src-d/go-mysql-server"
 	"github.com/src-d/go-mysql-server/auth"
@@ -28,12 +29,12 @@ import (
 	"vitess.io/vitess/go/mysql"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
-	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 )
 
 // serve starts a MySQL-compatible server. Returns any errors that were encountered.
-func serve(ctx context.Context, serverConfig *ServerConfig, rootValue *doltdb.RootValue, serverController *ServerController) (startError error, closeError error) {
+func serve(ctx context.Context, serverConfig *ServerConfig, dEnv *env.DoltEnv, serverController *ServerController) (startError error, closeError error) {
 	if serverConfig == nil {
 		cli.Println("No configuration given, using defaults")
 		serverConfig = DefaultServerConfig()
@@ -74,29 +75,43 @@ func serve(ctx context.Context, serverConfig *ServerConfig, rootValue *doltdb.Ro
 		permissions = auth.ReadPerm
 	}
 
+	rootValue, startError := dEnv.WorkingRoot(ctx)
+	if startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
+
 	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User, serverConfig.Password, permissions), auth.NewAuditLog(logrus.StandardLogger()))
-	sqlEngine := sqle.NewDefault()
-	sqlEngine.AddDatabase(dsqle.NewDatabase("dolt", rootValue))
+	catalog := sql.NewCatalog()
+	sqlEngine := sqle.New(catalog, dsqle.NewAnalyzer(catalog), &sqle.Config{Auth: userAuth})
+	db := dsqle.NewDatabase("dolt", rootValue, dEnv.DoltDB, dEnv.RepoState)
+	sqlEngine.AddDatabase(db)
+	sqlEngine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(db))
+	if startError = sqlEngine.Init(); startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
 
 	hostPort := net.JoinHostPort(serverConfig.Host, strconv.Itoa(serverConfig.Port))
 	timeout := time.Second * time.Duration(serverConfig.Timeout)
-	mySQLServer, startError = server.NewServer(
-		server.Config{
-			Protocol:         "tcp",
-			Address:          hostPort,
-			Auth:             userAuth,
-			ConnReadTimeout:  timeout,
-			ConnWriteTimeout: timeout,
-		},
-		sqlEngine,
+	sessionManager := server.NewSessionManager(
 		func(conn *mysql.Conn, host string) sql.Session {
 			return sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
 		},
+		opentracing.NoopTracer{},
+		hostPort,
 	)
+
+	// Writes are persisted to the working root of the repository, so that they're visible to other dolt commands, and
+	// build on the working root as it is when they're made, so that they include changes made by other dolt commands
+	handler := newDoltHandler(sqlEngine, sessionManager, db, dEnv.ReloadWorkingRoot, dEnv.UpdateWorkingRoot)
+	listener, startError := mysql.NewListener("tcp", hostPort, userAuth.Mysql(), handler, timeout, timeout)
 	if startError != nil {
 		cli.PrintErr(startError)
 		return
 	}
+
+	mySQLServer = &server.Server{Listener: listener}
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
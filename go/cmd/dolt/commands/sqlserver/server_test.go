@@ -15,7 +15,10 @@
 package sqlserver
 
 import (
+	"database/sql"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -24,7 +27,6 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
-	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
@@ -99,8 +101,6 @@ func TestServerBadArgs(t *testing.T) {
 
 func TestServerGoodParams(t *testing.T) {
 	env := createEnvWithSeedData(t)
-	root, verr := commands.GetWorkingWithVErr(env)
-	require.NoError(t, verr)
 
 	tests := []*ServerConfig{
 		DefaultServerConfig(),
@@ -121,7 +121,7 @@ func TestServerGoodParams(t *testing.T) {
 		t.Run(test.String(), func(t *testing.T) {
 			sc := CreateServerController()
 			go func(config *ServerConfig, sc *ServerController) {
-				serve(context.Background(), config, root, sc)
+				serve(context.Background(), config, env, sc)
 			}(test, sc)
 			err := sc.WaitForStart()
 			require.NoError(t, err)
@@ -138,14 +138,12 @@ func TestServerGoodParams(t *testing.T) {
 
 func TestServerSelect(t *testing.T) {
 	env := createEnvWithSeedData(t)
-	root, verr := commands.GetWorkingWithVErr(env)
-	require.NoError(t, verr)
 	serverConfig := DefaultServerConfig().WithLogLevel(LogLevel_Fatal).WithPort(15300)
 
 	sc := CreateServerController()
 	defer sc.StopServer()
 	go func() {
-		serve(context.Background(), serverConfig, root, sc)
+		serve(context.Background(), serverConfig, env, sc)
 	}()
 	err := sc.WaitForStart()
 	require.NoError(t, err)
@@ -188,6 +186,230 @@ func TestServerSelect(t *testing.T) {
 	}
 }
 
+func TestServerWrites(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().WithLogLevel(LogLevel_Fatal).WithPort(15301)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		serve(context.Background(), serverConfig, dEnv, sc)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", serverConfig.ConnectionString(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	sess := conn.NewSession(nil)
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedRes []testPerson
+	}{
+		{
+			"insert",
+			"insert into people (id, name, age, is_married, title) values ('00000000-0000-0000-0000-000000000003', 'Jack Jackson', 40, true, 'Big Dufus')",
+			[]testPerson{bill, john, rob, {"Jack Jackson", 40, true, "Big Dufus"}},
+		},
+		{
+			"update",
+			"update people set age = age + 1, title = 'Dufus' where name = 'Jack Jackson'",
+			[]testPerson{bill, john, rob, {"Jack Jackson", 41, true, "Dufus"}},
+		},
+		{
+			"replace",
+			"replace into people (id, name, age, is_married, title) values ('00000000-0000-0000-0000-000000000003', 'Jack Jackson', 50, false, '')",
+			[]testPerson{bill, john, rob, {"Jack Jackson", 50, false, ""}},
+		},
+		{
+			"delete",
+			"delete from people where age > 40",
+			[]testPerson{bill, john, rob},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := conn.Exec(test.query)
+			require.NoError(t, err)
+
+			var peoples []testPerson
+			_, err = sess.Select("*").From("people").LoadContext(context.Background(), &peoples)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedRes, peoples)
+
+			// Every write should be visible in the working root of the repository
+			root, err := dEnv.WorkingRoot(context.Background())
+			require.NoError(t, err)
+			tbl, ok, err := root.GetTable(context.Background(), "people")
+			require.NoError(t, err)
+			require.True(t, ok)
+			rowData, err := tbl.GetRowData(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(test.expectedRes)), rowData.Len())
+		})
+	}
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := conn.Exec("insert into people (id, name, age, is_married) values ('00000000-0000-0000-0000-000000000000', 'Bill Billerson', 1, true)")
+		assert.Error(t, err)
+	})
+}
+
+func TestServerWritesAfterOutsideChanges(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().WithLogLevel(LogLevel_Fatal).WithPort(15303)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		serve(context.Background(), serverConfig, dEnv, sc)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", serverConfig.ConnectionString(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	sess := conn.NewSession(nil)
+
+	// Copy the people table to a new table in the working root, as another dolt command would
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, "people")
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, dEnv.DoltDB, "people_copy", tbl)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+
+	// Reads see the outside change
+	var copies []testPerson
+	_, err = sess.Select("*").From("people_copy").LoadContext(ctx, &copies)
+	require.NoError(t, err)
+	assert.Len(t, copies, 3)
+
+	// Concurrent reads and writes each see the root before or after a write
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := conn.Exec(fmt.Sprintf("insert into people (id, name, age, is_married) values ('00000000-0000-0000-0000-00000000001%d', 'Person %d', %d, false)", i, i, i))
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			var peoples []testPerson
+			_, err := sess.Select("*").From("people").LoadContext(context.Background(), &peoples)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// The writes build on the outside change rather than overwriting it
+	root, err = dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	has, err := root.HasTable(ctx, "people_copy")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	tbl, _, err = root.GetTable(ctx, "people")
+	require.NoError(t, err)
+	rowData, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), rowData.Len())
+}
+
+func TestServerRevisions(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().WithLogLevel(LogLevel_Fatal).WithPort(15304)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		serve(context.Background(), serverConfig, dEnv, sc)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", serverConfig.ConnectionString(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	count := func(c *sql.Conn, query string) (int, error) {
+		var n int
+		err := c.QueryRowContext(ctx, query).Scan(&n)
+		return n, err
+	}
+
+	revConn, err := conn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer revConn.Close()
+	otherConn, err := conn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer otherConn.Close()
+
+	n, err := count(otherConn, "select count(*) from dolt_log as of 'HEAD'")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, err = revConn.ExecContext(ctx, "use dolt/notFound")
+	assert.Error(t, err)
+
+	// The people table is only in the working set, so it isn't in the revision database
+	_, err = revConn.ExecContext(ctx, "use dolt/HEAD")
+	require.NoError(t, err)
+	_, err = count(revConn, "select count(*) from people")
+	assert.Error(t, err)
+	_, err = revConn.ExecContext(ctx, "delete from people")
+	assert.Error(t, err)
+
+	// Switching databases only affects the connection that switched
+	n, err = count(otherConn, "select count(*) from people")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	_, err = revConn.ExecContext(ctx, "use dolt")
+	require.NoError(t, err)
+	n, err = count(revConn, "select count(*) from people")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestServerReadOnlyWrites(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().WithLogLevel(LogLevel_Fatal).WithPort(15302).WithReadOnly(true)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		serve(context.Background(), serverConfig, dEnv, sc)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", serverConfig.ConnectionString(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	queries := []string{
+		"insert into people (id, name, age, is_married) values ('00000000-0000-0000-0000-000000000003', 'Jack Jackson', 40, true)",
+		"update people set age = 1",
+		"delete from people",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			_, err := conn.Exec(query)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func createEnvWithSeedData(t *testing.T) *env.DoltEnv {
 	dEnv := dtestutils.CreateTestEnv()
 	imt, sch := dtestutils.CreateTestDataTable(true)
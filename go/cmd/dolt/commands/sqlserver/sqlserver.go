@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
-	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 )
@@ -37,8 +36,10 @@ const (
 var sqlServerShortDesc = "Start a MySQL-compatible server."
 var sqlServerLongDesc = `Start a MySQL-compatible server which can be connected to by MySQL clients.
 
-Currently, only SELECT statements are operational, as support for other statements is
-still being developed.
+SELECT, INSERT, REPLACE, UPDATE and DELETE statements are supported. Changes made through
+the server are written to the working set of the repository, where they can be viewed with
+dolt status and dolt diff, and committed like any other change. Other statements are still
+being developed.
 `
 var sqlServerSynopsis = []string{
 	"[-H <host>] [-P <port>] [-u <user>] [-p <password>] [-t <timeout>] [-l <loglevel>] [-r]",
@@ -59,16 +60,11 @@ func sqlServerImpl(ctx context.Context, commandStr string, args []string, dEnv *
 	ap.SupportsInt(timeoutFlag, "t", "Connection timeout", fmt.Sprintf("Defines the timeout, in seconds, used for connections\nA value of `0` represents an infinite timeout (default `%v`)", serverConfig.Timeout))
 	ap.SupportsFlag(readonlyFlag, "r", "Disables modification of the database")
 	ap.SupportsString(logLevelFlag, "l", "Log level", fmt.Sprintf("Defines the level of logging provided\nOptions are: `debug`, `info`, `warning`, `error`, `fatal` (default `%v`)", serverConfig.LogLevel))
-	help, usage := cli.HelpAndUsagePrinters(commandStr, sqlServerShortDesc, sqlServerLongDesc, sqlServerSynopsis, ap)
+	help, _ := cli.HelpAndUsagePrinters(commandStr, sqlServerShortDesc, sqlServerLongDesc, sqlServerSynopsis, ap)
 
 	apr := cli.ParseArgs(ap, args, help)
 	args = apr.Args()
 
-	root, verr := commands.GetWorkingWithVErr(dEnv)
-	if verr != nil {
-		return commands.HandleVErrAndExitCode(verr, usage)
-	}
-
 	if host, ok := apr.GetValue(hostFlag); ok {
 		serverConfig.Host = host
 	}
@@ -90,7 +86,7 @@ func sqlServerImpl(ctx context.Context, commandStr string, args []string, dEnv *
 	if logLevel, ok := apr.GetValue(logLevelFlag); ok {
 		serverConfig.LogLevel = LogLevel(logLevel)
 	}
-	if startError, closeError := serve(ctx, serverConfig, root, serverController); startError != nil || closeError != nil {
+	if startError, closeError := serve(ctx, serverConfig, dEnv, serverController); startError != nil || closeError != nil {
 		if startError != nil {
 			cli.PrintErrln(startError)
 		}
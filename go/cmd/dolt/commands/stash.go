@@ -0,0 +1,206 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+var stashShortDesc = "Stash the changes in a dirty working set away"
+var stashLongDesc = "Use <b>dolt stash</b> to record the current state of the working set and the staged tables, and " +
+	"go back to a clean working set matching <b>HEAD</b>. The changes are saved as a stash, which can be listed with " +
+	"<b>dolt stash list</b> and restored, possibly on top of a different commit, with <b>dolt stash apply</b>.\n" +
+	"\n" +
+	"Stashes are referred to as <stash>, which is stash@{0} for the most recent stash, stash@{1} for the one before it, " +
+	"and so on. The number alone can also be used. Commands that take a <stash> use the most recent one if it's omitted.\n" +
+	"\n" +
+	"<b>dolt stash apply</b> merges the stashed changes into the working set the same way dolt merge merges tables, " +
+	"using the commit the changes were made on as the merge base. Changes which were staged when they were stashed are " +
+	"applied to the working set only. If the changes conflict with the working set, the conflicts are recorded for " +
+	"<b>dolt conflicts</b> to resolve.\n" +
+	"\n" +
+	"<b>dolt stash pop</b> applies the stash and then removes it from the list of stashes, unless applying it resulted " +
+	"in conflicts, in which case the stash is kept and can be removed with <b>dolt stash drop</b> once the conflicts have " +
+	"been resolved."
+var stashSynopsis = []string{
+	"[-m <message>]",
+	"list",
+	"pop [<stash>]",
+	"apply [<stash>]",
+	"drop [<stash>]",
+}
+
+const (
+	stashMessageArg = "message"
+
+	stashListCmd  = "list"
+	stashPopCmd   = "pop"
+	stashApplyCmd = "apply"
+	stashDropCmd  = "drop"
+)
+
+func Stash(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp["stash"] = "The stash to use, e.g. stash@{1}. Defaults to the most recent stash."
+	ap.SupportsString(stashMessageArg, "m", "msg", "Use the given <msg> to describe the stash.")
+	help, usage := cli.HelpAndUsagePrinters(commandStr, stashShortDesc, stashLongDesc, stashSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		msg, _ := apr.GetValue(stashMessageArg)
+		err := actions.StashChanges(ctx, dEnv, msg)
+
+		if err == actions.ErrNameNotConfigured || err == actions.ErrEmailNotConfigured {
+			return handleCommitErr(err, usage)
+		}
+
+		return HandleVErrAndExitCode(handleStashChangesErr(ctx, dEnv, err), usage)
+	}
+
+	if apr.NArg() > 2 || (apr.Arg(0) == stashListCmd && apr.NArg() > 1) {
+		usage()
+		return 1
+	}
+
+	index := 0
+	if apr.NArg() == 2 {
+		var err error
+		index, err = actions.ParseStashName(apr.Arg(1))
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: %s", err.Error()).Build(), usage)
+		}
+	}
+
+	var verr errhand.VerboseError
+	switch apr.Arg(0) {
+	case stashListCmd:
+		verr = listStashes(ctx, dEnv)
+	case stashPopCmd:
+		tblToStats, err := actions.PopStash(ctx, dEnv, index)
+		verr = handleStashErr(tblToStats, err)
+	case stashApplyCmd:
+		tblToStats, err := actions.ApplyStash(ctx, dEnv, index)
+		verr = handleStashErr(tblToStats, err)
+	case stashDropCmd:
+		verr = dropStash(ctx, dEnv, index)
+	default:
+		verr = errhand.BuildDError("error: unknown stash command '%s'", apr.Arg(0)).SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func handleStashChangesErr(ctx context.Context, dEnv *env.DoltEnv, err error) errhand.VerboseError {
+	switch {
+	case err == nil:
+		stash, err := actions.GetStash(ctx, dEnv.DoltDB, 0)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read stash").AddCause(err).Build()
+		}
+
+		desc, err := stash.Description()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read stash").AddCause(err).Build()
+		}
+
+		cli.Println("Saved working set and staged tables:", desc)
+		return nil
+	case err == actions.ErrNoLocalChanges:
+		cli.Println("No local changes to save")
+		return nil
+	default:
+		return handleStashErr(nil, err)
+	}
+}
+
+func listStashes(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	for _, stash := range stashes {
+		desc, err := stash.Description()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read %s", stash.Name()).AddCause(err).Build()
+		}
+
+		cli.Printf("%s: %s\n", stash.Name(), desc)
+	}
+
+	return nil
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, index int) errhand.VerboseError {
+	stash, err := actions.GetStash(ctx, dEnv.DoltDB, index)
+
+	if err != nil {
+		return handleStashErr(nil, err)
+	}
+
+	err = actions.DropStash(ctx, dEnv.DoltDB, index)
+
+	if err != nil {
+		return handleStashErr(nil, err)
+	}
+
+	h, err := stash.Working.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to hash stash").AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped %s (%s)\n", stash.Name(), h.String())
+	return nil
+}
+
+func handleStashErr(tblToStats map[string]*merge.MergeStats, err error) errhand.VerboseError {
+	switch {
+	case err == nil:
+		printModifications(tblToStats)
+		return nil
+	case err == actions.ErrStashConflicts:
+		printConflicts(tblToStats)
+		return errhand.BuildDError("error: the stash could not be applied without conflicts").
+			AddDetails("hint: resolve them with 'dolt conflicts resolve'. The stash was kept in case it is needed again.").
+			Build()
+	case err == actions.ErrStashNotFound:
+		return errhand.BuildDError("error: no such stash").Build()
+	case err == actions.ErrMergeActive:
+		return errhand.BuildDError("error: a merge is in progress").
+			AddDetails("hint: commit the merge or abort it with 'dolt merge --abort'").Build()
+	case err == actions.ErrReplayActive:
+		return errhand.BuildDError("error: a cherry-pick, rebase or revert is in progress").Build()
+	case actions.IsTblInConflict(err):
+		tbls := actions.GetTablesForError(err)
+		return errhand.BuildDError("error: the following tables are still in conflict: %s", strings.Join(tbls, ", ")).
+			AddDetails("hint: resolve them with 'dolt conflicts resolve' before applying a stash").Build()
+	default:
+		return errhand.BuildDError("error: failed to apply stash").AddCause(err).Build()
+	}
+}
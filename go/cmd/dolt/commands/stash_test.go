@@ -0,0 +1,80 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+)
+
+func TestStash(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	commitQueries(t, dEnv, "base", "create table tbl (id bigint primary key, v bigint)", "insert into tbl (id, v) values (1, 1), (2, 2)")
+
+	require.Equal(t, 0, Sql(ctx, "dolt sql", []string{"-q", "update tbl set v = 10 where id = 1"}, dEnv))
+	require.Equal(t, 0, Add(ctx, "dolt add", []string{"tbl"}, dEnv))
+	require.Equal(t, 0, Sql(ctx, "dolt sql", []string{"-q", "insert into tbl (id, v) values (3, 3)"}, dEnv))
+	assert.Equal(t, 0, Stash(ctx, "dolt stash", nil, dEnv))
+	assert.Equal(t, map[int64]int64{1: 1, 2: 2}, readValues(t, dEnv))
+	isUnchanged, err := dEnv.IsUnchangedFromHead(ctx)
+	require.NoError(t, err)
+	assert.True(t, isUnchanged)
+
+	require.Equal(t, 0, Sql(ctx, "dolt sql", []string{"-q", "update tbl set v = 20 where id = 2"}, dEnv))
+	assert.Equal(t, 0, Stash(ctx, "dolt stash", []string{"-m", "update 2"}, dEnv))
+
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+	require.NoError(t, err)
+	require.Len(t, stashes, 2)
+	desc, err := stashes[0].Description()
+	require.NoError(t, err)
+	assert.Equal(t, "On master: update 2", desc)
+
+	// The older stash is merged into changes made since
+	commitQueries(t, dEnv, "update 2 on master", "update tbl set v = 200 where id = 2")
+	require.Equal(t, 0, Sql(ctx, "dolt sql", []string{"-q", "insert into tbl (id, v) values (4, 4)"}, dEnv))
+	assert.Equal(t, 0, Stash(ctx, "dolt stash", []string{"pop", "stash@{1}"}, dEnv))
+	assert.Equal(t, map[int64]int64{1: 10, 2: 200, 3: 3, 4: 4}, readValues(t, dEnv))
+
+	stashes, err = actions.GetStashes(ctx, dEnv.DoltDB)
+	require.NoError(t, err)
+	require.Len(t, stashes, 1)
+	assert.Equal(t, "stash@{0}", stashes[0].Name())
+
+	// The remaining stash conflicts with the commit made since, so popping it keeps it
+	commitQueries(t, dEnv, "apply stash")
+	assert.Equal(t, 1, Stash(ctx, "dolt stash", []string{"pop"}, dEnv))
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	inConflict, err := root.TablesInConflict(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tbl"}, inConflict)
+	stashes, err = actions.GetStashes(ctx, dEnv.DoltDB)
+	require.NoError(t, err)
+	assert.Len(t, stashes, 1)
+
+	assert.Equal(t, 0, Stash(ctx, "dolt stash", []string{"drop", "0"}, dEnv))
+	stashes, err = actions.GetStashes(ctx, dEnv.DoltDB)
+	require.NoError(t, err)
+	assert.Empty(t, stashes)
+	assert.Equal(t, 1, Stash(ctx, "dolt stash", []string{"drop"}, dEnv))
+}
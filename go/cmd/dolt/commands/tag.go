@@ -0,0 +1,175 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+)
+
+var tagShortDesc = `Create, list, or delete tags`
+var tagLongDesc = `If there are no non-option arguments, existing tags are listed.
+
+The command's second form creates a new tag named <tagname> which points to the current <b>HEAD</b>, or <ref> if given. The tagger is read from the user.name and user.email config values, and the message given with <b>-m</b> is stored with the tag along with the time it was created. Tags can't be moved once they are created; delete the tag and create it again instead.
+
+With a <b>-d</b>, <tagname> will be deleted. You may specify more than one tag for deletion.`
+
+var tagSynopsis = []string{
+	`[-v]`,
+	`[-m <message>] <tagname> [<ref>]`,
+	`-d <tagname>...`,
+}
+
+const (
+	tagMessageArg = "message"
+)
+
+func Tag(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp["ref"] = "A commit that the new tag should point at."
+	ap.SupportsString(tagMessageArg, "m", "msg", "Use the given <msg> as the tag message.")
+	ap.SupportsFlag(verboseFlag, "v", "When in list mode, show the commit, tagger, date and message of each tag")
+	ap.SupportsFlag(deleteFlag, "d", "Delete a tag.")
+	help, usage := cli.HelpAndUsagePrinters(commandStr, tagShortDesc, tagLongDesc, tagSynopsis, ap)
+	apr := cli.ParseArgs(ap, args, help)
+
+	switch {
+	case apr.Contains(deleteFlag):
+		return deleteTags(ctx, dEnv, apr, usage)
+	case apr.NArg() > 0:
+		return createTag(ctx, dEnv, apr, usage)
+	default:
+		return printTags(ctx, dEnv, apr, usage)
+	}
+}
+
+func printTags(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, _ cli.UsagePrinter) int {
+	tags, err := actions.GetTags(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read tags from db").AddCause(err).Build(), nil)
+	}
+
+	for _, tag := range tags {
+		if !apr.Contains(verboseFlag) {
+			cli.Println(tag.Name())
+			continue
+		}
+
+		verr := printVerboseTag(ctx, tag)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, nil)
+		}
+	}
+
+	return 0
+}
+
+func printVerboseTag(ctx context.Context, tag *doltdb.Tag) errhand.VerboseError {
+	meta, err := tag.GetTagMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read tag '%s'", tag.Name()).AddCause(err).Build()
+	}
+
+	cm, err := tag.GetCommit(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read commit of tag '%s'", tag.Name()).AddCause(err).Build()
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+	}
+
+	cli.Println(color.YellowString("tag %s", tag.Name()) + "\t" + h.String())
+	cli.Printf("Tagger: %s <%s>\n", meta.Name, meta.Email)
+	cli.Printf("Date:   %s\n", meta.FormatTS())
+
+	if meta.Description != "" {
+		cli.Println()
+		cli.Printf("\t%s\n", meta.Description)
+	}
+
+	cli.Println()
+	return nil
+}
+
+func createTag(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() > 2 {
+		usage()
+		return 1
+	}
+
+	tagName := apr.Arg(0)
+	startPt := "head"
+
+	if apr.NArg() == 2 {
+		startPt = apr.Arg(1)
+	}
+
+	msg, _ := apr.GetValue(tagMessageArg)
+	err := actions.CreateTag(ctx, dEnv, tagName, startPt, msg)
+
+	var verr errhand.VerboseError
+	if err != nil {
+		if err == doltdb.ErrTagExists {
+			verr = errhand.BuildDError("fatal: tag '%s' already exists", tagName).Build()
+		} else if err == doltdb.ErrInvTagName {
+			verr = errhand.BuildDError("fatal: '%s' is not a valid tag name.", tagName).Build()
+		} else if err == actions.ErrNameNotConfigured || err == actions.ErrEmailNotConfigured {
+			return handleCommitErr(err, usage)
+		} else if err == doltdb.ErrInvHash || doltdb.IsNotACommit(err) {
+			verr = errhand.BuildDError("fatal: '%s' is not a commit and a tag '%s' cannot be created from it", startPt, tagName).Build()
+		} else {
+			verr = errhand.BuildDError("fatal: Unexpected error creating tag '%s'", tagName).AddCause(err).Build()
+		}
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func deleteTags(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	for _, tagName := range apr.Args() {
+		err := actions.DeleteTag(ctx, dEnv, tagName)
+
+		if err == doltdb.ErrTagNotFound {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: tag '%s' not found.", tagName).Build(), usage)
+		} else if err != nil {
+			bdr := errhand.BuildDError("fatal: Unexpected error deleting tag '%s'", tagName)
+			return HandleVErrAndExitCode(bdr.AddCause(err).Build(), usage)
+		}
+
+		cli.Printf("Deleted tag '%s'\n", tagName)
+	}
+
+	return 0
+}
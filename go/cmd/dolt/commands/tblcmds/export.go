@@ -73,7 +73,7 @@ func validateExportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 		if val.Format == mvdata.InvalidDataFormat {
 			val = mvdata.StreamDataLocation{Format: mvdata.CsvFile, Reader: os.Stdin, Writer: iohelp.NopWrCloser(cli.CliOut)}
 			destLoc = val
-		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile {
+		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile && val.Format != mvdata.JsonLinesFile {
 			cli.PrintErrln(color.RedString("Cannot export this format to stdout"))
 			return "", mvdata.TableDataLocation{}, nil
 		}
@@ -27,8 +27,10 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/mvdata"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
 	"github.com/liquidata-inc/dolt/go/store/types"
@@ -37,6 +39,7 @@ import (
 const (
 	createParam      = "create-table"
 	updateParam      = "update-table"
+	replaceParam     = "replace"
 	tableParam       = "table"
 	fileParam        = "file"
 	outSchemaParam   = "schema"
@@ -46,6 +49,7 @@ const (
 	primaryKeyParam  = "pk"
 	fileTypeParam    = "file-type"
 	delimParam       = "delim"
+	dryRunParam      = "dry-run"
 )
 
 var schemaFileHelp = "Schema definition files are json files in the format:" + `
@@ -61,7 +65,7 @@ var schemaFileHelp = "Schema definition files are json files in the format:" + `
 	where "fields" is the array of columns in each row of the table
 	"constraints" is a list of table constraints.  (Only primary_key constraint types are supported currently)
 	FIELD_NAME is the name of a column in a row and can be any valid string
-	KIND must be a supported noms kind (bool, string, uuid, uint, int, float)
+	KIND must be a supported noms kind (bool, string, uuid, uint, int, float, timestamp, decimal)
 	INTEGER_FIELD_INDEX must be the 0 based index of the primary key in the "fields" array
 `
 
@@ -83,13 +87,37 @@ force flag forces the existing table to be overwritten.
 The schema for the new table can be specified explicitly by providing a schema definition file, or will be inferred 
 from the imported file.  All schemas, inferred or explicitly defined must define a primary key.  If the file format 
 being imported does not support defining a primary key, then the <b>--pk</b> parameter must supply the name of the 
-field that should be used as the primary key.
+field that should be used as the primary key, as it must for parquet files. The types of the columns of an inferred 
+schema are taken from the file when it has them, as parquet files do. For csv and psv files the file is read to find 
+the narrowest type (int, uint, float, bool or uuid) that every value of each column can be converted to, and columns 
+with no empty values are not null. Columns that don't fit one of these types are strings. If <b>--pk</b> isn't given the first column with a unique, 
+non-empty value in every row is used as the primary key, and if it is given its values are checked to be unique.
+
+The rows of a table can be imported from a file of SQL statements, such as a dump of a MySQL database written by 
+mysqldump. The table's rows are read from the INSERT statements for the table, and when creating a table its schema is 
+read from its CREATE TABLE statement unless a schema file is given. Other statements, and statements for other tables, 
+are ignored. To load every table in such a file, pipe it to <b>dolt sql</b> instead.
+
+JSON Lines files (.jsonl or .ndjson), which have a JSON object for each row on a line of its own, are read a row at a 
+time, so they can be of any size and can be piped to <b>dolt table import</b> with <b>--file-type jsonl</b>. The fields 
+of each object are imported into the columns with the same names, and other fields are ignored. The fields of nested 
+objects are imported by their paths, so that <b>{"address": {"city": "Seattle"}}</b> gives a value for the column 
+<b>address.city</b>, unless there is a column named <b>address</b>, which is given the object's JSON text. A mapping 
+file can map these paths to other columns, such as <b>{"address.city": "city"}</b>.
+
+The <b>--dry-run</b> flag prints the schema of the table that would be created without importing any data, so that an 
+inferred schema can be checked, or saved and edited for use with <b>--schema</b>.
 
 ` + schemaFileHelp +
 	`
 If <b>--update-table | -u</b> is given the operation will update <table> with the contents of file. The table's existing 
 schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
+If <b>--replace | -r</b> is given the operation will replace the rows of <table> with the contents of file, deleting 
+the rows which aren't in it. As with an update the table's existing schema is used, and unlike overwriting the table with 
+<b>-c -f</b> the rows which are unchanged are left as they are, so <b>dolt diff</b> shows only the rows that were added, 
+modified or deleted. This makes it suitable for importing regular snapshots of data kept elsewhere.
+
 During import, if there is an error importing any row, the import will be aborted by default.  Use the <b>--continue</b>
 flag to continue importing when an error is encountered.
 
@@ -98,14 +126,15 @@ be used when creating a new table, or updating an existing table.
 
 ` + mappingFileHelp +
 	`
-In both create and update scenarios the file's extension is used to infer the type of the file.  If a file does not 
+In all scenarios the file's extension is used to infer the type of the file.  If a file does not 
 have the expected extension then the <b>--file-type</b> parameter should be used to explicitly define the format of 
-the file in one of the supported formats (csv, psv, nbf, json, xlsx).  For files separated by a delimiter other than a 
+the file in one of the supported formats (csv, psv, nbf, json, jsonl, xlsx, parquet, sql).  For files separated by a delimiter other than a 
 ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimeter`
 
 var importSynopsis = []string{
-	"-c [-f] [--pk <field>] [--schema <file>] [--map <file>] [--continue] [--file-type <type>] <table> <file>",
+	"-c [-f] [--pk <field>] [--schema <file>] [--map <file>] [--continue] [--file-type <type>] [--dry-run] <table> <file>",
 	"-u [--map <file>] [--continue] [--file-type <type>] <table> <file>",
+	"-r [--map <file>] [--continue] [--file-type <type>] <table> <file>",
 }
 
 func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter) (mvdata.MoveOperation, mvdata.TableDataLocation, mvdata.DataLocation, interface{}) {
@@ -116,15 +145,26 @@ func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 
 	var mvOp mvdata.MoveOperation
 	var srcOpts interface{}
-	if !apr.Contains(createParam) && !apr.Contains(updateParam) {
-		cli.PrintErrln("Must include '-c' for initial table import or -u to update existing table.")
+	if !apr.ContainsAny(createParam, updateParam, replaceParam) {
+		cli.PrintErrln("Must include '-c' for initial table import, -u to update existing table, or -r to replace existing table.")
+		return mvdata.InvalidOp, mvdata.TableDataLocation{}, nil, nil
+	} else if len(apr.GetValues(createParam, updateParam, replaceParam)) > 1 {
+		cli.PrintErrln("Only one of '-c', '-u' and '-r' may be given.")
 		return mvdata.InvalidOp, mvdata.TableDataLocation{}, nil, nil
 	} else if apr.Contains(createParam) {
 		mvOp = mvdata.OverwriteOp
 	} else {
 		mvOp = mvdata.UpdateOp
+		if apr.Contains(replaceParam) {
+			mvOp = mvdata.ReplaceOp
+		}
+
 		if apr.Contains(outSchemaParam) {
-			cli.PrintErrln("fatal:", outSchemaParam+" is not supported for update operations")
+			cli.PrintErrln("fatal:", outSchemaParam+" is not supported for "+string(mvOp)+" operations")
+			usage()
+			return mvdata.InvalidOp, mvdata.TableDataLocation{}, nil, nil
+		} else if apr.Contains(dryRunParam) {
+			cli.PrintErrln("fatal:", dryRunParam+" is not supported for "+string(mvOp)+" operations")
 			usage()
 			return mvdata.InvalidOp, mvdata.TableDataLocation{}, nil, nil
 		}
@@ -145,6 +185,7 @@ func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 
 	delim, hasDelim := apr.GetValue(delimParam)
 	fType, hasFileType := apr.GetValue(fileTypeParam)
+	mappingFile, _ := apr.GetValue(mappingFileParam)
 
 	if hasFileType {
 		if mvdata.DFFromString(fType) == mvdata.InvalidDataFormat {
@@ -176,6 +217,10 @@ func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
 		} else if val.Format == mvdata.JsonFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName}
+		} else if val.Format == mvdata.JsonLinesFile {
+			srcOpts = mvdata.JSONLinesOptions{TableName: tableName, MappingFile: mappingFile}
+		} else if val.Format == mvdata.SqlFile {
+			srcOpts = mvdata.SqlOptions{TableName: tableName}
 		}
 
 	case mvdata.StreamDataLocation:
@@ -186,6 +231,8 @@ func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
+		} else if val.Format == mvdata.JsonLinesFile {
+			srcOpts = mvdata.JSONLinesOptions{TableName: tableName, MappingFile: mappingFile}
 		}
 
 	case mvdata.TableDataLocation:
@@ -201,12 +248,16 @@ func validateImportArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter)
 }
 
 func Import(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
-	force, mvOpts := parseCreateArgs(commandStr, args)
+	force, dryRun, mvOpts := parseCreateArgs(commandStr, args)
 
 	if mvOpts == nil {
 		return 1
 	}
 
+	if dryRun {
+		return printOutSchema(ctx, dEnv, force, mvOpts)
+	}
+
 	res := executeMove(ctx, dEnv, force, mvOpts)
 
 	if res == 0 {
@@ -216,7 +267,7 @@ func Import(ctx context.Context, commandStr string, args []string, dEnv *env.Dol
 	return res
 }
 
-func parseCreateArgs(commandStr string, args []string) (bool, *mvdata.MoveOptions) {
+func parseCreateArgs(commandStr string, args []string) (bool, bool, *mvdata.MoveOptions) {
 	ap := createArgParser()
 
 	help, usage := cli.HelpAndUsagePrinters(commandStr, importShortDesc, importLongDesc, importSynopsis, ap)
@@ -224,14 +275,14 @@ func parseCreateArgs(commandStr string, args []string) (bool, *mvdata.MoveOption
 	moveOp, tableLoc, fileLoc, srcOpts := validateImportArgs(apr, usage)
 
 	if fileLoc == nil || len(tableLoc.Name) == 0 {
-		return false, nil
+		return false, false, nil
 	}
 
 	schemaFile, _ := apr.GetValue(outSchemaParam)
 	mappingFile, _ := apr.GetValue(mappingFileParam)
 	primaryKey, _ := apr.GetValue(primaryKeyParam)
 
-	return apr.Contains(forceParam), &mvdata.MoveOptions{
+	return apr.Contains(forceParam), apr.Contains(dryRunParam), &mvdata.MoveOptions{
 		Operation:   moveOp,
 		ContOnErr:   apr.Contains(contOnErrParam),
 		SchFile:     schemaFile,
@@ -249,6 +300,7 @@ func createArgParser() *argparser.ArgParser {
 	ap.ArgListHelp[fileParam] = "The file being imported. Supported file types are csv, psv, and nbf."
 	ap.SupportsFlag(createParam, "c", "Create a new table, or overwrite an existing table (with the -f flag) from the imported data.")
 	ap.SupportsFlag(updateParam, "u", "Update an existing table with the imported data.")
+	ap.SupportsFlag(replaceParam, "r", "Replace the rows of an existing table with the imported data, deleting the rows not in it.")
 	ap.SupportsFlag(forceParam, "f", "If a create operation is being executed, data already exists in the destination, the Force flag will allow the target to be overwritten.")
 	ap.SupportsFlag(contOnErrParam, "", "Continue importing when row import errors are encountered.")
 	ap.SupportsString(outSchemaParam, "s", "schema_file", "The schema for the output data.")
@@ -256,6 +308,7 @@ func createArgParser() *argparser.ArgParser {
 	ap.SupportsString(primaryKeyParam, "pk", "primary_key", "Explicitly define the name of the field in the schema which should be used as the primary key.")
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
 	ap.SupportsString(delimParam, "", "delimiter", "Specify a delimeter for a csv style file with a non-comma delimiter.")
+	ap.SupportsFlag(dryRunParam, "", "Print the schema of the table that would be created without importing any data.")
 	return ap
 }
 
@@ -265,49 +318,22 @@ func importStatsCB(stats types.AppliedEditStats) {
 	noEffect := stats.NonExistentDeletes + stats.SameVal
 	total := noEffect + stats.Modifications + stats.Additions
 	displayStr := fmt.Sprintf("Rows Processed: %d, Additions: %d, Modifications: %d, Had No Effect: %d", total, stats.Additions, stats.Modifications, noEffect)
-	displayStrLen = cli.DeleteAndPrint(displayStrLen, displayStr)
-}
 
-func executeMove(ctx context.Context, dEnv *env.DoltEnv, force bool, mvOpts *mvdata.MoveOptions) int {
-	root, err := dEnv.WorkingRoot(ctx)
-
-	if err != nil {
-		cli.PrintErrln(color.RedString("Unable to get the working root value for this data repository."))
-		return 1
+	if stats.Deletions > 0 {
+		displayStr += fmt.Sprintf(", Deletions: %d", stats.Deletions)
 	}
 
-	_, isStdOut := mvOpts.Dest.(mvdata.StreamDataLocation)
-	if !isStdOut && mvOpts.Operation == mvdata.OverwriteOp && !force {
-		if exists, err := mvOpts.Dest.Exists(ctx, root, dEnv.FS); err != nil {
-			cli.Println(color.RedString(err.Error()))
-			return 1
-		} else if exists {
-			cli.PrintErrln(color.RedString("Data already exists.  Use -f to overwrite."))
-			return 1
-		}
-	}
-
-	if srcFileLoc, isFileType := mvOpts.Src.(mvdata.FileDataLocation); isFileType {
-		if srcFileLoc.Format == mvdata.SqlFile {
-			cli.Println(color.RedString("For SQL import, please pipe SQL input files to `dolt sql`"))
-			return 1
-		}
-
-		if srcFileLoc.Format == mvdata.JsonFile && mvOpts.Operation == mvdata.OverwriteOp && mvOpts.SchFile == "" {
-			cli.Println(color.RedString("Please specify schema file for .json tables."))
-			return 1
-		}
-	}
+	displayStrLen = cli.DeleteAndPrint(displayStrLen, displayStr)
+}
 
-	mover, nDMErr := mvdata.NewDataMover(ctx, root, dEnv.FS, mvOpts, importStatsCB)
+func executeMove(ctx context.Context, dEnv *env.DoltEnv, force bool, mvOpts *mvdata.MoveOptions) int {
+	mover, res := newDataMover(ctx, dEnv, force, mvOpts, importStatsCB)
 
-	if nDMErr != nil {
-		verr := newDataMoverErrToVerr(mvOpts, nDMErr)
-		cli.PrintErrln(verr.Verbose())
-		return 1
+	if res != 0 {
+		return res
 	}
 
-	err = mover.Move(ctx)
+	err := mover.Move(ctx)
 
 	if err != nil {
 		cli.Println()
@@ -346,6 +372,69 @@ func executeMove(ctx context.Context, dEnv *env.DoltEnv, force bool, mvOpts *mvd
 	return 0
 }
 
+// newDataMover checks that the move described by mvOpts can be made and creates the DataMover which makes it, returning a
+// non-zero result code if it can't.
+func newDataMover(ctx context.Context, dEnv *env.DoltEnv, force bool, mvOpts *mvdata.MoveOptions, statsCB noms.StatsCB) (*mvdata.DataMover, int) {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		cli.PrintErrln(color.RedString("Unable to get the working root value for this data repository."))
+		return nil, 1
+	}
+
+	_, isStdOut := mvOpts.Dest.(mvdata.StreamDataLocation)
+	if !isStdOut && mvOpts.Operation == mvdata.OverwriteOp && !force {
+		if exists, err := mvOpts.Dest.Exists(ctx, root, dEnv.FS); err != nil {
+			cli.Println(color.RedString(err.Error()))
+			return nil, 1
+		} else if exists {
+			cli.PrintErrln(color.RedString("Data already exists.  Use -f to overwrite."))
+			return nil, 1
+		}
+	}
+
+	var srcFormat mvdata.DataFormat
+	switch src := mvOpts.Src.(type) {
+	case mvdata.FileDataLocation:
+		srcFormat = src.Format
+	case mvdata.StreamDataLocation:
+		srcFormat = src.Format
+	}
+
+	if (srcFormat == mvdata.JsonFile || srcFormat == mvdata.JsonLinesFile) && mvOpts.Operation == mvdata.OverwriteOp && mvOpts.SchFile == "" {
+		cli.Println(color.RedString("Please specify schema file for %s tables.", srcFormat))
+		return nil, 1
+	}
+
+	mover, nDMErr := mvdata.NewDataMover(ctx, root, dEnv.FS, mvOpts, statsCB)
+
+	if nDMErr != nil {
+		verr := newDataMoverErrToVerr(mvOpts, nDMErr)
+		cli.PrintErrln(verr.Verbose())
+		return nil, 1
+	}
+
+	return mover, 0
+}
+
+// printOutSchema prints the schema of the table that an import would create as a CREATE TABLE statement, without
+// importing any data.
+func printOutSchema(ctx context.Context, dEnv *env.DoltEnv, force bool, mvOpts *mvdata.MoveOptions) int {
+	mover, res := newDataMover(ctx, dEnv, force, mvOpts, nil)
+
+	if res != 0 {
+		return res
+	}
+
+	defer mover.Rd.Close(ctx)
+	defer mover.Wr.Close(ctx)
+
+	tableDest := mvOpts.Dest.(mvdata.TableDataLocation)
+	cli.Println(sql.SchemaAsCreateStmt(tableDest.Name, mover.Wr.GetSchema()))
+
+	return 0
+}
+
 func newDataMoverErrToVerr(mvOpts *mvdata.MoveOptions, err *mvdata.DataMoverCreationError) errhand.VerboseError {
 	switch err.ErrType {
 	case mvdata.CreateReaderErr:
@@ -359,6 +448,14 @@ func newDataMoverErrToVerr(mvOpts *mvdata.MoveOptions, err *mvdata.DataMoverCrea
 		return bdr.AddCause(err.Cause).Build()
 
 	case mvdata.SchemaErr:
+		if err.Cause == csv.ErrNoInferredPK {
+			builder := errhand.BuildDError("Could not choose a primary key for %s.", mvOpts.Dest.String())
+			builder.AddDetails("No column of %s has a unique value in every row. A primary key can be specified by:\n"+
+				"\tusing -pk option to designate one or more fields as the primary key by name.\n"+
+				"\tusing -schema to provide a schema descriptor file.", mvOpts.Src.String())
+			return builder.Build()
+		}
+
 		bdr := errhand.BuildDError("Error determining the output schema.")
 		bdr.AddDetails("When attempting to move data from %s to %s, could not determine the output schema.", mvOpts.Src.String(), mvOpts.Dest.String())
 		bdr.AddDetails(`Schema File: "%s"`, mvOpts.SchFile)
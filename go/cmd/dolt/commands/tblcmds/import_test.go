@@ -274,7 +274,7 @@ func TestParseCreateArgs(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, actualOpts := parseCreateArgs("dolt edit create", test.args)
+		_, _, actualOpts := parseCreateArgs("dolt edit create", test.args)
 
 		if !optsEqual(test.expectedOpts, actualOpts) {
 			argStr := strings.Join(test.args, " ")
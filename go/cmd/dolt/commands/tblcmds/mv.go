@@ -75,6 +75,10 @@ func Mv(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv
 				} else {
 					working, err = working.PutTable(ctx, dEnv.DoltDB, new, tbl)
 
+					if err == nil {
+						working, err = working.RenameTableInForeignKeys(ctx, old, new)
+					}
+
 					if err != nil {
 						verr = errhand.BuildDError("error: failed to write table back to database").AddCause(err).Build()
 					} else {
@@ -223,7 +223,19 @@ func createRow(nbf *types.NomsBinFormat, sch schema.Schema, prArgs *putRowArgs)
 		return nil, errhand.BuildDError("inserted row does not match schema").AddCause(err).Build()
 	}
 
-	if col, _ := row.GetInvalidCol(typedRow, sch); col != nil {
+	typedRow, err = row.ApplyDefaults(typedRow, sch)
+
+	if err != nil {
+		return nil, errhand.BuildDError("failed to apply default values").AddCause(err).Build()
+	}
+
+	if col, cnst, _ := row.GetInvalidConstraint(typedRow, sch); col != nil {
+		if cnst != nil && cnst.GetConstraintType() != schema.NotNullConstraintType {
+			bdr := errhand.BuildDError("Constraint failed.")
+			bdr.AddDetails("The value for the column %s is not valid: %v", col.Name, cnst)
+			return nil, bdr.Build()
+		}
+
 		bdr := errhand.BuildDError("Missing required fields.")
 		bdr.AddDetails("The value for the column %s is not valid", col.Name)
 		return nil, bdr.Build()
@@ -23,6 +23,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
 )
 
 var tblRmShortDesc = "Removes table(s) from the working set of tables."
@@ -62,6 +63,21 @@ func Rm(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv
 }
 
 func removeTables(ctx context.Context, dEnv *env.DoltEnv, tables []string, working *doltdb.RootValue) errhand.VerboseError {
+	removed := set.NewStrSet(tables)
+	for _, tbl := range tables {
+		_, referencing, err := working.GetForeignKeysForTable(ctx, tbl)
+
+		if err != nil {
+			return errhand.BuildDError("Unable to read foreign keys").AddCause(err).Build()
+		}
+
+		for _, fk := range referencing {
+			if !removed.Contains(fk.Table) {
+				return errhand.BuildDError("Unable to remove '%s', which is referenced by foreign key '%s' of table '%s'", tbl, fk.Name, fk.Table).Build()
+			}
+		}
+	}
+
 	working, err := working.RemoveTables(ctx, tables...)
 
 	if err != nil {
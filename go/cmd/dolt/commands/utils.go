@@ -55,6 +55,12 @@ func UpdateWorkingWithVErr(dEnv *env.DoltEnv, updatedRoot *doltdb.RootValue) err
 		return errhand.BuildDError("fatal: failed to update the working root state").Build()
 	}
 
+	if doltdb.IsForeignKeyViolationErr(err) {
+		return errhand.BuildDError("error: foreign key constraint failed").AddCause(err).Build()
+	} else if err != nil {
+		return errhand.BuildDError("fatal: failed to update the working root").AddCause(err).Build()
+	}
+
 	return nil
 }
 
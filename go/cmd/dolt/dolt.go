@@ -52,8 +52,15 @@ var doltCommand = cli.GenSubCommandHandler([]*cli.Command{
 	{Name: "sql-server", Desc: "Starts a MySQL-compatible server.", Func: sqlserver.SqlServer, ReqRepo: true, EventType: eventsapi.ClientEventType_SQL_SERVER},
 	{Name: "log", Desc: "Show commit logs.", Func: commands.Log, ReqRepo: true, EventType: eventsapi.ClientEventType_LOG},
 	{Name: "diff", Desc: "Diff a table.", Func: commands.Diff, ReqRepo: true, EventType: eventsapi.ClientEventType_DIFF},
+	{Name: "blame", Desc: "Show what revision and author last modified each row of a table.", Func: commands.Blame, ReqRepo: true},
 	{Name: "merge", Desc: "Merge a branch.", Func: commands.Merge, ReqRepo: true, EventType: eventsapi.ClientEventType_MERGE},
 	{Name: "branch", Desc: "Create, list, edit, delete branches.", Func: commands.Branch, ReqRepo: true, EventType: eventsapi.ClientEventType_BRANCH},
+	{Name: "cherry-pick", Desc: "Apply the changes introduced by an existing commit.", Func: commands.CherryPick, ReqRepo: true},
+	{Name: "rebase", Desc: "Reapply commits on top of another branch.", Func: commands.Rebase, ReqRepo: true},
+	{Name: "stash", Desc: "Stash the changes in a dirty working set away.", Func: commands.Stash, ReqRepo: true},
+	{Name: "revert", Desc: "Undo the changes introduced by an existing commit.", Func: commands.Revert, ReqRepo: true},
+	{Name: "gc", Desc: "Cleans up unreferenced data from the repository.", Func: commands.GarbageCollection, ReqRepo: true},
+	{Name: "tag", Desc: "Create, list, delete tags.", Func: commands.Tag, ReqRepo: true},
 	{Name: "checkout", Desc: "Checkout a branch or overwrite a table from HEAD.", Func: commands.Checkout, ReqRepo: true, EventType: eventsapi.ClientEventType_CHECKOUT},
 	{Name: "remote", Desc: "Manage set of tracked repositories.", Func: commands.Remote, ReqRepo: true, EventType: eventsapi.ClientEventType_REMOTE},
 	{Name: "push", Desc: "Push to a dolt remote.", Func: commands.Push, ReqRepo: true, EventType: eventsapi.ClientEventType_PUSH},
@@ -187,6 +187,23 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 	return rwp.Row, rwp.Props, nil
 }
 
+// AddRemovedRows adds old rows to be returned as removed ahead of the rows of the diff. The rows must have the schema of
+// the old rows of the diff.
+func (rdRd *RowDiffSource) AddRemovedRows(rows ...row.Row) error {
+	for _, r := range rows {
+		mappedOld, err := rdRd.oldConv.Convert(r)
+
+		if err != nil {
+			return err
+		}
+
+		props := map[string]interface{}{DiffTypeProp: DiffRemoved}
+		rdRd.bufferedRows = append(rdRd.bufferedRows, pipeline.NewRowWithProps(mappedOld, props))
+	}
+
+	return nil
+}
+
 func (rdRd *RowDiffSource) nextFromBuffer() pipeline.RowWithProps {
 	r := rdRd.bufferedRows[0]
 	rdRd.bufferedRows = rdRd.bufferedRows[1:]
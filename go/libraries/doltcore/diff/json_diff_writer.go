@@ -0,0 +1,269 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	jsonDiffAdded    = "added"
+	jsonDiffRemoved  = "removed"
+	jsonDiffModified = "modified"
+)
+
+// JSONDiffWriter writes the differences between tables as a single JSON document of the form:
+//
+//	{"tables": [{"name": ..., "change": ..., "schema_changes": [...], "row_changes": [...]}, ...]}
+//
+// Each schema change holds the tag of the column and its definitions before and after the change, and each row change
+// holds the values of the row before and after the change, keyed by column name. Values which don't exist before or
+// after a change are null.
+type JSONDiffWriter struct {
+	wr            io.Writer
+	tablesWritten int
+	rowsWritten   int
+	oldSch        schema.Schema
+	newSch        schema.Schema
+}
+
+type jsonColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	NotNull    bool   `json:"not_null"`
+}
+
+type jsonSchemaChange struct {
+	Tag    uint64      `json:"tag"`
+	Change string      `json:"change"`
+	From   *jsonColumn `json:"from"`
+	To     *jsonColumn `json:"to"`
+}
+
+type jsonRowChange struct {
+	Change string                 `json:"change"`
+	From   map[string]interface{} `json:"from"`
+	To     map[string]interface{} `json:"to"`
+}
+
+// NewJSONDiffWriter returns a JSONDiffWriter writing to the writer given.
+func NewJSONDiffWriter(wr io.Writer) (*JSONDiffWriter, error) {
+	err := iohelp.WriteAll(wr, []byte(`{"tables":[`))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONDiffWriter{wr: wr}, nil
+}
+
+// BeginTable starts the diff of the table with the name given. oldSch is nil if the table was added, and newSch is nil
+// if it was removed. The schema changes of the table are only written if withSchemaChanges is true. Rows written until
+// the next call to EndTable are row changes of this table.
+func (w *JSONDiffWriter) BeginTable(tblName string, oldSch, newSch schema.Schema, withSchemaChanges bool) error {
+	change := jsonDiffModified
+	if oldSch == nil {
+		change = jsonDiffAdded
+	} else if newSch == nil {
+		change = jsonDiffRemoved
+	}
+
+	schChanges := []jsonSchemaChange{}
+	if withSchemaChanges {
+		var err error
+		schChanges, err = jsonSchemaChanges(oldSch, newSch)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	header := struct {
+		Name          string             `json:"name"`
+		Change        string             `json:"change"`
+		SchemaChanges []jsonSchemaChange `json:"schema_changes"`
+	}{tblName, change, schChanges}
+
+	data, err := json.Marshal(header)
+
+	if err != nil {
+		return err
+	}
+
+	// replace the closing brace so the row changes can be streamed into the table's object
+	data = append(data[:len(data)-1], []byte(`,"row_changes":[`)...)
+
+	if w.tablesWritten != 0 {
+		data = append([]byte{','}, data...)
+	}
+
+	w.tablesWritten++
+	w.rowsWritten = 0
+	w.oldSch, w.newSch = oldSch, newSch
+
+	return iohelp.WriteAll(w.wr, data)
+}
+
+// WriteRowDiff writes the change of a row of the current table. oldR is nil if the row was added, and newR is nil if it
+// was removed.
+func (w *JSONDiffWriter) WriteRowDiff(oldR, newR row.Row) error {
+	change := jsonDiffModified
+	if oldR == nil {
+		change = jsonDiffAdded
+	} else if newR == nil {
+		change = jsonDiffRemoved
+	}
+
+	from, err := jsonRowValues(w.oldSch, oldR)
+
+	if err != nil {
+		return err
+	}
+
+	to, err := jsonRowValues(w.newSch, newR)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(jsonRowChange{change, from, to})
+
+	if err != nil {
+		return err
+	}
+
+	if w.rowsWritten != 0 {
+		data = append([]byte{','}, data...)
+	}
+
+	w.rowsWritten++
+
+	return iohelp.WriteAll(w.wr, data)
+}
+
+// EndTable ends the diff of the current table.
+func (w *JSONDiffWriter) EndTable() error {
+	return iohelp.WriteAll(w.wr, []byte(`]}`))
+}
+
+// Close ends the JSON document.
+func (w *JSONDiffWriter) Close() error {
+	return iohelp.WriteAll(w.wr, []byte("]}\n"))
+}
+
+func jsonSchemaChanges(oldSch, newSch schema.Schema) ([]jsonSchemaChange, error) {
+	if oldSch == nil {
+		oldSch = schema.UnkeyedSchemaFromCols(schema.EmptyColColl)
+	}
+
+	if newSch == nil {
+		newSch = schema.UnkeyedSchemaFromCols(schema.EmptyColColl)
+	}
+
+	diffs, err := DiffSchemas(oldSch, newSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]jsonSchemaChange, 0, len(diffs))
+	for tag, dff := range diffs {
+		var change string
+		switch dff.DiffType {
+		case SchDiffColAdded:
+			change = jsonDiffAdded
+		case SchDiffColRemoved:
+			change = jsonDiffRemoved
+		case SchDiffColModified:
+			change = jsonDiffModified
+		default:
+			continue
+		}
+
+		changes = append(changes, jsonSchemaChange{tag, change, toJSONColumn(dff.Old), toJSONColumn(dff.New)})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Tag < changes[j].Tag
+	})
+
+	return changes, nil
+}
+
+func toJSONColumn(col *schema.Column) *jsonColumn {
+	if col == nil {
+		return nil
+	}
+
+	return &jsonColumn{col.Name, sql.ColumnSQLType(*col), col.IsPartOfPK, !col.IsNullable()}
+}
+
+func jsonRowValues(sch schema.Schema, r row.Row) (map[string]interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	vals := make(map[string]interface{})
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, _ := r.GetColVal(tag)
+		vals[col.Name], err = jsonValue(val)
+		return err != nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vals, nil
+}
+
+// jsonValue returns the value to marshal for a noms value. Numbers and bools are marshalled as JSON numbers and bools,
+// and all other kinds are marshalled as strings.
+func jsonValue(val types.Value) (interface{}, error) {
+	if types.IsNull(val) {
+		return nil, nil
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return uint64(v), nil
+	case types.Float:
+		return float64(v), nil
+	case types.Bool:
+		return bool(v), nil
+	case types.String:
+		return string(v), nil
+	}
+
+	str, err := doltcore.GetConvFunc(val.Kind(), types.StringKind)(val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(str.(types.String)), nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestJSONDiffWriter(t *testing.T) {
+	oldColColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	)
+	newColColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.NewColumn("married", 2, types.BoolKind, false),
+	)
+	oldSch := schema.SchemaFromCols(oldColColl)
+	newSch := schema.SchemaFromCols(newColColl)
+
+	newRow := func(sch schema.Schema, vals row.TaggedValues) row.Row {
+		r, err := row.New(types.Format_7_18, sch, vals)
+		require.NoError(t, err)
+		return r
+	}
+
+	buf := &bytes.Buffer{}
+	wr, err := NewJSONDiffWriter(buf)
+	require.NoError(t, err)
+
+	require.NoError(t, wr.BeginTable("people", oldSch, newSch, true))
+	require.NoError(t, wr.WriteRowDiff(nil, newRow(newSch, row.TaggedValues{0: types.Int(1), 1: types.String("a"), 2: types.Bool(true)})))
+	require.NoError(t, wr.WriteRowDiff(newRow(oldSch, row.TaggedValues{0: types.Int(2), 1: types.String("b")}), nil))
+	require.NoError(t, wr.WriteRowDiff(newRow(oldSch, row.TaggedValues{0: types.Int(3)}), newRow(newSch, row.TaggedValues{0: types.Int(3), 1: types.String("c")})))
+	require.NoError(t, wr.EndTable())
+	require.NoError(t, wr.BeginTable("removed", oldSch, nil, false))
+	require.NoError(t, wr.EndTable())
+	require.NoError(t, wr.Close())
+
+	expected := `{"tables": [
+		{"name": "people", "change": "modified",
+			"schema_changes": [
+				{"tag": 2, "change": "added", "from": null, "to": {"name": "married", "type": "bool", "primary_key": false, "not_null": false}}
+			],
+			"row_changes": [
+				{"change": "added", "from": null, "to": {"id": 1, "name": "a", "married": true}},
+				{"change": "removed", "from": {"id": 2, "name": "b"}, "to": null},
+				{"change": "modified", "from": {"id": 3, "name": null}, "to": {"id": 3, "name": "c", "married": null}}
+			]
+		},
+		{"name": "removed", "change": "removed", "schema_changes": [], "row_changes": []}
+	]}`
+
+	var expectedDoc, actualDoc interface{}
+	require.NoError(t, json.Unmarshal([]byte(expected), &expectedDoc))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actualDoc))
+	assert.Equal(t, expectedDoc, actualDoc)
+}
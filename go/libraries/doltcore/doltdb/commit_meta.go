@@ -119,11 +119,15 @@ func (cm *CommitMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, erro
 // FormatTS takes the internal timestamp and turns it into a human readable string in the time.RubyDate format
 // which looks like: "Mon Jan 02 15:04:05 -0700 2006"
 func (cm *CommitMeta) FormatTS() string {
+	return cm.Time().Format(time.RubyDate)
+}
+
+// Time returns the time at which the commit was made.
+func (cm *CommitMeta) Time() time.Time {
 	seconds := cm.Timestamp / secToMilli
 	nanos := (cm.Timestamp % secToMilli) * milliToNano
-	now := time.Unix(int64(seconds), int64(nanos))
 
-	return now.Format(time.RubyDate)
+	return time.Unix(int64(seconds), int64(nanos))
 }
 
 // String returns the human readable string representation of the commit data
@@ -42,6 +42,15 @@ func IsValidBranchRef(dref ref.DoltRef) bool {
 	return dref.GetType() == ref.BranchRefType && IsValidUserBranchName(dref.GetPath())
 }
 
+// IsValidUserTagName returns true if name isn't a valid commit hash, it is not named "head" and it is a valid tag name.
+func IsValidUserTagName(name string) bool {
+	return name != head && !hashRegex.MatchString(name) && ref.IsValidTagName(name)
+}
+
+func IsValidTagRef(dref ref.DoltRef) bool {
+	return dref.GetType() == ref.TagRefType && IsValidUserTagName(dref.GetPath())
+}
+
 type CommitSpecType string
 
 const (
@@ -50,7 +59,8 @@ const (
 )
 
 // CommitSpec handles three different types of string representations of commits.  Commits can either be represented
-// by the hash of the commit, a branch name, or using "head" to represent the latest commit of the current branch.
+// by the hash of the commit, a branch or tag name, or using "head" to represent the latest commit of the current
+// branch.  A name that isn't a branch is resolved as a tag.
 // An Ancestor spec can be appended to the end of any of these in order to reach commits that are in the ancestor tree
 // of the referenced commit.
 type CommitSpec struct {
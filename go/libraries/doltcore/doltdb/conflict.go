@@ -14,7 +14,11 @@
 
 package doltdb
 
-import "github.com/liquidata-inc/dolt/go/store/types"
+import (
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
 
 type Conflict struct {
 	Base       types.Value
@@ -59,3 +63,13 @@ func ConflictFromTuple(tpl types.Tuple) (Conflict, error) {
 func (c Conflict) ToNomsList(vrw types.ValueReadWriter) (types.Tuple, error) {
 	return types.NewTuple(vrw.Format(), c.Base, c.Value, c.MergeValue)
 }
+
+// ConflictRow returns the row with the key given stored for one side of a conflict, read with the schema of that side,
+// or nil if the row doesn't exist on that side.
+func ConflictRow(key, val types.Value, sch schema.Schema) (row.Row, error) {
+	if val == nil || types.IsNull(val) {
+		return nil, nil
+	}
+
+	return row.FromNoms(sch, key.(types.Tuple), val.(types.Tuple))
+}
@@ -43,6 +43,7 @@ const (
 	creationBranch   = "create"
 	MasterBranch     = "master"
 	CommitStructName = "Commit"
+	TagStructName    = "Tag"
 )
 
 // LocalDirDoltDB stores the db in the current directory
@@ -185,9 +186,17 @@ func getCommitStForRef(ctx context.Context, db datas.Database, dref ref.DoltRef)
 
 	dsHead, hasHead := ds.MaybeHead()
 	if hasHead {
+		if dsHead.Name() == TagStructName {
+			return commitStForTagSt(ctx, db, dsHead)
+		}
+
 		return dsHead, nil
 	}
 
+	if dref.GetType() == ref.TagRefType {
+		return types.EmptyStruct(db.Format()), ErrTagNotFound
+	}
+
 	return types.EmptyStruct(db.Format()), ErrBranchNotFound
 }
 
@@ -264,7 +273,18 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec) (*Commit, error)
 	if cs.CSType == HashCommitSpec {
 		commitSt, err = getCommitStForHash(ctx, ddb.db, cs.CommitStringer.String())
 	} else if cs.CSType == RefCommitSpec {
-		commitSt, err = getCommitStForRef(ctx, ddb.db, cs.CommitStringer.(ref.DoltRef))
+		dref := cs.CommitStringer.(ref.DoltRef)
+		commitSt, err = getCommitStForRef(ctx, ddb.db, dref)
+
+		// A name that isn't a branch may be a tag
+		if err == ErrBranchNotFound && dref.GetType() == ref.BranchRefType && IsValidUserTagName(dref.GetPath()) {
+			var tagErr error
+			commitSt, tagErr = getCommitStForRef(ctx, ddb.db, ref.NewTagRef(dref.GetPath()))
+
+			if tagErr != ErrTagNotFound {
+				err = tagErr
+			}
+		}
 	}
 
 	if err != nil {
@@ -280,6 +300,12 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec) (*Commit, error)
 	return &Commit{ddb.db, commitSt}, nil
 }
 
+// Rebase updates the database to the latest state of its storage, so that values written to it by other processes since
+// it was loaded can be read.
+func (ddb *DoltDB) Rebase(ctx context.Context) error {
+	return ddb.db.Rebase(ctx)
+}
+
 // WriteRootValue will write a doltdb.RootValue instance to the database.  This value will not be associated with a commit
 // and can be committed by hash at a later time.  Returns the hash of the value written.
 func (ddb *DoltDB) WriteRootValue(ctx context.Context, rv *RootValue) (hash.Hash, error) {
@@ -620,6 +646,145 @@ func (ddb *DoltDB) DeleteBranch(ctx context.Context, dref ref.DoltRef) error {
 	return err
 }
 
+var tagRefFilter = map[ref.RefType]struct{}{ref.TagRefType: {}}
+
+// GetTags returns a list of all tags in the database.
+func (ddb *DoltDB) GetTags(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, tagRefFilter)
+}
+
+// NewTagAtCommit creates a new tag of the commit given, with the metadata given. Tag names must pass
+// IsValidUserTagName. Returns ErrTagExists if there is already a tag with the same name, as tags can't be moved.
+func (ddb *DoltDB) NewTagAtCommit(ctx context.Context, dref ref.DoltRef, commit *Commit, meta *TagMeta) (*Tag, error) {
+	if !IsValidTagRef(dref) {
+		panic(fmt.Sprintf("invalid tag name %s, use IsValidUserTagName check", dref.String()))
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, dref.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ds.HasHead() {
+		return nil, ErrTagExists
+	}
+
+	rf, err := types.NewRef(commit.commitSt, ddb.db.Format())
+
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := meta.toNomsStruct(ddb.db.Format())
+
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err = ddb.db.Tag(ctx, ds, rf, datas.TagOptions{Meta: st})
+
+	if err == datas.ErrMergeNeeded {
+		return nil, ErrTagExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	tagSt, _ := ds.MaybeHead()
+	return &Tag{ddb.db, dref.GetPath(), tagSt}, nil
+}
+
+// ResolveTag returns the tag with the ref given, or ErrTagNotFound if there is no such tag.
+func (ddb *DoltDB) ResolveTag(ctx context.Context, dref ref.DoltRef) (*Tag, error) {
+	ds, err := ddb.db.GetDataset(ctx, dref.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	tagSt, hasHead := ds.MaybeHead()
+
+	if !hasHead || tagSt.Name() != TagStructName {
+		return nil, ErrTagNotFound
+	}
+
+	return &Tag{ddb.db, dref.GetPath(), tagSt}, nil
+}
+
+// SetTag makes the tag given, which is usually read from another database, the head of the tag ref given. The chunks
+// of the tag must already be present in this database, see PushTagChunks and PullTagChunks. Returns ErrTagExists if
+// a different tag with the same name already exists.
+func (ddb *DoltDB) SetTag(ctx context.Context, dref ref.DoltRef, tag *Tag) error {
+	ds, err := ddb.db.GetDataset(ctx, dref.String())
+
+	if err != nil {
+		return err
+	}
+
+	rf, err := types.NewRef(tag.tagSt, ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	if currRef, hasHead, err := ds.MaybeHeadRef(); err != nil {
+		return err
+	} else if hasHead {
+		if currRef.TargetHash() == rf.TargetHash() {
+			return nil
+		}
+
+		return ErrTagExists
+	}
+
+	_, err = ddb.db.SetHead(ctx, ds, rf)
+
+	return err
+}
+
+// DeleteTag deletes the tag given, returning an error if it doesn't exist.
+func (ddb *DoltDB) DeleteTag(ctx context.Context, dref ref.DoltRef) error {
+	ds, err := ddb.db.GetDataset(ctx, dref.String())
+
+	if err != nil {
+		return err
+	}
+
+	if !ds.HasHead() {
+		return ErrTagNotFound
+	}
+
+	_, err = ddb.db.Delete(ctx, ds)
+	return err
+}
+
+var internalRefFilter = map[ref.RefType]struct{}{ref.InternalRefType: {}}
+
+// GetInternalRefs returns a list of all internal refs in the database.
+func (ddb *DoltDB) GetInternalRefs(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, internalRefFilter)
+}
+
+// DeleteInternalRef deletes the internal ref given, returning ErrRefNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteInternalRef(ctx context.Context, dref ref.DoltRef) error {
+	if dref.GetType() != ref.InternalRefType {
+		panic(fmt.Sprintf("%s is not an internal ref", dref.String()))
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, dref.String())
+
+	if err != nil {
+		return err
+	}
+
+	if !ds.HasHead() {
+		return ErrRefNotFound
+	}
+
+	_, err = ddb.db.Delete(ctx, ds)
+	return err
+}
+
 // PushChunks initiates a push into a database from the source database given, at the commit given. Pull progress is
 // communicated over the provided channel.
 func (ddb *DoltDB) PushChunks(ctx context.Context, srcDB *DoltDB, cm *Commit, progChan chan datas.PullProgress) error {
@@ -643,3 +808,34 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, srcDB *DoltDB, cm *Commit, pr
 
 	return datas.PullWithoutBatching(ctx, srcDB.db, ddb.db, rf, progChan)
 }
+
+// PushTagChunks initiates a push into a database from the source database given, of the tag given and the commit it
+// refers to. Pull progress is communicated over the provided channel.
+func (ddb *DoltDB) PushTagChunks(ctx context.Context, srcDB *DoltDB, tag *Tag, progChan chan datas.PullProgress) error {
+	rf, err := types.NewRef(tag.tagSt, ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	return datas.Pull(ctx, srcDB.db, ddb.db, rf, progChan)
+}
+
+// PullTagChunks initiates a pull into a database from the source database given, of the tag given and the commit it
+// refers to. Progress is communicated over the provided channel.
+func (ddb *DoltDB) PullTagChunks(ctx context.Context, srcDB *DoltDB, tag *Tag, progChan chan datas.PullProgress) error {
+	rf, err := types.NewRef(tag.tagSt, ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	return datas.PullWithoutBatching(ctx, srcDB.db, ddb.db, rf, progChan)
+}
+
+// GC removes every value from the database which isn't reachable from a ref or from one of the |extraRoots| given.
+// Callers use |extraRoots| for values which are referenced from outside of the database, such as working and staged
+// root values.
+func (ddb *DoltDB) GC(ctx context.Context, extraRoots ...hash.Hash) error {
+	return ddb.db.GC(ctx, extraRoots...)
+}
@@ -21,13 +21,21 @@ var ErrInvTableName = errors.New("not a valid table name")
 var ErrInvHash = errors.New("not a valid hash")
 var ErrInvalidAncestorSpec = errors.New("invalid ancestor spec")
 var ErrInvalidBranchOrHash = errors.New("string is not a valid branch or hash")
+var ErrInvTagName = errors.New("not a valid tag name")
 
 var ErrFoundHashNotACommit = errors.New("the value retrieved for this hash is not a commit")
 
 var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
+var ErrTagNotFound = errors.New("tag not found")
+var ErrTagExists = errors.New("tag already exists")
+var ErrRefNotFound = errors.New("ref not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
+var ErrIndexNotFound = errors.New("index not found")
+var ErrIndexExists = errors.New("index already exists")
+var ErrForeignKeyNotFound = errors.New("foreign key not found")
+var ErrForeignKeyExists = errors.New("foreign key already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
 
 var ErrNomsIO = errors.New("error reading from or writing to noms")
@@ -39,7 +47,7 @@ var ErrIsBehind = errors.New("cannot reverse from b to a. b is a is behind a alr
 
 func IsInvalidFormatErr(err error) bool {
 	switch err {
-	case ErrInvBranchName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash:
+	case ErrInvBranchName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash, ErrInvTagName:
 		return true
 	default:
 		return false
@@ -48,7 +56,7 @@ func IsInvalidFormatErr(err error) bool {
 
 func IsNotFoundErr(err error) bool {
 	switch err {
-	case ErrHashNotFound, ErrBranchNotFound, ErrTableNotFound:
+	case ErrHashNotFound, ErrBranchNotFound, ErrTagNotFound, ErrRefNotFound, ErrTableNotFound:
 		return true
 	default:
 		return false
@@ -57,7 +65,7 @@ func IsNotFoundErr(err error) bool {
 
 func IsNotACommit(err error) bool {
 	switch err {
-	case ErrHashNotFound, ErrBranchNotFound, ErrFoundHashNotACommit:
+	case ErrHashNotFound, ErrBranchNotFound, ErrTagNotFound, ErrFoundHashNotACommit:
 		return true
 	default:
 		return false
@@ -0,0 +1,1085 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	foreignKeyStructName = "foreign_key"
+
+	fkTableKey    = "table"
+	fkTagsKey     = "tags"
+	fkRefTableKey = "ref_table"
+	fkRefTagsKey  = "ref_tags"
+)
+
+// ForeignKey is a constraint requiring that every row of a table whose columns with the given tags are all non-null
+// has a row in the referenced table with the same values in the columns with the referenced tags. Foreign keys are
+// stored in the root value, and belong to the table they're declared on.
+type ForeignKey struct {
+	Name            string
+	Table           string
+	Tags            []uint64
+	ReferencedTable string
+	ReferencedTags  []uint64
+}
+
+// Equals returns true if the foreign key given has the same name, tables and columns as this one.
+func (fk ForeignKey) Equals(other ForeignKey) bool {
+	return fk.Name == other.Name && fk.Table == other.Table && fk.ReferencedTable == other.ReferencedTable &&
+		tagsEqual(fk.Tags, other.Tags) && tagsEqual(fk.ReferencedTags, other.ReferencedTags)
+}
+
+// ForeignKeyViolation is a row which doesn't have a matching row in the table referenced by a foreign key.
+type ForeignKeyViolation struct {
+	ForeignKey ForeignKey
+	Key        types.Tuple
+	Values     []types.Value
+}
+
+// ForeignKeyViolationError is the error returned when a root has a row violating one of its foreign keys.
+type ForeignKeyViolationError struct {
+	ForeignKeyViolation
+}
+
+func (e ForeignKeyViolationError) Error() string {
+	valStrs := make([]string, len(e.Values))
+	for i, val := range e.Values {
+		str, err := types.EncodedValue(context.Background(), val)
+
+		if err != nil {
+			str = "<unknown>"
+		}
+
+		valStrs[i] = str
+	}
+
+	return fmt.Sprintf("Foreign key '%s' violated: table '%s' has a row referencing (%s), which doesn't exist in table '%s'",
+		e.ForeignKey.Name, e.ForeignKey.Table, strings.Join(valStrs, ", "), e.ForeignKey.ReferencedTable)
+}
+
+// IsForeignKeyViolationErr returns true if the error is a ForeignKeyViolationError
+func IsForeignKeyViolationErr(err error) bool {
+	_, ok := err.(ForeignKeyViolationError)
+	return ok
+}
+
+// GetForeignKeys returns all the foreign keys of the root, ordered by name.
+func (root *RootValue) GetForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	fkMap, err := root.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fks []ForeignKey
+	err = fkMap.IterAll(ctx, func(key, value types.Value) error {
+		fk, err := foreignKeyFromNoms(string(key.(types.String)), value.(types.Struct))
+
+		if err != nil {
+			return err
+		}
+
+		fks = append(fks, fk)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fks, nil
+}
+
+// GetForeignKey returns the foreign key with the name given, and whether it exists.
+func (root *RootValue) GetForeignKey(ctx context.Context, name string) (ForeignKey, bool, error) {
+	fkMap, err := root.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return ForeignKey{}, false, err
+	}
+
+	val, ok, err := fkMap.MaybeGet(ctx, types.String(name))
+
+	if err != nil || !ok {
+		return ForeignKey{}, false, err
+	}
+
+	fk, err := foreignKeyFromNoms(name, val.(types.Struct))
+
+	if err != nil {
+		return ForeignKey{}, false, err
+	}
+
+	return fk, true, nil
+}
+
+// GetForeignKeysForTable returns the foreign keys declared on the table given, and those of other tables which
+// reference it.
+func (root *RootValue) GetForeignKeysForTable(ctx context.Context, tName string) (declared, referencing []ForeignKey, err error) {
+	fks, err := root.GetForeignKeys(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, fk := range fks {
+		if fk.Table == tName {
+			declared = append(declared, fk)
+		} else if fk.ReferencedTable == tName {
+			referencing = append(referencing, fk)
+		}
+	}
+
+	return declared, referencing, nil
+}
+
+// PutForeignKey adds the foreign key given to the root, replacing any foreign key with the same name, and returns the
+// updated root. The rows of the root aren't checked against it.
+func (root *RootValue) PutForeignKey(ctx context.Context, fk ForeignKey) (*RootValue, error) {
+	fkMap, err := root.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := foreignKeyToNoms(root.vrw.Format(), fk)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fkMap, err = fkMap.Edit().Set(types.String(fk.Name), st).Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return root.withForeignKeyMap(fkMap)
+}
+
+// RemoveForeignKey removes the foreign key with the name given and returns the updated root. Returns
+// ErrForeignKeyNotFound if there is no such foreign key.
+func (root *RootValue) RemoveForeignKey(ctx context.Context, name string) (*RootValue, error) {
+	fkMap, err := root.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if has, err := fkMap.Has(ctx, types.String(name)); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrForeignKeyNotFound
+	}
+
+	fkMap, err = fkMap.Edit().Remove(types.String(name)).Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return root.withForeignKeyMap(fkMap)
+}
+
+// RenameTableInForeignKeys updates the foreign keys declared on or referencing the table with the old name given to
+// use the new name instead, and returns the updated root.
+func (root *RootValue) RenameTableInForeignKeys(ctx context.Context, oldName, newName string) (*RootValue, error) {
+	fks, err := root.GetForeignKeys(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fk := range fks {
+		if fk.Table != oldName && fk.ReferencedTable != oldName {
+			continue
+		}
+
+		if fk.Table == oldName {
+			fk.Table = newName
+		}
+
+		if fk.ReferencedTable == oldName {
+			fk.ReferencedTable = newName
+		}
+
+		if root, err = root.PutForeignKey(ctx, fk); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// FindForeignKeyViolations returns every row of the root which violates one of the foreign keys given.
+func (root *RootValue) FindForeignKeyViolations(ctx context.Context, fks []ForeignKey) ([]ForeignKeyViolation, error) {
+	var violations []ForeignKeyViolation
+	for _, fk := range fks {
+		err := root.iterForeignKeyViolations(ctx, fk, func(violation ForeignKeyViolation) error {
+			violations = append(violations, violation)
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return violations, nil
+}
+
+// ValidateForeignKeys returns a ForeignKeyViolationError if the root has a row which violates one of its foreign keys,
+// and which isn't recorded as a foreign key violation of its table by a merge. If prev is not nil, the root is assumed
+// to have been made by changing prev. Foreign keys which are new, or whose tables are new or have new schemas, are
+// checked against every row. For the rest, only the rows added or changed in their tables since prev, and the rows
+// referencing rows removed from their referenced tables since prev, are checked.
+func (root *RootValue) ValidateForeignKeys(ctx context.Context, prev *RootValue) error {
+	fks, err := root.GetForeignKeys(ctx)
+
+	if err != nil || len(fks) == 0 {
+		return err
+	}
+
+	for _, fk := range fks {
+		tbl, ok, err := root.GetTable(ctx, fk.Table)
+
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		recorded, err := tbl.GetForeignKeyViolations(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		cb := func(violation ForeignKeyViolation) error {
+			if has, err := recorded.Has(ctx, violation.Key); err != nil {
+				return err
+			} else if has {
+				return nil
+			}
+
+			return ForeignKeyViolationError{violation}
+		}
+
+		if prev == nil {
+			err = root.iterForeignKeyViolations(ctx, fk, cb)
+		} else {
+			err = root.iterChangedForeignKeyViolations(ctx, prev, fk, cb)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RefreshForeignKeyViolations removes the rows which no longer violate a foreign key from the foreign key violations
+// recorded for the table given, and returns the updated root.
+func (root *RootValue) RefreshForeignKeyViolations(ctx context.Context, tName string) (*RootValue, error) {
+	tbl, ok, err := root.GetTable(ctx, tName)
+
+	if err != nil || !ok {
+		return root, err
+	}
+
+	recorded, err := tbl.GetForeignKeyViolations(ctx)
+
+	if err != nil || recorded.Empty() {
+		return root, err
+	}
+
+	fks, _, err := root.GetForeignKeysForTable(ctx, tName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := types.NewMap(ctx, root.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ed := remaining.Edit()
+	for _, fk := range fks {
+		err = root.iterForeignKeyViolations(ctx, fk, func(violation ForeignKeyViolation) error {
+			if has, err := recorded.Has(ctx, violation.Key); err != nil {
+				return err
+			} else if has {
+				ed.Set(violation.Key, types.String(fk.Name))
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if remaining, err = ed.Map(ctx); err != nil {
+		return nil, err
+	}
+
+	if tbl, err = tbl.SetForeignKeyViolations(ctx, remaining); err != nil {
+		return nil, err
+	}
+
+	return root.putTable(ctx, tName, tbl)
+}
+
+// GetForeignKeyViolations returns the foreign key violations recorded for the table by a merge, as a map from the
+// primary key of each violating row to the name of a foreign key it violates.
+func (t *Table) GetForeignKeyViolations(ctx context.Context) (types.Map, error) {
+	val, ok, err := t.tableStruct.MaybeGet(fkViolationsKey)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if !ok {
+		return types.NewMap(ctx, t.vrw)
+	}
+
+	violationsVal, err := val.(types.Ref).TargetValue(ctx, t.vrw)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return violationsVal.(types.Map), nil
+}
+
+// SetForeignKeyViolations replaces the foreign key violations recorded for the table and returns the updated table.
+func (t *Table) SetForeignKeyViolations(ctx context.Context, violations types.Map) (*Table, error) {
+	if violations.Empty() {
+		updatedSt, err := t.tableStruct.Delete(fkViolationsKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Table{t.vrw, updatedSt}, nil
+	}
+
+	violationsRef, err := writeValAndGetRef(ctx, t.vrw, violations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(fkViolationsKey, violationsRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// HasForeignKeyViolations returns true if there are foreign key violations recorded for the table.
+func (t *Table) HasForeignKeyViolations() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(fkViolationsKey)
+
+	return ok, err
+}
+
+// iterChangedForeignKeyViolations calls cb for each row violating the foreign key given which was added or changed
+// since prev, or which references a row removed from the referenced table since prev, until it returns an error. Every
+// violating row is checked if the foreign key or either of its tables is new, or either table has a new schema.
+func (root *RootValue) iterChangedForeignKeyViolations(ctx context.Context, prev *RootValue, fk ForeignKey, cb func(violation ForeignKeyViolation) error) error {
+	if prevFk, ok, err := prev.GetForeignKey(ctx, fk.Name); err != nil {
+		return err
+	} else if !ok || !prevFk.Equals(fk) {
+		return root.iterForeignKeyViolations(ctx, fk, cb)
+	}
+
+	tbl, prevTbl, ok, err := root.tableAndPrev(ctx, prev, fk.Table)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	refTbl, prevRefTbl, ok, err := root.tableAndPrev(ctx, prev, fk.ReferencedTable)
+
+	if err != nil {
+		return err
+	} else if !ok || prevTbl == nil || prevRefTbl == nil {
+		return root.iterForeignKeyViolations(ctx, fk, cb)
+	}
+
+	referenced, err := newRowLookup(ctx, refTbl, fk.ReferencedTags)
+
+	if err != nil {
+		return err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// Rows added or changed in the table must reference an existing row
+	err = diffTableRows(ctx, tbl, prevTbl, func(change types.ValueChanged) error {
+		if change.NewValue == nil {
+			return nil
+		}
+
+		r, err := row.FromNoms(sch, change.Key.(types.Tuple), change.NewValue.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		vals, ok := rowValues(r, fk.Tags)
+
+		if !ok {
+			return nil
+		}
+
+		if has, err := referenced.has(ctx, vals); err != nil || has {
+			return err
+		}
+
+		return cb(ForeignKeyViolation{fk, change.Key.(types.Tuple), vals})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	refSch, err := refTbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	referencing, err := newRowLookup(ctx, tbl, fk.Tags)
+
+	if err != nil {
+		return err
+	}
+
+	// Rows which referenced a row removed from the referenced table, or whose referenced values changed, must reference
+	// another one
+	return diffTableRows(ctx, refTbl, prevRefTbl, func(change types.ValueChanged) error {
+		if change.OldValue == nil {
+			return nil
+		}
+
+		oldRow, err := row.FromNoms(refSch, change.Key.(types.Tuple), change.OldValue.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		vals, ok := rowValues(oldRow, fk.ReferencedTags)
+
+		if !ok {
+			return nil
+		}
+
+		if has, err := referenced.has(ctx, vals); err != nil || has {
+			return err
+		}
+
+		return referencing.iter(ctx, vals, func(key types.Tuple) error {
+			return cb(ForeignKeyViolation{fk, key, vals})
+		})
+	})
+}
+
+// tableAndPrev returns the table with the name given in the root and in prev, and whether it's in the root. The table
+// in prev is nil if it isn't there, or if its schema is different.
+func (root *RootValue) tableAndPrev(ctx context.Context, prev *RootValue, tName string) (tbl, prevTbl *Table, ok bool, err error) {
+	tbl, ok, err = root.GetTable(ctx, tName)
+
+	if err != nil || !ok {
+		return nil, nil, ok, err
+	}
+
+	prevTbl, prevOk, err := prev.GetTable(ctx, tName)
+
+	if err != nil {
+		return nil, nil, false, err
+	} else if !prevOk {
+		return tbl, nil, true, nil
+	}
+
+	if same, err := tbl.HasTheSameSchema(prevTbl); err != nil {
+		return nil, nil, false, err
+	} else if !same {
+		return tbl, nil, true, nil
+	}
+
+	return tbl, prevTbl, true, nil
+}
+
+// diffTableRows calls cb for each row which differs between the row data of the tables given, until it returns an
+// error.
+func diffTableRows(ctx context.Context, tbl, prevTbl *Table, cb func(change types.ValueChanged) error) error {
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	prevRowData, err := prevTbl.GetRowData(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return diffRows(ctx, rowData, prevRowData, cb)
+}
+
+// rowLookup finds the rows of a table with the values given in the columns with its tags. Rows are looked up by
+// primary key if the tags are the table's primary key columns, or through a secondary index on the table whose leading
+// columns are the tags. Otherwise, the table's rows are read once, on the first lookup.
+type rowLookup struct {
+	tbl  *Table
+	sch  schema.Schema
+	tags []uint64
+
+	rowData types.Map
+	isPK    bool
+	idxData *types.Map
+	scanned map[hash.Hash][]types.Tuple
+}
+
+func newRowLookup(ctx context.Context, tbl *Table, tags []uint64) (*rowLookup, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rl := &rowLookup{tbl: tbl, sch: sch, tags: tags, rowData: rowData}
+	pkTags := sch.GetPKCols().Tags
+	if len(pkTags) == len(tags) {
+		rl.isPK = true
+		for _, tag := range tags {
+			if _, ok := sch.GetPKCols().GetByTag(tag); !ok {
+				rl.isPK = false
+				break
+			}
+		}
+	}
+
+	if rl.isPK {
+		return rl, nil
+	}
+
+	indexes, err := tbl.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		if len(idx.Tags) >= len(tags) && tagsEqual(idx.Tags[:len(tags)], tags) {
+			idxData, err := tbl.GetIndexRowData(ctx, idx.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			rl.idxData = &idxData
+			break
+		}
+	}
+
+	return rl, nil
+}
+
+// has returns whether the table has a row with the values given.
+func (rl *rowLookup) has(ctx context.Context, vals []types.Value) (bool, error) {
+	found := false
+	err := rl.iter(ctx, vals, func(types.Tuple) error {
+		found = true
+		return errStopLookup
+	})
+
+	if err == errStopLookup {
+		err = nil
+	}
+
+	return found, err
+}
+
+var errStopLookup = errors.New("stop lookup")
+
+// iter calls cb with the primary key of each row of the table with the values given, until it returns an error.
+func (rl *rowLookup) iter(ctx context.Context, vals []types.Value, cb func(key types.Tuple) error) error {
+	nbf := rl.tbl.Format()
+	taggedVals := make(row.TaggedValues, len(vals))
+	for i, tag := range rl.tags {
+		taggedVals[tag] = vals[i]
+	}
+
+	if rl.isPK {
+		key, err := taggedVals.NomsTupleForTags(nbf, rl.sch.GetPKCols().Tags, true).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if has, err := rl.rowData.Has(ctx, key); err != nil || !has {
+			return err
+		}
+
+		return cb(key.(types.Tuple))
+	}
+
+	if rl.idxData != nil {
+		prefixVal, err := taggedVals.NomsTupleForTags(nbf, rl.tags, true).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		prefix := prefixVal.(types.Tuple)
+		itr, err := rl.idxData.IteratorFrom(ctx, prefix)
+
+		if err != nil {
+			return err
+		}
+
+		for {
+			idxKey, key, err := itr.Next(ctx)
+
+			if err != nil || idxKey == nil {
+				return err
+			}
+
+			if ok, err := hasPrefix(idxKey.(types.Tuple), prefix); err != nil || !ok {
+				return err
+			}
+
+			if err := cb(key.(types.Tuple)); err != nil {
+				return err
+			}
+		}
+	}
+
+	h, err := valuesHash(nbf, vals)
+
+	if err != nil {
+		return err
+	}
+
+	if rl.scanned == nil {
+		if err := rl.scan(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range rl.scanned[h] {
+		if err := cb(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scan reads the primary keys of the table's rows, by the hash of their values in the columns with the lookup's tags.
+func (rl *rowLookup) scan(ctx context.Context) error {
+	nbf := rl.tbl.Format()
+	rl.scanned = make(map[hash.Hash][]types.Tuple)
+	return rl.rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(rl.sch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		vals, ok := rowValues(r, rl.tags)
+
+		if !ok {
+			return nil
+		}
+
+		h, err := valuesHash(nbf, vals)
+
+		if err != nil {
+			return err
+		}
+
+		rl.scanned[h] = append(rl.scanned[h], key.(types.Tuple))
+		return nil
+	})
+}
+
+// hasPrefix returns whether the tuple given starts with the values of the prefix given.
+func hasPrefix(tpl, prefix types.Tuple) (bool, error) {
+	if tpl.Len() < prefix.Len() {
+		return false, nil
+	}
+
+	for i := uint64(0); i < prefix.Len(); i++ {
+		val, err := tpl.Get(i)
+
+		if err != nil {
+			return false, err
+		}
+
+		prefixVal, err := prefix.Get(i)
+
+		if err != nil {
+			return false, err
+		}
+
+		if !val.Equals(prefixVal) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// iterForeignKeyViolations calls cb for each row violating the foreign key given, until it returns an error.
+func (root *RootValue) iterForeignKeyViolations(ctx context.Context, fk ForeignKey, cb func(violation ForeignKeyViolation) error) error {
+	tbl, ok, err := root.GetTable(ctx, fk.Table)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	referenced, err := root.referencedValues(ctx, fk)
+
+	if err != nil {
+		return err
+	}
+
+	nbf := root.vrw.Format()
+	return rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		vals, ok := rowValues(r, fk.Tags)
+
+		if !ok {
+			return nil
+		}
+
+		h, err := valuesHash(nbf, vals)
+
+		if err != nil {
+			return err
+		}
+
+		if referenced[h] {
+			return nil
+		}
+
+		return cb(ForeignKeyViolation{fk, key.(types.Tuple), vals})
+	})
+}
+
+// referencedValues returns the set of the hashes of the values of the referenced columns of the foreign key given, for
+// every row of the referenced table.
+func (root *RootValue) referencedValues(ctx context.Context, fk ForeignKey) (map[hash.Hash]bool, error) {
+	referenced := make(map[hash.Hash]bool)
+	tbl, ok, err := root.GetTable(ctx, fk.ReferencedTable)
+
+	if err != nil || !ok {
+		return referenced, err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nbf := root.vrw.Format()
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		if vals, ok := rowValues(r, fk.ReferencedTags); ok {
+			h, err := valuesHash(nbf, vals)
+
+			if err != nil {
+				return err
+			}
+
+			referenced[h] = true
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return referenced, nil
+}
+
+// rowValues returns the values of the row given for the tags given, or false if any of them are null.
+func rowValues(r row.Row, tags []uint64) ([]types.Value, bool) {
+	vals := make([]types.Value, len(tags))
+	for i, tag := range tags {
+		val, ok := r.GetColVal(tag)
+
+		if !ok || types.IsNull(val) {
+			return nil, false
+		}
+
+		vals[i] = val
+	}
+
+	return vals, true
+}
+
+func valuesHash(nbf *types.NomsBinFormat, vals []types.Value) (hash.Hash, error) {
+	tpl, err := types.NewTuple(nbf, vals...)
+
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return tpl.Hash(nbf)
+}
+
+func (root *RootValue) getForeignKeyMap(ctx context.Context) (types.Map, error) {
+	val, ok, err := root.valueSt.MaybeGet(foreignKeysKey)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if !ok {
+		return types.NewMap(ctx, root.vrw)
+	}
+
+	return val.(types.Map), nil
+}
+
+func (root *RootValue) withForeignKeyMap(fkMap types.Map) (*RootValue, error) {
+	var rootValSt types.Struct
+	var err error
+	if fkMap.Empty() {
+		rootValSt, err = root.valueSt.Delete(foreignKeysKey)
+	} else {
+		rootValSt, err = root.valueSt.Set(foreignKeysKey, fkMap)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootValue(root.vrw, rootValSt), nil
+}
+
+// replaceForeignKeys replaces the foreign keys declared on the tables given with the ones declared on them in the
+// foreign key map given, and returns the updated root.
+func (root *RootValue) replaceForeignKeys(ctx context.Context, tblNames []string, otherFkMap types.Map) (*RootValue, error) {
+	fkMap, err := root.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fkMap.Empty() && otherFkMap.Empty() {
+		return root, nil
+	}
+
+	tbls := set.NewStrSet(tblNames)
+	ed := fkMap.Edit()
+	for i, m := range []types.Map{fkMap, otherFkMap} {
+		isOther := i == 1
+		err = m.IterAll(ctx, func(key, value types.Value) error {
+			tblVal, _, err := value.(types.Struct).MaybeGet(fkTableKey)
+
+			if err != nil {
+				return err
+			}
+
+			if !tbls.Contains(string(tblVal.(types.String))) {
+				return nil
+			}
+
+			if isOther {
+				ed.Set(key, value)
+			} else {
+				ed.Remove(key)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fkMap, err = ed.Map(ctx); err != nil {
+		return nil, err
+	}
+
+	return root.withForeignKeyMap(fkMap)
+}
+
+func foreignKeyToNoms(nbf *types.NomsBinFormat, fk ForeignKey) (types.Struct, error) {
+	tags, err := tagsToNoms(nbf, fk.Tags)
+
+	if err != nil {
+		return types.EmptyStruct(nbf), err
+	}
+
+	refTags, err := tagsToNoms(nbf, fk.ReferencedTags)
+
+	if err != nil {
+		return types.EmptyStruct(nbf), err
+	}
+
+	return types.NewStruct(nbf, foreignKeyStructName, types.StructData{
+		fkTableKey:    types.String(fk.Table),
+		fkTagsKey:     tags,
+		fkRefTableKey: types.String(fk.ReferencedTable),
+		fkRefTagsKey:  refTags,
+	})
+}
+
+func foreignKeyFromNoms(name string, st types.Struct) (ForeignKey, error) {
+	vals := make(map[string]types.Value)
+	for _, key := range []string{fkTableKey, fkTagsKey, fkRefTableKey, fkRefTagsKey} {
+		val, ok, err := st.MaybeGet(key)
+
+		if err != nil {
+			return ForeignKey{}, err
+		}
+
+		if !ok {
+			return ForeignKey{}, fmt.Errorf("foreign key %s is missing field %s", name, key)
+		}
+
+		vals[key] = val
+	}
+
+	tags, err := tagsFromNoms(vals[fkTagsKey].(types.Tuple))
+
+	if err != nil {
+		return ForeignKey{}, err
+	}
+
+	refTags, err := tagsFromNoms(vals[fkRefTagsKey].(types.Tuple))
+
+	if err != nil {
+		return ForeignKey{}, err
+	}
+
+	return ForeignKey{
+		Name:            name,
+		Table:           string(vals[fkTableKey].(types.String)),
+		Tags:            tags,
+		ReferencedTable: string(vals[fkRefTableKey].(types.String)),
+		ReferencedTags:  refTags,
+	}, nil
+}
+
+func tagsToNoms(nbf *types.NomsBinFormat, tags []uint64) (types.Tuple, error) {
+	tagVals := make([]types.Value, len(tags))
+	for i, tag := range tags {
+		tagVals[i] = types.Uint(tag)
+	}
+
+	return types.NewTuple(nbf, tagVals...)
+}
+
+func tagsFromNoms(tagTuple types.Tuple) ([]uint64, error) {
+	tags := make([]uint64, tagTuple.Len())
+	for i := range tags {
+		tag, err := tagTuple.Get(uint64(i))
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags[i] = uint64(tag.(types.Uint))
+	}
+
+	return tags, nil
+}
+
+func tagsEqual(tags, other []uint64) bool {
+	if len(tags) != len(other) {
+		return false
+	}
+
+	for i := range tags {
+		if tags[i] != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	petIdTag uint64 = iota + 100
+	petOwnerTag
+)
+
+func TestForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	ddb, _ := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	ddb.WriteEmptyRepo(ctx, "billy bob", "bigbillieb@fake.horse")
+
+	cs, _ := NewCommitSpec("head", "master")
+	cm, _ := ddb.Resolve(ctx, cs)
+	root, err := cm.GetRootValue()
+	require.NoError(t, err)
+
+	peopleSch := createTestSchema()
+	peopleData, _ := createTestRowData(t, ddb.ValueReadWriter(), peopleSch)
+	people, err := createTestTable(ddb.ValueReadWriter(), peopleSch, peopleData)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, ddb, "people", people)
+	require.NoError(t, err)
+
+	petColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", petIdTag, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("owner", petOwnerTag, types.UUIDKind, false),
+	)
+	petSch := schema.SchemaFromCols(petColl)
+	unknownOwner, _ := uuid.NewRandom()
+	petData := createPetRowData(t, ddb.ValueReadWriter(), petSch, map[int64]types.Value{
+		0: types.UUID(id0),
+		1: types.UUID(id0),
+		2: types.UUID(id1),
+		3: types.NullValue,
+	})
+	pets, err := createTestTable(ddb.ValueReadWriter(), petSch, petData)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, ddb, "pets", pets)
+	require.NoError(t, err)
+
+	fk := ForeignKey{"pets_owner", "pets", []uint64{petOwnerTag}, "people", []uint64{idTag}}
+	withFk, err := root.PutForeignKey(ctx, fk)
+	require.NoError(t, err)
+
+	fks, err := withFk.GetForeignKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ForeignKey{fk}, fks)
+
+	declared, referencing, err := withFk.GetForeignKeysForTable(ctx, "people")
+	require.NoError(t, err)
+	assert.Empty(t, declared)
+	assert.Equal(t, []ForeignKey{fk}, referencing)
+
+	assert.NoError(t, withFk.ValidateForeignKeys(ctx, nil))
+
+	t.Run("violations", func(t *testing.T) {
+		badPets, err := createTestTable(ddb.ValueReadWriter(), petSch, createPetRowData(t, ddb.ValueReadWriter(), petSch, map[int64]types.Value{
+			0: types.UUID(id0),
+			4: types.UUID(unknownOwner),
+		}))
+		require.NoError(t, err)
+
+		badRoot, err := withFk.PutTable(ctx, ddb, "pets", badPets)
+		require.NoError(t, err)
+
+		err = badRoot.ValidateForeignKeys(ctx, withFk)
+		assert.True(t, IsForeignKeyViolationErr(err))
+
+		// Only the foreign keys of changed tables are checked against a previous root
+		unchanged, err := badRoot.PutTable(ctx, ddb, "unrelated", people)
+		require.NoError(t, err)
+		assert.NoError(t, unchanged.ValidateForeignKeys(ctx, badRoot))
+
+		violations, err := badRoot.FindForeignKeyViolations(ctx, []ForeignKey{fk})
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, []types.Value{types.UUID(unknownOwner)}, violations[0].Values)
+
+		recorded, err := types.NewMap(ctx, ddb.ValueReadWriter())
+		require.NoError(t, err)
+		recorded, err = recorded.Edit().Set(violations[0].Key, types.String(fk.Name)).Map(ctx)
+		require.NoError(t, err)
+		badPets, err = badPets.SetForeignKeyViolations(ctx, recorded)
+		require.NoError(t, err)
+		badRoot, err = badRoot.PutTable(ctx, ddb, "pets", badPets)
+		require.NoError(t, err)
+
+		assert.NoError(t, badRoot.ValidateForeignKeys(ctx, nil))
+		inConflict, err := badRoot.TablesInConflict(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pets"}, inConflict)
+
+		refreshed, err := badRoot.RefreshForeignKeyViolations(ctx, "pets")
+		require.NoError(t, err)
+		inConflict, err = refreshed.TablesInConflict(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pets"}, inConflict)
+
+		// Adding the missing row to the referenced table resolves the violation
+		newPerson, err := row.New(types.Format_7_18, peopleSch, row.TaggedValues{
+			idTag: types.UUID(unknownOwner), firstTag: types.String("new"), lastTag: types.String("person")})
+		require.NoError(t, err)
+		fixedData, err := peopleData.Edit().Set(newPerson.NomsMapKey(peopleSch), newPerson.NomsMapValue(peopleSch)).Map(ctx)
+		require.NoError(t, err)
+		fixedPeople, err := people.UpdateRows(ctx, fixedData)
+		require.NoError(t, err)
+		fixedRoot, err := badRoot.PutTable(ctx, ddb, "people", fixedPeople)
+		require.NoError(t, err)
+
+		refreshed, err = fixedRoot.RefreshForeignKeyViolations(ctx, "pets")
+		require.NoError(t, err)
+		inConflict, err = refreshed.TablesInConflict(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, inConflict)
+	})
+
+	t.Run("removed referenced rows", func(t *testing.T) {
+		id1Key, err := types.NewTuple(types.Format_7_18, types.Uint(idTag), types.UUID(id1))
+		require.NoError(t, err)
+		lessPeopleData, err := peopleData.Edit().Remove(id1Key).Map(ctx)
+		require.NoError(t, err)
+		lessPeople, err := people.UpdateRows(ctx, lessPeopleData)
+		require.NoError(t, err)
+
+		indexedPets, err := pets.CreateIndex(ctx, "owner", []uint64{petOwnerTag})
+		require.NoError(t, err)
+		indexedRoot, err := withFk.PutTable(ctx, ddb, "pets", indexedPets)
+		require.NoError(t, err)
+
+		// The rows referencing removed rows are found by reading the table, or through its index
+		for _, prev := range []*RootValue{withFk, indexedRoot} {
+			badRoot, err := prev.PutTable(ctx, ddb, "people", lessPeople)
+			require.NoError(t, err)
+
+			err = badRoot.ValidateForeignKeys(ctx, prev)
+			require.True(t, IsForeignKeyViolationErr(err))
+			assert.Equal(t, []types.Value{types.UUID(id1)}, err.(ForeignKeyViolationError).Values)
+		}
+	})
+
+	t.Run("table changes", func(t *testing.T) {
+		renamed, err := withFk.RenameTableInForeignKeys(ctx, "people", "owners")
+		require.NoError(t, err)
+		renamedFk, ok, err := renamed.GetForeignKey(ctx, fk.Name)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "owners", renamedFk.ReferencedTable)
+
+		removed, err := withFk.RemoveTables(ctx, "pets")
+		require.NoError(t, err)
+		fks, err := removed.GetForeignKeys(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, fks)
+
+		updated, err := root.UpdateTablesFromOther(ctx, []string{"pets"}, withFk)
+		require.NoError(t, err)
+		fks, err = updated.GetForeignKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []ForeignKey{fk}, fks)
+
+		updated, err = withFk.UpdateTablesFromOther(ctx, []string{"pets"}, root)
+		require.NoError(t, err)
+		fks, err = updated.GetForeignKeys(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, fks)
+
+		_, err = root.RemoveForeignKey(ctx, fk.Name)
+		assert.Equal(t, ErrForeignKeyNotFound, err)
+	})
+}
+
+func createPetRowData(t *testing.T, vrw types.ValueReadWriter, sch schema.Schema, owners map[int64]types.Value) types.Map {
+	m, err := types.NewMap(context.Background(), vrw)
+	require.NoError(t, err)
+
+	ed := m.Edit()
+	for id, owner := range owners {
+		r, err := row.New(types.Format_7_18, sch, row.TaggedValues{petIdTag: types.Int(id), petOwnerTag: owner})
+		require.NoError(t, err)
+		ed = ed.Set(r.NomsMapKey(sch), r.NomsMapValue(sch))
+	}
+
+	m, err = ed.Map(context.Background())
+	require.NoError(t, err)
+
+	return m
+}
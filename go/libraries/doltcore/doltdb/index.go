@@ -0,0 +1,545 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	indexStructName = "index"
+
+	indexTagsKey = "tags"
+	indexRowsKey = "rows"
+)
+
+// Index is a secondary index on the columns of a table with the given tags, in order. The row data of an index is a
+// map whose keys are tuples of the indexed column values followed by the primary key column values, in the same tagged
+// format as the keys of the table's row data, and whose values are the primary keys of the indexed rows.
+type Index struct {
+	Name string
+	Tags []uint64
+}
+
+// GetIndexes returns all the secondary indexes of the table, ordered by name.
+func (t *Table) GetIndexes(ctx context.Context) ([]Index, error) {
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []Index
+	err = indexMap.IterAll(ctx, func(key, value types.Value) error {
+		idx, _, err := indexFromNoms(string(key.(types.String)), value.(types.Struct))
+
+		if err != nil {
+			return err
+		}
+
+		indexes = append(indexes, idx)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// GetIndex returns the secondary index with the name given, and whether it exists.
+func (t *Table) GetIndex(ctx context.Context, name string) (Index, bool, error) {
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return Index{}, false, err
+	}
+
+	val, ok, err := indexMap.MaybeGet(ctx, types.String(name))
+
+	if err != nil || !ok {
+		return Index{}, false, err
+	}
+
+	idx, _, err := indexFromNoms(name, val.(types.Struct))
+
+	if err != nil {
+		return Index{}, false, err
+	}
+
+	return idx, true, nil
+}
+
+// GetIndexRowData retrieves the row data of the secondary index with the name given.
+func (t *Table) GetIndexRowData(ctx context.Context, name string) (types.Map, error) {
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	val, ok, err := indexMap.MaybeGet(ctx, types.String(name))
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if !ok {
+		return types.EmptyMap, ErrIndexNotFound
+	}
+
+	_, rowsRef, err := indexFromNoms(name, val.(types.Struct))
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	rowsVal, err := rowsRef.TargetValue(ctx, t.vrw)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return rowsVal.(types.Map), nil
+}
+
+// CreateIndex creates a new secondary index on the columns with the tags given, populated with the table's current
+// rows, and returns the updated Table. Returns ErrIndexExists if an index with the same name already exists.
+func (t *Table) CreateIndex(ctx context.Context, name string, tags []uint64) (*Table, error) {
+	if _, ok, err := t.GetIndex(ctx, name); err != nil {
+		return nil, err
+	} else if ok {
+		return nil, ErrIndexExists
+	}
+
+	sch, err := t.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("index '%s' must have at least one column", name)
+	}
+
+	for _, tag := range tags {
+		if _, ok := sch.GetAllCols().GetByTag(tag); !ok {
+			return nil, fmt.Errorf("index '%s' refers to unknown column tag %d", name, tag)
+		}
+	}
+
+	rowData, err := t.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	idx := Index{name, tags}
+	idxData, err := buildIndexRowData(ctx, t.vrw, sch, idx, rowData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ed := indexMap.Edit()
+	if err := putIndex(ctx, t.vrw, ed, idx, idxData); err != nil {
+		return nil, err
+	}
+
+	indexMap, err = ed.Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.setIndexMap(ctx, indexMap)
+}
+
+// DropIndex removes the secondary index with the name given and returns the updated Table. Returns ErrIndexNotFound
+// if there is no such index.
+func (t *Table) DropIndex(ctx context.Context, name string) (*Table, error) {
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if has, err := indexMap.Has(ctx, types.String(name)); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIndexNotFound
+	}
+
+	indexMap, err = indexMap.Edit().Remove(types.String(name)).Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.setIndexMap(ctx, indexMap)
+}
+
+// RebuildIndexes replaces the secondary indexes of the table with the indexes given, populated from the table's
+// current rows, and returns the updated Table. This is used to carry indexes over to a table created from another
+// table's data, such as the result of a merge or a schema change. Indexes on columns that are no longer in the table's
+// schema are dropped, and unique columns left without an index are given one, as in NewTable.
+func (t *Table) RebuildIndexes(ctx context.Context, indexes []Index) (*Table, error) {
+	sch, err := t.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := t.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indexMap, err := types.NewMap(ctx, t.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ed := indexMap.Edit()
+	for _, idx := range indexes {
+		if !hasAllTags(sch, idx.Tags) {
+			continue
+		}
+
+		idxData, err := buildIndexRowData(ctx, t.vrw, sch, idx, rowData)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := putIndex(ctx, t.vrw, ed, idx, idxData); err != nil {
+			return nil, err
+		}
+	}
+
+	indexMap, err = ed.Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err := t.setIndexMap(ctx, indexMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl.checkUniqueConstraints(ctx, sch, rowData)
+}
+
+// RebuildIndexesFrom rebuilds the secondary indexes of the table given on this table. See RebuildIndexes.
+func (t *Table) RebuildIndexesFrom(ctx context.Context, other *Table) (*Table, error) {
+	indexes, err := other.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) == 0 {
+		return t, nil
+	}
+
+	return t.RebuildIndexes(ctx, indexes)
+}
+
+// updateIndexes applies the differences between the old and new row data given to each of the table's secondary
+// indexes, and returns the updated table struct.
+func (t *Table) updateIndexes(ctx context.Context, tableStruct types.Struct, oldRows, newRows types.Map) (types.Struct, error) {
+	indexMap, err := t.getIndexMap(ctx)
+
+	if err != nil {
+		return types.EmptyStruct(t.Format()), err
+	}
+
+	if indexMap.Empty() || oldRows.Equals(newRows) {
+		return tableStruct, nil
+	}
+
+	sch, err := t.GetSchema(ctx)
+
+	if err != nil {
+		return types.EmptyStruct(t.Format()), err
+	}
+
+	indexes, err := t.GetIndexes(ctx)
+
+	if err != nil {
+		return types.EmptyStruct(t.Format()), err
+	}
+
+	editors := make([]*types.MapEditor, len(indexes))
+	for i, idx := range indexes {
+		idxData, err := t.GetIndexRowData(ctx, idx.Name)
+
+		if err != nil {
+			return types.EmptyStruct(t.Format()), err
+		}
+
+		editors[i] = idxData.Edit()
+	}
+
+	ae := atomicerr.New()
+	changeChan, stopChan := make(chan types.ValueChanged, 32), make(chan struct{})
+
+	go func() {
+		defer close(changeChan)
+		newRows.Diff(ctx, oldRows, ae, changeChan, stopChan)
+	}()
+
+	for change := range changeChan {
+		if ae.IsSet() {
+			break
+		}
+
+		for i, idx := range indexes {
+			if change.OldValue != nil {
+				oldKey, err := indexKey(ctx, t.Format(), sch, idx, change.Key, change.OldValue)
+
+				if err != nil {
+					ae.SetIfError(err)
+					break
+				}
+
+				editors[i].Remove(oldKey)
+			}
+
+			if change.NewValue != nil {
+				newKey, err := indexKey(ctx, t.Format(), sch, idx, change.Key, change.NewValue)
+
+				if err != nil {
+					ae.SetIfError(err)
+					break
+				}
+
+				editors[i].Set(newKey, change.Key)
+			}
+		}
+	}
+
+	close(stopChan)
+	for range changeChan {
+	}
+
+	if err := ae.Get(); err != nil {
+		return types.EmptyStruct(t.Format()), err
+	}
+
+	ed := indexMap.Edit()
+	for i, idx := range indexes {
+		idxData, err := editors[i].Map(ctx)
+
+		if err != nil {
+			return types.EmptyStruct(t.Format()), err
+		}
+
+		if err := putIndex(ctx, t.vrw, ed, idx, idxData); err != nil {
+			return types.EmptyStruct(t.Format()), err
+		}
+	}
+
+	indexMap, err = ed.Map(ctx)
+
+	if err != nil {
+		return types.EmptyStruct(t.Format()), err
+	}
+
+	return withIndexMap(ctx, t.vrw, tableStruct, indexMap)
+}
+
+func (t *Table) getIndexMap(ctx context.Context) (types.Map, error) {
+	val, ok, err := t.tableStruct.MaybeGet(indexesKey)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if !ok {
+		return types.NewMap(ctx, t.vrw)
+	}
+
+	indexMapVal, err := val.(types.Ref).TargetValue(ctx, t.vrw)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return indexMapVal.(types.Map), nil
+}
+
+func (t *Table) setIndexMap(ctx context.Context, indexMap types.Map) (*Table, error) {
+	updatedSt, err := withIndexMap(ctx, t.vrw, t.tableStruct, indexMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+func withIndexMap(ctx context.Context, vrw types.ValueReadWriter, tableStruct types.Struct, indexMap types.Map) (types.Struct, error) {
+	if indexMap.Empty() {
+		return tableStruct.Delete(indexesKey)
+	}
+
+	indexMapRef, err := writeValAndGetRef(ctx, vrw, indexMap)
+
+	if err != nil {
+		return types.EmptyStruct(vrw.Format()), err
+	}
+
+	return tableStruct.Set(indexesKey, indexMapRef)
+}
+
+// putIndex writes the row data given for the index, and sets the index's entry in the map being edited.
+func putIndex(ctx context.Context, vrw types.ValueReadWriter, ed *types.MapEditor, idx Index, idxData types.Map) error {
+	rowsRef, err := writeValAndGetRef(ctx, vrw, idxData)
+
+	if err != nil {
+		return err
+	}
+
+	tagVals := make([]types.Value, len(idx.Tags))
+	for i, tag := range idx.Tags {
+		tagVals[i] = types.Uint(tag)
+	}
+
+	tagTuple, err := types.NewTuple(vrw.Format(), tagVals...)
+
+	if err != nil {
+		return err
+	}
+
+	st, err := types.NewStruct(vrw.Format(), indexStructName, types.StructData{
+		indexTagsKey: tagTuple,
+		indexRowsKey: rowsRef,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	ed.Set(types.String(idx.Name), st)
+	return nil
+}
+
+func indexFromNoms(name string, st types.Struct) (Index, types.Ref, error) {
+	tagsVal, _, err := st.MaybeGet(indexTagsKey)
+
+	if err != nil {
+		return Index{}, types.Ref{}, err
+	}
+
+	rowsVal, _, err := st.MaybeGet(indexRowsKey)
+
+	if err != nil {
+		return Index{}, types.Ref{}, err
+	}
+
+	tagTuple := tagsVal.(types.Tuple)
+	tags := make([]uint64, tagTuple.Len())
+	for i := range tags {
+		tag, err := tagTuple.Get(uint64(i))
+
+		if err != nil {
+			return Index{}, types.Ref{}, err
+		}
+
+		tags[i] = uint64(tag.(types.Uint))
+	}
+
+	return Index{name, tags}, rowsVal.(types.Ref), nil
+}
+
+func buildIndexRowData(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, idx Index, rowData types.Map) (types.Map, error) {
+	idxData, err := types.NewMap(ctx, vrw)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	ed := idxData.Edit()
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		idxKey, err := indexKey(ctx, vrw.Format(), sch, idx, key, value)
+
+		if err != nil {
+			return err
+		}
+
+		ed.Set(idxKey, key)
+		return nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return ed.Map(ctx)
+}
+
+// indexKey returns the key in the index given for the row with the noms key and value given.
+func indexKey(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, idx Index, key, value types.Value) (types.Value, error) {
+	taggedVals, err := row.ParseTaggedValues(key.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonKeyVals, err := row.ParseTaggedValues(value.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for tag, val := range nonKeyVals {
+		taggedVals[tag] = val
+	}
+
+	pkTags := sch.GetPKCols().Tags
+	tags := make([]uint64, 0, len(idx.Tags)+len(pkTags))
+	tags = append(tags, idx.Tags...)
+	tags = append(tags, pkTags...)
+
+	return taggedVals.NomsTupleForTags(nbf, tags, true).Value(ctx)
+}
+
+func hasAllTags(sch schema.Schema, tags []uint64) bool {
+	for _, tag := range tags {
+		if _, ok := sch.GetAllCols().GetByTag(tag); !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestIndexes(t *testing.T) {
+	ctx := context.Background()
+	db, _ := dbfactory.MemFactory{}.CreateDB(ctx, types.Format_7_18, nil, nil)
+	tSchema := createTestSchema()
+	rowData, rows := createTestRowData(t, db, tSchema)
+	tbl, err := createTestTable(db, tSchema, rowData)
+	require.NoError(t, err)
+
+	tbl, err = tbl.CreateIndex(ctx, "age_idx", []uint64{ageTag})
+	require.NoError(t, err)
+
+	_, err = tbl.CreateIndex(ctx, "age_idx", []uint64{ageTag})
+	assert.Equal(t, ErrIndexExists, err)
+
+	_, err = tbl.CreateIndex(ctx, "bad_idx", []uint64{1000})
+	assert.Error(t, err)
+
+	indexes, err := tbl.GetIndexes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Index{{"age_idx", []uint64{ageTag}}}, indexes)
+
+	// Rows 0 and 2 are both 53, and sort by their primary keys after the indexed value
+	assertIndexedAges(t, tbl, tSchema, []uint64{21, 36, 53, 53})
+
+	// Update a row, remove a row, and add a row, and make sure the index matches
+	ed := rowData.Edit()
+	updated, err := rows[0].SetColVal(ageTag, types.Uint(20), tSchema)
+	require.NoError(t, err)
+	ed.Set(updated.NomsMapKey(tSchema), updated.NomsMapValue(tSchema))
+	ed.Remove(rows[3].NomsMapKey(tSchema))
+	added, err := rows[3].SetColVal(idTag, types.UUID(id0), tSchema)
+	require.NoError(t, err)
+	added, err = added.SetColVal(ageTag, types.Uint(99), tSchema)
+	require.NoError(t, err)
+	// added has the same key as rows[0], so it replaces the update above
+	ed.Set(added.NomsMapKey(tSchema), added.NomsMapValue(tSchema))
+	updatedRows, err := ed.Map(ctx)
+	require.NoError(t, err)
+
+	tbl, err = tbl.UpdateRows(ctx, updatedRows)
+	require.NoError(t, err)
+	assertIndexedAges(t, tbl, tSchema, []uint64{21, 53, 99})
+
+	rebuilt, err := tbl.RebuildIndexes(ctx, indexes)
+	require.NoError(t, err)
+	assertIndexedAges(t, rebuilt, tSchema, []uint64{21, 53, 99})
+
+	tbl, err = tbl.DropIndex(ctx, "age_idx")
+	require.NoError(t, err)
+
+	indexes, err = tbl.GetIndexes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexes)
+
+	_, err = tbl.DropIndex(ctx, "age_idx")
+	assert.Equal(t, ErrIndexNotFound, err)
+}
+
+func assertIndexedAges(t *testing.T, tbl *Table, sch schema.Schema, expectedAges []uint64) {
+	ctx := context.Background()
+	idxData, err := tbl.GetIndexRowData(ctx, "age_idx")
+	require.NoError(t, err)
+
+	var ages []uint64
+	err = idxData.IterAll(ctx, func(key, value types.Value) error {
+		r, ok, err := tbl.GetRow(ctx, value.(types.Tuple), sch)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		age, _ := r.GetColVal(ageTag)
+		idxVals, err := row.ParseTaggedValues(key.(types.Tuple))
+		require.NoError(t, err)
+		assert.Equal(t, age, idxVals[ageTag])
+
+		ages = append(ages, uint64(age.(types.Uint)))
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedAges, ages)
+}
@@ -24,7 +24,8 @@ import (
 const (
 	ddbRootStructName = "dolt_db_root"
 
-	tablesKey = "tables"
+	tablesKey      = "tables"
+	foreignKeysKey = "foreign_keys"
 )
 
 // RootValue defines the structure used inside all Liquidata noms dbs
@@ -227,6 +228,14 @@ func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
 			return false, err
 		} else if has {
 			names = append(names, string(key.(types.String)))
+		} else if has, err := tbl.HasForeignKeyViolations(); err != nil {
+			return false, err
+		} else if has {
+			names = append(names, string(key.(types.String)))
+		} else if has, err := tbl.HasSchemaConflicts(); err != nil {
+			return false, err
+		} else if has {
+			names = append(names, string(key.(types.String)))
 		}
 
 		return false, nil
@@ -251,12 +260,16 @@ func (root *RootValue) HasConflicts(ctx context.Context) (bool, error) {
 
 // PutTable inserts a table by name into the map of tables. If a table already exists with that name it will be replaced
 func (root *RootValue) PutTable(ctx context.Context, ddb *DoltDB, tName string, table *Table) (*RootValue, error) {
+	return root.putTable(ctx, tName, table)
+}
+
+func (root *RootValue) putTable(ctx context.Context, tName string, table *Table) (*RootValue, error) {
 	if !IsValidTableName(tName) {
 		panic("Don't attempt to put a table with a name that fails the IsValidTableName check")
 	}
 
 	rootValSt := root.valueSt
-	tableRef, err := writeValAndGetRef(ctx, ddb.ValueReadWriter(), table.tableStruct)
+	tableRef, err := writeValAndGetRef(ctx, root.vrw, table.tableStruct)
 
 	if err != nil {
 		return nil, err
@@ -394,6 +407,8 @@ func (root *RootValue) TableDiff(ctx context.Context, other *RootValue) (added,
 	return added, modified, removed, nil
 }
 
+// UpdateTablesFromOther replaces the tables with the names given, along with the foreign keys declared on them, with
+// those in the other root given, and returns the updated root. Tables which aren't in the other root are removed.
 func (root *RootValue) UpdateTablesFromOther(ctx context.Context, tblNames []string, other *RootValue) (*RootValue, error) {
 	tableMap, err := root.getTableMap()
 
@@ -433,9 +448,17 @@ func (root *RootValue) UpdateTablesFromOther(ctx context.Context, tblNames []str
 		return nil, err
 	}
 
-	return newRootValue(root.vrw, rootValSt), nil
+	otherFkMap, err := other.getForeignKeyMap(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootValue(root.vrw, rootValSt).replaceForeignKeys(ctx, tblNames, otherFkMap)
 }
 
+// RemoveTables removes the tables with the names given, along with the foreign keys declared on them, and returns the
+// updated root. Returns ErrTableNotFound if any of the tables don't exist.
 func (root *RootValue) RemoveTables(ctx context.Context, tables ...string) (*RootValue, error) {
 	tableMap, err := root.getTableMap()
 
@@ -468,5 +491,11 @@ func (root *RootValue) RemoveTables(ctx context.Context, tables ...string) (*Roo
 		return nil, err
 	}
 
-	return newRootValue(root.vrw, rootValSt), nil
+	noFks, err := types.NewMap(ctx, root.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootValue(root.vrw, rootValSt).replaceForeignKeys(ctx, tables, noFks)
 }
@@ -0,0 +1,164 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// SchemaConflict is a column which the two commits of a merge changed in ways that can't be merged, such as changing its
+// type differently, or adding columns with the same name but different types. Base, Ours and Theirs are the column as
+// it is in the merge base and in each commit, or schema.InvalidCol if that side doesn't have it.
+type SchemaConflict struct {
+	Base   schema.Column
+	Ours   schema.Column
+	Theirs schema.Column
+}
+
+// SetSchemaConflicts replaces the schema conflicts recorded for the table and returns the updated table. The schemas
+// given are refs to the schemas of the merge base and each commit, which must have the columns of the conflicts.
+func (t *Table) SetSchemaConflicts(ctx context.Context, schemas Conflict, conflicts []SchemaConflict) (*Table, error) {
+	if len(conflicts) == 0 {
+		updatedSt, err := t.tableStruct.Delete(schemaConflictsKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Table{t.vrw, updatedSt}, nil
+	}
+
+	schemasTpl, err := schemas.ToNomsList(t.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vals := []types.Value{schemasTpl}
+	for _, cnf := range conflicts {
+		tagsTpl, err := types.NewTuple(t.vrw.Format(), types.Uint(cnf.Base.Tag), types.Uint(cnf.Ours.Tag), types.Uint(cnf.Theirs.Tag))
+
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, tagsTpl)
+	}
+
+	tpl, err := types.NewTuple(t.vrw.Format(), vals...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictsKey, tpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// GetSchemaConflicts returns the schema conflicts recorded for the table by a merge.
+func (t *Table) GetSchemaConflicts(ctx context.Context) ([]SchemaConflict, error) {
+	val, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	tpl := val.(types.Tuple)
+	schemasVal, err := tpl.Get(0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schemaRefs, err := ConflictFromTuple(schemasVal.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas [3]schema.Schema
+	for i, ref := range []types.Value{schemaRefs.Base, schemaRefs.Value, schemaRefs.MergeValue} {
+		if schemas[i], err = refToSchema(ctx, t.vrw, ref.(types.Ref)); err != nil {
+			return nil, err
+		}
+	}
+
+	conflicts := make([]SchemaConflict, 0, tpl.Len()-1)
+	for i := uint64(1); i < tpl.Len(); i++ {
+		tagsVal, err := tpl.Get(i)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var cols [3]schema.Column
+		for j := range cols {
+			tag, err := tagsVal.(types.Tuple).Get(uint64(j))
+
+			if err != nil {
+				return nil, err
+			}
+
+			col, ok := schemas[j].GetAllCols().GetByTag(uint64(tag.(types.Uint)))
+
+			if !ok {
+				col = schema.InvalidCol
+			}
+
+			cols[j] = col
+		}
+
+		conflicts = append(conflicts, SchemaConflict{Base: cols[0], Ours: cols[1], Theirs: cols[2]})
+	}
+
+	return conflicts, nil
+}
+
+// HasSchemaConflicts returns true if there are schema conflicts recorded for the table.
+func (t *Table) HasSchemaConflicts() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	return ok, err
+}
+
+// CopySchemaConflictsFrom returns the table with the schema conflicts recorded for the other table given, for tables
+// rebuilt from another table's schema and rows.
+func (t *Table) CopySchemaConflictsFrom(other *Table) (*Table, error) {
+	val, ok, err := other.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil || !ok {
+		return t, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictsKey, val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
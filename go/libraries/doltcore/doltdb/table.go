@@ -22,6 +22,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -33,6 +34,9 @@ const (
 	tableRowsKey       = "rows"
 	conflictsKey       = "conflicts"
 	conflictSchemasKey = "conflict_schemas"
+	indexesKey         = "indexes"
+	fkViolationsKey    = "fk_violations"
+	schemaConflictsKey = "schema_conflicts"
 
 	// TableNameRegexStr is the regular expression that valid tables must match.
 	TableNameRegexStr = `^[a-zA-Z]+[-_0-9a-zA-Z]*[0-9a-zA-Z]+$`
@@ -52,8 +56,16 @@ type Table struct {
 	tableStruct types.Struct
 }
 
-// NewTable creates a noms Struct which stores the schema and the row data
+// NewTable creates a noms Struct which stores the schema and the row data. Each column with a unique constraint is given
+// a secondary index on it alone, named after the column. Returns a UniqueConstraintError if the row data violates a
+// unique constraint of the schema.
 func NewTable(ctx context.Context, vrw types.ValueReadWriter, schema types.Value, rowData types.Map) (*Table, error) {
+	sch, err := encoding.UnmarshalNomsValue(ctx, vrw.Format(), schema)
+
+	if err != nil {
+		return nil, err
+	}
+
 	schemaRef, err := writeValAndGetRef(ctx, vrw, schema)
 
 	if err != nil {
@@ -77,7 +89,13 @@ func NewTable(ctx context.Context, vrw types.ValueReadWriter, schema types.Value
 		return nil, err
 	}
 
-	return &Table{vrw, tableStruct}, nil
+	noRows, err := types.NewMap(ctx, vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return (&Table{vrw, tableStruct}).checkUniqueConstraints(ctx, sch, noRows)
 }
 
 func (t *Table) Format() *types.NomsBinFormat {
@@ -392,8 +410,16 @@ func (t *Table) GetRows(ctx context.Context, pkItr PKItr, numPKs int, sch schema
 }
 
 // UpdateRows replaces the current row data and returns and updated Table.  Calls to UpdateRows will not be written to the
-// database.  The root must be updated with the updated table, and the root must be committed or written.
+// database.  The root must be updated with the updated table, and the root must be committed or written.  The table's
+// secondary indexes are updated to match the new row data.  Returns a UniqueConstraintError if a row added or changed
+// by the new row data violates a unique constraint of the table's schema.
 func (t *Table) UpdateRows(ctx context.Context, updatedRows types.Map) (*Table, error) {
+	sch, err := t.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
 	rowDataRef, err := writeValAndGetRef(ctx, t.vrw, updatedRows)
 
 	if err != nil {
@@ -406,7 +432,47 @@ func (t *Table) UpdateRows(ctx context.Context, updatedRows types.Map) (*Table,
 		return nil, err
 	}
 
-	return &Table{t.vrw, updatedSt}, nil
+	oldRows, err := t.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err = t.updateIndexes(ctx, updatedSt, oldRows, updatedRows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return (&Table{t.vrw, updatedSt}).checkUniqueConstraints(ctx, sch, oldRows)
+}
+
+// diffRows calls cb for each row which differs between the row data given, until it returns an error.
+func diffRows(ctx context.Context, rowData, prevRowData types.Map, cb func(change types.ValueChanged) error) error {
+	ae := atomicerr.New()
+	changeChan, stopChan := make(chan types.ValueChanged, 32), make(chan struct{})
+
+	go func() {
+		defer close(changeChan)
+		rowData.Diff(ctx, prevRowData, ae, changeChan, stopChan)
+	}()
+
+	for change := range changeChan {
+		if ae.IsSet() {
+			break
+		}
+
+		if err := cb(change); err != nil {
+			ae.SetIfError(err)
+			break
+		}
+	}
+
+	close(stopChan)
+	for range changeChan {
+	}
+
+	return ae.Get()
 }
 
 // GetRowData retrieves the underlying map which is a map from a primary key to a list of field values.
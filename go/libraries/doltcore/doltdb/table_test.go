@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
@@ -128,3 +129,65 @@ func TestTables(t *testing.T) {
 		}
 	}
 }
+
+func TestUniqueConstraints(t *testing.T) {
+	db, _ := dbfactory.MemFactory{}.CreateDB(context.Background(), types.Format_7_18, nil, nil)
+
+	colColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", idTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("first", firstTag, types.StringKind, false, schema.NotNullConstraint{}),
+		schema.NewColumn("last", lastTag, types.StringKind, false, schema.UniqueConstraint{}),
+		schema.NewColumn("is_married", isMarriedTag, types.BoolKind, false, schema.UniqueConstraint{}),
+		schema.NewColumn("age", ageTag, types.UintKind, false),
+		schema.NewColumn("empty", emptyTag, types.IntKind, false),
+	)
+	sch := schema.SchemaFromCols(colColl)
+	rowData, rows := createTestRowData(t, db, sch)
+	schemaVal, err := encoding.MarshalAsNomsValue(context.Background(), db, sch)
+	require.NoError(t, err)
+
+	// rows 0 and 3 both have null is_married values, which don't conflict
+	tbl, err := NewTable(context.Background(), db, schemaVal, rowData)
+	require.NoError(t, err)
+
+	// each unique column is checked against an index on it alone
+	idx, ok, err := tbl.GetIndex(context.Background(), "last")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{lastTag}, idx.Tags)
+
+	// a dropped unique index is recreated when the rows are updated
+	tbl, err = tbl.DropIndex(context.Background(), "is_married")
+	require.NoError(t, err)
+	tbl, err = tbl.UpdateRows(context.Background(), rowData)
+	require.NoError(t, err)
+	_, ok, err = tbl.GetIndex(context.Background(), "is_married")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	dupRow, err := rows[1].SetColVal(lastTag, types.String("billerson"), sch)
+	require.NoError(t, err)
+	dupRow, err = dupRow.SetColVal(isMarriedTag, types.NullValue, sch)
+	require.NoError(t, err)
+	dupData, err := rowData.Edit().Set(dupRow.NomsMapKey(sch), dupRow.NomsMapValue(sch)).Map(context.Background())
+	require.NoError(t, err)
+
+	_, err = tbl.UpdateRows(context.Background(), dupData)
+	assert.True(t, IsUniqueConstraintErr(err))
+	assert.Equal(t, UniqueConstraintError{"last", types.String("billerson")}, err)
+
+	_, err = NewTable(context.Background(), db, schemaVal, dupData)
+	assert.True(t, IsUniqueConstraintErr(err))
+
+	ageColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", idTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("age", ageTag, types.UintKind, false, schema.UniqueConstraint{}),
+	)
+	ageSchemaVal, err := encoding.MarshalAsNomsValue(context.Background(), db, schema.SchemaFromCols(ageColl))
+	require.NoError(t, err)
+
+	// rows 0 and 2 both have age 53
+	_, err = NewTable(context.Background(), db, ageSchemaVal, rowData)
+	assert.Equal(t, UniqueConstraintError{"age", types.Uint(53)}, err)
+	assert.Equal(t, "Duplicate value 53 for unique column 'age'", err.Error())
+}
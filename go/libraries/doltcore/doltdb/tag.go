@@ -0,0 +1,159 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var errTagHasNoMeta = errors.New("tag has no metadata")
+var errTagHasNoCommit = errors.New("tag does not refer to a commit")
+
+// TagMeta contains all the metadata that is associated with a tag: the tagger, the time the tag was created and the
+// tag message.
+type TagMeta struct {
+	Name        string
+	Email       string
+	Timestamp   uint64
+	Description string
+}
+
+// NewTagMeta creates a TagMeta instance from a name, email, and message and uses the current time for the timestamp.
+// The message may be empty.
+func NewTagMeta(name, email, desc string) (*TagMeta, error) {
+	n := strings.TrimSpace(name)
+	e := strings.TrimSpace(email)
+	d := strings.TrimSpace(desc)
+
+	if n == "" || e == "" {
+		return nil, errors.New("Aborting tag due to empty tagger name or email.")
+	}
+
+	ns := uint64(time.Now().UnixNano())
+	ms := ns / milliToNano
+
+	return &TagMeta{n, e, ms, d}, nil
+}
+
+func tagMetaFromNomsSt(st types.Struct) (*TagMeta, error) {
+	cm, err := commitMetaFromNomsSt(st)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagMeta{cm.Name, cm.Email, cm.Timestamp, cm.Description}, nil
+}
+
+func (tm *TagMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, error) {
+	cm := CommitMeta{tm.Name, tm.Email, tm.Timestamp, tm.Description}
+	return cm.toNomsStruct(nbf)
+}
+
+// FormatTS takes the internal timestamp and turns it into a human readable string in the time.RubyDate format
+func (tm *TagMeta) FormatTS() string {
+	cm := CommitMeta{Timestamp: tm.Timestamp}
+	return cm.FormatTS()
+}
+
+// Tag is an immutable, named reference to a commit that was written to noms
+type Tag struct {
+	vrw   types.ValueReadWriter
+	name  string
+	tagSt types.Struct
+}
+
+// Name returns the name of the tag, e.g. v1.0
+func (t *Tag) Name() string {
+	return t.name
+}
+
+// GetRef returns the ref of the tag e.g. refs/tags/v1.0
+func (t *Tag) GetRef() ref.TagRef {
+	return ref.NewTagRef(t.name)
+}
+
+// HashOf returns the hash of the tag
+func (t *Tag) HashOf() (hash.Hash, error) {
+	return t.tagSt.Hash(t.vrw.Format())
+}
+
+// GetTagMeta gets the metadata associated with the tag
+func (t *Tag) GetTagMeta() (*TagMeta, error) {
+	metaVal, found, err := t.tagSt.MaybeGet(datas.TagMetaField)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, errTagHasNoMeta
+	}
+
+	metaSt, ok := metaVal.(types.Struct)
+
+	if !ok {
+		return nil, errTagHasNoMeta
+	}
+
+	return tagMetaFromNomsSt(metaSt)
+}
+
+// GetCommit returns the commit the tag refers to
+func (t *Tag) GetCommit(ctx context.Context) (*Commit, error) {
+	commitSt, err := commitStForTagSt(ctx, t.vrw, t.tagSt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Commit{t.vrw, commitSt}, nil
+}
+
+func commitStForTagSt(ctx context.Context, vr types.ValueReader, tagSt types.Struct) (types.Struct, error) {
+	refVal, found, err := tagSt.MaybeGet(datas.TagCommitRefField)
+
+	if err != nil {
+		return types.EmptyStruct(vr.Format()), err
+	}
+
+	commitRef, ok := refVal.(types.Ref)
+
+	if !found || !ok {
+		return types.EmptyStruct(vr.Format()), errTagHasNoCommit
+	}
+
+	commitVal, err := commitRef.TargetValue(ctx, vr)
+
+	if err != nil {
+		return types.EmptyStruct(vr.Format()), err
+	}
+
+	commitSt, ok := commitVal.(types.Struct)
+
+	if !ok || commitSt.Name() != CommitStructName {
+		return types.EmptyStruct(vr.Format()), errTagHasNoCommit
+	}
+
+	return commitSt, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cs, _ := NewCommitSpec("master", "")
+	commit, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	commitHash, err := commit.HashOf()
+	require.NoError(t, err)
+
+	meta, err := NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "First release")
+	require.NoError(t, err)
+	tagRef := ref.NewTagRef("v1.0")
+	tag, err := ddb.NewTagAtCommit(ctx, tagRef, commit, meta)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0", tag.Name())
+
+	// Tags can't be moved
+	_, err = ddb.NewTagAtCommit(ctx, tagRef, commit, meta)
+	assert.Equal(t, ErrTagExists, err)
+
+	tags, err := ddb.GetTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{tagRef}, tags)
+
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{ref.NewBranchRef("master")}, branches)
+
+	resolved, err := ddb.ResolveTag(ctx, tagRef)
+	require.NoError(t, err)
+	resolvedMeta, err := resolved.GetTagMeta()
+	require.NoError(t, err)
+	assert.Equal(t, meta, resolvedMeta)
+
+	// Commit specs accept tags by their name or their full ref
+	for _, spec := range []string{"v1.0", "refs/tags/v1.0"} {
+		cs, err := NewCommitSpec(spec, "")
+		require.NoError(t, err)
+		tagged, err := ddb.Resolve(ctx, cs)
+		require.NoError(t, err)
+		taggedHash, err := tagged.HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, commitHash, taggedHash)
+	}
+
+	cs, _ = NewCommitSpec("v2.0", "")
+	_, err = ddb.Resolve(ctx, cs)
+	assert.Equal(t, ErrBranchNotFound, err)
+
+	// Tags can be copied to another database
+	otherDB, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	require.NoError(t, otherDB.PullTagChunks(ctx, ddb, tag, nil))
+	require.NoError(t, otherDB.SetTag(ctx, tagRef, tag))
+	require.NoError(t, otherDB.SetTag(ctx, tagRef, tag))
+	otherTag, err := otherDB.ResolveTag(ctx, tagRef)
+	require.NoError(t, err)
+	otherCommit, err := otherTag.GetCommit(ctx)
+	require.NoError(t, err)
+	otherHash, err := otherCommit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, commitHash, otherHash)
+
+	require.NoError(t, ddb.DeleteTag(ctx, tagRef))
+	_, err = ddb.ResolveTag(ctx, tagRef)
+	assert.Equal(t, ErrTagNotFound, err)
+	assert.Equal(t, ErrTagNotFound, ddb.DeleteTag(ctx, tagRef))
+}
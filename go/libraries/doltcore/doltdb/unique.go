@@ -0,0 +1,472 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// UniqueConstraintError is the error returned when table data has more than one row with the same non-null value for a
+// column with a unique constraint.
+type UniqueConstraintError struct {
+	Column string
+	Value  types.Value
+}
+
+func (e UniqueConstraintError) Error() string {
+	valStr, err := types.EncodedValue(context.Background(), e.Value)
+
+	if err != nil {
+		valStr = "<unknown>"
+	}
+
+	return fmt.Sprintf("Duplicate value %s for unique column '%s'", valStr, e.Column)
+}
+
+// IsUniqueConstraintErr returns true if the error is a UniqueConstraintError
+func IsUniqueConstraintErr(err error) bool {
+	_, ok := err.(UniqueConstraintError)
+	return ok
+}
+
+// checkUniqueConstraints returns a UniqueConstraintError if any row of the table's row data which was added or changed
+// since the old row data given has the same non-null value as another row for a column with a unique constraint. Each
+// unique column is checked against a secondary index on that column alone. Any unique column without one has its index
+// created, and is checked against all of the table's rows. Returns the table with the indexes created.
+func (t *Table) checkUniqueConstraints(ctx context.Context, sch schema.Schema, oldRows types.Map) (*Table, error) {
+	tbl := t
+	indexes, err := tbl.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var changedCols []schema.Column
+	var changedIdxData []types.Map
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if !col.IsUnique() {
+			return false, nil
+		}
+
+		if idx, ok := uniqueIndex(indexes, tag); ok {
+			idxData, err := tbl.GetIndexRowData(ctx, idx.Name)
+
+			if err != nil {
+				return true, err
+			}
+
+			changedCols = append(changedCols, col)
+			changedIdxData = append(changedIdxData, idxData)
+			return false, nil
+		}
+
+		name := uniqueIndexName(indexes, col.Name)
+		tbl, err = tbl.CreateIndex(ctx, name, []uint64{tag})
+
+		if err != nil {
+			return true, err
+		}
+
+		indexes = append(indexes, Index{name, []uint64{tag}})
+		idxData, err := tbl.GetIndexRowData(ctx, name)
+
+		if err != nil {
+			return true, err
+		}
+
+		return false, checkUniqueIndexData(ctx, col, idxData)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changedCols) == 0 {
+		return tbl, nil
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = diffRows(ctx, rowData, oldRows, func(change types.ValueChanged) error {
+		if change.NewValue == nil {
+			return nil
+		}
+
+		r, err := row.FromNoms(sch, change.Key.(types.Tuple), change.NewValue.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		for i, col := range changedCols {
+			if err := checkUniqueValue(ctx, col, r, changedIdxData[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl, nil
+}
+
+// FindUniqueConstraintViolations returns the keys of the rows of the row data given which have the same non-null value
+// as another row for a column with a unique constraint in the schema given. The row data is the table's row data with
+// changes applied, such as by a merge, and keyed the same way. Only the values of rows added or changed since the
+// table's row data are looked for, in the table's index on each unique column updated with the changes. Unique columns
+// the table has no index on, and all columns if the schema given has a different primary key, are checked against all
+// of the rows.
+func (t *Table) FindUniqueConstraintViolations(ctx context.Context, sch schema.Schema, rowData types.Map) ([]types.Value, error) {
+	tblSch, err := t.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := t.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !tagsEqual(tblSch.GetPKCols().Tags, sch.GetPKCols().Tags) {
+		indexes = nil
+	}
+
+	var changedIdxs, fullIdxs []Index
+	var changedEds []*types.MapEditor
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if !col.IsUnique() {
+			return false, nil
+		}
+
+		idx, ok := uniqueIndex(indexes, tag)
+
+		if !ok {
+			fullIdxs = append(fullIdxs, Index{col.Name, []uint64{tag}})
+			return false, nil
+		}
+
+		idxData, err := t.GetIndexRowData(ctx, idx.Name)
+
+		if err != nil {
+			return true, err
+		}
+
+		changedIdxs = append(changedIdxs, idx)
+		changedEds = append(changedEds, idxData.Edit())
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := &uniqueViolations{nbf: t.Format(), seen: make(map[hash.Hash]bool)}
+	if len(changedIdxs) > 0 {
+		oldRows, err := t.GetRowData(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		changedVals := make([][]types.Value, len(changedIdxs))
+		err = diffRows(ctx, rowData, oldRows, func(change types.ValueChanged) error {
+			for i, idx := range changedIdxs {
+				if change.OldValue != nil {
+					oldKey, err := indexKey(ctx, t.Format(), sch, idx, change.Key, change.OldValue)
+
+					if err != nil {
+						return err
+					}
+
+					changedEds[i].Remove(oldKey)
+				}
+
+				if change.NewValue != nil {
+					newKey, err := indexKey(ctx, t.Format(), sch, idx, change.Key, change.NewValue)
+
+					if err != nil {
+						return err
+					}
+
+					changedEds[i].Set(newKey, change.Key)
+
+					if val, err := uniqueIndexKeyVal(newKey); err != nil {
+						return err
+					} else if !types.IsNull(val) {
+						changedVals[i] = append(changedVals[i], val)
+					}
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i, idx := range changedIdxs {
+			idxData, err := changedEds[i].Map(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, val := range changedVals[i] {
+				keys, err := uniqueValueRows(ctx, idx.Tags[0], val, idxData)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if len(keys) > 1 {
+					if err := violations.add(keys); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	for _, idx := range fullIdxs {
+		idxData, err := buildIndexRowData(ctx, t.vrw, sch, idx, rowData)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var prev types.Value
+		var keys []types.Value
+		err = idxData.IterAll(ctx, func(key, value types.Value) error {
+			val, err := uniqueIndexKeyVal(key)
+
+			if err != nil {
+				return err
+			}
+
+			if prev == nil || types.IsNull(val) || !prev.Equals(val) {
+				if len(keys) > 1 {
+					if err := violations.add(keys); err != nil {
+						return err
+					}
+				}
+
+				keys = nil
+			}
+
+			prev = nil
+			if !types.IsNull(val) {
+				prev = val
+				keys = append(keys, value)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keys) > 1 {
+			if err := violations.add(keys); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return violations.keys, nil
+}
+
+// uniqueViolations collects the keys of rows which violate unique constraints, once each.
+type uniqueViolations struct {
+	nbf  *types.NomsBinFormat
+	keys []types.Value
+	seen map[hash.Hash]bool
+}
+
+func (uv *uniqueViolations) add(keys []types.Value) error {
+	for _, key := range keys {
+		h, err := key.Hash(uv.nbf)
+
+		if err != nil {
+			return err
+		}
+
+		if !uv.seen[h] {
+			uv.seen[h] = true
+			uv.keys = append(uv.keys, key)
+		}
+	}
+
+	return nil
+}
+
+// uniqueIndex returns the index of those given on the column with the tag given alone, and whether there is one.
+func uniqueIndex(indexes []Index, tag uint64) (Index, bool) {
+	for _, idx := range indexes {
+		if len(idx.Tags) == 1 && idx.Tags[0] == tag {
+			return idx, true
+		}
+	}
+
+	return Index{}, false
+}
+
+// uniqueIndexName returns the name of the column given, or the first of <name>_2, <name>_3, etc. which isn't the name
+// of one of the indexes given, as MySQL names the indexes of its unique keys.
+func uniqueIndexName(indexes []Index, colName string) string {
+	name := colName
+	for i := 2; ; i++ {
+		taken := false
+		for _, idx := range indexes {
+			if idx.Name == name {
+				taken = true
+				break
+			}
+		}
+
+		if !taken {
+			return name
+		}
+
+		name = fmt.Sprintf("%s_%d", colName, i)
+	}
+}
+
+// checkUniqueValue returns a UniqueConstraintError if the value of the unique column given in the row given isn't null,
+// and the row data of the index on the column has another entry with the same value.
+func checkUniqueValue(ctx context.Context, col schema.Column, r row.Row, idxData types.Map) error {
+	val, ok := r.GetColVal(col.Tag)
+
+	if !ok || types.IsNull(val) {
+		return nil
+	}
+
+	prefix, err := types.NewTuple(idxData.Format(), types.Uint(col.Tag), val)
+
+	if err != nil {
+		return err
+	}
+
+	itr, err := idxData.IteratorFrom(ctx, prefix)
+
+	if err != nil {
+		return err
+	}
+
+	// The row given is in the index, so a second entry with its value is another row's
+	for i := 0; i < 2; i++ {
+		key, _, err := itr.Next(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if key == nil {
+			return nil
+		}
+
+		if keyVal, err := uniqueIndexKeyVal(key); err != nil {
+			return err
+		} else if !keyVal.Equals(val) {
+			return nil
+		}
+	}
+
+	return UniqueConstraintError{col.Name, val}
+}
+
+// uniqueValueRows returns the keys of the rows with the value given in the row data of the index on the column with the
+// tag given alone.
+func uniqueValueRows(ctx context.Context, tag uint64, val types.Value, idxData types.Map) ([]types.Value, error) {
+	prefix, err := types.NewTuple(idxData.Format(), types.Uint(tag), val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := idxData.IteratorFrom(ctx, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []types.Value
+	for {
+		key, value, err := itr.Next(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if key == nil {
+			return keys, nil
+		}
+
+		if keyVal, err := uniqueIndexKeyVal(key); err != nil {
+			return nil, err
+		} else if !keyVal.Equals(val) {
+			return keys, nil
+		}
+
+		keys = append(keys, value)
+	}
+}
+
+// checkUniqueIndexData returns a UniqueConstraintError if the row data of the index on the unique column given has more
+// than one entry with the same non-null value. Entries with the same value are adjacent in the index.
+func checkUniqueIndexData(ctx context.Context, col schema.Column, idxData types.Map) error {
+	var prev types.Value
+	return idxData.IterAll(ctx, func(key, _ types.Value) error {
+		val, err := uniqueIndexKeyVal(key)
+
+		if err != nil {
+			return err
+		}
+
+		if types.IsNull(val) {
+			prev = nil
+			return nil
+		}
+
+		if prev != nil && prev.Equals(val) {
+			return UniqueConstraintError{col.Name, val}
+		}
+
+		prev = val
+		return nil
+	})
+}
+
+// uniqueIndexKeyVal returns the indexed column value of a key of the index on a single column.
+func uniqueIndexKeyVal(key types.Value) (types.Value, error) {
+	return key.(types.Tuple).Get(1)
+}
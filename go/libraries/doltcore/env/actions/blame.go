@@ -0,0 +1,152 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// BlameTable returns the commit which last modified each row of the table given as of the commit given, keyed by the
+// hash of the row's primary key tuple. History is followed through the first parent of each commit, so a row changed
+// on a merged branch is blamed on the merge commit. A row which was deleted and inserted again is blamed on the commit
+// which inserted it again. Returns doltdb.ErrTableNotFound if the table doesn't exist as of the commit given.
+func BlameTable(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tblName string) (map[hash.Hash]*doltdb.Commit, error) {
+	rows, ok, err := getRowsAtCommit(ctx, cm, tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+
+	nbf := rows.Format()
+	unblamed := make(map[hash.Hash]bool)
+	err = rows.IterAll(ctx, func(key, _ types.Value) error {
+		h, err := key.Hash(nbf)
+		unblamed[h] = true
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	blame := make(map[hash.Hash]*doltdb.Commit)
+	for len(unblamed) > 0 {
+		numParents, err := cm.NumParents()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var parent *doltdb.Commit
+		parentRows, parentOk := types.EmptyMap, false
+		if numParents > 0 {
+			parent, err = ddb.ResolveParent(ctx, cm, 0)
+
+			if err != nil {
+				return nil, err
+			}
+
+			parentRows, parentOk, err = getRowsAtCommit(ctx, parent, tblName)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Every remaining row was inserted by this commit if its parent doesn't have the table
+		if !parentOk {
+			for h := range unblamed {
+				blame[h] = cm
+			}
+
+			break
+		}
+
+		err = iterChangedKeys(ctx, rows, parentRows, func(key types.Value) error {
+			h, err := key.Hash(nbf)
+
+			if err == nil && unblamed[h] {
+				blame[h] = cm
+				delete(unblamed, h)
+			}
+
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		cm, rows = parent, parentRows
+	}
+
+	return blame, nil
+}
+
+func getRowsAtCommit(ctx context.Context, cm *doltdb.Commit, tblName string) (types.Map, bool, error) {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, tblName)
+
+	if err != nil || !ok {
+		return types.EmptyMap, false, err
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+
+	return rows, err == nil, err
+}
+
+// iterChangedKeys calls the function given with the key of each row which was added or modified in rows since
+// parentRows.
+func iterChangedKeys(ctx context.Context, rows, parentRows types.Map, cb func(key types.Value) error) error {
+	ae := atomicerr.New()
+	changeChan := make(chan types.ValueChanged, 32)
+	stopChan := make(chan struct{})
+
+	go func() {
+		defer close(changeChan)
+		rows.Diff(ctx, parentRows, ae, changeChan, stopChan)
+	}()
+
+	defer func() {
+		close(stopChan)
+		for range changeChan {
+		}
+	}()
+
+	for change := range changeChan {
+		if change.ChangeType == types.DiffChangeRemoved {
+			continue
+		}
+
+		if ae.SetIfError(cb(change.Key)) {
+			break
+		}
+	}
+
+	return ae.Get()
+}
@@ -227,13 +227,13 @@ func CheckoutBranch(ctx context.Context, dEnv *env.DoltEnv, brName string) error
 		return CheckoutWouldOverwrite{conflicts.AsSlice()}
 	}
 
-	wrkHash, err := writeRoot(ctx, dEnv, wrkTblHashes)
+	wrkHash, err := writeRoot(ctx, dEnv, wrkTblHashes, newRoot, currRoots[WorkingRoot])
 
 	if err != nil {
 		return err
 	}
 
-	stgHash, err := writeRoot(ctx, dEnv, stgTblHashes)
+	stgHash, err := writeRoot(ctx, dEnv, stgTblHashes, newRoot, currRoots[StagedRoot])
 
 	if err != nil {
 		return err
@@ -319,10 +319,26 @@ func tblHashesForCO(ctx context.Context, oldRoot, newRoot, changedRoot *doltdb.R
 	return resultMap, nil
 }
 
-func writeRoot(ctx context.Context, dEnv *env.DoltEnv, tblHashes map[string]hash.Hash) (hash.Hash, error) {
+// writeRoot writes a root with the tables given. The foreign keys declared on each table are taken from newRoot, unless
+// the table was taken from changedRoot.
+func writeRoot(ctx context.Context, dEnv *env.DoltEnv, tblHashes map[string]hash.Hash, newRoot, changedRoot *doltdb.RootValue) (hash.Hash, error) {
+	var newTbls, changedTbls []string
 	for k, v := range tblHashes {
 		if v == emptyHash {
 			delete(tblHashes, k)
+			continue
+		}
+
+		newHash, _, err := newRoot.GetTableHash(ctx, k)
+
+		if err != nil {
+			return emptyHash, err
+		}
+
+		if v == newHash {
+			newTbls = append(newTbls, k)
+		} else {
+			changedTbls = append(changedTbls, k)
 		}
 	}
 
@@ -335,6 +351,18 @@ func writeRoot(ctx context.Context, dEnv *env.DoltEnv, tblHashes map[string]hash
 		return emptyHash, doltdb.ErrNomsIO
 	}
 
+	root, err = root.UpdateTablesFromOther(ctx, newTbls, newRoot)
+
+	if err != nil {
+		return emptyHash, err
+	}
+
+	root, err = root.UpdateTablesFromOther(ctx, changedTbls, changedRoot)
+
+	if err != nil {
+		return emptyHash, err
+	}
+
 	return dEnv.DoltDB.WriteRootValue(ctx, root)
 }
 
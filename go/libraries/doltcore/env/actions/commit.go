@@ -88,6 +88,18 @@ func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, msg string, allowEmpty
 		return err
 	}
 
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = root.ValidateForeignKeys(ctx, headRoot)
+
+	if err != nil {
+		return err
+	}
+
 	h, err := dEnv.UpdateStagedRoot(ctx, root)
 
 	if err != nil {
@@ -123,15 +123,19 @@ func newQueue(ddb *doltdb.DoltDB) *q {
 // Return the commits reachable from commit at hash `includedHead`
 // that are not reachable from hash `excludedHead`. `includedHead` and
 // `excludedHead` must be commits in `ddb`. Returns up to `num`
-// commits, in reverse topological order starting at `includedHead`,
-// with tie breaking based on the height of commit graph between
-// concurrent commits --- higher commits appear first. Beyond the
+// commits, or all of them if `num` is negative, in reverse
+// topological order starting at `includedHead`, with tie breaking
+// based on the height of commit graph between concurrent commits
+// --- higher commits appear first. Beyond the
 // deterministic tie-break, concurrent commits are ordered
 // non-deterministically.
 //
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
-	commitList := make([]*doltdb.Commit, 0, num)
+	var commitList []*doltdb.Commit
+	if num > 0 {
+		commitList = make([]*doltdb.Commit, 0, num)
+	}
 	q := newQueue(ddb)
 	if err := q.SetInvisible(ctx, excludedHead); err != nil {
 		return nil, err
@@ -167,3 +171,57 @@ func GetDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead ha
 	}
 	return commitList, nil
 }
+
+// Return all the commits reachable from the commit at hash
+// `startCommitHash`, in reverse topological order starting at
+// `startCommitHash`, with the same tie breaking as
+// GetDotDotRevisions.
+//
+// Roughly mimics `git log`.
+func GetTopologicalOrderCommits(ctx context.Context, ddb *doltdb.DoltDB, startCommitHash hash.Hash) ([]*doltdb.Commit, error) {
+	itr, err := GetTopologicalOrderIterator(ctx, ddb, startCommitHash)
+	if err != nil {
+		return nil, err
+	}
+	var commitList []*doltdb.Commit
+	for {
+		c, ok, err := itr(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return commitList, nil
+		}
+		commitList = append(commitList, c)
+	}
+}
+
+// CommitItr returns the next commit of a walk of the commit graph,
+// or false if there are no more.
+type CommitItr func(ctx context.Context) (*doltdb.Commit, bool, error)
+
+// Return an iterator over the commits returned by
+// GetTopologicalOrderCommits, which loads each commit's parents as
+// the commit is returned.
+func GetTopologicalOrderIterator(ctx context.Context, ddb *doltdb.DoltDB, startCommitHash hash.Hash) (CommitItr, error) {
+	q := newQueue(ddb)
+	if err := q.AddPendingIfUnseen(ctx, startCommitHash); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) (*doltdb.Commit, bool, error) {
+		if q.NumVisiblePending() == 0 {
+			return nil, false, nil
+		}
+		nextC := q.PopPending()
+		parents, err := nextC.commit.ParentHashes(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, parentID := range parents {
+			if err := q.AddPendingIfUnseen(ctx, parentID); err != nil {
+				return nil, false, err
+			}
+		}
+		return nextC.commit, true, nil
+	}, nil
+}
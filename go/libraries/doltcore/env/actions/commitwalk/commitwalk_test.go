@@ -135,6 +135,52 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assert.Equal(t, featureCommits[1], res[2])
 }
 
+func TestGetTopologicalOrderCommits(t *testing.T) {
+	env := createUninitializedEnv()
+	err := env.InitRepo(context.Background(), types.Format_LD_1, "Bill Billerson", "bill@billerson.com")
+	require.NoError(t, err)
+
+	cs, err := doltdb.NewCommitSpec("HEAD", "master")
+	require.NoError(t, err)
+	commit, err := env.DoltDB.Resolve(context.Background(), cs)
+	require.NoError(t, err)
+
+	rv, err := commit.GetRootValue()
+	require.NoError(t, err)
+	rvh, err := env.DoltDB.WriteRootValue(context.Background(), rv)
+	require.NoError(t, err)
+
+	// Create 2 commits on master, then 2 commits on each of master and feature, and merge them.
+	//
+	//          feature:  *--*
+	//                   /    \
+	// master: *--*--*--*--*---*
+	masterCommits := []*doltdb.Commit{commit}
+	for i := 1; i < 3; i++ {
+		masterCommits = append(masterCommits, mustCreateCommit(t, env.DoltDB, "master", rvh, masterCommits[i-1]))
+	}
+
+	featureCommits := []*doltdb.Commit{mustCreateCommit(t, env.DoltDB, "feature", rvh, masterCommits[2])}
+	featureCommits = append(featureCommits, mustCreateCommit(t, env.DoltDB, "feature", rvh, featureCommits[0]))
+
+	masterCommits = append(masterCommits, mustCreateCommit(t, env.DoltDB, "master", rvh, masterCommits[2]))
+	masterCommits = append(masterCommits, mustCreateCommit(t, env.DoltDB, "master", rvh, masterCommits[3], featureCommits[1]))
+
+	res, err := GetTopologicalOrderCommits(context.Background(), env.DoltDB, mustGetHash(t, masterCommits[4]))
+	require.NoError(t, err)
+	require.Len(t, res, 7)
+	assert.Equal(t, masterCommits[4], res[0])
+	assert.ElementsMatch(t, []*doltdb.Commit{masterCommits[3], featureCommits[0], featureCommits[1]}, res[1:4])
+	assert.NotEqual(t, featureCommits[0], res[1], "a commit must come after its children")
+	assert.Equal(t, masterCommits[2], res[4])
+	assert.Equal(t, masterCommits[1], res[5])
+	assert.Equal(t, masterCommits[0], res[6])
+
+	res, err = GetTopologicalOrderCommits(context.Background(), env.DoltDB, mustGetHash(t, featureCommits[0]))
+	require.NoError(t, err)
+	assert.Equal(t, []*doltdb.Commit{featureCommits[0], masterCommits[2], masterCommits[1], masterCommits[0]}, res)
+}
+
 func mustCreateCommit(t *testing.T, ddb *doltdb.DoltDB, bn string, rvh hash.Hash, parents ...*doltdb.Commit) *doltdb.Commit {
 	cm, err := doltdb.NewCommitMeta("Bill Billerson", "bill@billerson.com", "A New Commit.")
 	require.NoError(t, err)
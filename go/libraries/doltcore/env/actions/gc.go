@@ -0,0 +1,46 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// GarbageCollect removes every value from the repository's database which isn't reachable from a ref, from the working
+// or staged roots, or from the state of a merge, cherry-pick, rebase or revert in progress.
+func GarbageCollect(ctx context.Context, dEnv *env.DoltEnv) error {
+	rootStrs := []string{dEnv.RepoState.Working, dEnv.RepoState.Staged}
+
+	if dEnv.RepoState.Merge != nil {
+		rootStrs = append(rootStrs, dEnv.RepoState.Merge.Commit, dEnv.RepoState.Merge.PreMergeWorking)
+	}
+
+	if dEnv.RepoState.Replay != nil {
+		rootStrs = append(rootStrs, dEnv.RepoState.Replay.PreReplayHead)
+		rootStrs = append(rootStrs, dEnv.RepoState.Replay.Commits...)
+	}
+
+	var extraRoots []hash.Hash
+	for _, s := range rootStrs {
+		if h, ok := hash.MaybeParse(s); ok {
+			extraRoots = append(extraRoots, h)
+		}
+	}
+
+	return dEnv.DoltDB.GC(ctx, extraRoots...)
+}
@@ -16,19 +16,46 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
 )
 
-func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, cm1, cm2 *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
+// MergeCommits merges cm2 into cm1, using the strategies given to merge tables changed in both. Tables are merged
+// cell-wise if strategies is nil.
+func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, cm1, cm2 *doltdb.Commit, strategies merge.StrategyForTable) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
 	merger, err := merge.NewMerger(ctx, cm1, cm2, ddb.ValueReadWriter())
 
 	if err != nil {
 		return nil, nil, err
 	}
 
+	merger.SetStrategies(strategies)
+
+	return mergeAllTables(ctx, ddb, merger, cm1, cm2)
+}
+
+// ConfigMergeStrategies returns the merge strategies of tables set in the config of the environment given. Tables
+// without a strategy in the config are merged cell-wise.
+func ConfigMergeStrategies(dEnv *env.DoltEnv) merge.StrategyForTable {
+	return func(tblName string) (merge.Strategy, error) {
+		name := dEnv.Config.GetStringOrDefault(env.MergeStrategyKeyPrefix+tblName, string(merge.CellWise))
+		strategy, err := merge.ParseStrategy(*name)
+
+		if err != nil {
+			return "", fmt.Errorf("invalid config %s%s: %v", env.MergeStrategyKeyPrefix, tblName, err)
+		}
+
+		return strategy, nil
+	}
+}
+
+// mergeAllTables uses the merger given to merge every table and foreign key in either of the commits given, and returns
+// the root of cm1 with the merged tables. Rows of the merged tables which violate a foreign key are recorded as foreign
+// key violations of their tables.
+func mergeAllTables(ctx context.Context, ddb *doltdb.DoltDB, merger *merge.Merger, cm1, cm2 *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
 	root, err := cm1.GetRootValue()
 
 	if err != nil {
@@ -41,6 +68,12 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, cm1, cm2 *doltdb.Comm
 		return nil, nil, err
 	}
 
+	return mergeAllRoots(ctx, ddb, merger, root, rv)
+}
+
+// mergeAllRoots uses the merger given to merge every table and foreign key in either of the roots given, as
+// mergeAllTables does for commits.
+func mergeAllRoots(ctx context.Context, ddb *doltdb.DoltDB, merger *merge.Merger, root, rv *doltdb.RootValue) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
 	tblNames, err := AllTables(ctx, root, rv)
 
 	if err != nil {
@@ -75,11 +108,21 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, cm1, cm2 *doltdb.Comm
 			if err != nil {
 				return nil, nil, err
 			}
-		} else {
-			panic("?")
 		}
 	}
 
+	root, err = merger.MergeForeignKeys(ctx, root)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err = merge.ConflictForeignKeyViolations(ctx, ddb, root, tblToStats)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return root, tblToStats, nil
 }
 
@@ -92,3 +92,73 @@ func Fetch(ctx context.Context, destRef ref.DoltRef, srcDB, destDB *doltdb.DoltD
 
 	return destDB.FastForward(ctx, destRef, commit)
 }
+
+// PushTag pushes a tag, and the commit it refers to, to a destination database. Tags can't be moved once they're
+// created, so this returns doltdb.ErrTagExists if destDB already has a different tag named destRef.
+func PushTag(ctx context.Context, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress) error {
+	isNew, err := canSetTag(ctx, destDB, destRef, tag)
+
+	if err != nil {
+		return err
+	} else if !isNew {
+		return doltdb.ErrUpToDate
+	}
+
+	err = destDB.PushTagChunks(ctx, srcDB, tag, progChan)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.SetTag(ctx, destRef, tag)
+}
+
+// FetchTag copies a tag, and the commit it refers to, from a source database to a destination database, keeping the
+// tag's name. Returns doltdb.ErrTagExists if destDB already has a different tag with the same name.
+func FetchTag(ctx context.Context, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress) error {
+	isNew, err := canSetTag(ctx, destDB, tag.GetRef(), tag)
+
+	if err != nil {
+		return err
+	} else if !isNew {
+		return doltdb.ErrUpToDate
+	}
+
+	err = destDB.PullTagChunks(ctx, srcDB, tag, progChan)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.SetTag(ctx, tag.GetRef(), tag)
+}
+
+// canSetTag returns whether the tag given is new to the database given. Returns doltdb.ErrTagExists if the database
+// already has a different tag with the same ref.
+func canSetTag(ctx context.Context, ddb *doltdb.DoltDB, tagRef ref.TagRef, tag *doltdb.Tag) (bool, error) {
+	existing, err := ddb.ResolveTag(ctx, tagRef)
+
+	if err == doltdb.ErrTagNotFound {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	existingHash, err := existing.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	h, err := tag.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	if existingHash != h {
+		return false, doltdb.ErrTagExists
+	}
+
+	return false, nil
+}
@@ -0,0 +1,471 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+)
+
+const (
+	CherryPickOperation = "cherry-pick"
+	RebaseOperation     = "rebase"
+	RevertOperation     = "revert"
+)
+
+var ErrMergeActive = errors.New("a merge is in progress")
+var ErrReplayActive = errors.New("a cherry-pick, rebase or revert is in progress")
+var ErrNoReplayActive = errors.New("no cherry-pick, rebase or revert in progress")
+var ErrLocalChanges = errors.New("local changes would be overwritten")
+var ErrReplayConflicts = errors.New("conflicts replaying commit")
+var ErrUnstagedChanges = errors.New("changes must be staged before continuing")
+var ErrNoParent = errors.New("commit has no parent to compare it to")
+
+// CherryPick applies the changes made by the commit given to the current branch, and commits them with the commit's
+// author and message. Returns ErrReplayConflicts if applying the changes resulted in conflicts, in which case the
+// working root holds the conflicts to be resolved before calling ContinueReplay.
+func CherryPick(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) (map[string]*merge.MergeStats, error) {
+	head, err := checkCanReplay(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return replayCommits(ctx, dEnv, CherryPickOperation, []string{h.String()}, headHash.String())
+}
+
+// Rebase replays the commits of the current branch which aren't in the history of upstream on top of upstream, and
+// points the current branch at the result. Only the first-parent history of the branch is replayed, so merge commits
+// become ordinary commits. Returns doltdb.ErrUpToDate if upstream is already in the history of the branch, and
+// ErrReplayConflicts if replaying a commit resulted in conflicts. If replaying fails for any other reason the branch is
+// left pointing at the commit it pointed to before the rebase.
+func Rebase(ctx context.Context, dEnv *env.DoltEnv, upstream *doltdb.Commit) (map[string]*merge.MergeStats, error) {
+	head, err := checkCanReplay(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isAnc, err := isAncestor(ctx, upstream, head); err != nil {
+		return nil, err
+	} else if isAnc {
+		return nil, doltdb.ErrUpToDate
+	}
+
+	commits, err := commitsToReplay(ctx, dEnv.DoltDB, head, upstream)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the rebase is recorded before the branch is moved, so that it can be aborted if it is interrupted
+	err = dEnv.RepoState.StartReplay(RebaseOperation, commits, headHash.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = resetHead(ctx, dEnv, upstream)
+
+	if err != nil {
+		return nil, restoreOnErr(ctx, dEnv, headHash.String(), err)
+	}
+
+	return replayCommits(ctx, dEnv, RebaseOperation, commits, headHash.String())
+}
+
+// ContinueReplay commits the staged root in place of the commit whose conflicts stopped a cherry-pick, rebase or revert,
+// then replays the commits that remain.
+func ContinueReplay(ctx context.Context, dEnv *env.DoltEnv) (map[string]*merge.MergeStats, error) {
+	if !dEnv.IsReplayActive() {
+		return nil, ErrNoReplayActive
+	}
+
+	inConflict, err := dEnv.GetTablesWithConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	} else if len(inConflict) > 0 {
+		return nil, NewTblInConflictError(inConflict)
+	}
+
+	if dEnv.RepoState.Working != dEnv.RepoState.Staged {
+		return nil, ErrUnstagedChanges
+	}
+
+	replay := dEnv.RepoState.Replay
+	cm, err := resolveCommitHash(ctx, dEnv, replay.Commits[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = commitReplayed(ctx, dEnv, replay.Operation, staged, cm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return replayCommits(ctx, dEnv, replay.Operation, replay.Commits[1:], replay.PreReplayHead)
+}
+
+// AbortReplay points the current branch back at the commit it pointed to before the cherry-pick, rebase or revert in
+// progress started, and resets the working and staged roots to that commit.
+func AbortReplay(ctx context.Context, dEnv *env.DoltEnv) error {
+	if !dEnv.IsReplayActive() {
+		return ErrNoReplayActive
+	}
+
+	return restorePreReplayHead(ctx, dEnv, dEnv.RepoState.Replay.PreReplayHead)
+}
+
+// restorePreReplayHead points the current branch back at the commit with the hash given, resets the working and staged
+// roots to that commit and clears any cherry-pick, rebase or revert in progress.
+func restorePreReplayHead(ctx context.Context, dEnv *env.DoltEnv, preReplayHead string) error {
+	cm, err := resolveCommitHash(ctx, dEnv, preReplayHead)
+
+	if err != nil {
+		return err
+	}
+
+	err = resetHead(ctx, dEnv, cm)
+
+	if err != nil {
+		return err
+	}
+
+	if dEnv.IsReplayActive() {
+		return dEnv.RepoState.ClearReplay()
+	}
+
+	return nil
+}
+
+// restoreOnErr restores the branch to the commit it pointed to before replaying started when replaying fails with an
+// error other than ErrReplayConflicts, so that a failure part way through doesn't leave the branch with only some of the
+// commits replayed. Returns the error given, or the error restoring the branch if that fails.
+func restoreOnErr(ctx context.Context, dEnv *env.DoltEnv, preReplayHead string, err error) error {
+	if err == nil || err == ErrReplayConflicts {
+		return err
+	}
+
+	if restoreErr := restorePreReplayHead(ctx, dEnv, preReplayHead); restoreErr != nil {
+		return restoreErr
+	}
+
+	return err
+}
+
+// checkCanReplay returns the head commit of the current branch if a cherry-pick, rebase or revert can be started, and
+// an error otherwise.
+func checkCanReplay(ctx context.Context, dEnv *env.DoltEnv) (*doltdb.Commit, error) {
+	if dEnv.IsMergeActive() {
+		return nil, ErrMergeActive
+	} else if dEnv.IsReplayActive() {
+		return nil, ErrReplayActive
+	}
+
+	if isUnchanged, err := dEnv.IsUnchangedFromHead(ctx); err != nil {
+		return nil, err
+	} else if !isUnchanged {
+		return nil, ErrLocalChanges
+	}
+
+	return dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+}
+
+// replayCommits replays each of the commits given onto the current branch in order, stopping at the first commit
+// whose changes conflict with the branch and recording the commits left to replay in the repo state. If replaying fails
+// for any other reason the branch is restored to preReplayHead.
+func replayCommits(ctx context.Context, dEnv *env.DoltEnv, operation string, commits []string, preReplayHead string) (map[string]*merge.MergeStats, error) {
+	tblToStats, err := replayEach(ctx, dEnv, operation, commits, preReplayHead)
+	err = restoreOnErr(ctx, dEnv, preReplayHead, err)
+
+	if err != nil && err != ErrReplayConflicts {
+		return nil, err
+	}
+
+	return tblToStats, err
+}
+
+func replayEach(ctx context.Context, dEnv *env.DoltEnv, operation string, commits []string, preReplayHead string) (map[string]*merge.MergeStats, error) {
+	var tblToStats map[string]*merge.MergeStats
+	for i, h := range commits {
+		cm, err := resolveCommitHash(ctx, dEnv, h)
+
+		if err != nil {
+			return nil, err
+		}
+
+		head, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+
+		if err != nil {
+			return nil, err
+		}
+
+		var root *doltdb.RootValue
+		if operation == RevertOperation {
+			root, tblToStats, err = RevertCommit(ctx, dEnv.DoltDB, head, cm)
+		} else {
+			root, tblToStats, err = ReplayCommit(ctx, dEnv.DoltDB, head, cm)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hasConflicts(tblToStats) {
+			err = dEnv.UpdateWorkingRoot(ctx, root)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = dEnv.RepoState.StartReplay(operation, commits[i:], preReplayHead)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return tblToStats, ErrReplayConflicts
+		}
+
+		err = commitReplayed(ctx, dEnv, operation, root, cm)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dEnv.IsReplayActive() {
+		return tblToStats, dEnv.RepoState.ClearReplay()
+	}
+
+	return tblToStats, nil
+}
+
+// ReplayCommit merges the changes made by the commit given into the root of onto, using the commit's first parent as
+// the merge ancestor. Conflicts are recorded in the tables of the root returned.
+func ReplayCommit(ctx context.Context, ddb *doltdb.DoltDB, onto, cm *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, nil, err
+	} else if numParents == 0 {
+		return nil, nil, ErrNoParent
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merger := merge.NewMergerWithAncestor(onto, cm, parent, ddb.ValueReadWriter())
+
+	return mergeAllTables(ctx, ddb, merger, onto, cm)
+}
+
+// commitReplayed commits the root given to the current branch for the commit given. Replayed commits keep the author and
+// message of the original, and reverts are authored by the user with a generated message. Nothing is committed if the
+// root is the same as the root of the head of the branch, as happens when the commit's changes were already made on the
+// branch.
+func commitReplayed(ctx context.Context, dEnv *env.DoltEnv, operation string, root *doltdb.RootValue, cm *doltdb.Commit) error {
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	h, err := dEnv.UpdateStagedRoot(ctx, root)
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, root)
+
+	if err != nil || h == headHash {
+		return err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return err
+	}
+
+	name, email, msg := meta.Name, meta.Email, meta.Description
+	if operation == RevertOperation {
+		name, email, err = getNameAndEmail(dEnv.Config)
+
+		if err != nil {
+			return err
+		}
+
+		msg, err = revertMessage(cm, meta)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	replayedMeta, err := doltdb.NewCommitMeta(name, email, msg)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dEnv.DoltDB.Commit(ctx, h, dEnv.RepoState.CWBHeadRef(), replayedMeta)
+
+	return err
+}
+
+// resetHead points the current branch at the commit given and sets the working and staged roots to its root.
+func resetHead(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) error {
+	err := dEnv.DoltDB.NewBranchAtCommit(ctx, dEnv.RepoState.CWBHeadRef(), cm)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, root)
+
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateWorkingRoot(ctx, root)
+}
+
+// commitsToReplay returns the hashes of the commits in the first-parent history of head which aren't in the history of
+// upstream, oldest first.
+func commitsToReplay(ctx context.Context, ddb *doltdb.DoltDB, head, upstream *doltdb.Commit) ([]string, error) {
+	var commits []string
+	for cm := head; ; {
+		if isAnc, err := isAncestor(ctx, cm, upstream); err != nil {
+			return nil, err
+		} else if isAnc {
+			break
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append([]string{h.String()}, commits...)
+
+		numParents, err := cm.NumParents()
+
+		if err != nil {
+			return nil, err
+		} else if numParents == 0 {
+			break
+		}
+
+		cm, err = ddb.ResolveParent(ctx, cm, 0)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return commits, nil
+}
+
+// isAncestor returns whether cm is in the history of other, which includes other itself.
+func isAncestor(ctx context.Context, cm, other *doltdb.Commit) (bool, error) {
+	anc, err := doltdb.GetCommitAncestor(ctx, cm, other)
+
+	if err != nil {
+		return false, err
+	}
+
+	ancHash, err := anc.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return ancHash == h, nil
+}
+
+func resolveCommitHash(ctx context.Context, dEnv *env.DoltEnv, h string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(h, dEnv.RepoState.CWBHeadRef().String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dEnv.DoltDB.Resolve(ctx, cs)
+}
+
+func hasConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Conflicts > 0 || stats.SchemaConflicts > 0 {
+			return true
+		}
+	}
+
+	return false
+}
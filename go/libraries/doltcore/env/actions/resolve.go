@@ -52,6 +52,7 @@ func AutoResolveTables(ctx context.Context, dEnv *env.DoltEnv, autoResolver merg
 }
 
 func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, autoResolver merge.AutoResolver, tbls []string) error {
+	resolved := false
 	for _, tblName := range tbls {
 		tbl, ok, err := root.GetTable(ctx, tblName)
 
@@ -65,15 +66,77 @@ func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue,
 
 		updatedTbl, err := merge.ResolveTable(ctx, root.VRW(), tbl, autoResolver)
 
+		if err == doltdb.ErrNoConflicts {
+			// the table may be in conflict only because of its schema
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		root, err = root.PutTable(ctx, dEnv.DoltDB, tblName, updatedTbl)
+
+		if err != nil {
+			return err
+		}
+
+		resolved = true
+	}
+
+	if !resolved {
+		return doltdb.ErrNoConflicts
+	}
+
+	return dEnv.UpdateWorkingRoot(ctx, root)
+}
+
+// ResolveSchemaConflicts resolves the schema conflicts of the tables given, or of every table when tbls is nil, keeping
+// our column definitions or taking theirs. Returns doltdb.ErrNoConflicts if none of the tables have schema conflicts.
+func ResolveSchemaConflicts(ctx context.Context, dEnv *env.DoltEnv, tbls []string, theirs bool) error {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if tbls == nil {
+		tbls, err = root.TablesInConflict(ctx)
+
 		if err != nil {
 			return err
 		}
+	}
+
+	resolved := false
+	for _, tblName := range tbls {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return doltdb.ErrTableNotFound
+		}
+
+		updatedTbl, err := merge.ResolveSchemaConflicts(ctx, dEnv.DoltDB, tbl, theirs)
+
+		if err == doltdb.ErrNoConflicts {
+			continue
+		} else if err != nil {
+			return err
+		}
 
 		root, err = root.PutTable(ctx, dEnv.DoltDB, tblName, updatedTbl)
 
 		if err != nil {
 			return err
 		}
+
+		resolved = true
+	}
+
+	if !resolved {
+		return doltdb.ErrNoConflicts
 	}
 
 	return dEnv.UpdateWorkingRoot(ctx, root)
@@ -0,0 +1,83 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+)
+
+// Revert undoes the changes made by the commit given on the current branch, and commits the result with a generated
+// message. Returns ErrReplayConflicts if commits made since the commit given changed the same rows, in which case the
+// working root holds the conflicts to be resolved before calling ContinueReplay.
+func Revert(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) (map[string]*merge.MergeStats, error) {
+	head, err := checkCanReplay(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return replayCommits(ctx, dEnv, RevertOperation, []string{h.String()}, headHash.String())
+}
+
+// RevertCommit merges the inverse of the changes made by the commit given into the root of onto. The inverse is the
+// difference between the commit and its first parent, so the merge uses the commit as the ancestor and the parent as
+// the commit being merged. Schema changes are inverted along with row changes, and rows changed by onto since the
+// commit are recorded as conflicts in the tables of the root returned.
+func RevertCommit(ctx context.Context, ddb *doltdb.DoltDB, onto, cm *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, error) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, nil, err
+	} else if numParents == 0 {
+		return nil, nil, ErrNoParent
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merger := merge.NewMergerWithAncestor(onto, parent, cm, ddb.ValueReadWriter())
+
+	return mergeAllTables(ctx, ddb, merger, onto, parent)
+}
+
+func revertMessage(cm *doltdb.Commit, meta *doltdb.CommitMeta) (string, error) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", meta.Description, h.String()), nil
+}
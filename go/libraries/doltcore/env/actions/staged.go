@@ -59,6 +59,16 @@ func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *
 		return err
 	}
 
+	// Foreign key violations found by a merge are resolved by fixing the rows in violation, so clear the ones which
+	// have been fixed before checking for conflicts.
+	for _, tblName := range tbls {
+		working, err = working.RefreshForeignKeyViolations(ctx, tblName)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	if !allowConflicts {
 		var inConflict []string
 		for _, tblName := range tbls {
@@ -74,6 +84,14 @@ func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *
 				if !allowConflicts {
 					inConflict = append(inConflict, tblName)
 				}
+			} else if has, err := tbl.HasForeignKeyViolations(); err != nil {
+				return err
+			} else if has {
+				inConflict = append(inConflict, tblName)
+			} else if has, err := tbl.HasSchemaConflicts(); err != nil {
+				return err
+			} else if has {
+				inConflict = append(inConflict, tblName)
 			}
 		}
 
@@ -0,0 +1,378 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+// stashRefPrefix is the prefix of the paths of the internal refs of stashes, which are followed by a number that
+// increases with each stash.
+const stashRefPrefix = "stash/"
+
+var ErrNoLocalChanges = errors.New("no local changes to save")
+var ErrStashNotFound = errors.New("stash not found")
+var ErrStashConflicts = errors.New("conflicts applying stash")
+
+// Stash is an entry of the list of stashed changes. The stash's ref points at a commit of the stashed working root,
+// whose parent is a commit of the stashed staged root, whose parent is the commit that was the head of the branch when
+// the changes were stashed.
+type Stash struct {
+	// Index is the position of the stash in the list of stashes, where 0 is the most recent.
+	Index int
+	Ref   ref.DoltRef
+
+	Working *doltdb.Commit
+	Staged  *doltdb.Commit
+	Head    *doltdb.Commit
+}
+
+// Name returns the name of the stash used to refer to it on the command line, e.g. stash@{0}.
+func (s *Stash) Name() string {
+	return fmt.Sprintf("stash@{%d}", s.Index)
+}
+
+// Description returns the message the stash was made with.
+func (s *Stash) Description() (string, error) {
+	meta, err := s.Working.GetCommitMeta()
+
+	if err != nil {
+		return "", err
+	}
+
+	return meta.Description, nil
+}
+
+// StashChanges saves the working and staged roots as a new stash, and resets them to the root of the head of the current
+// branch. The stash is described by the message given, or by the head commit if the message is empty. Returns
+// ErrNoLocalChanges if the working and staged roots are the same as the head's.
+func StashChanges(ctx context.Context, dEnv *env.DoltEnv, msg string) error {
+	if dEnv.IsMergeActive() {
+		return ErrMergeActive
+	} else if dEnv.IsReplayActive() {
+		return ErrReplayActive
+	}
+
+	if isUnchanged, err := dEnv.IsUnchangedFromHead(ctx); err != nil {
+		return err
+	} else if isUnchanged {
+		return ErrNoLocalChanges
+	}
+
+	name, email, err := getNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return err
+	}
+
+	head, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+
+	if err != nil {
+		return err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	branch := dEnv.RepoState.CWBHeadRef().GetPath()
+	if msg == "" {
+		headMeta, err := head.GetCommitMeta()
+
+		if err != nil {
+			return err
+		}
+
+		msg = fmt.Sprintf("WIP on %s: %s %s", branch, headHash.String(), headMeta.Description)
+	} else {
+		msg = fmt.Sprintf("On %s: %s", branch, msg)
+	}
+
+	stashes, err := GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return err
+	}
+
+	num := 0
+	if len(stashes) > 0 {
+		num = stashNumber(stashes[0].Ref) + 1
+	}
+
+	stashRef := ref.NewInternalRef(stashRefPrefix + strconv.Itoa(num))
+
+	staged, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	headSpec, err := doltdb.NewCommitSpec(headHash.String(), dEnv.RepoState.CWBHeadRef().String())
+
+	if err != nil {
+		return err
+	}
+
+	// The commit of the staged root is made first, so that it becomes the parent of the commit of the working root
+	for _, r := range []struct {
+		root    *doltdb.RootValue
+		parents []*doltdb.CommitSpec
+		msg     string
+	}{
+		{staged, []*doltdb.CommitSpec{headSpec}, "index on " + branch},
+		{working, nil, msg},
+	} {
+		h, err := dEnv.DoltDB.WriteRootValue(ctx, r.root)
+
+		if err != nil {
+			return err
+		}
+
+		meta, err := doltdb.NewCommitMeta(name, email, r.msg)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = dEnv.DoltDB.CommitWithParents(ctx, h, stashRef, r.parents, meta)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	headRoot, err := head.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, headRoot)
+
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateWorkingRoot(ctx, headRoot)
+}
+
+// GetStashes returns the stashes in the database, most recent first.
+func GetStashes(ctx context.Context, ddb *doltdb.DoltDB) ([]*Stash, error) {
+	refs, err := ddb.GetInternalRefs(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stashRefs []ref.DoltRef
+	for _, r := range refs {
+		if stashNumber(r) >= 0 {
+			stashRefs = append(stashRefs, r)
+		}
+	}
+
+	sort.Slice(stashRefs, func(i, j int) bool {
+		return stashNumber(stashRefs[i]) > stashNumber(stashRefs[j])
+	})
+
+	stashes := make([]*Stash, len(stashRefs))
+	for i, r := range stashRefs {
+		stashes[i], err = resolveStash(ctx, ddb, i, r)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stashes, nil
+}
+
+// GetStash returns the stash at the index given of the list of stashes, where 0 is the most recent. Returns
+// ErrStashNotFound if there is no such stash.
+func GetStash(ctx context.Context, ddb *doltdb.DoltDB, index int) (*Stash, error) {
+	stashes, err := GetStashes(ctx, ddb)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(stashes) {
+		return nil, ErrStashNotFound
+	}
+
+	return stashes[index], nil
+}
+
+// ParseStashName returns the index of the stash with the name given, which is either a name like stash@{1} or just the
+// index.
+func ParseStashName(name string) (int, error) {
+	idxStr := name
+	if strings.HasPrefix(name, "stash@{") && strings.HasSuffix(name, "}") {
+		idxStr = name[len("stash@{") : len(name)-1]
+	}
+
+	idx, err := strconv.Atoi(idxStr)
+
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("'%s' is not a valid stash", name)
+	}
+
+	return idx, nil
+}
+
+// ApplyStash merges the changes saved by the stash at the index given into the working root, using the commit the
+// changes were made on as the merge ancestor. Changes which were staged when they were stashed are applied to the working
+// root only. Returns ErrStashConflicts if the changes conflict with the working root, in which case the conflicts are
+// recorded in its tables.
+func ApplyStash(ctx context.Context, dEnv *env.DoltEnv, index int) (map[string]*merge.MergeStats, error) {
+	if dEnv.IsMergeActive() {
+		return nil, ErrMergeActive
+	} else if dEnv.IsReplayActive() {
+		return nil, ErrReplayActive
+	}
+
+	stash, err := GetStash(ctx, dEnv.DoltDB, index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if inConflict, err := working.TablesInConflict(ctx); err != nil {
+		return nil, err
+	} else if len(inConflict) > 0 {
+		return nil, NewTblInConflictError(inConflict)
+	}
+
+	stashRoot, err := stash.Working.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ancRoot, err := stash.Head.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	merger := merge.NewRootMerger(working, stashRoot, ancRoot, dEnv.DoltDB.ValueReadWriter())
+	root, tblToStats, err := mergeAllRoots(ctx, dEnv.DoltDB, merger, working, stashRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasConflicts(tblToStats) {
+		return tblToStats, ErrStashConflicts
+	}
+
+	return tblToStats, nil
+}
+
+// PopStash applies the stash at the index given as ApplyStash does, and drops it if its changes were applied without
+// conflicts.
+func PopStash(ctx context.Context, dEnv *env.DoltEnv, index int) (map[string]*merge.MergeStats, error) {
+	tblToStats, err := ApplyStash(ctx, dEnv, index)
+
+	if err != nil {
+		return tblToStats, err
+	}
+
+	return tblToStats, DropStash(ctx, dEnv.DoltDB, index)
+}
+
+// DropStash removes the stash at the index given from the list of stashes.
+func DropStash(ctx context.Context, ddb *doltdb.DoltDB, index int) error {
+	stash, err := GetStash(ctx, ddb, index)
+
+	if err != nil {
+		return err
+	}
+
+	return ddb.DeleteInternalRef(ctx, stash.Ref)
+}
+
+func resolveStash(ctx context.Context, ddb *doltdb.DoltDB, index int, stashRef ref.DoltRef) (*Stash, error) {
+	cs, err := doltdb.NewCommitSpec(stashRef.String(), "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := ddb.Resolve(ctx, cs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := ddb.ResolveParent(ctx, working, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := ddb.ResolveParent(ctx, staged, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{Index: index, Ref: stashRef, Working: working, Staged: staged, Head: head}, nil
+}
+
+// stashNumber returns the number of the stash ref given, or -1 if it isn't the ref of a stash.
+func stashNumber(r ref.DoltRef) int {
+	if !strings.HasPrefix(r.GetPath(), stashRefPrefix) {
+		return -1
+	}
+
+	num, err := strconv.Atoi(r.GetPath()[len(stashRefPrefix):])
+
+	if err != nil || num < 0 {
+		return -1
+	}
+
+	return num
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+// CreateTag creates a tag named tagName of the commit that startPoint resolves to. The tagger is read from the
+// user.name and user.email config values, and msg is stored as the tag's message.
+func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint, msg string) error {
+	if !doltdb.IsValidUserTagName(tagName) {
+		return doltdb.ErrInvTagName
+	}
+
+	tagRef := ref.NewTagRef(tagName)
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	} else if hasRef {
+		return doltdb.ErrTagExists
+	}
+
+	name, email, err := getNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := doltdb.NewTagMeta(name, email, msg)
+
+	if err != nil {
+		return err
+	}
+
+	cs, err := doltdb.NewCommitSpec(startPoint, dEnv.RepoState.Head.Ref.String())
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dEnv.DoltDB.NewTagAtCommit(ctx, tagRef, cm, meta)
+	return err
+}
+
+// DeleteTag deletes the tag named tagName.
+func DeleteTag(ctx context.Context, dEnv *env.DoltEnv, tagName string) error {
+	return dEnv.DoltDB.DeleteTag(ctx, ref.NewTagRef(tagName))
+}
+
+// GetTags returns all tags in the repository, sorted by name.
+func GetTags(ctx context.Context, ddb *doltdb.DoltDB) ([]*doltdb.Tag, error) {
+	tagRefs, err := ddb.GetTags(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tagRefs, func(i, j int) bool {
+		return tagRefs[i].GetPath() < tagRefs[j].GetPath()
+	})
+
+	tags := make([]*doltdb.Tag, 0, len(tagRefs))
+	for _, tagRef := range tagRefs {
+		tag, err := ddb.ResolveTag(ctx, tagRef)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -47,6 +47,10 @@ const (
 	MetricsHost     = "metrics.host"
 	MetricsPort     = "metrics.port"
 	MetricsInsecure = "metrics.insecure"
+
+	// MergeStrategyKeyPrefix is the prefix of the keys of the merge strategies of tables. The strategy used to merge the
+	// table people is set by merge.strategy.people.
+	MergeStrategyKeyPrefix = "merge.strategy."
 )
 
 var LocalConfigWhitelist = set.NewStrSet([]string{UserNameKey, UserEmailKey})
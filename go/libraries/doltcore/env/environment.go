@@ -251,7 +251,43 @@ func (dEnv *DoltEnv) WorkingRoot(ctx context.Context) (*doltdb.RootValue, error)
 	return dEnv.DoltDB.ReadRootValue(ctx, h)
 }
 
+// ReloadWorkingRoot reloads the repo state from its file and returns the working root it names, so that changes made to
+// the working root by other processes are seen.
+func (dEnv *DoltEnv) ReloadWorkingRoot(ctx context.Context) (*doltdb.RootValue, error) {
+	rs, err := LoadRepoState(dEnv.FS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.DoltDB.Rebase(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the repo state is updated in place, as it's shared with the things it was given to
+	*dEnv.RepoState = *rs
+
+	return dEnv.WorkingRoot(ctx)
+}
+
+// UpdateWorkingRoot writes the root given and makes it the working root. Returns a doltdb.ForeignKeyViolationError if
+// the root has a row violating one of its foreign keys, and the foreign key or one of its tables has changed since the
+// current working root.
 func (dEnv *DoltEnv) UpdateWorkingRoot(ctx context.Context, newRoot *doltdb.RootValue) error {
+	prevRoot, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = newRoot.ValidateForeignKeys(ctx, prevRoot)
+
+	if err != nil {
+		return err
+	}
+
 	h, err := dEnv.DoltDB.WriteRootValue(ctx, newRoot)
 
 	if err != nil {
@@ -323,6 +359,17 @@ func (dEnv *DoltEnv) PutTableToWorking(ctx context.Context, rows types.Map, sch
 		return err
 	}
 
+	if existing, ok, err := root.GetTable(ctx, tableName); err != nil {
+		return err
+	} else if ok {
+		// Carry over the indexes of the table being replaced
+		tbl, err = tbl.RebuildIndexesFrom(ctx, existing)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	newRoot, err := root.PutTable(ctx, dEnv.DoltDB, tableName, tbl)
 
 	if err != nil {
@@ -348,6 +395,10 @@ func (dEnv *DoltEnv) IsMergeActive() bool {
 	return dEnv.RepoState.Merge != nil
 }
 
+func (dEnv *DoltEnv) IsReplayActive() bool {
+	return dEnv.RepoState.Replay != nil
+}
+
 func (dEnv *DoltEnv) GetTablesWithConflicts(ctx context.Context) ([]string, error) {
 	root, err := dEnv.WorkingRoot(ctx)
 
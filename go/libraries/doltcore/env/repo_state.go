@@ -34,11 +34,26 @@ type MergeState struct {
 	PreMergeWorking string             `json:"working_pre_merge"`
 }
 
+// ReplayState is the state of a cherry-pick, rebase or revert that stopped because of conflicts.
+type ReplayState struct {
+	Operation     string   `json:"operation"`
+	Commits       []string `json:"commits"`
+	PreReplayHead string   `json:"head_pre_replay"`
+}
+
+// RepoStateReader gives access to the parts of the repository state which don't live in the database, such as the
+// current working branch.
+type RepoStateReader interface {
+	CWBHeadRef() ref.DoltRef
+	CWBHeadSpec() *doltdb.CommitSpec
+}
+
 type RepoState struct {
 	Head     ref.MarshalableRef      `json:"head"`
 	Staged   string                  `json:"staged"`
 	Working  string                  `json:"working"`
 	Merge    *MergeState             `json:"merge"`
+	Replay   *ReplayState            `json:"replay"`
 	Remotes  map[string]Remote       `json:"remotes"`
 	Branches map[string]BranchConfig `json:"branches"`
 
@@ -68,7 +83,7 @@ func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
 func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 	h := hash.Hash{}
 	hashStr := h.String()
-	rs := &RepoState{ref.MarshalableRef{Ref: ref.NewBranchRef("master")}, hashStr, hashStr, nil, map[string]Remote{r.Name: r}, nil, fs}
+	rs := &RepoState{ref.MarshalableRef{Ref: ref.NewBranchRef("master")}, hashStr, hashStr, nil, nil, map[string]Remote{r.Name: r}, nil, fs}
 
 	err := rs.Save()
 
@@ -87,7 +102,7 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 		return nil, err
 	}
 
-	rs := &RepoState{ref.MarshalableRef{Ref: headRef}, hashStr, hashStr, nil, nil, nil, fs}
+	rs := &RepoState{ref.MarshalableRef{Ref: headRef}, hashStr, hashStr, nil, nil, nil, nil, fs}
 
 	err = rs.Save()
 
@@ -110,6 +125,10 @@ func (rs *RepoState) Save() error {
 	return rs.fs.WriteFile(path, data)
 }
 
+func (rs *RepoState) CWBHeadRef() ref.DoltRef {
+	return rs.Head.Ref
+}
+
 func (rs *RepoState) CWBHeadSpec() *doltdb.CommitSpec {
 	spec, _ := doltdb.NewCommitSpec("HEAD", rs.Head.Ref.String())
 
@@ -131,6 +150,18 @@ func (rs *RepoState) ClearMerge() error {
 	return rs.Save()
 }
 
+// StartReplay records that the operation given stopped with the commits given left to replay, the first of which is the
+// commit whose conflicts are being resolved. preReplayHead is the commit the branch pointed to before the operation.
+func (rs *RepoState) StartReplay(operation string, commits []string, preReplayHead string) error {
+	rs.Replay = &ReplayState{operation, commits, preReplayHead}
+	return rs.Save()
+}
+
+func (rs *RepoState) ClearReplay() error {
+	rs.Replay = nil
+	return rs.Save()
+}
+
 func (rs *RepoState) AddRemote(r Remote) {
 	if rs.Remotes == nil {
 		rs.Remotes = make(map[string]Remote)
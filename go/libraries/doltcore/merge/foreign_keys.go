@@ -0,0 +1,184 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var ErrForeignKeysDiffer = errors.New("foreign key with the same name changed differently in 2 commits can't be merged")
+
+// MergeForeignKeys merges the foreign keys of the commits being merged, and sets them on the root given, which should
+// hold the merged tables. Foreign keys declared on tables which aren't in the root are left out. Returns
+// ErrForeignKeysDiffer if both commits changed a foreign key with the same name in different ways.
+func (merger *Merger) MergeForeignKeys(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
+	ourRoot, theirRoot, ancRoot, err := merger.roots()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fkMaps [3]map[string]doltdb.ForeignKey
+	for i, mergeRoot := range []*doltdb.RootValue{ourRoot, theirRoot, ancRoot} {
+		fks, err := mergeRoot.GetForeignKeys(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fkMaps[i] = make(map[string]doltdb.ForeignKey)
+		for _, fk := range fks {
+			fkMaps[i][fk.Name] = fk
+		}
+	}
+
+	fkMap, mergeFkMap, ancFkMap := fkMaps[0], fkMaps[1], fkMaps[2]
+	names := make(map[string]bool)
+	for _, m := range fkMaps {
+		for name := range m {
+			names[name] = true
+		}
+	}
+
+	rootFks, err := root.GetForeignKeys(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fk := range rootFks {
+		if root, err = root.RemoveForeignKey(ctx, fk.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	for name := range names {
+		fk, ok := fkMap[name]
+		mergeFk, mergeOk := mergeFkMap[name]
+		ancFk, ancOk := ancFkMap[name]
+
+		var merged doltdb.ForeignKey
+		var mergedOk bool
+		switch {
+		case foreignKeysEqual(fk, ok, mergeFk, mergeOk):
+			merged, mergedOk = fk, ok
+		case foreignKeysEqual(fk, ok, ancFk, ancOk):
+			merged, mergedOk = mergeFk, mergeOk
+		case foreignKeysEqual(mergeFk, mergeOk, ancFk, ancOk):
+			merged, mergedOk = fk, ok
+		default:
+			return nil, ErrForeignKeysDiffer
+		}
+
+		if !mergedOk {
+			continue
+		}
+
+		if has, err := root.HasTable(ctx, merged.Table); err != nil {
+			return nil, err
+		} else if !has {
+			continue
+		}
+
+		if root, err = root.PutForeignKey(ctx, merged); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// ConflictForeignKeyViolations finds the rows of the merged root given which violate its foreign keys, and records them
+// as the foreign key violations of their tables. These must be resolved, like conflicts, before the merge can be
+// committed. The number of violations in each table is set in its stats, and the updated root is returned.
+func ConflictForeignKeyViolations(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, tblToStats map[string]*MergeStats) (*doltdb.RootValue, error) {
+	fks, err := root.GetForeignKeys(ctx)
+
+	if err != nil || len(fks) == 0 {
+		return root, err
+	}
+
+	violations, err := root.FindForeignKeyViolations(ctx, fks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tblToEditor := make(map[string]*types.MapEditor)
+	var tblNames []string
+	for _, violation := range violations {
+		tblName := violation.ForeignKey.Table
+		ed, ok := tblToEditor[tblName]
+
+		if !ok {
+			m, err := types.NewMap(ctx, root.VRW())
+
+			if err != nil {
+				return nil, err
+			}
+
+			ed = m.Edit()
+			tblToEditor[tblName] = ed
+			tblNames = append(tblNames, tblName)
+		}
+
+		ed.Set(violation.Key, types.String(violation.ForeignKey.Name))
+	}
+
+	for _, tblName := range tblNames {
+		violationMap, err := tblToEditor[tblName].Map(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, _, err := root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if tbl, err = tbl.SetForeignKeyViolations(ctx, violationMap); err != nil {
+			return nil, err
+		}
+
+		if root, err = root.PutTable(ctx, ddb, tblName, tbl); err != nil {
+			return nil, err
+		}
+
+		stats, ok := tblToStats[tblName]
+
+		if !ok {
+			stats = &MergeStats{Operation: TableUnmodified}
+			tblToStats[tblName] = stats
+		}
+
+		stats.ForeignKeyViolations = int(violationMap.Len())
+	}
+
+	return root, nil
+}
+
+func foreignKeysEqual(fk doltdb.ForeignKey, ok bool, other doltdb.ForeignKey, otherOk bool) bool {
+	if !ok || !otherOk {
+		return ok == otherOk
+	}
+
+	return fk.Equals(other)
+}
@@ -24,20 +24,28 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
-	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrTblDeletedAndModified = errors.New("table deleted in one commit and modified in the other can't be merged")
+var ErrPrimaryKeysDiffer = errors.New("primary key changed to different columns in 2 commits can't be merged")
 
 type Merger struct {
 	commit      *doltdb.Commit
 	mergeCommit *doltdb.Commit
 	ancestor    *doltdb.Commit
 	vrw         types.ValueReadWriter
+	strategies  StrategyForTable
+
+	// the roots being merged, for mergers of roots which aren't commits
+	root      *doltdb.RootValue
+	mergeRoot *doltdb.RootValue
+	ancRoot   *doltdb.RootValue
 }
 
 func NewMerger(ctx context.Context, commit, mergeCommit *doltdb.Commit, vrw types.ValueReadWriter) (*Merger, error) {
@@ -53,23 +61,69 @@ func NewMerger(ctx context.Context, commit, mergeCommit *doltdb.Commit, vrw type
 	} else if ff {
 		return nil, ErrFastForward
 	}
-	return &Merger{commit, mergeCommit, ancestor, vrw}, nil
+	return &Merger{commit: commit, mergeCommit: mergeCommit, ancestor: ancestor, vrw: vrw}, nil
 }
 
-func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.Table, *MergeStats, error) {
-	root, err := merger.commit.GetRootValue()
+// NewMergerWithAncestor returns a Merger which merges the changes made between the ancestor and mergeCommit given into
+// commit. The ancestor need not be a common ancestor of the two commits, so this can be used to apply the changes made
+// by a single commit onto another, as cherry-pick does, by using that commit's parent as the ancestor.
+func NewMergerWithAncestor(commit, mergeCommit, ancestor *doltdb.Commit, vrw types.ValueReadWriter) *Merger {
+	return &Merger{commit: commit, mergeCommit: mergeCommit, ancestor: ancestor, vrw: vrw}
+}
 
-	if err != nil {
-		return nil, nil, err
+// NewRootMerger returns a Merger which merges the changes made between ancRoot and mergeRoot into root, for merging
+// roots which haven't been committed, such as the working root. Tables can't be merged with the
+// LastWriterByCommitTime strategy, as the roots have no commit times.
+func NewRootMerger(root, mergeRoot, ancRoot *doltdb.RootValue, vrw types.ValueReadWriter) *Merger {
+	return &Merger{root: root, mergeRoot: mergeRoot, ancRoot: ancRoot, vrw: vrw}
+}
+
+// roots returns the roots being merged: ours, theirs and the ancestor's.
+func (merger *Merger) roots() (*doltdb.RootValue, *doltdb.RootValue, *doltdb.RootValue, error) {
+	if merger.root != nil {
+		return merger.root, merger.mergeRoot, merger.ancRoot, nil
 	}
 
-	mergeRoot, err := merger.mergeCommit.GetRootValue()
+	var roots [3]*doltdb.RootValue
+	for i, cm := range []*doltdb.Commit{merger.commit, merger.mergeCommit, merger.ancestor} {
+		var err error
+		roots[i], err = cm.GetRootValue()
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return roots[0], roots[1], roots[2], nil
+}
+
+// SetStrategies sets the function giving the strategy used to merge each table. Tables are merged cell-wise if it isn't
+// set, or if it returns an empty strategy.
+func (merger *Merger) SetStrategies(strategies StrategyForTable) {
+	merger.strategies = strategies
+}
+
+// strategy returns the strategy used to merge the table with the name given.
+func (merger *Merger) strategy(tblName string) (Strategy, error) {
+	if merger.strategies == nil {
+		return CellWise, nil
+	}
+
+	strategy, err := merger.strategies(tblName)
 
 	if err != nil {
-		return nil, nil, err
+		return "", err
+	} else if strategy == "" {
+		return CellWise, nil
 	}
 
-	ancRoot, err := merger.ancestor.GetRootValue()
+	return strategy, nil
+}
+
+// MergeTable merges the table with the name given. Tables changed in both commits are merged using the table's
+// strategy, which is reported in the stats returned. The strategy is empty for tables that didn't need to be merged.
+func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.Table, *MergeStats, error) {
+	root, mergeRoot, ancRoot, err := merger.roots()
 
 	if err != nil {
 		return nil, nil, err
@@ -138,6 +192,20 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 		return mergeTbl, &MergeStats{Operation: TableModified}, nil
 	} else if mh == anch {
 		return tbl, &MergeStats{Operation: TableUnmodified}, nil
+	} else if !ok || !mergeOk {
+		return nil, nil, ErrTblDeletedAndModified
+	}
+
+	strategy, err := merger.strategy(tblName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rowStrategy, err := merger.rowStrategy(strategy)
+
+	if err != nil {
+		return nil, nil, err
 	}
 
 	tblSchema, err := tbl.GetSchema(ctx)
@@ -152,31 +220,71 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 		return nil, nil, err
 	}
 
-	schemaUnion, err := typed.TypedSchemaUnion(tblSchema, mergeTblSchema)
+	ancTblSchema, err := ancTbl.GetSchema(ctx)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	rows, err := tbl.GetRowData(ctx)
+	pkTags, err := mergePrimaryKeys(tblSchema, mergeTblSchema, ancTblSchema)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Rows of tables whose primary key differs from the merged primary key are re-keyed, so that the rows of all three
+	// tables can be compared.
+	ancSide, droppedAncKeys, err := rekeyMergeSide(ctx, merger.vrw, ancTbl, pkTags, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ancestor rows that can't be keyed by the merged primary key are left out, so rows which are unchanged from them
+	// must be too.
+	ancRowData, err := ancTbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isUnchangedDroppedRow, err := unchangedRows(ctx, ancRowData, droppedAncKeys)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	mergeRows, err := mergeTbl.GetRowData(ctx)
+	tblSide, _, err := rekeyMergeSide(ctx, merger.vrw, tbl, pkTags, isUnchangedDroppedRow)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ancRows, err := ancTbl.GetRowData(ctx)
+	mergeSide, _, err := rekeyMergeSide(ctx, merger.vrw, mergeTbl, pkTags, isUnchangedDroppedRow)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	mergedRowData, conflicts, stats, err := mergeTableData(ctx, schemaUnion, rows, mergeRows, ancRows, merger.vrw)
+	// Rows of all three tables are converted to the merged schema, so that their values can be compared.
+	sm, conformedTbl, conformedMerge, conformedAnc, err := conformMergeSides(ctx, merger.vrw, tblSide, mergeSide, ancSide)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schemaUnion := sm.sch
+	rows, mergeRows, ancRows := conformedTbl.rows, conformedMerge.rows, conformedAnc.rows
+	mergedRowData, conflicts, stats, err := mergeTableData(ctx, schemaUnion, rows, mergeRows, ancRows, merger.vrw, rowStrategy)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats.Strategy = strategy
+	stats.SchemaConflicts = len(sm.conflicts)
+
+	mergedRowData, conflicts, err = conflictInvalidRows(ctx, tbl, schemaUnion, tblSide.sch, mergedRowData, conflicts, stats, rows, mergeRows, ancRows, merger.vrw)
 
 	if err != nil {
 		return nil, nil, err
@@ -194,43 +302,235 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 		return nil, nil, err
 	}
 
+	mergedIndexes, err := mergeIndexes(ctx, tbl, mergeTbl, ancTbl, sm.theirTags)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedTable, err = mergedTable.RebuildIndexes(ctx, mergedIndexes)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if conflicts.Len() > 0 {
+		schemas := doltdb.NewConflict(conformedAnc.schRef, conformedTbl.schRef, conformedMerge.schRef)
+		mergedTable, err = mergedTable.SetConflicts(ctx, schemas, conflicts)
 
 		if err != nil {
 			return nil, nil, err
 		}
+	}
+
+	if len(sm.conflicts) > 0 {
+		schemas := doltdb.NewConflict(ancSide.schRef, tblSide.schRef, mergeSide.schRef)
+		mergedTable, err = mergedTable.SetSchemaConflicts(ctx, schemas, sm.conflicts)
 
 		if err != nil {
 			return nil, nil, err
 		}
+	}
 
-		if err != nil {
-			return nil, nil, err
+	return mergedTable, stats, nil
+}
+
+// mergeSide holds the schema and rows of one of the tables being merged, keyed by the merged primary key.
+type mergeSide struct {
+	sch    schema.Schema
+	schRef types.Ref
+	rows   types.Map
+}
+
+// mergePrimaryKeys returns the tags of the primary key columns of the merged table. If only one of the tables changed
+// its primary key since the ancestor, its primary key is used. Returns ErrPrimaryKeysDiffer if both tables changed it
+// to different columns.
+func mergePrimaryKeys(sch, mergeSch, ancSch schema.Schema) ([]uint64, error) {
+	changed := alterschema.PrimaryKeysDiffer(sch, ancSch)
+	mergeChanged := alterschema.PrimaryKeysDiffer(mergeSch, ancSch)
+
+	switch {
+	case changed && mergeChanged && alterschema.PrimaryKeysDiffer(sch, mergeSch):
+		return nil, ErrPrimaryKeysDiffer
+	case changed:
+		return sch.GetPKCols().Tags, nil
+	case mergeChanged:
+		return mergeSch.GetPKCols().Tags, nil
+	default:
+		return ancSch.GetPKCols().Tags, nil
+	}
+}
+
+// rekeyMergeSide returns the schema and rows of the table given keyed by the primary key columns with the tags given.
+// If the table has a different primary key, its rows are re-keyed, first leaving out any rows for which the skip
+// function returns true. It is an error for any other row to be unable to be keyed. If the skip function is nil, rows
+// that can't be keyed are left out instead, and their old keys are returned.
+func rekeyMergeSide(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Table, pkTags []uint64, skip func(key, value types.Value) (bool, error)) (*mergeSide, []types.Value, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schRef, err := tbl.GetSchemaRef()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkSch, err := alterschema.RekeySchema(sch, pkTags)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !alterschema.PrimaryKeysDiffer(sch, pkSch) {
+		return &mergeSide{sch, schRef, rows}, nil, nil
+	}
+
+	var dropped []types.Value
+	if skip == nil {
+		rows, dropped, err = alterschema.RekeyRowsDroppingErrors(ctx, vrw, rows, sch, pkSch)
+	} else {
+		me := rows.Edit()
+		err = rows.IterAll(ctx, func(key, value types.Value) error {
+			if skipRow, err := skip(key, value); err != nil {
+				return err
+			} else if skipRow {
+				me.Remove(key)
+			}
+
+			return nil
+		})
+
+		if err == nil {
+			rows, err = me.Map(ctx)
+		}
+
+		if err == nil {
+			rows, err = alterschema.RekeyRows(ctx, vrw, rows, sch, pkSch)
 		}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkSchVal, err := encoding.MarshalAsNomsValue(ctx, vrw, pkSch)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkSchRef, err := vrw.WriteValue(ctx, pkSchVal)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mergeSide{pkSch, pkSchRef, rows}, dropped, nil
+}
 
-		asr, err := ancTbl.GetSchemaRef()
+// unchangedRows returns a function reporting whether a row is the same as one of the rows of the ancestor with the keys
+// given.
+func unchangedRows(ctx context.Context, ancRows types.Map, ancKeys []types.Value) (func(key, value types.Value) (bool, error), error) {
+	nbf := ancRows.Format()
+	ancValues := make(map[hash.Hash]types.Value)
+	for _, key := range ancKeys {
+		value, _, err := ancRows.MaybeGet(ctx, key)
 
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		sr, err := tbl.GetSchemaRef()
+		h, err := key.Hash(nbf)
 
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		msr, err := mergeTbl.GetSchemaRef()
+		ancValues[h] = value
+	}
+
+	return func(key, value types.Value) (bool, error) {
+		h, err := key.Hash(nbf)
 
 		if err != nil {
-			return nil, nil, err
+			return false, err
 		}
 
-		schemas := doltdb.NewConflict(asr, sr, msr)
-		mergedTable, err = mergedTable.SetConflicts(ctx, schemas, conflicts)
+		ancValue, ok := ancValues[h]
+		return ok && ancValue.Equals(value), nil
+	}, nil
+}
+
+// mergeIndexes returns the secondary indexes of the merged table: the indexes of the table, plus any indexes added to
+// the merge table since the ancestor, minus any indexes the merge table dropped since the ancestor. If both tables
+// have an index with the same name, the table's definition is used. The columns of indexes added to the merge table
+// are mapped to the columns they were merged into by theirTags.
+func mergeIndexes(ctx context.Context, tbl, mergeTbl, ancTbl *doltdb.Table, theirTags map[uint64]uint64) ([]doltdb.Index, error) {
+	indexes, err := tbl.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return mergedTable, stats, nil
+	mergeIndexes, err := mergeTbl.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ancIndexes, err := ancTbl.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	inMerge := make(map[string]bool)
+	for _, idx := range mergeIndexes {
+		inMerge[idx.Name] = true
+	}
+
+	inAnc := make(map[string]bool)
+	for _, idx := range ancIndexes {
+		inAnc[idx.Name] = true
+	}
+
+	var merged []doltdb.Index
+	inMerged := make(map[string]bool)
+	for _, idx := range indexes {
+		if inAnc[idx.Name] && !inMerge[idx.Name] {
+			continue
+		}
+
+		merged = append(merged, idx)
+		inMerged[idx.Name] = true
+	}
+
+	for _, idx := range mergeIndexes {
+		if !inAnc[idx.Name] && !inMerged[idx.Name] {
+			tags := make([]uint64, len(idx.Tags))
+			for i, tag := range idx.Tags {
+				if mappedTag, ok := theirTags[tag]; ok {
+					tag = mappedTag
+				}
+
+				tags[i] = tag
+			}
+
+			idx.Tags = tags
+			merged = append(merged, idx)
+		}
+	}
+
+	return merged, nil
 }
 
 func stopAndDrain(stop chan<- struct{}, drain <-chan types.ValueChanged) {
@@ -239,7 +539,7 @@ func stopAndDrain(stop chan<- struct{}, drain <-chan types.ValueChanged) {
 	}
 }
 
-func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter) (types.Map, types.Map, *MergeStats, error) {
+func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter, strategy Strategy) (types.Map, types.Map, *MergeStats, error) {
 	//changeChan1, changeChan2 := make(chan diff.Difference, 32), make(chan diff.Difference, 32)
 	ae := atomicerr.New()
 	changeChan, mergeChangeChan := make(chan types.ValueChanged, 32), make(chan types.ValueChanged, 32)
@@ -327,7 +627,7 @@ func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, anc
 
 			if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
-				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
+				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow, strategy)
 
 				if err != nil {
 					return err
@@ -343,7 +643,16 @@ func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, anc
 
 					addConflict(conflictValChan, key, conflictTuple)
 				} else {
-					applyChange(mapEditor, stats, types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: r, NewValue: mergedRow})
+					// A strategy may resolve a row removed on one side by taking the other side, so the change made to
+					// our row isn't necessarily the one made in our commit.
+					changeType := change.ChangeType
+					if mergedRow == nil && r != nil {
+						changeType = types.DiffChangeRemoved
+					} else if mergedRow != nil && r == nil {
+						changeType = types.DiffChangeAdded
+					}
+
+					applyChange(mapEditor, stats, types.ValueChanged{ChangeType: changeType, Key: key, OldValue: r, NewValue: mergedRow})
 				}
 
 				change = types.ValueChanged{}
@@ -374,6 +683,185 @@ func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, anc
 	return mergedData, conflicts, stats, nil
 }
 
+// conflictInvalidRows finds the merged rows which don't satisfy the constraints of the merged schema, such as a row
+// added on one side violating a constraint added on the other, and the merged rows with the same value as another row
+// for a unique column, such as rows added on both sides with the same value. Each is recorded as a conflict, and the row
+// is left as it is in rows. Unique values are looked up in the indexes of our table, tbl. Returns the updated merged row
+// data and conflicts.
+func conflictInvalidRows(ctx context.Context, tbl *doltdb.Table, sch, ourSch schema.Schema, mergedRows, conflicts types.Map, stats *MergeStats, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter) (types.Map, types.Map, error) {
+	invalidKeys, err := invalidRows(ctx, sch, ourSch, mergedRows, rows)
+
+	if err != nil {
+		return types.EmptyMap, types.EmptyMap, err
+	}
+
+	mergedRows, conflicts, err = conflictRows(ctx, invalidKeys, mergedRows, conflicts, stats, rows, mergeRows, ancRows, vrw)
+
+	if err != nil {
+		return types.EmptyMap, types.EmptyMap, err
+	}
+
+	// Leaving a row as it is in rows can give it the same unique value as another merged row, so the rows are checked
+	// again until no more rows are changed.
+	for {
+		duplicateKeys, err := tbl.FindUniqueConstraintViolations(ctx, sch, mergedRows)
+
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, err
+		}
+
+		updatedRows, updatedConflicts, err := conflictRows(ctx, duplicateKeys, mergedRows, conflicts, stats, rows, mergeRows, ancRows, vrw)
+
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, err
+		}
+
+		if updatedRows.Equals(mergedRows) {
+			return updatedRows, updatedConflicts, nil
+		}
+
+		mergedRows, conflicts = updatedRows, updatedConflicts
+	}
+}
+
+// conflictRows records a conflict for each of the merged rows with the keys given, and leaves the row as it is in rows.
+// Returns the updated merged row data and conflicts.
+func conflictRows(ctx context.Context, keys []types.Value, mergedRows, conflicts types.Map, stats *MergeStats, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter) (types.Map, types.Map, error) {
+	if len(keys) == 0 {
+		return mergedRows, conflicts, nil
+	}
+
+	rowEd := mergedRows.Edit()
+	conflictEd := conflicts.Edit()
+	for _, key := range keys {
+		var vals [3]types.Value
+		var err error
+		for i, m := range []types.Map{ancRows, rows, mergeRows} {
+			if vals[i], _, err = m.MaybeGet(ctx, key); err != nil {
+				return types.EmptyMap, types.EmptyMap, err
+			}
+		}
+
+		if vals[1] == nil {
+			rowEd.Remove(key)
+		} else {
+			rowEd.Set(key, vals[1])
+		}
+
+		if has, err := conflicts.Has(ctx, key); err != nil {
+			return types.EmptyMap, types.EmptyMap, err
+		} else if has {
+			continue
+		}
+
+		conflictTuple, err := doltdb.NewConflict(vals[0], vals[1], vals[2]).ToNomsList(vrw)
+
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, err
+		}
+
+		conflictEd.Set(key, conflictTuple)
+		stats.Conflicts++
+	}
+
+	mergedRows, err := rowEd.Map(ctx)
+
+	if err != nil {
+		return types.EmptyMap, types.EmptyMap, err
+	}
+
+	conflicts, err = conflictEd.Map(ctx)
+
+	if err != nil {
+		return types.EmptyMap, types.EmptyMap, err
+	}
+
+	return mergedRows, conflicts, nil
+}
+
+// invalidRows returns the keys of the merged rows which don't satisfy the constraints of the merged schema. Our rows
+// satisfy the constraints of our schema, so only the merged rows which differ from them are checked, unless the merged
+// schema adds constraints to our columns.
+func invalidRows(ctx context.Context, sch, ourSch schema.Schema, mergedRows, rows types.Map) ([]types.Value, error) {
+	hasConstraints, addsConstraints := false, false
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if len(col.Constraints) == 0 {
+			return false, nil
+		}
+
+		hasConstraints = true
+		ourCol, ok := ourSch.GetAllCols().GetByTag(tag)
+		addsConstraints = !ok || ourCol.Kind != col.Kind || !schema.ColConstraintsAreEqual(col.Constraints, ourCol.Constraints)
+
+		return addsConstraints, nil
+	})
+
+	if err != nil || !hasConstraints {
+		return nil, err
+	}
+
+	var invalidKeys []types.Value
+	checkRow := func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		if isValid, err := row.IsValid(r, sch); err != nil {
+			return err
+		} else if !isValid {
+			invalidKeys = append(invalidKeys, key)
+		}
+
+		return nil
+	}
+
+	if addsConstraints {
+		err = mergedRows.IterAll(ctx, checkRow)
+	} else {
+		err = diffRows(ctx, mergedRows, rows, func(change types.ValueChanged) error {
+			if change.NewValue == nil {
+				return nil
+			}
+
+			return checkRow(change.Key, change.NewValue)
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return invalidKeys, nil
+}
+
+// diffRows calls cb for each row which differs between the row data given, until it returns an error.
+func diffRows(ctx context.Context, rowData, otherRowData types.Map, cb func(change types.ValueChanged) error) error {
+	ae := atomicerr.New()
+	changeChan, stopChan := make(chan types.ValueChanged, 32), make(chan struct{})
+
+	go func() {
+		defer close(changeChan)
+		rowData.Diff(ctx, otherRowData, ae, changeChan, stopChan)
+	}()
+
+	for change := range changeChan {
+		if ae.IsSet() {
+			break
+		}
+
+		if err := cb(change); err != nil {
+			ae.SetIfError(err)
+			break
+		}
+	}
+
+	stopAndDrain(stopChan, changeChan)
+
+	return ae.Get()
+}
+
 func addConflict(conflictChan chan types.Value, key types.Value, value types.Tuple) {
 	conflictChan <- key
 	conflictChan <- value
@@ -393,7 +881,9 @@ func applyChange(me *types.MapEditor, stats *MergeStats, change types.ValueChang
 	}
 }
 
-func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+// rowMerge merges a row changed in both commits, returning the merged row and whether it is a conflict. Changes to the
+// same cells, and a row removed on one side and modified on the other, are resolved using the row strategy given.
+func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value, strategy Strategy) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
@@ -405,7 +895,8 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 		return nil, false, nil
 	} else if r == nil || mergeRow == nil {
 		// removed from one and modified in another
-		return nil, true, nil
+		mergedRow, isConflict := resolveRow(strategy, r, mergeRow)
+		return mergedRow, isConflict, nil
 	} else {
 		var err error
 		baseVals, err = row.ParseTaggedValues(baseRow.(types.Tuple))
@@ -439,7 +930,7 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
 			switch {
 			case modified && mergeModified:
-				return nil, true
+				return resolveCell(strategy, baseVal, val, mergeVal)
 			case modified:
 				return val, false
 			default:
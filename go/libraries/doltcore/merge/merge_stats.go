@@ -29,4 +29,13 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+
+	// ForeignKeyViolations is the number of rows of the merged table which violate a foreign key.
+	ForeignKeyViolations int
+
+	// SchemaConflicts is the number of columns of the table whose changes couldn't be merged.
+	SchemaConflicts int
+
+	// Strategy is the strategy used to merge the table's rows, or empty if the table didn't need to be merged.
+	Strategy Strategy
 }
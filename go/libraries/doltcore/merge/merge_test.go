@@ -16,15 +16,20 @@ package merge
 
 import (
 	"context"
+	"math"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -205,7 +210,7 @@ func TestRowMerge(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow)
+			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow, CellWise)
 			assert.NoError(t, err)
 			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
 			assert.Equal(t, test.expectConflict, isConflict)
@@ -413,6 +418,216 @@ func setupMergeTest() (types.ValueReadWriter, *doltdb.Commit, *doltdb.Commit, ty
 	return vrw, commit, mergeCommit, expectedRows, expectedConflicts
 }
 
+func TestRowMergeStrategies(t *testing.T) {
+	tests := []struct {
+		strategy Strategy
+		test     RowMergeTest
+	}{
+		{
+			OursStrategy,
+			createRowMergeStruct(
+				"ours takes our conflicting changes",
+				[]types.Value{types.String("two"), types.Uint(2), types.Int(5)},
+				[]types.Value{types.String("three"), types.Uint(3), types.Int(5)},
+				[]types.Value{types.String("one"), types.Uint(3), types.Int(4)},
+				[]types.Value{types.String("two"), types.Uint(2), types.Int(5)},
+				false,
+			),
+		},
+		{
+			TheirsStrategy,
+			createRowMergeStruct(
+				"theirs takes their conflicting changes",
+				[]types.Value{types.String("two"), types.Uint(2), types.Int(5)},
+				[]types.Value{types.String("three"), types.Uint(3), types.Int(4)},
+				[]types.Value{types.String("one"), types.Uint(3), types.Int(4)},
+				[]types.Value{types.String("three"), types.Uint(2), types.Int(5)},
+				false,
+			),
+		},
+		{
+			OursStrategy,
+			createRowMergeStruct(
+				"ours keeps our deletion",
+				nil,
+				[]types.Value{types.String("two"), types.Uint(2)},
+				[]types.Value{types.String("one"), types.Uint(2)},
+				nil,
+				false,
+			),
+		},
+		{
+			TheirsStrategy,
+			createRowMergeStruct(
+				"theirs keeps their modification",
+				nil,
+				[]types.Value{types.String("two"), types.Uint(2)},
+				[]types.Value{types.String("one"), types.Uint(2)},
+				[]types.Value{types.String("two"), types.Uint(2)},
+				false,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive adds numeric changes",
+				[]types.Value{types.Int(12), types.Uint(7), types.Float(1.5), types.String("a")},
+				[]types.Value{types.Int(15), types.Uint(4), types.Float(3), types.String("a")},
+				[]types.Value{types.Int(10), types.Uint(5), types.Float(1), types.String("a")},
+				[]types.Value{types.Int(17), types.Uint(6), types.Float(3.5), types.String("a")},
+				false,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive adds rows added in both from zero",
+				[]types.Value{types.Int(2)},
+				[]types.Value{types.Int(3)},
+				nil,
+				[]types.Value{types.Int(5)},
+				false,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive conflicts on other changes",
+				[]types.Value{types.Int(12), types.String("b")},
+				[]types.Value{types.Int(15), types.String("c")},
+				[]types.Value{types.Int(10), types.String("a")},
+				nil,
+				true,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive conflicts on unsigned underflow",
+				[]types.Value{types.Uint(1)},
+				[]types.Value{types.Uint(2)},
+				[]types.Value{types.Uint(5)},
+				nil,
+				true,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive conflicts on signed overflow",
+				[]types.Value{types.Int(math.MaxInt64 - 1)},
+				[]types.Value{types.Int(3)},
+				[]types.Value{types.Int(1)},
+				nil,
+				true,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive conflicts on signed underflow",
+				[]types.Value{types.Int(math.MinInt64 + 1)},
+				[]types.Value{types.Int(-5)},
+				[]types.Value{types.Int(0)},
+				nil,
+				true,
+			),
+		},
+		{
+			NumericAdditive,
+			createRowMergeStruct(
+				"numeric-additive conflicts on deletion",
+				nil,
+				[]types.Value{types.Int(15)},
+				[]types.Value{types.Int(10)},
+				nil,
+				true,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.test.name, func(t *testing.T) {
+			test := tt.test
+			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow, tt.strategy)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
+			assert.Equal(t, test.expectConflict, isConflict)
+		})
+	}
+}
+
+func TestMergeTableStrategies(t *testing.T) {
+	tests := []struct {
+		strategy     Strategy
+		resolvedSide int
+		expectedMods int
+		expectedAdds int
+	}{
+		{CellWise, -1, 3, 2},
+		{OursStrategy, 0, 4, 3},
+		{TheirsStrategy, 1, 4, 3},
+		// Both commits were made at the same time, so ours wins.
+		{LastWriterByCommitTime, 0, 4, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			ctx := context.Background()
+			vrw, commit, mergeCommit, _, _ := setupMergeTest()
+			merger, err := NewMerger(ctx, commit, mergeCommit, vrw)
+			require.NoError(t, err)
+			merger.SetStrategies(func(tblName string) (Strategy, error) {
+				assert.Equal(t, tableName, tblName)
+				return tt.strategy, nil
+			})
+
+			merged, stats, err := merger.MergeTable(ctx, tableName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.strategy, stats.Strategy)
+			assert.Equal(t, tt.expectedMods, stats.Modifications)
+			assert.Equal(t, tt.expectedAdds, stats.Adds)
+
+			numConflicts, err := merged.NumRowsInConflict(ctx)
+			require.NoError(t, err)
+
+			if tt.resolvedSide < 0 {
+				assert.Equal(t, 2, stats.Conflicts)
+				assert.Equal(t, uint64(2), numConflicts)
+				return
+			}
+
+			assert.Equal(t, 0, stats.Conflicts)
+			assert.Equal(t, uint64(0), numConflicts)
+
+			rows, err := merged.GetRowData(ctx)
+			require.NoError(t, err)
+			sides := []*doltdb.Commit{commit, mergeCommit}
+			sideRoot, err := sides[tt.resolvedSide].GetRootValue()
+			require.NoError(t, err)
+			sideTbl, _, err := sideRoot.GetTable(ctx, tableName)
+			require.NoError(t, err)
+			sideRows, err := sideTbl.GetRowData(ctx)
+			require.NoError(t, err)
+
+			for _, i := range []int{8, 12} {
+				assert.Equal(t, mustGetValue(sideRows.MaybeGet(ctx, keyTuples[i])), mustGetValue(rows.MaybeGet(ctx, keyTuples[i])))
+			}
+		})
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, s := range Strategies {
+		parsed, err := ParseStrategy(strings.ToUpper(string(s)))
+		assert.NoError(t, err)
+		assert.Equal(t, s, parsed)
+	}
+
+	_, err := ParseStrategy("octopus")
+	assert.Error(t, err)
+}
+
 func TestMergeCommits(t *testing.T) {
 	vrw, commit, mergeCommit, expectedRows, expectedConflicts := setupMergeTest()
 	merger, err := NewMerger(context.Background(), commit, mergeCommit, vrw)
@@ -456,3 +671,334 @@ func TestMergeCommits(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeSchemas(t *testing.T) {
+	const ageTag, otherAgeTag = 2, 3
+	age := schema.NewColumn("age", ageTag, types.UintKind, false)
+	schWith := func(cols ...schema.Column) schema.Schema {
+		colColl, err := schema.NewColCollection(append(colColl.GetColumns(), cols...)...)
+		require.NoError(t, err)
+		return schema.SchemaFromCols(colColl)
+	}
+	renamed := func(col schema.Column, name string) schema.Column {
+		col.Name = name
+		return col
+	}
+	retyped := func(col schema.Column, kind types.NomsKind) schema.Column {
+		col.Kind = kind
+		return col
+	}
+	retagged := func(col schema.Column, tag uint64) schema.Column {
+		col.Tag = tag
+		return col
+	}
+
+	schWithAge := schWith(age)
+	id, _ := colColl.GetByTag(idTag)
+	name, _ := colColl.GetByTag(nameTag)
+	title, _ := colColl.GetByTag(titleTag)
+	renamedTitleColl, err := schema.NewColCollection(id, name, renamed(title, "age"))
+	require.NoError(t, err)
+	schWithRenamedTitle := schema.SchemaFromCols(renamedTitleColl)
+
+	tests := []struct {
+		name              string
+		sch, mergeSch     schema.Schema
+		ancSch            schema.Schema
+		expectedCols      []schema.Column
+		expectedConflicts int
+		expectedTheirTags map[uint64]uint64
+	}{
+		{"added in merge", sch, schWithAge, sch, []schema.Column{age}, 0, nil},
+		{"added in both", schWithAge, schWithAge, sch, []schema.Column{age}, 0, nil},
+		{"dropped in merge", schWithAge, sch, schWithAge, nil, 0, nil},
+		{"dropped in table", sch, schWithAge, schWithAge, nil, 0, nil},
+		{"unchanged", schWithAge, schWithAge, schWithAge, []schema.Column{age}, 0, nil},
+		{
+			"dropped in one and changed in other",
+			schWith(renamed(age, "years")), sch, schWithAge,
+			nil, 0, nil,
+		},
+		{
+			"renamed in one and retyped in other",
+			schWith(renamed(age, "years")), schWith(retyped(age, types.IntKind)), schWithAge,
+			[]schema.Column{retyped(renamed(age, "years"), types.IntKind)}, 0, nil,
+		},
+		{
+			"renamed differently in both",
+			schWith(renamed(age, "years")), schWith(renamed(age, "yrs")), schWithAge,
+			[]schema.Column{renamed(age, "years")}, 1, nil,
+		},
+		{
+			"retyped differently in both",
+			schWith(retyped(age, types.IntKind)), schWith(retyped(age, types.StringKind)), schWithAge,
+			[]schema.Column{retyped(age, types.IntKind)}, 1, nil,
+		},
+		{
+			"same tag added in both with different types",
+			schWithAge, schWith(retyped(age, types.StringKind)), sch,
+			[]schema.Column{age}, 1, nil,
+		},
+		{
+			"same name added in both with different tags",
+			schWithAge, schWith(retagged(age, otherAgeTag)), sch,
+			[]schema.Column{age}, 0, map[uint64]uint64{otherAgeTag: ageTag},
+		},
+		{
+			"same name added in both with different types",
+			schWithAge, schWith(retyped(retagged(age, otherAgeTag), types.StringKind)), sch,
+			[]schema.Column{age}, 1, nil,
+		},
+		{
+			"renamed in merge to the name of a column added in table",
+			schWithAge, schWithRenamedTitle, sch,
+			[]schema.Column{age}, 1, nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeSchemas(tt.sch, tt.mergeSch, tt.ancSch, nil)
+			require.NoError(t, err)
+
+			expectedCols := append(colColl.GetColumns(), tt.expectedCols...)
+			assert.Equal(t, len(expectedCols), merged.sch.GetAllCols().Size())
+			for _, expected := range expectedCols {
+				col, ok := merged.sch.GetAllCols().GetByTag(expected.Tag)
+				if assert.True(t, ok) {
+					assert.True(t, expected.Equals(col), "expected %v got %v", expected, col)
+				}
+			}
+
+			assert.Len(t, merged.conflicts, tt.expectedConflicts)
+
+			if tt.expectedTheirTags == nil {
+				tt.expectedTheirTags = map[uint64]uint64{}
+			}
+			assert.Equal(t, tt.expectedTheirTags, merged.theirTags)
+		})
+	}
+}
+
+func TestConformMergeSides(t *testing.T) {
+	const ageTag, otherAgeTag = 2, 3
+	ctx := context.Background()
+	ddb, _ := doltdb.LoadDoltDB(ctx, types.Format_7_18, doltdb.InMemDoltDB)
+	vrw := ddb.ValueReadWriter()
+
+	side := func(ageCol schema.Column, ages ...types.Value) *mergeSide {
+		colColl, err := schema.NewColCollection(append(colColl.GetColumns(), ageCol)...)
+		require.NoError(t, err)
+		sideSch := schema.SchemaFromCols(colColl)
+		schVal, err := encoding.MarshalAsNomsValue(ctx, vrw, sideSch)
+		require.NoError(t, err)
+		schRef, err := vrw.WriteValue(ctx, schVal)
+		require.NoError(t, err)
+
+		var kvs []types.Value
+		for i, age := range ages {
+			vals := row.TaggedValues{nameTag: types.String("person"), ageCol.Tag: age}
+			val, err := vals.NomsTupleForTags(types.Format_7_18, sideSch.GetNonPKCols().Tags, false).Value(ctx)
+			require.NoError(t, err)
+			kvs = append(kvs, keyTuples[i], val)
+		}
+
+		rows, err := types.NewMap(ctx, vrw, kvs...)
+		require.NoError(t, err)
+		return &mergeSide{sideSch, schRef, rows}
+	}
+	ages := func(side *mergeSide, tag uint64) []types.Value {
+		var vals []types.Value
+		err := side.rows.IterAll(ctx, func(key, value types.Value) error {
+			tvs, err := row.ParseTaggedValues(value.(types.Tuple))
+			require.NoError(t, err)
+			vals = append(vals, tvs[tag])
+			return nil
+		})
+		require.NoError(t, err)
+		return vals
+	}
+
+	intAge := schema.NewColumn("age", ageTag, types.IntKind, false)
+	stringAge := schema.NewColumn("age", ageTag, types.StringKind, false)
+
+	t.Run("converts retyped column", func(t *testing.T) {
+		ours := side(stringAge, types.String("1"), types.String("2"))
+		theirs := side(intAge, types.Int(1), types.Int(3))
+		anc := side(intAge, types.Int(1), types.Int(2))
+
+		sm, ours, theirs, anc, err := conformMergeSides(ctx, vrw, ours, theirs, anc)
+		require.NoError(t, err)
+		assert.Empty(t, sm.conflicts)
+		assert.Equal(t, []types.Value{types.String("1"), types.String("3")}, ages(theirs, ageTag))
+		assert.Equal(t, []types.Value{types.String("1"), types.String("2")}, ages(anc, ageTag))
+	})
+
+	t.Run("conflicts on unconvertible values", func(t *testing.T) {
+		ours := side(intAge, types.Int(1), types.Int(2))
+		theirs := side(schema.NewColumn("age", ageTag, types.UUIDKind, false), uuids[0], uuids[1])
+		anc := side(intAge, types.Int(1), types.Int(2))
+
+		sm, _, theirs, _, err := conformMergeSides(ctx, vrw, ours, theirs, anc)
+		require.NoError(t, err)
+		assert.Len(t, sm.conflicts, 1)
+		col, _ := sm.sch.GetAllCols().GetByTag(ageTag)
+		assert.Equal(t, types.IntKind, col.Kind)
+		assert.Equal(t, []types.Value{types.Int(1), types.Int(2)}, ages(theirs, ageTag))
+	})
+
+	t.Run("moves values of merged columns", func(t *testing.T) {
+		otherAge := intAge
+		otherAge.Tag = otherAgeTag
+		ours := side(intAge, types.Int(1))
+		theirs := side(otherAge, types.Int(2))
+		anc := side(schema.NewColumn("title", titleTag, types.StringKind, false))
+
+		sm, _, theirs, _, err := conformMergeSides(ctx, vrw, ours, theirs, anc)
+		require.NoError(t, err)
+		assert.Empty(t, sm.conflicts)
+		assert.Equal(t, []types.Value{types.Int(2)}, ages(theirs, ageTag))
+	})
+}
+
+func TestMergePrimaryKeys(t *testing.T) {
+	schByName, err := alterschema.RekeySchema(sch, []uint64{nameTag})
+	assert.NoError(t, err)
+	schByTitle, err := alterschema.RekeySchema(sch, []uint64{titleTag})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		sch, mergeSch  schema.Schema
+		ancSch         schema.Schema
+		expectedPKTags []uint64
+		expectedErr    error
+	}{
+		{"changed in merge", sch, schByName, sch, []uint64{nameTag}, nil},
+		{"changed in table", schByName, sch, sch, []uint64{nameTag}, nil},
+		{"changed the same in both", schByName, schByName, sch, []uint64{nameTag}, nil},
+		{"changed differently in both", schByName, schByTitle, sch, nil, ErrPrimaryKeysDiffer},
+		{"unchanged", schByName, schByName, schByName, []uint64{nameTag}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkTags, err := mergePrimaryKeys(tt.sch, tt.mergeSch, tt.ancSch)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedPKTags, pkTags)
+		})
+	}
+}
+
+func TestConflictInvalidRows(t *testing.T) {
+	ctx := context.Background()
+	ddb, _ := doltdb.LoadDoltDB(ctx, types.Format_7_18, doltdb.InMemDoltDB)
+	vrw := ddb.ValueReadWriter()
+
+	limitedColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", idTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", nameTag, types.StringKind, false, schema.NotNullConstraint{}),
+		schema.NewColumn("title", titleTag, types.StringKind, false, schema.LengthConstraint{Length: 5}),
+	)
+	limitedSch := schema.SchemaFromCols(limitedColl)
+
+	validRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 1"), types.String("dufus")})
+	invalidRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 2"), types.String("senior dufus")})
+	modifiedRow := valsToTestTupleWithoutPks([]types.Value{types.String("person three"), types.String("doctor")})
+	ancRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 3"), types.String("dr")})
+
+	ancRows, err := types.NewMap(ctx, vrw, keyTuples[2], ancRow)
+	assert.NoError(t, err)
+	// row 0 added in the table, row 1 added in the merge, and row 2 modified in both to make an invalid merged row
+	rows, err := types.NewMap(ctx, vrw, keyTuples[0], validRow, keyTuples[2], valsToTestTupleWithoutPks([]types.Value{types.String("person three"), types.String("dr")}))
+	assert.NoError(t, err)
+	mergeRows, err := types.NewMap(ctx, vrw, keyTuples[1], invalidRow, keyTuples[2], valsToTestTupleWithoutPks([]types.Value{types.String("person 3"), types.String("doctor")}))
+	assert.NoError(t, err)
+	mergedRows, err := types.NewMap(ctx, vrw, keyTuples[0], validRow, keyTuples[1], invalidRow, keyTuples[2], modifiedRow)
+	assert.NoError(t, err)
+	conflicts, err := types.NewMap(ctx, vrw)
+	assert.NoError(t, err)
+
+	stats := &MergeStats{Operation: TableModified}
+	checkedRows, checkedConflicts, err := conflictInvalidRows(ctx, newTestTable(t, vrw, limitedSch, rows), limitedSch, limitedSch, mergedRows, conflicts, stats, rows, mergeRows, ancRows, vrw)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Conflicts)
+
+	expectedRows, err := types.NewMap(ctx, vrw, keyTuples[0], validRow, keyTuples[2], mustGetValue(rows.MaybeGet(ctx, keyTuples[2])))
+	assert.NoError(t, err)
+	assert.True(t, expectedRows.Equals(checkedRows))
+
+	assert.Equal(t, uint64(2), checkedConflicts.Len())
+	for _, key := range []types.Tuple{keyTuples[1], keyTuples[2]} {
+		conflict, err := doltdb.ConflictFromTuple(mustGetValue(checkedConflicts.MaybeGet(ctx, key)).(types.Tuple))
+		assert.NoError(t, err)
+		assert.True(t, conflict.MergeValue.Equals(mustGetValue(mergeRows.MaybeGet(ctx, key))))
+	}
+
+	// Rows unchanged from ours are only checked when the merged schema adds constraints to our columns
+	invalidOurRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 4"), types.String("professor")})
+	rows, err = rows.Edit().Set(keyTuples[3], invalidOurRow).Map(ctx)
+	assert.NoError(t, err)
+	mergedRows, err = mergedRows.Edit().Set(keyTuples[3], invalidOurRow).Map(ctx)
+	assert.NoError(t, err)
+
+	for ourSch, expectedConflicts := range map[schema.Schema]int{limitedSch: 2, sch: 3} {
+		stats = &MergeStats{Operation: TableModified}
+		_, _, err = conflictInvalidRows(ctx, newTestTable(t, vrw, ourSch, rows), limitedSch, ourSch, mergedRows, conflicts, stats, rows, mergeRows, ancRows, vrw)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedConflicts, stats.Conflicts)
+	}
+}
+
+func TestConflictDuplicateUniqueRows(t *testing.T) {
+	ctx := context.Background()
+	ddb, _ := doltdb.LoadDoltDB(ctx, types.Format_7_18, doltdb.InMemDoltDB)
+	vrw := ddb.ValueReadWriter()
+
+	uniqueColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", idTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", nameTag, types.StringKind, false, schema.NotNullConstraint{}),
+		schema.NewColumn("title", titleTag, types.StringKind, false, schema.UniqueConstraint{}),
+	)
+	uniqueSch := schema.SchemaFromCols(uniqueColl)
+
+	ourRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 1"), types.String("boss")})
+	theirRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 2"), types.String("boss")})
+	otherRow := valsToTestTupleWithoutPks([]types.Value{types.String("person 3"), types.String("dufus")})
+
+	// row 0 added in the table and row 1 added in the merge, with the same title
+	ancRows, err := types.NewMap(ctx, vrw, keyTuples[2], otherRow)
+	require.NoError(t, err)
+	rows, err := types.NewMap(ctx, vrw, keyTuples[0], ourRow, keyTuples[2], otherRow)
+	require.NoError(t, err)
+	mergeRows, err := types.NewMap(ctx, vrw, keyTuples[1], theirRow, keyTuples[2], otherRow)
+	require.NoError(t, err)
+	mergedRows, err := types.NewMap(ctx, vrw, keyTuples[0], ourRow, keyTuples[1], theirRow, keyTuples[2], otherRow)
+	require.NoError(t, err)
+	conflicts, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+
+	// The title is looked up in the index of our table, or in all the rows if the constraint is added by the merge
+	for _, ourSch := range []schema.Schema{uniqueSch, sch} {
+		stats := &MergeStats{Operation: TableModified}
+		checkedRows, checkedConflicts, err := conflictInvalidRows(ctx, newTestTable(t, vrw, ourSch, rows), uniqueSch, ourSch, mergedRows, conflicts, stats, rows, mergeRows, ancRows, vrw)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Conflicts)
+		assert.True(t, rows.Equals(checkedRows))
+
+		assert.Equal(t, uint64(2), checkedConflicts.Len())
+		for _, key := range []types.Tuple{keyTuples[0], keyTuples[1]} {
+			has, err := checkedConflicts.Has(ctx, key)
+			require.NoError(t, err)
+			assert.True(t, has)
+		}
+	}
+}
+
+func newTestTable(t *testing.T, vrw types.ValueReadWriter, sch schema.Schema, rows types.Map) *doltdb.Table {
+	schVal, err := encoding.MarshalAsNomsValue(context.Background(), vrw, sch)
+	require.NoError(t, err)
+	tbl, err := doltdb.NewTable(context.Background(), vrw, schVal, rows)
+	require.NoError(t, err)
+	return tbl
+}
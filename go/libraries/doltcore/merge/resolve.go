@@ -16,14 +16,22 @@ package merge
 
 import (
 	"context"
+	"errors"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
+// ErrRowConflictsUnresolved is returned when resolving schema conflicts with their column definitions before the row
+// conflicts of the table have been resolved.
+var ErrRowConflictsUnresolved = errors.New("row conflicts must be resolved before schema conflicts can be resolved with theirs")
+
 type AutoResolver func(key types.Value, conflict doltdb.Conflict) (types.Value, error)
 
 func Ours(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
@@ -122,6 +130,12 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Ta
 		return nil, err
 	}
 
+	newTbl, err = newTbl.RebuildIndexesFrom(ctx, tbl)
+
+	if err != nil {
+		return nil, err
+	}
+
 	m, err = types.NewMap(ctx, vrw)
 
 	if err != nil {
@@ -134,5 +148,86 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Ta
 		return nil, err
 	}
 
-	return newTbl, nil
+	return newTbl.CopySchemaConflictsFrom(tbl)
+}
+
+// ResolveSchemaConflicts resolves the schema conflicts recorded for the table given by a merge. Resolving with ours
+// keeps the table's columns as they are. Resolving with theirs changes each column in conflict to its definition in
+// their commit, keeping its tag and converting its values, which fails if any value can't be converted. The row
+// conflicts of the table must be resolved before its schema conflicts can be resolved with theirs.
+func ResolveSchemaConflicts(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, theirs bool) (*doltdb.Table, error) {
+	conflicts, err := tbl.GetSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	} else if len(conflicts) == 0 {
+		return nil, doltdb.ErrNoConflicts
+	}
+
+	if theirs {
+		if num, err := tbl.NumRowsInConflict(ctx); err != nil {
+			return nil, err
+		} else if num > 0 {
+			return nil, ErrRowConflictsUnresolved
+		}
+
+		for _, cnf := range conflicts {
+			tbl, err = takeTheirColumn(ctx, db, tbl, cnf)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return tbl.SetSchemaConflicts(ctx, doltdb.Conflict{}, nil)
+}
+
+// takeTheirColumn changes the column of a schema conflict to their definition of it.
+func takeTheirColumn(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, cnf doltdb.SchemaConflict) (*doltdb.Table, error) {
+	if cnf.Theirs.Tag == schema.InvalidTag {
+		return tbl, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	col, ok := sch.GetAllCols().GetByTag(cnf.Ours.Tag)
+
+	if !ok {
+		return tbl, nil
+	}
+
+	tbl, err = alterschema.RenameColumn(ctx, db, tbl, col.Name, cnf.Theirs.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	convFn := func(val types.Value) types.Value {
+		return val
+	}
+
+	if col.Kind != cnf.Theirs.Kind {
+		kindConv := doltcore.GetConvFunc(col.Kind, cnf.Theirs.Kind)
+
+		if kindConv == nil {
+			convFn = nil
+		} else {
+			convFn = func(val types.Value) types.Value {
+				newVal, err := kindConv(val)
+
+				if err != nil {
+					return nil
+				}
+
+				return newVal
+			}
+		}
+	}
+
+	return alterschema.ModifyColumn(ctx, db, tbl, cnf.Theirs, convFn)
 }
@@ -0,0 +1,413 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// schemaMerge is the result of merging the schemas of the tables being merged.
+type schemaMerge struct {
+	// sch is the schema of the merged table.
+	sch schema.Schema
+
+	// conflicts are the columns which were changed in ways that can't be merged. The merged schema has our definition
+	// of each, or none if only their table has it.
+	conflicts []doltdb.SchemaConflict
+
+	// theirTags maps the tags of columns added to their table to the tags of columns with the same name and type added
+	// to ours, which they are merged into.
+	theirTags map[uint64]uint64
+}
+
+// mergeSchemas merges the schemas of the tables column by column, matching columns by tag. A column changed in only one
+// table takes that table's definition, and a column dropped from either table is dropped. A column changed in both
+// tables is merged by taking each of its name, type, primary key membership and constraints from the table that changed
+// it, and is a conflict if both changed the same one differently. Columns added to both tables with the same name are
+// merged if they have the same type, and are a conflict if they don't. Each column whose tag is in keepOurs is a conflict
+// which keeps our definition.
+func mergeSchemas(sch, mergeSch, ancSch schema.Schema, keepOurs map[uint64]bool) (*schemaMerge, error) {
+	cols, mergeCols, ancCols := sch.GetAllCols(), mergeSch.GetAllCols(), ancSch.GetAllCols()
+	sm := &schemaMerge{theirTags: make(map[uint64]uint64)}
+
+	var merged []schema.Column
+	err := cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		ancCol, inAnc := ancCols.GetByTag(tag)
+		mergeCol, inMerge := mergeCols.GetByTag(tag)
+
+		switch {
+		case !inMerge && inAnc:
+			// dropped from their table
+		case !inMerge:
+			merged = append(merged, col)
+		case keepOurs[tag]:
+			sm.addConflict(ancCol, inAnc, col, mergeCol)
+			merged = append(merged, col)
+		default:
+			mergedCol, ok := mergeColumns(col, mergeCol, ancCol, inAnc)
+
+			if !ok {
+				sm.addConflict(ancCol, inAnc, col, mergeCol)
+				mergedCol = col
+			}
+
+			merged = append(merged, mergedCol)
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A column renamed in their table can take the name of another column, in which case it keeps our definition.
+	nameToCol := make(map[string]schema.Column)
+	for i, col := range merged {
+		other, ok := nameToCol[strings.ToLower(col.Name)]
+
+		if !ok {
+			nameToCol[strings.ToLower(col.Name)] = col
+			continue
+		}
+
+		renamed, kept, renamedIdx := col, other, i
+		if ourCol, _ := cols.GetByTag(col.Tag); ourCol.Name == col.Name {
+			renamed, kept = other, col
+
+			for j := range merged[:i] {
+				if merged[j].Tag == other.Tag {
+					renamedIdx = j
+				}
+			}
+		}
+
+		ancCol, inAnc := ancCols.GetByTag(renamed.Tag)
+		ourCol, _ := cols.GetByTag(renamed.Tag)
+		mergeCol, _ := mergeCols.GetByTag(renamed.Tag)
+		sm.addConflict(ancCol, inAnc, ourCol, mergeCol)
+
+		merged[renamedIdx] = ourCol
+		nameToCol[strings.ToLower(kept.Name)] = kept
+		nameToCol[strings.ToLower(ourCol.Name)] = ourCol
+	}
+
+	err = mergeCols.Iter(func(tag uint64, mergeCol schema.Column) (stop bool, err error) {
+		if _, inOurs := cols.GetByTag(tag); inOurs {
+			return false, nil
+		} else if _, inAnc := ancCols.GetByTag(tag); inAnc {
+			// dropped from our table
+			return false, nil
+		}
+
+		ourCol, ok := nameToCol[strings.ToLower(mergeCol.Name)]
+
+		if !ok {
+			merged = append(merged, mergeCol)
+			return false, nil
+		}
+
+		_, ourColInAnc := ancCols.GetByTag(ourCol.Tag)
+		withTag := mergeCol
+		withTag.Tag = ourCol.Tag
+
+		if !ourColInAnc && ourCol.Equals(withTag) {
+			sm.theirTags[tag] = ourCol.Tag
+		} else {
+			sm.addConflict(schema.InvalidCol, false, ourCol, mergeCol)
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(merged...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sm.sch = schema.SchemaFromCols(colColl)
+
+	return sm, nil
+}
+
+// addConflict records a conflict for the column given, unless it's already a conflict.
+func (sm *schemaMerge) addConflict(ancCol schema.Column, inAnc bool, col, mergeCol schema.Column) {
+	for _, cnf := range sm.conflicts {
+		if cnf.Ours.Tag == col.Tag && cnf.Theirs.Tag == mergeCol.Tag {
+			return
+		}
+	}
+
+	if !inAnc {
+		ancCol = schema.InvalidCol
+	}
+
+	sm.conflicts = append(sm.conflicts, doltdb.SchemaConflict{Base: ancCol, Ours: col, Theirs: mergeCol})
+}
+
+// mergeColumns merges the definitions of a column in both tables and the ancestor, returning false if both tables
+// changed the same part of the definition differently.
+func mergeColumns(col, mergeCol, ancCol schema.Column, inAnc bool) (schema.Column, bool) {
+	if col.Equals(mergeCol) {
+		return col, true
+	}
+
+	merged := col
+	var ok [4]bool
+	var from schema.Column
+
+	from, ok[0] = mergeColumnPart(col, mergeCol, ancCol, inAnc, func(c1, c2 schema.Column) bool {
+		return c1.Name == c2.Name
+	})
+	merged.Name = from.Name
+
+	from, ok[1] = mergeColumnPart(col, mergeCol, ancCol, inAnc, func(c1, c2 schema.Column) bool {
+		return schema.NewColumnWithTypeParams("", 0, c1.Kind, false, c1.TypeParams).
+			Equals(schema.NewColumnWithTypeParams("", 0, c2.Kind, false, c2.TypeParams))
+	})
+	merged.Kind, merged.TypeParams = from.Kind, from.TypeParams
+
+	from, ok[2] = mergeColumnPart(col, mergeCol, ancCol, inAnc, func(c1, c2 schema.Column) bool {
+		return c1.IsPartOfPK == c2.IsPartOfPK
+	})
+	merged.IsPartOfPK = from.IsPartOfPK
+
+	from, ok[3] = mergeColumnPart(col, mergeCol, ancCol, inAnc, func(c1, c2 schema.Column) bool {
+		return schema.ColConstraintsAreEqual(c1.Constraints, c2.Constraints)
+	})
+	merged.Constraints = from.Constraints
+
+	return merged, ok[0] && ok[1] && ok[2] && ok[3]
+}
+
+// mergeColumnPart returns the definition of the column to take the part compared by the equality function given from.
+// Returns false if both tables changed it differently.
+func mergeColumnPart(col, mergeCol, ancCol schema.Column, inAnc bool, eq func(c1, c2 schema.Column) bool) (schema.Column, bool) {
+	switch {
+	case eq(col, mergeCol):
+		return col, true
+	case inAnc && eq(col, ancCol):
+		return mergeCol, true
+	case inAnc && eq(mergeCol, ancCol):
+		return col, true
+	}
+
+	return col, false
+}
+
+// conformMergeSides converts the rows of each table being merged to the merged schema, and returns the result of
+// merging the schemas along with the converted tables. Values of columns the merged schema doesn't have are removed,
+// values of columns merged into another column are moved to it, and values of columns whose type changed are converted.
+//
+// If one of our values can't be converted to a type their table changed a column to, the column is a conflict and
+// keeps our definition. If one of their values can't be converted to a type our table changed a column to, the column
+// is a conflict and takes our value in that row, or no value if we don't have the row.
+func conformMergeSides(ctx context.Context, vrw types.ValueReadWriter, ours, theirs, anc *mergeSide) (*schemaMerge, *mergeSide, *mergeSide, *mergeSide, error) {
+	keepOurs := make(map[uint64]bool)
+
+	for {
+		sm, err := mergeSchemas(ours.sch, theirs.sch, anc.sch, keepOurs)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		schVal, err := encoding.MarshalAsNomsValue(ctx, vrw, sm.sch)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		schRef, err := vrw.WriteValue(ctx, schVal)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		conformedOurs, failed, err := conformMergeSide(ctx, vrw, ours, sm.sch, schRef, nil, nil)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		} else if len(failed) > 0 {
+			for tag := range failed {
+				keepOurs[tag] = true
+			}
+
+			continue
+		}
+
+		conformedTheirs, failed, err := conformMergeSide(ctx, vrw, theirs, sm.sch, schRef, sm.theirTags, &conformedOurs.rows)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		for tag := range failed {
+			ancCol, inAnc := anc.sch.GetAllCols().GetByTag(tag)
+			ourCol, _ := ours.sch.GetAllCols().GetByTag(tag)
+			mergeCol, _ := theirs.sch.GetAllCols().GetByTag(tag)
+			sm.addConflict(ancCol, inAnc, ourCol, mergeCol)
+		}
+
+		conformedAnc, _, err := conformMergeSide(ctx, vrw, anc, sm.sch, schRef, nil, &conformedOurs.rows)
+
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		return sm, conformedOurs, conformedTheirs, conformedAnc, nil
+	}
+}
+
+// conformMergeSide converts the rows of the table given to the schema given, moving the values of each column in
+// tagMap to the column it maps to. Returns the table unchanged if its rows needn't be converted. The tags of the columns
+// with values that couldn't be converted are returned. If fallbackRows is non-nil, those values are replaced by the
+// value of the row in fallbackRows, and otherwise the rows aren't converted.
+func conformMergeSide(ctx context.Context, vrw types.ValueReadWriter, side *mergeSide, sch schema.Schema, schRef types.Ref, tagMap map[uint64]uint64, fallbackRows *types.Map) (*mergeSide, map[uint64]bool, error) {
+	destTags := make(map[uint64]uint64)
+	convFuncs := make(map[uint64]doltcore.ConvFunc)
+	needed := false
+
+	err := side.sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		destTag, ok := tagMap[tag]
+
+		if !ok {
+			destTag = tag
+		}
+
+		destCol, ok := sch.GetAllCols().GetByTag(destTag)
+
+		switch {
+		case col.IsPartOfPK && (!ok || destCol.Kind != col.Kind):
+			return true, fmt.Errorf("the type of primary key column '%s' can't be merged", col.Name)
+		case col.IsPartOfPK:
+			return false, nil
+		case !ok:
+			destTags[tag] = schema.InvalidTag
+			needed = true
+		case destCol.Kind != col.Kind:
+			convFuncs[tag] = doltcore.GetConvFunc(col.Kind, destCol.Kind)
+			destTags[tag] = destTag
+			needed = true
+		default:
+			destTags[tag] = destTag
+			needed = needed || destTag != tag
+		}
+
+		return false, nil
+	})
+
+	if err != nil || !needed {
+		return side, nil, err
+	}
+
+	failed := make(map[uint64]bool)
+	nonPKTags := sch.GetNonPKCols().Tags
+	ed := side.rows.Edit()
+	err = side.rows.IterAll(ctx, func(key, value types.Value) error {
+		vals, err := row.ParseTaggedValues(value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		var fallbackVals row.TaggedValues
+		converted := make(row.TaggedValues, len(vals))
+		for tag, val := range vals {
+			destTag, ok := destTags[tag]
+
+			if !ok || destTag == schema.InvalidTag {
+				continue
+			}
+
+			if convFunc, ok := convFuncs[tag]; ok {
+				var convErr error
+				if convFunc != nil {
+					val, convErr = convFunc(val)
+				}
+
+				if convFunc == nil || convErr != nil {
+					failed[tag] = true
+
+					if fallbackRows == nil {
+						continue
+					}
+
+					if fallbackVals == nil {
+						if fallbackVals, err = fallbackRowVals(ctx, *fallbackRows, key); err != nil {
+							return err
+						}
+					}
+
+					val = fallbackVals[destTag]
+				}
+			}
+
+			if !types.IsNull(val) {
+				converted[destTag] = val
+			}
+		}
+
+		convertedVal, err := converted.NomsTupleForTags(vrw.Format(), nonPKTags, false).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		ed.Set(key, convertedVal)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(failed) > 0 && fallbackRows == nil {
+		return side, failed, nil
+	}
+
+	rows, err := ed.Map(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mergeSide{sch, schRef, rows}, failed, nil
+}
+
+func fallbackRowVals(ctx context.Context, rows types.Map, key types.Value) (row.TaggedValues, error) {
+	val, ok, err := rows.MaybeGet(ctx, key)
+
+	if err != nil || !ok {
+		return row.TaggedValues{}, err
+	}
+
+	return row.ParseTaggedValues(val.(types.Tuple))
+}
@@ -0,0 +1,199 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// Strategy is the way rows changed in both commits being merged are merged. Rows changed in only one of the commits
+// take that commit's changes whatever the strategy.
+type Strategy string
+
+const (
+	// CellWise merges the columns of a row separately, taking the value of a column changed in only one commit from that
+	// commit. A conflict is recorded if both commits change a column to different values, or one removes a row that the
+	// other modifies. This is the default strategy.
+	CellWise Strategy = "cell-wise"
+
+	// OursStrategy merges rows cell-wise, but resolves what would be a conflict by taking the value from our commit.
+	OursStrategy Strategy = "ours"
+
+	// TheirsStrategy merges rows cell-wise, but resolves what would be a conflict by taking the value from their commit.
+	TheirsStrategy Strategy = "theirs"
+
+	// NumericAdditive merges rows cell-wise, but a numeric column changed in both commits takes the sum of the changes
+	// made by each, so that counters incremented on both sides keep both increments. A column added in both commits is
+	// treated as changed from zero. Other conflicts are recorded as they are by CellWise.
+	NumericAdditive Strategy = "numeric-additive"
+
+	// LastWriterByCommitTime merges rows cell-wise, but resolves what would be a conflict by taking the value from
+	// whichever of the two commits being merged was made last. Our commit wins a tie.
+	LastWriterByCommitTime Strategy = "last-writer-by-commit-time"
+)
+
+// ErrNoCommitTimes is returned when merging roots which aren't commits with the LastWriterByCommitTime strategy.
+var ErrNoCommitTimes = errors.New("the last-writer-by-commit-time strategy can only be used to merge commits")
+
+// Strategies is every merge strategy, with the default first.
+var Strategies = []Strategy{CellWise, OursStrategy, TheirsStrategy, NumericAdditive, LastWriterByCommitTime}
+
+// StrategyForTable returns the strategy used to merge the table with the name given.
+type StrategyForTable func(tblName string) (Strategy, error)
+
+// ParseStrategy returns the strategy with the name given.
+func ParseStrategy(name string) (Strategy, error) {
+	for _, s := range Strategies {
+		if strings.EqualFold(name, string(s)) {
+			return s, nil
+		}
+	}
+
+	names := make([]string, len(Strategies))
+	for i, s := range Strategies {
+		names[i] = string(s)
+	}
+
+	return "", fmt.Errorf("unknown merge strategy '%s'. Valid strategies are: %s", name, strings.Join(names, ", "))
+}
+
+// rowStrategy returns the strategy used to merge rows for the strategy given. LastWriterByCommitTime is translated to
+// the strategy favoring the commit made last.
+func (merger *Merger) rowStrategy(strategy Strategy) (Strategy, error) {
+	if strategy != LastWriterByCommitTime {
+		return strategy, nil
+	} else if merger.commit == nil || merger.mergeCommit == nil {
+		return "", ErrNoCommitTimes
+	}
+
+	ourTime, err := commitTime(merger.commit)
+
+	if err != nil {
+		return "", err
+	}
+
+	theirTime, err := commitTime(merger.mergeCommit)
+
+	if err != nil {
+		return "", err
+	}
+
+	if theirTime > ourTime {
+		return TheirsStrategy, nil
+	}
+
+	return OursStrategy, nil
+}
+
+func commitTime(cm *doltdb.Commit) (uint64, error) {
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return meta.Timestamp, nil
+}
+
+// resolveCell returns the merged value of a column changed to different values in both commits according to the row
+// strategy given, and whether it is a conflict.
+func resolveCell(strategy Strategy, baseVal, val, mergeVal types.Value) (types.Value, bool) {
+	switch strategy {
+	case OursStrategy:
+		return val, false
+	case TheirsStrategy:
+		return mergeVal, false
+	case NumericAdditive:
+		if sum, ok := addChanges(baseVal, val, mergeVal); ok {
+			return sum, false
+		}
+	}
+
+	return nil, true
+}
+
+// resolveRow returns the merged row for a row removed in one commit and modified in the other according to the row
+// strategy given, and whether it is a conflict. A nil row is removed.
+func resolveRow(strategy Strategy, r, mergeRow types.Value) (types.Value, bool) {
+	switch strategy {
+	case OursStrategy:
+		return r, false
+	case TheirsStrategy:
+		return mergeRow, false
+	}
+
+	return nil, true
+}
+
+// addChanges returns base + (val - base) + (mergeVal - base) for numeric values of the same kind. A missing base value
+// is zero. Returns false if the values aren't numeric, or if the result can't be represented.
+func addChanges(baseVal, val, mergeVal types.Value) (types.Value, bool) {
+	if types.IsNull(val) || types.IsNull(mergeVal) || val.Kind() != mergeVal.Kind() {
+		return nil, false
+	}
+
+	if !types.IsNull(baseVal) && baseVal.Kind() != val.Kind() {
+		return nil, false
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		var base types.Int
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Int)
+		}
+
+		if change, ok := subInt(int64(mergeVal.(types.Int)), int64(base)); ok {
+			if sum, ok := addInt(int64(v), change); ok {
+				return types.Int(sum), true
+			}
+		}
+	case types.Float:
+		var base types.Float
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Float)
+		}
+
+		return v + mergeVal.(types.Float) - base, true
+	case types.Uint:
+		var base types.Uint
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Uint)
+		}
+
+		if sum := v + mergeVal.(types.Uint); sum >= v && sum >= base {
+			return sum - base, true
+		}
+	}
+
+	return nil, false
+}
+
+// addInt returns a + b, or false if the sum overflows an int64.
+func addInt(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, (b >= 0) == (sum >= a)
+}
+
+// subInt returns a - b, or false if the difference overflows an int64.
+func subInt(a, b int64) (int64, bool) {
+	diff := a - b
+	return diff, (b >= 0) == (diff <= a)
+}
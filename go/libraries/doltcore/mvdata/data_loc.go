@@ -59,8 +59,14 @@ const (
 	// JsonFile is the format of a data location that is a json file
 	JsonFile DataFormat = ".json"
 
+	// JsonLinesFile is the format of a data location that is a json lines file, with a json object on each line
+	JsonLinesFile DataFormat = ".jsonl"
+
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
+
+	// ParquetFile is the format of a data location that is a .parquet file
+	ParquetFile DataFormat = ".parquet"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -76,8 +82,12 @@ func (df DataFormat) ReadableStr() string {
 		return "xlsx file"
 	case JsonFile:
 		return "json file"
+	case JsonLinesFile:
+		return "json lines file"
 	case SqlFile:
 		return "sql file"
+	case ParquetFile:
+		return "parquet file"
 	default:
 		return "invalid"
 	}
@@ -100,6 +110,10 @@ type DataLocation interface {
 	// NewUpdatingWriter will create a TableWriteCloser for a DataLocation that will update and append rows based on
 	// their primary key.
 	NewUpdatingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error)
+
+	// NewReplacingWriter will create a TableWriteCloser for a DataLocation that will replace all of its existing rows
+	// with the rows written, deleting the rows which aren't.
+	NewReplacingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error)
 }
 
 // NewDataLocation creates a DataLocation object from a path and a format string.  If the path is the name of a table
@@ -125,8 +139,12 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 				dataFmt = XlsxFile
 			case string(JsonFile):
 				dataFmt = JsonFile
+			case string(JsonLinesFile), ".ndjson":
+				dataFmt = JsonLinesFile
 			case string(SqlFile):
 				dataFmt = SqlFile
+			case string(ParquetFile):
+				dataFmt = ParquetFile
 			}
 		}
 	}
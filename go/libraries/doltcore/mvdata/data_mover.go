@@ -17,6 +17,7 @@ package mvdata
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
@@ -27,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/funcitr"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
@@ -37,6 +39,7 @@ type MoveOperation string
 const (
 	OverwriteOp MoveOperation = "overwrite"
 	UpdateOp    MoveOperation = "update"
+	ReplaceOp   MoveOperation = "replace"
 	InvalidOp   MoveOperation = "invalid"
 )
 
@@ -52,6 +55,18 @@ type JSONOptions struct {
 	TableName string
 }
 
+type JSONLinesOptions struct {
+	TableName string
+
+	// MappingFile is the mapping file of the import, which names the fields of the file that are imported into
+	// columns with different names.
+	MappingFile string
+}
+
+type SqlOptions struct {
+	TableName string
+}
+
 type MoveOptions struct {
 	Operation   MoveOperation
 	ContOnErr   bool
@@ -131,7 +146,8 @@ func NewDataMover(ctx context.Context, root *doltdb.RootValue, fs filesys.Filesy
 		return nil, &DataMoverCreationError{MappingErr, err}
 	}
 
-	err = maybeMapFields(transforms, mapping)
+	_, destIsTable := mvOpts.Dest.(TableDataLocation)
+	err = maybeMapFields(transforms, mapping, destIsTable)
 
 	if err != nil {
 		return nil, &DataMoverCreationError{CreateMapperErr, err}
@@ -140,6 +156,8 @@ func NewDataMover(ctx context.Context, root *doltdb.RootValue, fs filesys.Filesy
 	var wr table.TableWriteCloser
 	if mvOpts.Operation == OverwriteOp {
 		wr, err = mvOpts.Dest.NewCreatingWriter(ctx, mvOpts, root, fs, srcIsSorted, outSch, statsCB)
+	} else if mvOpts.Operation == ReplaceOp {
+		wr, err = mvOpts.Dest.NewReplacingWriter(ctx, mvOpts, root, fs, srcIsSorted, outSch, statsCB)
 	} else {
 		wr, err = mvOpts.Dest.NewUpdatingWriter(ctx, mvOpts, root, fs, srcIsSorted, outSch, statsCB)
 	}
@@ -184,14 +202,19 @@ func (imp *DataMover) Move(ctx context.Context) error {
 	return rowErr
 }
 
-func maybeMapFields(transforms *pipeline.TransformCollection, mapping *rowconv.FieldMapping) error {
+// maybeMapFields adds a transform converting rows with the mapping given, if they need converting. Rows written to a
+// table are always given the default values of the table's columns and checked against its constraints.
+func maybeMapFields(transforms *pipeline.TransformCollection, mapping *rowconv.FieldMapping, destIsTable bool) error {
 	rconv, err := rowconv.NewRowConverter(mapping)
 
 	if err != nil {
 		return err
 	}
 
-	if !rconv.IdentityConverter {
+	if destIsTable {
+		nt := pipeline.NewNamedTransform("Mapping transform", rowconv.GetRowConvTransformFuncWithDefaults(rconv))
+		transforms.AppendTransforms(nt)
+	} else if !rconv.IdentityConverter {
 		nt := pipeline.NewNamedTransform("Mapping transform", rowconv.GetRowConvTransformFunc(rconv))
 		transforms.AppendTransforms(nt)
 	}
@@ -200,7 +223,7 @@ func maybeMapFields(transforms *pipeline.TransformCollection, mapping *rowconv.F
 }
 
 func getOutSchema(ctx context.Context, inSch schema.Schema, root *doltdb.RootValue, fs filesys.ReadableFS, mvOpts *MoveOptions) (schema.Schema, error) {
-	if mvOpts.Operation == UpdateOp {
+	if mvOpts.Operation == UpdateOp || mvOpts.Operation == ReplaceOp {
 		// Get schema from target
 
 		rd, _, err := mvOpts.Dest.NewReader(ctx, root, fs, mvOpts.SchFile, mvOpts.SrcOptions)
@@ -212,6 +235,8 @@ func getOutSchema(ctx context.Context, inSch schema.Schema, root *doltdb.RootVal
 		defer rd.Close(ctx)
 
 		return rd.GetSchema(), nil
+	} else if mvOpts.SchFile == "" && inferSchema(mvOpts) {
+		return inferCSVSchema(ctx, root, fs, mvOpts)
 	} else {
 		sch, err := schFromFileOrDefault(mvOpts.SchFile, fs, inSch)
 
@@ -230,6 +255,32 @@ func getOutSchema(ctx context.Context, inSch schema.Schema, root *doltdb.RootVal
 
 }
 
+// inferSchema returns whether the schema of a table created from the source should be inferred from its values rather
+// than giving every column the string kind, which is the case for csv and psv files.
+func inferSchema(mvOpts *MoveOptions) bool {
+	if _, destIsTable := mvOpts.Dest.(TableDataLocation); !destIsTable {
+		return false
+	}
+
+	fileLoc, srcIsFile := mvOpts.Src.(FileDataLocation)
+	return srcIsFile && (fileLoc.Format == CsvFile || fileLoc.Format == PsvFile)
+}
+
+func inferCSVSchema(ctx context.Context, root *doltdb.RootValue, fs filesys.ReadableFS, mvOpts *MoveOptions) (schema.Schema, error) {
+	var pkCols []string
+	if mvOpts.PrimaryKey != "" {
+		keyCols := strings.Split(mvOpts.PrimaryKey, ",")
+		pkCols = funcitr.MapStrings(keyCols, func(s string) string { return strings.TrimSpace(s) })
+	}
+
+	openRd := func() (table.TableReadCloser, error) {
+		rd, _, err := mvOpts.Src.NewReader(ctx, root, fs, "", mvOpts.SrcOptions)
+		return rd, err
+	}
+
+	return csv.InferSchema(ctx, root.VRW().Format(), openRd, pkCols)
+}
+
 func schFromFileOrDefault(path string, fs filesys.ReadableFS, defSch schema.Schema) (schema.Schema, error) {
 	if path != "" {
 		data, err := fs.ReadFile(path)
@@ -244,6 +295,72 @@ func schFromFileOrDefault(path string, fs filesys.ReadableFS, defSch schema.Sche
 	}
 }
 
+// jsonLinesSchema returns the schema of the rows read from JSON Lines data, which is the schema in the schema file given
+// or else the schema of the table being imported to. If there is a mapping file, each column that it maps a field to is
+// renamed to that field, so that the fields of nested objects, which are read by their paths such as "address.city", can
+// be mapped to columns.
+func jsonLinesSchema(ctx context.Context, root *doltdb.RootValue, fs filesys.ReadableFS, schPath string, opts interface{}) (schema.Schema, error) {
+	jsonOpts, _ := opts.(JSONLinesOptions)
+	sch, err := schFromFileOrDefault(schPath, fs, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if sch == nil {
+		tbl, ok, err := root.GetTable(ctx, jsonOpts.TableName)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("The following table could not be found:\n%v", jsonOpts.TableName)
+		}
+
+		sch, err = tbl.GetSchema(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if jsonOpts.MappingFile == "" {
+		return sch, nil
+	}
+
+	inNameToOutName, err := rowconv.NameMapFromFile(jsonOpts.MappingFile, fs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	outNameToInName := make(map[string]string, len(inNameToOutName))
+	for inName, outName := range inNameToOutName {
+		outNameToInName[outName] = inName
+	}
+
+	var cols []schema.Column
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if inName, ok := outNameToInName[col.Name]; ok {
+			col.Name = inName
+		}
+
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.SchemaFromCols(colColl), nil
+}
+
 func addPrimaryKey(sch schema.Schema, explicitKey string) (schema.Schema, error) {
 	if explicitKey != "" {
 		keyCols := strings.Split(explicitKey, ",")
@@ -26,8 +26,10 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/sqlexport"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/sqlimport"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/xlsx"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 )
@@ -43,8 +45,12 @@ func DFFromString(dfStr string) DataFormat {
 		return XlsxFile
 	case "json", ".json":
 		return JsonFile
+	case "jsonl", ".jsonl", "ndjson", ".ndjson":
+		return JsonLinesFile
 	case "sql", ".sql":
 		return SqlFile
+	case "parquet", ".parquet":
+		return ParquetFile
 	default:
 		return InvalidDataFormat
 	}
@@ -126,6 +132,45 @@ func (dl FileDataLocation) NewReader(ctx context.Context, root *doltdb.RootValue
 		}
 		rd, err := json.OpenJSONReader(root.VRW().Format(), dl.Path, fs, json.NewJSONInfo(), sch, schPath)
 		return rd, false, err
+
+	case JsonLinesFile:
+		sch, err := jsonLinesSchema(ctx, root, fs, schPath, opts)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.OpenJSONLinesReader(root.VRW().Format(), dl.Path, fs, sch)
+		return rd, false, err
+
+	case ParquetFile:
+		rd, err := parquet.OpenParquetReader(root.VRW().Format(), dl.Path, fs)
+		return rd, false, err
+
+	case SqlFile:
+		sqlOpts, _ := opts.(SqlOptions)
+		sch, err := schFromFileOrDefault(schPath, fs, nil)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := sqlimport.OpenSQLImportReader(root.VRW().Format(), dl.Path, fs, sqlOpts.TableName, sch)
+
+		if err == sqlimport.ErrNoCreateTable {
+			// files of only INSERT statements can be read when updating a table, using the table's schema
+			if table, exists, tblErr := root.GetTable(ctx, sqlOpts.TableName); tblErr != nil {
+				return nil, false, tblErr
+			} else if exists {
+				if sch, err = table.GetSchema(ctx); err != nil {
+					return nil, false, err
+				}
+
+				rd, err = sqlimport.OpenSQLImportReader(root.VRW().Format(), dl.Path, fs, sqlOpts.TableName, sch)
+			}
+		}
+
+		return rd, false, err
 	}
 
 	return nil, false, errors.New("unsupported format")
@@ -143,8 +188,12 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts *MoveOp
 		panic("writing to xlsx files is not supported yet")
 	case JsonFile:
 		return json.OpenJSONWriter(dl.Path, fs, outSch, json.NewJSONInfo())
+	case JsonLinesFile:
+		return json.OpenJSONLinesWriter(dl.Path, fs, outSch)
 	case SqlFile:
 		return sqlexport.OpenSQLExportWriter(dl.Path, mvOpts.TableName, fs, outSch)
+	case ParquetFile:
+		return parquet.OpenParquetWriter(dl.Path, fs, outSch)
 	}
 
 	panic("Invalid Data Format." + string(dl.Format))
@@ -155,3 +204,9 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts *MoveOp
 func (dl FileDataLocation) NewUpdatingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
 	panic("Updating of files is not supported")
 }
+
+// NewReplacingWriter will create a TableWriteCloser for a DataLocation that will replace all of its existing rows
+// with the rows written, deleting the rows which aren't.
+func (dl FileDataLocation) NewReplacingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
+	panic("Replacing of files is not supported")
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -67,6 +68,16 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, root *doltdb.RootVal
 	case PsvFile:
 		rd, err := csv.NewCSVReader(root.VRW().Format(), ioutil.NopCloser(dl.Reader), csv.NewCSVInfo().SetDelim("|"))
 		return rd, false, err
+
+	case JsonLinesFile:
+		sch, err := jsonLinesSchema(ctx, root, fs, schPath, opts)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.NewJSONLinesReader(root.VRW().Format(), ioutil.NopCloser(dl.Reader), sch)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New(string(dl.Format) + "is an unsupported format to read from stdin")
@@ -81,6 +92,9 @@ func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts *Move
 
 	case PsvFile:
 		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo().SetDelim("|"))
+
+	case JsonLinesFile:
+		return json.NewJSONLinesWriter(iohelp.NopWrCloser(dl.Writer), outSch)
 	}
 
 	return nil, errors.New(string(dl.Format) + "is an unsupported format to write to stdout")
@@ -91,3 +105,9 @@ func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts *Move
 func (dl StreamDataLocation) NewUpdatingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
 	panic("Updating is not supported for stdout")
 }
+
+// NewReplacingWriter will create a TableWriteCloser for a DataLocation that will replace all of its existing rows
+// with the rows written, deleting the rows which aren't.
+func (dl StreamDataLocation) NewReplacingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
+	panic("Replacing is not supported for stdout")
+}
@@ -119,3 +119,26 @@ func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, mvOpts *MoveO
 
 	return noms.NewNomsMapUpdater(ctx, root.VRW(), m, outSch, statsCB), nil
 }
+
+// NewReplacingWriter will create a TableWriteCloser for a DataLocation that will replace all of its existing rows
+// with the rows written, deleting the rows which aren't. Rows which are unchanged are left as they are, so that the
+// table's diff shows only the rows which changed.
+func (dl TableDataLocation) NewReplacingWriter(ctx context.Context, mvOpts *MoveOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
+	tbl, ok, err := root.GetTable(ctx, dl.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, errors.New("Could not find table " + dl.Name)
+	}
+
+	m, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return noms.NewNomsMapReplacer(ctx, root.VRW(), m, outSch, statsCB), nil
+}
@@ -42,3 +42,8 @@ var InvalidBranchNameRegex = regexp.MustCompile(strings.Join([]string{
 func IsValidBranchName(s string) bool {
 	return !InvalidBranchNameRegex.MatchString(s)
 }
+
+// IsValidTagName returns true if the string given is a valid tag name. Tag names follow the same rules as branch names.
+func IsValidTagName(s string) bool {
+	return IsValidBranchName(s)
+}
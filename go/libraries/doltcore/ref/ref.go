@@ -45,11 +45,14 @@ const (
 
 	// InternalRefType is a reference to a dolt internal commit
 	InternalRefType RefType = "internal"
+
+	// TagRefType is a reference to a tag in the format refs/tags/...
+	TagRefType RefType = "tags"
 )
 
 // RefTypes is the set of all supported reference types.  External RefTypes can be added to this map in order to add
 // RefTypes for external tooling
-var RefTypes = map[RefType]struct{}{BranchRefType: {}, RemoteRefType: {}, InternalRefType: {}}
+var RefTypes = map[RefType]struct{}{BranchRefType: {}, RemoteRefType: {}, InternalRefType: {}, TagRefType: {}}
 
 // PrefixForType returns what a reference string for a given type should start with
 func PrefixForType(refType RefType) string {
@@ -130,6 +133,8 @@ func Parse(str string) (DoltRef, error) {
 				return NewRemoteRefFromPathStr(str)
 			case InternalRefType:
 				return NewInternalRef(str), nil
+			case TagRefType:
+				return NewTagRef(str), nil
 			default:
 				panic("unknown type " + rType)
 			}
@@ -29,7 +29,8 @@ var ErrInvalidRefSpec = errors.New("invalid ref spec")
 var ErrInvalidMapping = errors.New("invalid ref spec mapping")
 
 // ErrUnsupportedMapping is returned when trying to do anything other than map local branches (refs/heads/*) to
-// remote tracking branches (refs/remotes/*).  As other mappings are added this code will need updating
+// remote tracking branches (refs/remotes/*), branches to branches, or tags (refs/tags/*) to tags.  As other mappings
+// are added this code will need updating
 var ErrUnsupportedMapping = errors.New("unsupported mapping")
 
 // RefSpec is an interface for mapping a reference in one space to a reference in another space.
@@ -101,6 +102,8 @@ func ParseRefSpecForRemote(remote, refSpecStr string) (RefSpec, error) {
 		return newLocalToRemoteTrackingRef(remote, fromRef.(BranchRef), toRef.(RemoteRef))
 	} else if fromRef.GetType() == BranchRefType && toRef.GetType() == BranchRefType {
 		return NewBranchToBranchRefSpec(fromRef.(BranchRef), toRef.(BranchRef))
+	} else if fromRef.GetType() == TagRefType && toRef.GetType() == TagRefType {
+		return NewTagToTagRefSpec(fromRef.(TagRef), toRef.(TagRef))
 	}
 
 	return nil, ErrUnsupportedMapping
@@ -248,3 +251,52 @@ func (rs BranchToTrackingBranchRefSpec) DestRef(branchRef DoltRef) DoltRef {
 func (rs BranchToTrackingBranchRefSpec) GetRemote() string {
 	return rs.remote
 }
+
+// TagToTagRefSpec maps a tag, or a set of tags matching a pattern, to tags with the corresponding names.
+type TagToTagRefSpec struct {
+	srcPattern pattern
+	srcToDest  branchMapper
+}
+
+// NewTagToTagRefSpec takes a source and destination TagRef and returns a RefSpec that maps source to dest. Both refs
+// may contain a single wildcard, in which case the portion of the tag name matched by the wildcard in the source is
+// substituted into the destination.
+func NewTagToTagRefSpec(srcRef, destRef TagRef) (RefSpec, error) {
+	srcWCs := strings.Count(srcRef.GetPath(), "*")
+	destWCs := strings.Count(destRef.GetPath(), "*")
+
+	if srcWCs != destWCs || srcWCs > 1 {
+		return nil, ErrInvalidRefSpec
+	}
+
+	if srcWCs == 0 {
+		return TagToTagRefSpec{
+			srcPattern: strPattern(srcRef.GetPath()),
+			srcToDest:  identityBranchMapper(destRef.GetPath()),
+		}, nil
+	}
+
+	return TagToTagRefSpec{
+		srcPattern: newWildcardPattern(srcRef.GetPath()),
+		srcToDest:  newWildcardBranchMapper(destRef.GetPath()),
+	}, nil
+}
+
+// SrcRef returns nil, as a tag ref spec doesn't depend on the current working branch. Callers should match each of
+// the source tags against DestRef instead.
+func (rs TagToTagRefSpec) SrcRef(cwbRef DoltRef) DoltRef {
+	return nil
+}
+
+// DestRef verifies the tagRef matches the refspec's source pattern, and then maps it to the destination tag, or to
+// nil if it does not match the pattern.
+func (rs TagToTagRefSpec) DestRef(tagRef DoltRef) DoltRef {
+	if tagRef.GetType() == TagRefType {
+		captured, matches := rs.srcPattern.matches(tagRef.GetPath())
+		if matches {
+			return NewTagRef(rs.srcToDest.mapBranch(captured))
+		}
+	}
+
+	return nil
+}
@@ -65,6 +65,28 @@ func TestRefSpec(t *testing.T) {
 				"refs/heads/master":  "refs/heads/master",
 				"refs/heads/feature": "refs/nil/",
 			},
+		}, {
+			"origin",
+			"refs/tags/*:refs/tags/*",
+			true,
+			map[string]string{
+				"refs/tags/v1.0":    "refs/tags/v1.0",
+				"refs/tags/v2.0":    "refs/tags/v2.0",
+				"refs/heads/master": "refs/nil/",
+			},
+		}, {
+			"",
+			"refs/tags/v1.0:refs/tags/release-1",
+			true,
+			map[string]string{
+				"refs/tags/v1.0": "refs/tags/release-1",
+				"refs/tags/v2.0": "refs/nil/",
+			},
+		}, {
+			"",
+			"refs/tags/*:refs/tags/v1.0",
+			false,
+			nil,
 		}, {
 			"origin",
 			"refs/heads/master:refs/remotes/not_borigin/mymaster",
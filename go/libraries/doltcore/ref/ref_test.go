@@ -40,6 +40,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewInternalRef("create"),
 			`{"test":"refs/internal/create"}`,
 		},
+		{
+			NewTagRef("v1.0"),
+			`{"test":"refs/tags/v1.0"}`,
+		},
 	}
 
 	for _, test := range tests {
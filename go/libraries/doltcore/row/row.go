@@ -85,6 +85,31 @@ func IsValid(r Row, sch schema.Schema) (bool, error) {
 	return column == nil && constraint == nil, nil
 }
 
+// ApplyDefaults returns the row given with each column of the schema which has a default value, and which the row has
+// no value for, set to its default value.
+func ApplyDefaults(r Row, sch schema.Schema) (Row, error) {
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if val, ok := r.GetColVal(tag); ok && !types.IsNull(val) {
+			return false, nil
+		}
+
+		defaultVal, err := col.DefaultValue()
+
+		if err != nil || defaultVal == nil {
+			return err != nil, err
+		}
+
+		r, err = r.SetColVal(tag, defaultVal, sch)
+		return err != nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
 // GetInvalidCol returns the first column in the schema that fails a constraint, or nil if none do.
 func GetInvalidCol(r Row, sch schema.Schema) (*schema.Column, error) {
 	badCol, _, err := findInvalidCol(r, sch)
@@ -191,6 +191,17 @@ func NameMapping(srcSch, destSch schema.Schema) (*FieldMapping, error) {
 
 // MappingFromFile reads a FieldMapping from a json file
 func MappingFromFile(mappingFile string, fs filesys.ReadableFS, inSch, outSch schema.Schema) (*FieldMapping, error) {
+	inNameToOutName, err := NameMapFromFile(mappingFile, fs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFieldMappingFromNameMap(inSch, outSch, inNameToOutName)
+}
+
+// NameMapFromFile reads the map from source field names to destination field names in a json mapping file
+func NameMapFromFile(mappingFile string, fs filesys.ReadableFS) (map[string]string, error) {
 	data, err := fs.ReadFile(mappingFile)
 
 	if err != nil {
@@ -204,7 +215,7 @@ func MappingFromFile(mappingFile string, fs filesys.ReadableFS, inSch, outSch sc
 		return nil, ErrUnmarshallingMapping
 	}
 
-	return NewFieldMappingFromNameMap(inSch, outSch, inNameToOutName)
+	return inNameToOutName, nil
 }
 
 // TypedToUntypedMapping takes a schema and creates a mapping to an untyped schema with all the same columns.
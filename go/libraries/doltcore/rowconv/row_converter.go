@@ -56,16 +56,36 @@ func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 			return nil, fmt.Errorf("Could not find column being mapped. src tag: %d, dest tag: %d", srcTag, destTag)
 		}
 
-		convFuncs[srcTag] = doltcore.GetConvFunc(srcCol.Kind, destCol.Kind)
+		convFunc := doltcore.GetConvFunc(srcCol.Kind, destCol.Kind)
 
-		if convFuncs[srcTag] == nil {
+		if convFunc == nil {
 			return nil, fmt.Errorf("Unsupported conversion from type %s to %s", srcCol.KindString(), destCol.KindString())
 		}
+
+		if len(destCol.TypeParams) > 0 {
+			convFunc = normalizingConvFunc(convFunc, destCol)
+		}
+
+		convFuncs[srcTag] = convFunc
 	}
 
 	return &RowConverter{mapping, false, convFuncs}, nil
 }
 
+// normalizingConvFunc returns a ConvFunc which converts values with the ConvFunc given, then normalizes them to the type
+// params of the destination column, e.g. rounding decimals to its scale.
+func normalizingConvFunc(convFunc doltcore.ConvFunc, destCol schema.Column) doltcore.ConvFunc {
+	return func(val types.Value) (types.Value, error) {
+		outVal, err := convFunc(val)
+
+		if err != nil || types.IsNull(outVal) {
+			return outVal, err
+		}
+
+		return destCol.NormalizeValue(outVal)
+	}
+}
+
 // Convert takes a row maps its columns to their destination columns, and performs any type conversion needed to create
 // a row of the expected destination schema.
 func (rc *RowConverter) Convert(inRow row.Row) (row.Row, error) {
@@ -160,26 +180,47 @@ func GetRowConvTransformFunc(rc *RowConverter) func(row.Row, pipeline.ReadableMa
 			return []*pipeline.TransformedRowResult{{RowData: inRow, PropertyUpdates: nil}}, ""
 		}
 	} else {
-		return func(inRow row.Row, props pipeline.ReadableMap) (outRows []*pipeline.TransformedRowResult, badRowDetails string) {
-			outRow, err := rc.Convert(inRow)
+		return rowConvTransformFunc(rc, false)
+	}
+}
+
+// GetRowConvTransformFuncWithDefaults is like GetRowConvTransformFunc, but each converted row is given the default
+// values of the destination schema's columns which it has no values for before it's validated. Used when writing rows
+// to a table.
+func GetRowConvTransformFuncWithDefaults(rc *RowConverter) func(row.Row, pipeline.ReadableMap) ([]*pipeline.TransformedRowResult, string) {
+	return rowConvTransformFunc(rc, true)
+}
+
+func rowConvTransformFunc(rc *RowConverter, withDefaults bool) func(row.Row, pipeline.ReadableMap) ([]*pipeline.TransformedRowResult, string) {
+	return func(inRow row.Row, props pipeline.ReadableMap) (outRows []*pipeline.TransformedRowResult, badRowDetails string) {
+		outRow, err := rc.Convert(inRow)
+
+		if err != nil {
+			return nil, err.Error()
+		}
+
+		if withDefaults {
+			outRow, err = row.ApplyDefaults(outRow, rc.DestSch)
 
 			if err != nil {
 				return nil, err.Error()
 			}
+		}
 
-			if isv, err := row.IsValid(outRow, rc.DestSch); err != nil {
-				return nil, err.Error()
-			} else if !isv {
-				col, err := row.GetInvalidCol(outRow, rc.DestSch)
-
-				if err != nil {
-					return nil, "invalid column"
-				} else {
-					return nil, "invalid column: " + col.Name
-				}
+		if isv, err := row.IsValid(outRow, rc.DestSch); err != nil {
+			return nil, err.Error()
+		} else if !isv {
+			col, cnst, err := row.GetInvalidConstraint(outRow, rc.DestSch)
+
+			if err != nil || col == nil {
+				return nil, "invalid column"
+			} else if cnst == nil {
+				return nil, "invalid column: " + col.Name
+			} else {
+				return nil, fmt.Sprintf("invalid column: %s: %v", col.Name, cnst)
 			}
-
-			return []*pipeline.TransformedRowResult{{RowData: outRow, PropertyUpdates: nil}}, ""
 		}
+
+		return []*pipeline.TransformedRowResult{{RowData: outRow, PropertyUpdates: nil}}, ""
 	}
 }
@@ -96,3 +96,45 @@ func TestUnneccessaryConversion(t *testing.T) {
 		t.Error("expected identity converter")
 	}
 }
+
+func TestRowConverterNormalizesValues(t *testing.T) {
+	strCols, _ := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.StringKind, true),
+		schema.NewColumn("amount", 1, types.StringKind, false),
+		schema.NewColumn("day", 2, types.StringKind, false),
+	)
+	destCols, _ := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.StringKind, true),
+		schema.NewColumnWithTypeParams("amount", 1, types.DecimalKind, false, map[string]string{schema.PrecisionParam: "5", schema.ScaleParam: "2"}),
+		schema.NewColumnWithTypeParams("day", 2, types.TimestampKind, false, map[string]string{schema.SQLTypeParam: schema.DateSQLType}),
+	)
+	strSch := schema.SchemaFromCols(strCols)
+	destSch := schema.SchemaFromCols(destCols)
+
+	mapping, err := TagMapping(strSch, destSch)
+	assert.NoError(t, err)
+
+	rConv, err := NewRowConverter(mapping)
+	assert.NoError(t, err)
+
+	inRow, err := row.New(types.Format_7_18, strSch, row.TaggedValues{
+		0: types.String("a"),
+		1: types.String("1.005"),
+		2: types.String("2019-08-01 12:30:00"),
+	})
+	assert.NoError(t, err)
+
+	outRow, err := rConv.Convert(inRow)
+	assert.NoError(t, err)
+
+	amount, _ := outRow.GetColVal(1)
+	day, _ := outRow.GetColVal(2)
+	assert.Equal(t, "1.01", amount.(types.Decimal).String())
+	assert.Equal(t, "2019-08-01 00:00:00", day.(types.Timestamp).String())
+
+	inRow, err = inRow.SetColVal(1, types.String("1000"), strSch)
+	assert.NoError(t, err)
+
+	_, err = rConv.Convert(inRow)
+	assert.Error(t, err)
+}
@@ -38,22 +38,45 @@ const (
 //
 // Returns an error if the column added conflicts with the existing schema in tag or name.
 func AddColumnToTable(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, tag uint64, newColName string, colKind types.NomsKind, nullable Nullable, defaultVal types.Value) (*doltdb.Table, error) {
+	var col schema.Column
+	if nullable {
+		col = schema.NewColumn(newColName, tag, colKind, false)
+	} else {
+		col = schema.NewColumn(newColName, tag, colKind, false, schema.NotNullConstraint{})
+	}
+
+	return AddColumn(ctx, db, tbl, col, defaultVal)
+}
+
+// AddColumn adds the column given, along with its type parameters and constraints, to the table given and returns the
+// new table value. Every existing row is given the default value provided, which must satisfy the column's constraints.
+//
+// Returns an error if the column added conflicts with the existing schema in tag or name.
+func AddColumn(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, col schema.Column, defaultVal types.Value) (*doltdb.Table, error) {
 	sch, err := tbl.GetSchema(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if err := validateNewColumn(ctx, tbl, tag, newColName, colKind, nullable, defaultVal); err != nil {
+	if err := validateNewColumn(ctx, tbl, col.Tag, col.Name, col.Kind, Nullable(col.IsNullable()), defaultVal); err != nil {
 		return nil, err
 	}
 
-	newSchema, err := createNewSchema(sch, tag, newColName, colKind, nullable)
+	if defaultVal != nil {
+		for _, cnst := range col.Constraints {
+			if !cnst.SatisfiesConstraint(defaultVal) {
+				return nil, fmt.Errorf("Default value for column %s does not satisfy constraint: %v", col.Name, cnst)
+			}
+		}
+	}
+
+	updatedCols, err := sch.GetAllCols().Append(col)
 	if err != nil {
 		return nil, err
 	}
 
-	return updateTableWithNewSchema(ctx, db, tbl, tag, newSchema, defaultVal)
+	return updateTableWithNewSchema(ctx, db, tbl, col.Tag, schema.SchemaFromCols(updatedCols), defaultVal)
 }
 
 // updateTableWithNewSchema updates the existing table with a new schema and new values for the new column as necessary,
@@ -72,7 +95,13 @@ func updateTableWithNewSchema(ctx context.Context, db *doltdb.DoltDB, tbl *doltd
 	}
 
 	if defaultVal == nil {
-		return doltdb.NewTable(ctx, vrw, newSchemaVal, rowData)
+		newTable, err := doltdb.NewTable(ctx, vrw, newSchemaVal, rowData)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return newTable.RebuildIndexesFrom(ctx, tbl)
 	}
 
 	me := rowData.Edit()
@@ -102,24 +131,13 @@ func updateTableWithNewSchema(ctx context.Context, db *doltdb.DoltDB, tbl *doltd
 		return nil, err
 	}
 
-	return doltdb.NewTable(ctx, vrw, newSchemaVal, m)
-}
-
-// createNewSchema Creates a new schema with a column as specified by the params.
-func createNewSchema(sch schema.Schema, tag uint64, newColName string, colKind types.NomsKind, nullable Nullable) (schema.Schema, error) {
-	var col schema.Column
-	if nullable {
-		col = schema.NewColumn(newColName, tag, colKind, false)
-	} else {
-		col = schema.NewColumn(newColName, tag, colKind, false, schema.NotNullConstraint{})
-	}
+	newTable, err := doltdb.NewTable(ctx, vrw, newSchemaVal, m)
 
-	updatedCols, err := sch.GetAllCols().Append(col)
 	if err != nil {
 		return nil, err
 	}
 
-	return schema.SchemaFromCols(updatedCols), nil
+	return newTable.RebuildIndexesFrom(ctx, tbl)
 }
 
 // validateNewColumn returns an error if the column as specified cannot be added to the schema given.
@@ -0,0 +1,244 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ConvFunc converts a value of a column's old type to its new type, returning nil for a non-null value that can't be
+// represented in the new type.
+type ConvFunc func(types.Value) types.Value
+
+// RowConversionError is returned by ModifyColumn when some of the table's rows couldn't be converted to the new column
+// definition. It describes the problem with each of those rows.
+type RowConversionError struct {
+	ColName string
+	RowErrs []string
+}
+
+func (e *RowConversionError) Error() string {
+	return fmt.Sprintf("%d row(s) could not be converted for column '%s':\n\t%s", len(e.RowErrs), e.ColName, strings.Join(e.RowErrs, "\n\t"))
+}
+
+// ModifyColumn changes the type, type parameters and nullability of the column of the table with the same name as
+// newCol to those of newCol, and returns the new table value. The column keeps its tag and its membership of the primary
+// key. Every row of the table is rewritten, converting the column's values with the conversion function given, which
+// may be nil if there is no conversion from the column's old type to its new type.
+//
+// Returns a *RowConversionError listing every row whose value can't be converted, violates the new column's
+// constraints, or gives a primary key that collides with another row's.
+func ModifyColumn(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, newCol schema.Column, convFn ConvFunc) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	oldCol, ok := sch.GetAllCols().GetByName(newCol.Name)
+
+	if !ok {
+		return nil, schema.ErrColNotFound
+	}
+
+	if convFn == nil {
+		return nil, fmt.Errorf("Column '%s' can't be converted from %s to %s", newCol.Name, schema.KindToLwrStr[oldCol.Kind], schema.KindToLwrStr[newCol.Kind])
+	}
+
+	// The column keeps its place in or out of the primary key, and primary key columns may never be null
+	newCol.Tag = oldCol.Tag
+	newCol.IsPartOfPK = oldCol.IsPartOfPK
+	if newCol.IsPartOfPK && newCol.IsNullable() {
+		newCol.Constraints = append(newCol.Constraints, schema.NotNullConstraint{})
+	}
+
+	cols := make([]schema.Column, 0)
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if tag == newCol.Tag {
+			col = newCol
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newSch := schema.SchemaFromCols(colColl)
+	newRowData, err := convertRows(ctx, db.ValueReadWriter(), tbl, sch, newSch, newCol, convFn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVal, err := encoding.MarshalAsNomsValue(ctx, db.ValueReadWriter(), newSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newTable, err := doltdb.NewTable(ctx, db.ValueReadWriter(), schemaVal, newRowData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTable.RebuildIndexesFrom(ctx, tbl)
+}
+
+// convertRows returns the rows of the table given rewritten under the new schema, with the values of the modified column
+// converted.
+func convertRows(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Table, oldSch, newSch schema.Schema, newCol schema.Column, convFn ConvFunc) (types.Map, error) {
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	newRowData, err := types.NewMap(ctx, vrw)
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	me := newRowData.Edit()
+	nbf := rowData.Format()
+	seenKeys := make(map[hash.Hash]bool)
+	var rowErrs []string
+	err = rowData.IterAll(ctx, func(k, v types.Value) error {
+		r, err := row.FromNoms(oldSch, k.(types.Tuple), v.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		oldVal, _ := r.GetColVal(newCol.Tag)
+		newVal, err := convertValue(oldVal, newCol, convFn)
+
+		if err != nil {
+			rowErrs = append(rowErrs, fmt.Sprintf("row with primary key (%s): %s", primaryKeyString(oldSch, r), err.Error()))
+			return nil
+		}
+
+		r, err = r.SetColVal(newCol.Tag, newVal, newSch)
+
+		if err != nil {
+			return err
+		}
+
+		key, err := r.NomsMapKey(newSch).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if newCol.IsPartOfPK {
+			h, err := key.Hash(nbf)
+
+			if err != nil {
+				return err
+			}
+
+			if seenKeys[h] {
+				rowErrs = append(rowErrs, fmt.Sprintf("row with primary key (%s): the converted primary key duplicates another row's", primaryKeyString(oldSch, r)))
+				return nil
+			}
+
+			seenKeys[h] = true
+		}
+
+		me.Set(key, r.NomsMapValue(newSch))
+		return nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if len(rowErrs) > 0 {
+		return types.EmptyMap, &RowConversionError{ColName: newCol.Name, RowErrs: rowErrs}
+	}
+
+	return me.Map(ctx)
+}
+
+// convertValue converts a value of the modified column to its new type, returning an error if the value can't be
+// represented in the new type or doesn't satisfy the new column's constraints.
+func convertValue(val types.Value, newCol schema.Column, convFn ConvFunc) (types.Value, error) {
+	var newVal types.Value
+	if !types.IsNull(val) {
+		newVal = convFn(val)
+
+		if types.IsNull(newVal) {
+			return nil, fmt.Errorf("value %s can't be converted to %s", valueString(val), schema.KindToLwrStr[newCol.Kind])
+		}
+
+		var err error
+		newVal, err = newCol.NormalizeValue(newVal)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cnst := range newCol.Constraints {
+		if !cnst.SatisfiesConstraint(newVal) {
+			return nil, fmt.Errorf("value %s violates the %s constraint of column '%s'", valueString(newVal), cnst.GetConstraintType(), newCol.Name)
+		}
+	}
+
+	return newVal, nil
+}
+
+func primaryKeyString(sch schema.Schema, r row.Row) string {
+	var pkVals []string
+	_ = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, _ := r.GetColVal(tag)
+		pkVals = append(pkVals, col.Name+": "+valueString(val))
+		return false, nil
+	})
+
+	return strings.Join(pkVals, ", ")
+}
+
+func valueString(val types.Value) string {
+	if types.IsNull(val) {
+		return "NULL"
+	}
+
+	str, err := types.EncodedValue(context.Background(), val)
+
+	if err != nil {
+		return "<" + err.Error() + ">"
+	}
+
+	return str
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestModifyColumn(t *testing.T) {
+	uintToInt := func(val types.Value) types.Value {
+		return types.Int(int64(val.(types.Uint)))
+	}
+
+	identity := func(val types.Value) types.Value {
+		return val
+	}
+
+	tests := []struct {
+		name           string
+		newCol         schema.Column
+		convFn         ConvFunc
+		expectedSchema schema.Schema
+		expectedRows   []row.Row
+		expectedErr    string
+	}{
+		{
+			name:   "change type",
+			newCol: schema.NewColumn("age", 0, types.IntKind, false, schema.NotNullConstraint{}),
+			convFn: uintToInt,
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.IntKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: convertedAgeRows(t, uintToInt),
+		},
+		{
+			name:   "drop not null",
+			newCol: schema.NewColumn("name", 0, types.StringKind, false),
+			convFn: identity,
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: dtestutils.TypedRows,
+		},
+		{
+			name:   "primary key column stays in the primary key",
+			newCol: schema.NewColumn("id", 0, types.UUIDKind, false),
+			convFn: identity,
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: dtestutils.TypedRows,
+		},
+		{
+			name:        "no conversion",
+			newCol:      schema.NewColumn("age", 0, types.BoolKind, false),
+			expectedErr: "can't be converted from uint to bool",
+		},
+		{
+			name:   "values that can't be converted",
+			newCol: schema.NewColumn("age", 0, types.IntKind, false),
+			convFn: func(val types.Value) types.Value {
+				if val.(types.Uint) > 30 {
+					return nil
+				}
+				return uintToInt(val)
+			},
+			expectedErr: "1 row(s) could not be converted for column 'age'",
+		},
+		{
+			name:        "column not found",
+			newCol:      schema.NewColumn("not found", 0, types.IntKind, false),
+			convFn:      identity,
+			expectedErr: "column not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := createEnvWithSeedData(t)
+			ctx := context.Background()
+
+			root, err := dEnv.WorkingRoot(ctx)
+			assert.NoError(t, err)
+			tbl, _, err := root.GetTable(ctx, tableName)
+			require.NoError(t, err)
+
+			updatedTable, err := ModifyColumn(ctx, dEnv.DoltDB, tbl, tt.newCol, tt.convFn)
+			if len(tt.expectedErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			} else {
+				require.NoError(t, err)
+			}
+
+			sch, err := updatedTable.GetSchema(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSchema, sch)
+
+			rowData, err := updatedTable.GetRowData(ctx)
+			require.NoError(t, err)
+
+			var foundRows []row.Row
+			err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+				tpl, err := row.FromNoms(tt.expectedSchema, key.(types.Tuple), value.(types.Tuple))
+
+				if err != nil {
+					return false, err
+				}
+
+				foundRows = append(foundRows, tpl)
+				return false, nil
+			})
+
+			assert.Equal(t, tt.expectedRows, foundRows)
+		})
+	}
+}
+
+func TestModifyColumnReportsEachRow(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	ctx := context.Background()
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, tableName)
+	require.NoError(t, err)
+
+	newCol := schema.NewColumn("age", 0, types.IntKind, false)
+	_, err = ModifyColumn(ctx, dEnv.DoltDB, tbl, newCol, func(types.Value) types.Value { return nil })
+	require.Error(t, err)
+
+	convErr, ok := err.(*RowConversionError)
+	require.True(t, ok)
+	assert.Equal(t, "age", convErr.ColName)
+	assert.Len(t, convErr.RowErrs, len(dtestutils.TypedRows))
+	assert.Contains(t, convErr.RowErrs[0], "can't be converted to int")
+}
+
+func convertedAgeRows(t *testing.T, convFn ConvFunc) []row.Row {
+	var rows []row.Row
+	for _, r := range dtestutils.TypedRows {
+		age, _ := r.GetColVal(dtestutils.AgeTag)
+		r, err := r.SetColVal(dtestutils.AgeTag, convFn(age), dtestutils.TypedSchema)
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	return rows
+}
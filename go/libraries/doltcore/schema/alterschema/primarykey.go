@@ -0,0 +1,258 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// PrimaryKeyError is returned when rows can't be keyed by a new primary key, because their values of the new primary
+// key columns are null or the same as another row's. It describes the problem with each of those rows.
+type PrimaryKeyError struct {
+	RowErrs []string
+}
+
+func (e *PrimaryKeyError) Error() string {
+	return fmt.Sprintf("%d row(s) can't be keyed by the new primary key:\n\t%s", len(e.RowErrs), strings.Join(e.RowErrs, "\n\t"))
+}
+
+// ChangePrimaryKey makes the columns with the names given the primary key of the table given, and returns the new table
+// value. The primary key columns keep the order they have in the schema, become required, and every row of the table is
+// re-keyed by them. Columns removed from the primary key remain in the table.
+//
+// Returns a *PrimaryKeyError listing every row with a null value in a new primary key column, or with the same new
+// primary key as another row.
+func ChangePrimaryKey(ctx context.Context, db *doltdb.DoltDB, tbl *doltdb.Table, pkColNames []string) (*doltdb.Table, error) {
+	if len(pkColNames) == 0 {
+		return nil, schema.ErrNoPrimaryKeyColumns
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pkTags []uint64
+	for _, name := range pkColNames {
+		col, ok := sch.GetAllCols().GetByName(name)
+
+		if !ok {
+			return nil, schema.ErrColNotFound
+		}
+
+		pkTags = append(pkTags, col.Tag)
+	}
+
+	newSch, err := RekeySchema(sch, pkTags)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newRowData, err := RekeyRows(ctx, db.ValueReadWriter(), rowData, sch, newSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVal, err := encoding.MarshalAsNomsValue(ctx, db.ValueReadWriter(), newSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newTable, err := doltdb.NewTable(ctx, db.ValueReadWriter(), schemaVal, newRowData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTable.RebuildIndexesFrom(ctx, tbl)
+}
+
+// PrimaryKeysDiffer returns whether the two schemas given have different primary key columns.
+func PrimaryKeysDiffer(sch1, sch2 schema.Schema) bool {
+	pkTags1, pkTags2 := sch1.GetPKCols().Tags, sch2.GetPKCols().Tags
+
+	if len(pkTags1) != len(pkTags2) {
+		return true
+	}
+
+	for i := range pkTags1 {
+		if pkTags1[i] != pkTags2[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RekeySchema returns the schema given with the columns with the tags given as its primary key columns. New primary
+// key columns are made required. Returns schema.ErrColNotFound if any of the tags isn't in the schema.
+func RekeySchema(sch schema.Schema, pkTags []uint64) (schema.Schema, error) {
+	isPK := make(map[uint64]bool)
+	for _, tag := range pkTags {
+		if _, ok := sch.GetAllCols().GetByTag(tag); !ok {
+			return nil, schema.ErrColNotFound
+		}
+
+		isPK[tag] = true
+	}
+
+	cols := make([]schema.Column, 0)
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if isPK[tag] && !col.IsPartOfPK && col.IsNullable() {
+			col.Constraints = append(col.Constraints, schema.NotNullConstraint{})
+		}
+
+		col.IsPartOfPK = isPK[tag]
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.SchemaFromCols(colColl), nil
+}
+
+// RekeyRows returns the rows given, which are keyed by the primary key of oldSch, keyed by the primary key of newSch.
+// The two schemas must have the same columns. Returns a *PrimaryKeyError if any rows can't be keyed by the new primary
+// key.
+func RekeyRows(ctx context.Context, vrw types.ValueReadWriter, rowData types.Map, oldSch, newSch schema.Schema) (types.Map, error) {
+	newRowData, _, err := rekeyRows(ctx, vrw, rowData, oldSch, newSch, false)
+	return newRowData, err
+}
+
+// RekeyRowsDroppingErrors is like RekeyRows, except that rows which can't be keyed by the new primary key are left out
+// rather than causing an error. Of rows with the same new primary key, the first in the order of the old primary key is
+// kept. Returns the old keys of the rows left out.
+func RekeyRowsDroppingErrors(ctx context.Context, vrw types.ValueReadWriter, rowData types.Map, oldSch, newSch schema.Schema) (types.Map, []types.Value, error) {
+	return rekeyRows(ctx, vrw, rowData, oldSch, newSch, true)
+}
+
+func rekeyRows(ctx context.Context, vrw types.ValueReadWriter, rowData types.Map, oldSch, newSch schema.Schema, dropErrs bool) (types.Map, []types.Value, error) {
+	newRowData, err := types.NewMap(ctx, vrw)
+
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	me := newRowData.Edit()
+	nbf := rowData.Format()
+	keyToOldKey := make(map[hash.Hash]string)
+	var rowErrs []string
+	var dropped []types.Value
+	err = rowData.IterAll(ctx, func(k, v types.Value) error {
+		oldRow, err := row.FromNoms(oldSch, k.(types.Tuple), v.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		taggedVals, err := row.GetTaggedVals(oldRow)
+
+		if err != nil {
+			return err
+		}
+
+		r, err := row.New(nbf, newSch, taggedVals)
+
+		if err != nil {
+			return err
+		}
+
+		oldKeyStr := primaryKeyString(oldSch, oldRow)
+		var nullCols []string
+		_ = newSch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			if val, _ := r.GetColVal(tag); types.IsNull(val) {
+				nullCols = append(nullCols, col.Name)
+			}
+
+			return false, nil
+		})
+
+		if len(nullCols) > 0 && dropErrs {
+			dropped = append(dropped, k)
+			return nil
+		} else if len(nullCols) > 0 {
+			rowErrs = append(rowErrs, fmt.Sprintf("row with primary key (%s): NULL value in primary key column(s) %s", oldKeyStr, strings.Join(nullCols, ", ")))
+			return nil
+		}
+
+		key, err := r.NomsMapKey(newSch).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		h, err := key.Hash(nbf)
+
+		if err != nil {
+			return err
+		}
+
+		if otherKeyStr, ok := keyToOldKey[h]; ok && dropErrs {
+			dropped = append(dropped, k)
+			return nil
+		} else if ok {
+			rowErrs = append(rowErrs, fmt.Sprintf("rows with primary keys (%s) and (%s) have the same new primary key (%s)", otherKeyStr, oldKeyStr, primaryKeyString(newSch, r)))
+			return nil
+		}
+
+		keyToOldKey[h] = oldKeyStr
+		me.Set(key, r.NomsMapValue(newSch))
+		return nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	if len(rowErrs) > 0 {
+		return types.EmptyMap, nil, &PrimaryKeyError{RowErrs: rowErrs}
+	}
+
+	newRowData, err = me.Map(ctx)
+
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	return newRowData, dropped, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestChangePrimaryKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		pkColNames     []string
+		expectedSchema schema.Schema
+		expectedErr    string
+	}{
+		{
+			name:       "single column",
+			pkColNames: []string{"name"},
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+		},
+		{
+			name:       "nullable columns become required",
+			pkColNames: []string{"title", "age"},
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, true, schema.NotNullConstraint{}),
+			),
+		},
+		{
+			name:        "duplicate keys",
+			pkColNames:  []string{"is_married"},
+			expectedErr: "1 row(s) can't be keyed by the new primary key",
+		},
+		{
+			name:        "column not found",
+			pkColNames:  []string{"not found"},
+			expectedErr: "column not found",
+		},
+		{
+			name:        "no columns",
+			expectedErr: schema.ErrNoPrimaryKeyColumns.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := createEnvWithSeedData(t)
+			ctx := context.Background()
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			tbl, _, err := root.GetTable(ctx, tableName)
+			require.NoError(t, err)
+
+			updatedTable, err := ChangePrimaryKey(ctx, dEnv.DoltDB, tbl, tt.pkColNames)
+			if len(tt.expectedErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			} else {
+				require.NoError(t, err)
+			}
+
+			sch, err := updatedTable.GetSchema(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSchema, sch)
+
+			rowData, err := updatedTable.GetRowData(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(dtestutils.TypedRows)), rowData.Len())
+
+			for _, expectedRow := range dtestutils.TypedRows {
+				vals, err := row.GetTaggedVals(expectedRow)
+				require.NoError(t, err)
+				r, err := row.New(rowData.Format(), sch, vals)
+				require.NoError(t, err)
+
+				key, err := r.NomsMapKey(sch).Value(ctx)
+				require.NoError(t, err)
+				value, ok, err := rowData.MaybeGet(ctx, key)
+				require.NoError(t, err)
+				require.True(t, ok)
+
+				foundRow, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+				require.NoError(t, err)
+				assert.True(t, row.AreEqual(r, foundRow, sch))
+			}
+		})
+	}
+}
+
+func TestChangePrimaryKeyReportsEachRow(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	ctx := context.Background()
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, tableName)
+	require.NoError(t, err)
+
+	rowData, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+	r := nullTitleRow(t)
+	rowData, err = rowData.Edit().Set(r.NomsMapKey(dtestutils.TypedSchema), r.NomsMapValue(dtestutils.TypedSchema)).Map(ctx)
+	require.NoError(t, err)
+	tbl, err = tbl.UpdateRows(ctx, rowData)
+	require.NoError(t, err)
+
+	_, err = ChangePrimaryKey(ctx, dEnv.DoltDB, tbl, []string{"title"})
+	require.Error(t, err)
+
+	pkErr, ok := err.(*PrimaryKeyError)
+	require.True(t, ok)
+	require.Len(t, pkErr.RowErrs, 1)
+	assert.Contains(t, pkErr.RowErrs[0], "NULL value in primary key column(s) title")
+}
+
+func TestRekeyRowsDroppingErrors(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	ctx := context.Background()
+	vrw := dEnv.DoltDB.ValueReadWriter()
+
+	dupTitleRow, err := dtestutils.TypedRows[1].SetColVal(dtestutils.IdTag, types.UUID(uuid.MustParse("00000000-0000-0000-0000-000000000003")), dtestutils.TypedSchema)
+	require.NoError(t, err)
+
+	rowData, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+	me := rowData.Edit()
+	for _, r := range append(dtestutils.TypedRows, nullTitleRow(t), dupTitleRow) {
+		me.Set(r.NomsMapKey(dtestutils.TypedSchema), r.NomsMapValue(dtestutils.TypedSchema))
+	}
+	rowData, err = me.Map(ctx)
+	require.NoError(t, err)
+
+	newSch, err := RekeySchema(dtestutils.TypedSchema, []uint64{dtestutils.TitleTag})
+	require.NoError(t, err)
+
+	_, err = RekeyRows(ctx, vrw, rowData, dtestutils.TypedSchema, newSch)
+	require.Error(t, err)
+	assert.Len(t, err.(*PrimaryKeyError).RowErrs, 2)
+
+	newRowData, dropped, err := RekeyRowsDroppingErrors(ctx, vrw, rowData, dtestutils.TypedSchema, newSch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(dtestutils.TypedRows)), newRowData.Len())
+	assert.Len(t, dropped, 2)
+}
+
+func nullTitleRow(t *testing.T) row.Row {
+	r, err := row.New(types.Format_7_18, dtestutils.TypedSchema, row.TaggedValues{
+		dtestutils.IdTag:        types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")),
+		dtestutils.NameTag:      types.String("Nameless"),
+		dtestutils.AgeTag:       types.Uint(40),
+		dtestutils.IsMarriedTag: types.Bool(false),
+	})
+	require.NoError(t, err)
+
+	return r
+}
@@ -50,6 +50,7 @@ func RenameColumn(ctx context.Context, doltDB *doltdb.DoltDB, tbl *doltdb.Table,
 	err = allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		if col.Name == oldName {
 			col.Name = newName
+			col.Constraints = renameCheckConstraints(col.Constraints, newName)
 		}
 		cols = append(cols, col)
 		return false, nil
@@ -85,5 +86,19 @@ func RenameColumn(ctx context.Context, doltDB *doltdb.DoltDB, tbl *doltdb.Table,
 		return nil, err
 	}
 
-	return newTable, nil
+	return newTable.RebuildIndexesFrom(ctx, tbl)
+}
+
+// renameCheckConstraints returns the constraints given with any check constraints changed to reference the column name
+// given.
+func renameCheckConstraints(constraints []schema.ColConstraint, newName string) []schema.ColConstraint {
+	var renamed []schema.ColConstraint
+	for _, cnst := range constraints {
+		if cc, ok := cnst.(schema.CheckConstraint); ok {
+			cnst = cc.WithColumnName(newName)
+		}
+		renamed = append(renamed, cnst)
+	}
+
+	return renamed
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 )
 
-// RenameTable renames a table with in a RootValue and returns the updated root.
+// RenameTable renames a table with in a RootValue, along with its foreign keys, and returns the updated root.
 func RenameTable(ctx context.Context, doltDb *doltdb.DoltDB, root *doltdb.RootValue, oldName, newName string) (*doltdb.RootValue, error) {
 	if newName == oldName {
 		return root, nil
@@ -44,6 +44,10 @@ func RenameTable(ctx context.Context, doltDb *doltdb.DoltDB, root *doltdb.RootVa
 		return nil, doltdb.ErrTableExists
 	}
 
+	if root, err = root.RenameTableInForeignKeys(ctx, oldName, newName); err != nil {
+		return nil, err
+	}
+
 	if root, err = root.RemoveTables(ctx, oldName); err != nil {
 		return nil, err
 	}
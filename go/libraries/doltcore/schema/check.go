@@ -0,0 +1,831 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// CheckConstraint validates that a value satisfies a simple boolean expression over the column's value. Expressions
+// are comparisons of the column with literals, using =, !=, <>, <, <=, >, >=, BETWEEN, IN and IS NULL, combined with
+// AND, OR, NOT and parentheses, such as "`age` >= 18 AND `age` < 65". As in SQL, a value satisfies the constraint unless
+// the expression is false for it, so an expression which is unknown because the value is null is satisfied.
+type CheckConstraint struct {
+	// Expr is the expression in its canonical form
+	Expr string
+
+	colName string
+	expr    checkExpr
+}
+
+// NewCheckConstraint parses the expression given and returns a CheckConstraint for it. Every column referenced by the
+// expression must have the same name.
+func NewCheckConstraint(expr string) (CheckConstraint, error) {
+	p := &checkParser{}
+
+	if err := p.tokenize(expr); err != nil {
+		return CheckConstraint{}, err
+	}
+
+	e, err := p.parseOr()
+
+	if err != nil {
+		return CheckConstraint{}, err
+	}
+
+	if !p.atEnd() {
+		return CheckConstraint{}, fmt.Errorf("invalid check expression '%s': unexpected '%s'", expr, p.peek().text)
+	}
+
+	if p.colName == "" {
+		return CheckConstraint{}, fmt.Errorf("invalid check expression '%s': no column referenced", expr)
+	}
+
+	cc := CheckConstraint{colName: p.colName, expr: e}
+	cc.Expr = cc.Format(p.colName)
+
+	return cc, nil
+}
+
+// ColumnName returns the name of the column referenced by the expression.
+func (cc CheckConstraint) ColumnName() string {
+	return cc.colName
+}
+
+// WithColumnName returns the constraint with its expression referencing the column name given, for use when the column
+// is renamed.
+func (cc CheckConstraint) WithColumnName(name string) CheckConstraint {
+	cc.colName = name
+	cc.Expr = cc.Format(name)
+	return cc
+}
+
+// Format returns the expression with the column it references written as the name given.
+func (cc CheckConstraint) Format(colName string) string {
+	if cc.expr == nil {
+		return cc.Expr
+	}
+
+	return cc.expr.format("`" + colName + "`")
+}
+
+// ValidateKind returns an error if any literal in the expression can't be compared with values of the kind given.
+func (cc CheckConstraint) ValidateKind(kind types.NomsKind) error {
+	if cc.expr == nil {
+		return nil
+	}
+
+	return cc.expr.validate(kind)
+}
+
+// AsRange returns the constraint as a RangeConstraint if its expression is a BETWEEN, >= or <= comparison with a number,
+// and whether it is one.
+func (cc CheckConstraint) AsRange() (RangeConstraint, bool) {
+	switch e := cc.expr.(type) {
+	case checkBetween:
+		if !e.not && e.lo.num != nil && e.hi.num != nil {
+			return RangeConstraint{e.lo.text, e.hi.text}, true
+		}
+	case checkCmp:
+		if e.lit.num != nil && e.op == ">=" {
+			return RangeConstraint{Min: e.lit.text}, true
+		} else if e.lit.num != nil && e.op == "<=" {
+			return RangeConstraint{Max: e.lit.text}, true
+		}
+	}
+
+	return RangeConstraint{}, false
+}
+
+// SatisfiesConstraint returns true unless the expression is false for the value given
+func (cc CheckConstraint) SatisfiesConstraint(value types.Value) bool {
+	if cc.expr == nil {
+		return true
+	}
+
+	return cc.expr.eval(value) != checkFalse
+}
+
+// GetConstraintType returns "check"
+func (cc CheckConstraint) GetConstraintType() string {
+	return CheckConstraintType
+}
+
+// GetConstraintParams returns the expression
+func (cc CheckConstraint) GetConstraintParams() map[string]string {
+	return map[string]string{checkExprParam: cc.Expr}
+}
+
+// String returns a useful description of the constraint
+func (cc CheckConstraint) String() string {
+	return "Check (" + cc.Expr + ")"
+}
+
+// checkResult is the result of evaluating a check expression, which is unknown when comparing with null.
+type checkResult int
+
+const (
+	checkFalse checkResult = iota
+	checkTrue
+	checkUnknown
+)
+
+func checkResultOf(b bool) checkResult {
+	if b {
+		return checkTrue
+	}
+	return checkFalse
+}
+
+func (r checkResult) not() checkResult {
+	switch r {
+	case checkTrue:
+		return checkFalse
+	case checkFalse:
+		return checkTrue
+	}
+	return checkUnknown
+}
+
+type checkExpr interface {
+	eval(val types.Value) checkResult
+	format(col string) string
+	validate(kind types.NomsKind) error
+}
+
+type checkAnd struct {
+	left, right checkExpr
+}
+
+func (e checkAnd) eval(val types.Value) checkResult {
+	l, r := e.left.eval(val), e.right.eval(val)
+
+	switch {
+	case l == checkFalse || r == checkFalse:
+		return checkFalse
+	case l == checkTrue && r == checkTrue:
+		return checkTrue
+	}
+
+	return checkUnknown
+}
+
+func (e checkAnd) format(col string) string {
+	return formatOperand(e.left, col) + " and " + formatOperand(e.right, col)
+}
+
+func (e checkAnd) validate(kind types.NomsKind) error {
+	if err := e.left.validate(kind); err != nil {
+		return err
+	}
+	return e.right.validate(kind)
+}
+
+type checkOr struct {
+	left, right checkExpr
+}
+
+func (e checkOr) eval(val types.Value) checkResult {
+	l, r := e.left.eval(val), e.right.eval(val)
+
+	switch {
+	case l == checkTrue || r == checkTrue:
+		return checkTrue
+	case l == checkFalse && r == checkFalse:
+		return checkFalse
+	}
+
+	return checkUnknown
+}
+
+func (e checkOr) format(col string) string {
+	return e.left.format(col) + " or " + e.right.format(col)
+}
+
+func (e checkOr) validate(kind types.NomsKind) error {
+	if err := e.left.validate(kind); err != nil {
+		return err
+	}
+	return e.right.validate(kind)
+}
+
+// formatOperand formats an operand of AND, which needs parentheses if it is an OR.
+func formatOperand(e checkExpr, col string) string {
+	if _, ok := e.(checkOr); ok {
+		return "(" + e.format(col) + ")"
+	}
+	return e.format(col)
+}
+
+type checkNot struct {
+	expr checkExpr
+}
+
+func (e checkNot) eval(val types.Value) checkResult {
+	return e.expr.eval(val).not()
+}
+
+func (e checkNot) format(col string) string {
+	return "not (" + e.expr.format(col) + ")"
+}
+
+func (e checkNot) validate(kind types.NomsKind) error {
+	return e.expr.validate(kind)
+}
+
+type checkCmp struct {
+	op  string
+	lit checkLit
+}
+
+func (e checkCmp) eval(val types.Value) checkResult {
+	cmp, ok := e.lit.compare(val)
+
+	if !ok {
+		return checkUnknown
+	}
+
+	switch e.op {
+	case "=":
+		return checkResultOf(cmp == 0)
+	case "!=", "<>":
+		return checkResultOf(cmp != 0)
+	case "<":
+		return checkResultOf(cmp < 0)
+	case "<=":
+		return checkResultOf(cmp <= 0)
+	case ">":
+		return checkResultOf(cmp > 0)
+	case ">=":
+		return checkResultOf(cmp >= 0)
+	}
+
+	panic("unknown comparison operator " + e.op)
+}
+
+func (e checkCmp) format(col string) string {
+	return col + " " + e.op + " " + e.lit.format()
+}
+
+func (e checkCmp) validate(kind types.NomsKind) error {
+	return e.lit.validate(kind)
+}
+
+type checkBetween struct {
+	not    bool
+	lo, hi checkLit
+}
+
+func (e checkBetween) eval(val types.Value) checkResult {
+	loCmp, loOk := e.lo.compare(val)
+	hiCmp, hiOk := e.hi.compare(val)
+
+	var res checkResult
+	switch {
+	case (loOk && loCmp < 0) || (hiOk && hiCmp > 0):
+		res = checkFalse
+	case loOk && hiOk:
+		res = checkTrue
+	default:
+		res = checkUnknown
+	}
+
+	if e.not {
+		return res.not()
+	}
+	return res
+}
+
+func (e checkBetween) format(col string) string {
+	op := " between "
+	if e.not {
+		op = " not between "
+	}
+	return col + op + e.lo.format() + " and " + e.hi.format()
+}
+
+func (e checkBetween) validate(kind types.NomsKind) error {
+	if err := e.lo.validate(kind); err != nil {
+		return err
+	}
+	return e.hi.validate(kind)
+}
+
+type checkIn struct {
+	not  bool
+	lits []checkLit
+}
+
+func (e checkIn) eval(val types.Value) checkResult {
+	res := checkFalse
+	for _, lit := range e.lits {
+		cmp, ok := lit.compare(val)
+
+		if !ok {
+			res = checkUnknown
+		} else if cmp == 0 {
+			res = checkTrue
+			break
+		}
+	}
+
+	if e.not {
+		return res.not()
+	}
+	return res
+}
+
+func (e checkIn) format(col string) string {
+	var litStrs []string
+	for _, lit := range e.lits {
+		litStrs = append(litStrs, lit.format())
+	}
+
+	op := " in ("
+	if e.not {
+		op = " not in ("
+	}
+	return col + op + strings.Join(litStrs, ", ") + ")"
+}
+
+func (e checkIn) validate(kind types.NomsKind) error {
+	for _, lit := range e.lits {
+		if err := lit.validate(kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type checkIsNull struct {
+	not bool
+}
+
+func (e checkIsNull) eval(val types.Value) checkResult {
+	return checkResultOf(types.IsNull(val) != e.not)
+}
+
+func (e checkIsNull) format(col string) string {
+	if e.not {
+		return col + " is not null"
+	}
+	return col + " is null"
+}
+
+func (e checkIsNull) validate(kind types.NomsKind) error {
+	return nil
+}
+
+// checkLit is a literal in a check expression. Numbers have num set, and strings have str set. Booleans are numbers.
+type checkLit struct {
+	text string
+	num  *big.Rat
+	str  *string
+}
+
+// compare returns the result of comparing the value given with the literal, which is negative if the value is less than
+// the literal, and whether the two could be compared.
+func (lit checkLit) compare(val types.Value) (int, bool) {
+	if types.IsNull(val) || (lit.num == nil && lit.str == nil) {
+		return 0, false
+	}
+
+	if lit.num != nil {
+		if b, ok := val.(types.Bool); ok {
+			r := new(big.Rat)
+			if b {
+				r.SetInt64(1)
+			}
+			return r.Cmp(lit.num), true
+		}
+
+		if r, ok := numericRat(val); ok {
+			return r.Cmp(lit.num), true
+		}
+
+		return 0, false
+	}
+
+	switch v := val.(type) {
+	case types.String:
+		return strings.Compare(string(v), *lit.str), true
+	case types.UUID:
+		return strings.Compare(v.String(), strings.ToLower(*lit.str)), true
+	case types.Timestamp:
+		ts, err := types.ParseTimestamp(*lit.str)
+
+		if err != nil {
+			return 0, false
+		}
+
+		t, litT := time.Time(v), time.Time(ts)
+		if t.Before(litT) {
+			return -1, true
+		} else if t.After(litT) {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func (lit checkLit) format() string {
+	if lit.str != nil {
+		return "'" + strings.Replace(*lit.str, "'", "''", -1) + "'"
+	}
+	return lit.text
+}
+
+func (lit checkLit) validate(kind types.NomsKind) error {
+	if lit.num == nil && lit.str == nil {
+		return nil
+	}
+
+	switch kind {
+	case types.IntKind, types.UintKind, types.FloatKind, types.DecimalKind, types.BoolKind:
+		if lit.num != nil {
+			return nil
+		}
+	case types.StringKind, types.UUIDKind:
+		if lit.str != nil {
+			return nil
+		}
+	case types.TimestampKind:
+		if lit.str != nil {
+			if _, err := types.ParseTimestamp(*lit.str); err != nil {
+				return fmt.Errorf("invalid timestamp %s in check expression", lit.format())
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s can't be compared with values of type %s in check expression", lit.format(), KindToLwrStr[kind])
+}
+
+type checkTokenType int
+
+const (
+	identToken checkTokenType = iota
+	numberToken
+	stringToken
+	symbolToken
+)
+
+type checkToken struct {
+	typ    checkTokenType
+	text   string
+	quoted bool
+}
+
+type checkParser struct {
+	tokens  []checkToken
+	pos     int
+	colName string
+}
+
+func (p *checkParser) tokenize(expr string) error {
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != '`' {
+				end++
+			}
+
+			if end == len(runes) {
+				return fmt.Errorf("invalid check expression '%s': unterminated identifier", expr)
+			}
+
+			p.tokens = append(p.tokens, checkToken{identToken, string(runes[i+1 : end]), true})
+			i = end + 1
+
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			end := i + 1
+			for ; end < len(runes); end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				} else if runes[end] == c && end+1 < len(runes) && runes[end+1] == c {
+					end++
+				} else if runes[end] == c {
+					break
+				}
+				sb.WriteRune(runes[end])
+			}
+
+			if end == len(runes) {
+				return fmt.Errorf("invalid check expression '%s': unterminated string", expr)
+			}
+
+			p.tokens = append(p.tokens, checkToken{stringToken, sb.String(), true})
+			i = end + 1
+
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == 'e' || runes[end] == 'E' ||
+				((runes[end] == '+' || runes[end] == '-') && (runes[end-1] == 'e' || runes[end-1] == 'E'))) {
+				end++
+			}
+
+			p.tokens = append(p.tokens, checkToken{numberToken, string(runes[i:end]), false})
+			i = end
+
+		case unicode.IsLetter(c) || c == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '$') {
+				end++
+			}
+
+			p.tokens = append(p.tokens, checkToken{identToken, string(runes[i:end]), false})
+			i = end
+
+		default:
+			sym := string(c)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "<=", ">=", "<>", "!=":
+					sym = two
+				}
+			}
+
+			if !strings.Contains("=<>!=()-,", sym) && len(sym) == 1 {
+				return fmt.Errorf("invalid check expression '%s': unexpected '%s'", expr, sym)
+			}
+
+			p.tokens = append(p.tokens, checkToken{symbolToken, sym, false})
+			i += len([]rune(sym))
+		}
+	}
+
+	return nil
+}
+
+func (p *checkParser) atEnd() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *checkParser) peek() checkToken {
+	if p.atEnd() {
+		return checkToken{symbolToken, "", false}
+	}
+	return p.tokens[p.pos]
+}
+
+// isKeyword returns whether the next token is the unquoted keyword given.
+func (p *checkParser) isKeyword(kw string) bool {
+	if p.atEnd() {
+		return false
+	}
+
+	tok := p.tokens[p.pos]
+	return tok.typ == identToken && !tok.quoted && strings.EqualFold(tok.text, kw)
+}
+
+func (p *checkParser) accept(kw string) bool {
+	if p.isKeyword(kw) || (p.peek().typ == symbolToken && p.peek().text == kw && !p.atEnd()) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *checkParser) expect(kw string) error {
+	if !p.accept(kw) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *checkParser) unexpected() error {
+	if p.atEnd() {
+		return fmt.Errorf("invalid check expression: unexpected end of expression")
+	}
+	return fmt.Errorf("invalid check expression: unexpected '%s'", p.peek().text)
+}
+
+func (p *checkParser) parseOr() (checkExpr, error) {
+	left, err := p.parseAnd()
+
+	for err == nil && p.accept("or") {
+		var right checkExpr
+		right, err = p.parseAnd()
+		left = checkOr{left, right}
+	}
+
+	return left, err
+}
+
+func (p *checkParser) parseAnd() (checkExpr, error) {
+	left, err := p.parseNot()
+
+	for err == nil && p.accept("and") {
+		var right checkExpr
+		right, err = p.parseNot()
+		left = checkAnd{left, right}
+	}
+
+	return left, err
+}
+
+func (p *checkParser) parseNot() (checkExpr, error) {
+	if p.accept("not") {
+		e, err := p.parseNot()
+		return checkNot{e}, err
+	}
+
+	if p.accept("(") {
+		e, err := p.parseOr()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return e, p.expect(")")
+	}
+
+	return p.parsePredicate()
+}
+
+var flippedOps = map[string]string{"=": "=", "!=": "!=", "<>": "<>", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+func (p *checkParser) parsePredicate() (checkExpr, error) {
+	// A comparison may have the literal first, in which case the operator is flipped
+	if !p.isColumn() {
+		lit, err := p.parseLit()
+
+		if err != nil {
+			return nil, err
+		}
+
+		op := p.peek().text
+		flipped, ok := flippedOps[op]
+
+		if p.peek().typ != symbolToken || !ok {
+			return nil, p.unexpected()
+		}
+
+		p.pos++
+
+		if err := p.parseColumn(); err != nil {
+			return nil, err
+		}
+
+		return checkCmp{flipped, lit}, nil
+	}
+
+	if err := p.parseColumn(); err != nil {
+		return nil, err
+	}
+
+	if p.accept("is") {
+		not := p.accept("not")
+		return checkIsNull{not}, p.expect("null")
+	}
+
+	not := p.accept("not")
+
+	switch {
+	case p.accept("between"):
+		lo, err := p.parseLit()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("and"); err != nil {
+			return nil, err
+		}
+
+		hi, err := p.parseLit()
+		return checkBetween{not, lo, hi}, err
+
+	case p.accept("in"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+
+		var lits []checkLit
+		for {
+			lit, err := p.parseLit()
+
+			if err != nil {
+				return nil, err
+			}
+
+			lits = append(lits, lit)
+
+			if !p.accept(",") {
+				break
+			}
+		}
+
+		return checkIn{not, lits}, p.expect(")")
+
+	case not:
+		return nil, p.unexpected()
+	}
+
+	op := p.peek().text
+	if _, ok := flippedOps[op]; p.peek().typ != symbolToken || !ok {
+		return nil, p.unexpected()
+	}
+
+	p.pos++
+
+	lit, err := p.parseLit()
+	return checkCmp{op, lit}, err
+}
+
+// isColumn returns whether the next token is a column name rather than a literal.
+func (p *checkParser) isColumn() bool {
+	if p.peek().typ != identToken {
+		return false
+	}
+
+	for _, kw := range []string{"null", "true", "false"} {
+		if p.isKeyword(kw) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *checkParser) parseColumn() error {
+	if !p.isColumn() {
+		return p.unexpected()
+	}
+
+	name := p.peek().text
+	if p.colName == "" {
+		p.colName = name
+	} else if !strings.EqualFold(p.colName, name) {
+		return fmt.Errorf("invalid check expression: references columns '%s' and '%s', but can only reference one", p.colName, name)
+	}
+
+	p.pos++
+	return nil
+}
+
+func (p *checkParser) parseLit() (checkLit, error) {
+	switch {
+	case p.accept("null"):
+		return checkLit{text: "null"}, nil
+	case p.accept("true"):
+		return checkLit{text: "true", num: big.NewRat(1, 1)}, nil
+	case p.accept("false"):
+		return checkLit{text: "false", num: new(big.Rat)}, nil
+	}
+
+	neg := p.accept("-")
+	tok := p.peek()
+
+	switch {
+	case tok.typ == numberToken:
+		text := tok.text
+		if neg {
+			text = "-" + text
+		}
+
+		d, err := types.ParseDecimal(text)
+
+		if err != nil {
+			return checkLit{}, fmt.Errorf("invalid check expression: invalid number '%s'", text)
+		}
+
+		p.pos++
+		return checkLit{text: text, num: d.Rat()}, nil
+
+	case tok.typ == stringToken && !neg:
+		str := tok.text
+		p.pos++
+		return checkLit{text: str, str: &str}, nil
+	}
+
+	return checkLit{}, p.unexpected()
+}
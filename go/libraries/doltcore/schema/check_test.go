@@ -0,0 +1,185 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestCheckConstraint(t *testing.T) {
+	tests := []struct {
+		expr         string
+		expectedExpr string
+		satisfied    []types.Value
+		unsatisfied  []types.Value
+	}{
+		{
+			expr:         "age >= 18",
+			expectedExpr: "`age` >= 18",
+			satisfied:    []types.Value{types.Int(18), types.Uint(100), types.Float(18.5), types.NullValue},
+			unsatisfied:  []types.Value{types.Int(17), types.Float(17.99)},
+		},
+		{
+			expr:         "0 < `age` AND age < 65",
+			expectedExpr: "`age` > 0 and `age` < 65",
+			satisfied:    []types.Value{types.Int(1), types.Int(64)},
+			unsatisfied:  []types.Value{types.Int(0), types.Int(65)},
+		},
+		{
+			expr:         "(x = 'a' OR x = 'b') and not (x <> 'b')",
+			expectedExpr: "(`x` = 'a' or `x` = 'b') and not (`x` <> 'b')",
+			satisfied:    []types.Value{types.String("b")},
+			unsatisfied:  []types.Value{types.String("a"), types.String("c")},
+		},
+		{
+			expr:         "x between -1.5 and 2e1",
+			expectedExpr: "`x` between -1.5 and 2e1",
+			satisfied:    []types.Value{types.Int(-1), types.Float(20), types.NullValue},
+			unsatisfied:  []types.Value{types.Int(-2), types.Int(21)},
+		},
+		{
+			expr:         "x NOT IN ('it''s', \"b\")",
+			expectedExpr: "`x` not in ('it''s', 'b')",
+			satisfied:    []types.Value{types.String("a"), types.NullValue},
+			unsatisfied:  []types.Value{types.String("it's"), types.String("b")},
+		},
+		{
+			expr:         "x is not null",
+			expectedExpr: "`x` is not null",
+			satisfied:    []types.Value{types.String("")},
+			unsatisfied:  []types.Value{types.NullValue, nil},
+		},
+		{
+			expr:         "`and` = true",
+			expectedExpr: "`and` = true",
+			satisfied:    []types.Value{types.Bool(true)},
+			unsatisfied:  []types.Value{types.Bool(false)},
+		},
+		{
+			expr:         "t < '2020-01-01'",
+			expectedExpr: "`t` < '2020-01-01'",
+			satisfied:    []types.Value{mustTimestamp(t, "2019-12-31 23:59:59")},
+			unsatisfied:  []types.Value{mustTimestamp(t, "2020-01-01")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cc, err := NewCheckConstraint(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExpr, cc.Expr)
+
+			for _, val := range tt.satisfied {
+				assert.True(t, cc.SatisfiesConstraint(val), "expected %v to satisfy %s", val, cc.Expr)
+			}
+
+			for _, val := range tt.unsatisfied {
+				assert.False(t, cc.SatisfiesConstraint(val), "expected %v not to satisfy %s", val, cc.Expr)
+			}
+
+			reparsed, err := NewCheckConstraint(cc.Expr)
+			require.NoError(t, err)
+			assert.Equal(t, cc, reparsed)
+		})
+	}
+}
+
+func TestCheckConstraintErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"5 > 3",
+		"a > b",
+		"a >",
+		"a > 1 and",
+		"a = 'unterminated",
+		"a in ()",
+		"a not > 1",
+		"a > 1)",
+		"a + 1 > 2",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := NewCheckConstraint(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckConstraintValidateKind(t *testing.T) {
+	cc, err := NewCheckConstraint("a > 5")
+	require.NoError(t, err)
+	assert.NoError(t, cc.ValidateKind(types.IntKind))
+	assert.NoError(t, cc.ValidateKind(types.DecimalKind))
+	assert.Error(t, cc.ValidateKind(types.StringKind))
+
+	cc, err = NewCheckConstraint("a in ('x', 'y')")
+	require.NoError(t, err)
+	assert.NoError(t, cc.ValidateKind(types.StringKind))
+	assert.Error(t, cc.ValidateKind(types.IntKind))
+
+	cc, err = NewCheckConstraint("a > 'not a time'")
+	require.NoError(t, err)
+	assert.Error(t, cc.ValidateKind(types.TimestampKind))
+}
+
+func TestCheckConstraintAsRange(t *testing.T) {
+	tests := []struct {
+		expr          string
+		expectedRange RangeConstraint
+		expectedOk    bool
+	}{
+		{"a between 1 and 10", RangeConstraint{"1", "10"}, true},
+		{"a >= -5", RangeConstraint{Min: "-5"}, true},
+		{"100 >= a", RangeConstraint{Max: "100"}, true},
+		{"a not between 1 and 10", RangeConstraint{}, false},
+		{"a > 1", RangeConstraint{}, false},
+		{"a >= 1 and a <= 10", RangeConstraint{}, false},
+		{"a <= 'z'", RangeConstraint{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cc, err := NewCheckConstraint(tt.expr)
+			require.NoError(t, err)
+
+			rng, ok := cc.AsRange()
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedRange, rng)
+		})
+	}
+}
+
+func TestCheckConstraintWithColumnName(t *testing.T) {
+	cc, err := NewCheckConstraint("a > 1 or a is null")
+	require.NoError(t, err)
+
+	renamed := cc.WithColumnName("b")
+	assert.Equal(t, "b", renamed.ColumnName())
+	assert.Equal(t, "`b` > 1 or `b` is null", renamed.Expr)
+	assert.True(t, renamed.SatisfiesConstraint(types.Int(2)))
+	assert.False(t, renamed.SatisfiesConstraint(types.Int(1)))
+}
+
+func mustTimestamp(t *testing.T, s string) types.Timestamp {
+	ts, err := types.ParseTimestamp(s)
+	require.NoError(t, err)
+	return ts
+}
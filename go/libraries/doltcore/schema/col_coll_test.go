@@ -25,10 +25,10 @@ import (
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
-var firstNameCol = Column{"first", 0, types.StringKind, false, nil}
-var lastNameCol = Column{"last", 1, types.StringKind, false, nil}
-var firstNameCapsCol = Column{"FiRsT", 2, types.StringKind, false, nil}
-var lastNameCapsCol = Column{"LAST", 3, types.StringKind, false, nil}
+var firstNameCol = Column{"first", 0, types.StringKind, false, nil, nil}
+var lastNameCol = Column{"last", 1, types.StringKind, false, nil, nil}
+var firstNameCapsCol = Column{"FiRsT", 2, types.StringKind, false, nil, nil}
+var lastNameCapsCol = Column{"LAST", 3, types.StringKind, false, nil, nil}
 
 func TestGetByNameAndTag(t *testing.T) {
 	cols := []Column{firstNameCol, lastNameCol, firstNameCapsCol, lastNameCapsCol}
@@ -110,7 +110,7 @@ func TestNewColCollectionErrorHandling(t *testing.T) {
 	}{
 		{
 			name:        "tag collision",
-			cols:        []Column{firstNameCol, lastNameCol, {"collision", 0, types.StringKind, false, nil}},
+			cols:        []Column{firstNameCol, lastNameCol, {"collision", 0, types.StringKind, false, nil, nil}},
 			expectedErr: ErrColTagCollision,
 		},
 	}
@@ -126,18 +126,18 @@ func TestNewColCollectionErrorHandling(t *testing.T) {
 
 func TestAppendAndItrInSortOrder(t *testing.T) {
 	cols := []Column{
-		{"0", 0, types.StringKind, false, nil},
-		{"2", 2, types.StringKind, false, nil},
-		{"4", 4, types.StringKind, false, nil},
-		{"3", 3, types.StringKind, false, nil},
-		{"1", 1, types.StringKind, false, nil},
+		{"0", 0, types.StringKind, false, nil, nil},
+		{"2", 2, types.StringKind, false, nil, nil},
+		{"4", 4, types.StringKind, false, nil, nil},
+		{"3", 3, types.StringKind, false, nil, nil},
+		{"1", 1, types.StringKind, false, nil, nil},
 	}
 	cols2 := []Column{
-		{"7", 7, types.StringKind, false, nil},
-		{"9", 9, types.StringKind, false, nil},
-		{"5", 5, types.StringKind, false, nil},
-		{"8", 8, types.StringKind, false, nil},
-		{"6", 6, types.StringKind, false, nil},
+		{"7", 7, types.StringKind, false, nil, nil},
+		{"9", 9, types.StringKind, false, nil, nil},
+		{"5", 5, types.StringKind, false, nil, nil},
+		{"8", 8, types.StringKind, false, nil, nil},
+		{"6", 6, types.StringKind, false, nil, nil},
 	}
 
 	colColl, _ := NewColCollection(cols...)
@@ -15,9 +15,13 @@
 package schema
 
 import (
+	"fmt"
 	"math"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -40,6 +44,22 @@ var InvalidTag uint64 = math.MaxUint64
 // ReservedTagMin is the start of a range of tags which the user should not be able to use in their schemas.
 const ReservedTagMin uint64 = 1 << 63
 
+const (
+	// SQLTypeParam is the type param holding the SQL type of a timestamp column, which is one of the values below
+	SQLTypeParam = "sql_type"
+	// PrecisionParam is the type param holding the maximum number of digits of the values of a decimal column
+	PrecisionParam = "precision"
+	// ScaleParam is the type param holding the number of digits after the decimal point of the values of a decimal
+	// column
+	ScaleParam = "scale"
+)
+
+const (
+	DateSQLType      = "date"
+	DatetimeSQLType  = "datetime"
+	TimestampSQLType = "timestamp"
+)
+
 // InvalidCol is a Column instance that is returned when there is nothing to return and can be tested against.
 var InvalidCol = NewColumn("invalid", InvalidTag, types.NullKind, false)
 
@@ -59,6 +79,10 @@ type Column struct {
 
 	// Constraints are rules that can be checked on each column to say if the columns value is valid
 	Constraints []ColConstraint
+
+	// TypeParams are parameters of the column's SQL type which aren't captured by its kind, such as the precision and
+	// scale of a decimal column
+	TypeParams map[string]string
 }
 
 // NewColumn creates a Column instance
@@ -75,9 +99,21 @@ func NewColumn(name string, tag uint64, kind types.NomsKind, partOfPK bool, cons
 		kind,
 		partOfPK,
 		constraints,
+		nil,
 	}
 }
 
+// NewColumnWithTypeParams creates a Column instance whose SQL type has the parameters given
+func NewColumnWithTypeParams(name string, tag uint64, kind types.NomsKind, partOfPK bool, typeParams map[string]string, constraints ...ColConstraint) Column {
+	col := NewColumn(name, tag, kind, partOfPK, constraints...)
+
+	if len(typeParams) > 0 {
+		col.TypeParams = typeParams
+	}
+
+	return col
+}
+
 // IsNullable returns whether the column can be set to a null value.
 func (c Column) IsNullable() bool {
 	for _, cnst := range c.Constraints {
@@ -88,16 +124,109 @@ func (c Column) IsNullable() bool {
 	return true
 }
 
+// GetConstraint returns the first of the column's constraints with the type given, and whether it has one.
+func (c Column) GetConstraint(cnstType string) (ColConstraint, bool) {
+	for _, cnst := range c.Constraints {
+		if cnst.GetConstraintType() == cnstType {
+			return cnst, true
+		}
+	}
+	return nil, false
+}
+
+// IsUnique returns whether the column's non-null values must be different in every row.
+func (c Column) IsUnique() bool {
+	_, ok := c.GetConstraint(UniqueConstraintType)
+	return ok
+}
+
+// DefaultValue returns the value given to the column when a row is inserted without one, or nil if the column doesn't
+// have a default value.
+func (c Column) DefaultValue() (types.Value, error) {
+	cnst, ok := c.GetConstraint(DefaultConstraintType)
+
+	if !ok {
+		return nil, nil
+	}
+
+	val, err := doltcore.StringToValue(cnst.(DefaultConstraint).Value, c.Kind)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid default value for column '%s': %s", c.Name, err.Error())
+	}
+
+	return c.NormalizeValue(val)
+}
+
 // Equals tests equality between two columns.
 func (c Column) Equals(other Column) bool {
 	return c.Name == other.Name &&
 		c.Tag == other.Tag &&
 		c.Kind == other.Kind &&
 		c.IsPartOfPK == other.IsPartOfPK &&
-		ColConstraintsAreEqual(c.Constraints, other.Constraints)
+		ColConstraintsAreEqual(c.Constraints, other.Constraints) &&
+		typeParamsAreEqual(c.TypeParams, other.TypeParams)
+}
+
+// NormalizeValue returns the value given as it is stored in the column. Decimals are rounded to the scale of the column,
+// and timestamps in date columns are truncated to the start of their day. Returns an error if the value is a decimal
+// with more digits before the decimal point than the column allows.
+func (c Column) NormalizeValue(val types.Value) (types.Value, error) {
+	switch v := val.(type) {
+	case types.Decimal:
+		scaleStr, ok := c.TypeParams[ScaleParam]
+
+		if !ok {
+			return v, nil
+		}
+
+		scale, err := strconv.ParseInt(scaleStr, 10, 32)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v = v.Round(int32(scale))
+
+		if precisionStr, ok := c.TypeParams[PrecisionParam]; ok {
+			precision, err := strconv.Atoi(precisionStr)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if v.Precision()-int(v.Scale()) > precision-int(scale) {
+				return nil, fmt.Errorf("value %s is out of range for column '%s' of type decimal(%d,%d)", v.String(), c.Name, precision, scale)
+			}
+		}
+
+		return v, nil
+
+	case types.Timestamp:
+		if c.TypeParams[SQLTypeParam] == DateSQLType {
+			t := time.Time(v)
+			return types.NewTimestamp(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)), nil
+		}
+	}
+
+	return val, nil
 }
 
 // KindString returns the string representation of the NomsKind stored in the column.
 func (c Column) KindString() string {
 	return KindToLwrStr[c.Kind]
 }
+
+func typeParamsAreEqual(params, otherParams map[string]string) bool {
+	if len(params) != len(otherParams) {
+		return false
+	}
+
+	for k, v := range params {
+		if otherV, ok := otherParams[k]; !ok || v != otherV {
+			return false
+		}
+	}
+
+	return true
+}
@@ -15,8 +15,15 @@
 package schema
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -40,18 +47,60 @@ type ColConstraint interface {
 
 const (
 	NotNullConstraintType = "not_null"
+	DefaultConstraintType = "default"
+	RangeConstraintType   = "range"
+	LengthConstraintType  = "length"
+	EnumConstraintType    = "enum"
+	UniqueConstraintType  = "unique"
+	CheckConstraintType   = "check"
 )
 
+const (
+	defaultValueParam = "value"
+	rangeMinParam     = "min"
+	rangeMaxParam     = "max"
+	lengthParam       = "length"
+	enumValuesParam   = "values"
+	checkExprParam    = "expr"
+)
+
+// ErrUnknownConstraintType is returned when deserializing a constraint of a type this version doesn't know about.
+var ErrUnknownConstraintType = errors.New("unknown column constraint type")
+
 // ColConstraintFromTypeAndParams takes in a string representing the type of the constraint and a map of parameters
 // that can be used to determine the behavior of the constraint.  An example might be a constraint which validated
-// a value is in a given range.  For this the constraint type might by "in_range_constraint", and the parameters might
-// be {"min": -10, "max": 10}
-func ColConstraintFromTypeAndParams(colCnstType string, params map[string]string) ColConstraint {
+// a value is in a given range.  For this the constraint type is "range", and the parameters might be
+// {"min": "-10", "max": "10"}
+func ColConstraintFromTypeAndParams(colCnstType string, params map[string]string) (ColConstraint, error) {
 	switch colCnstType {
 	case NotNullConstraintType:
-		return NotNullConstraint{}
+		return NotNullConstraint{}, nil
+	case DefaultConstraintType:
+		return DefaultConstraint{params[defaultValueParam]}, nil
+	case RangeConstraintType:
+		return NewRangeConstraint(params[rangeMinParam], params[rangeMaxParam])
+	case LengthConstraintType:
+		length, err := strconv.ParseUint(params[lengthParam], 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid length constraint: %s", err.Error())
+		}
+
+		return LengthConstraint{length}, nil
+	case EnumConstraintType:
+		var vals []string
+		if err := json.Unmarshal([]byte(params[enumValuesParam]), &vals); err != nil {
+			return nil, fmt.Errorf("invalid enum constraint: %s", err.Error())
+		}
+
+		return EnumConstraint{vals}, nil
+	case UniqueConstraintType:
+		return UniqueConstraint{}, nil
+	case CheckConstraintType:
+		return NewCheckConstraint(params[checkExprParam])
 	}
-	panic("Unknown column constraint type: " + colCnstType)
+
+	return nil, fmt.Errorf("%s: %s", ErrUnknownConstraintType.Error(), colCnstType)
 }
 
 // NotNullConstraint validates that a value is not null.  It does not restrict 0 length strings, or 0 valued ints, or
@@ -78,6 +127,248 @@ func (nnc NotNullConstraint) String() string {
 	return "Not null"
 }
 
+// DefaultConstraint holds the value given to the column when a row is inserted without one. Every value satisfies it.
+// The value is held as a string so that it can be converted to the kind of the column, which may change.
+type DefaultConstraint struct {
+	Value string
+}
+
+// NewDefaultConstraint returns a DefaultConstraint for the non-null value given.
+func NewDefaultConstraint(val types.Value) (DefaultConstraint, error) {
+	if types.IsNull(val) {
+		return DefaultConstraint{}, errors.New("default value can't be null")
+	}
+
+	str, err := doltcore.GetConvFunc(val.Kind(), types.StringKind)(val)
+
+	if err != nil {
+		return DefaultConstraint{}, err
+	}
+
+	return DefaultConstraint{string(str.(types.String))}, nil
+}
+
+// SatisfiesConstraint returns true, as a default value doesn't restrict the values of a column
+func (dc DefaultConstraint) SatisfiesConstraint(value types.Value) bool {
+	return true
+}
+
+// GetConstraintType returns "default"
+func (dc DefaultConstraint) GetConstraintType() string {
+	return DefaultConstraintType
+}
+
+// GetConstraintParams returns the default value
+func (dc DefaultConstraint) GetConstraintParams() map[string]string {
+	return map[string]string{defaultValueParam: dc.Value}
+}
+
+// String returns a useful description of the constraint
+func (dc DefaultConstraint) String() string {
+	return "Default " + dc.Value
+}
+
+// RangeConstraint validates that a numeric value is between a minimum and maximum value, inclusive. Either bound may be
+// empty, meaning the range is unbounded on that side. Null values satisfy the constraint.
+type RangeConstraint struct {
+	Min string
+	Max string
+}
+
+// NewRangeConstraint returns a RangeConstraint with the bounds given, which must be empty or decimal numbers.
+func NewRangeConstraint(min, max string) (RangeConstraint, error) {
+	for _, bound := range []string{min, max} {
+		if bound == "" {
+			continue
+		} else if _, err := types.ParseDecimal(bound); err != nil {
+			return RangeConstraint{}, fmt.Errorf("invalid range constraint: %s", err.Error())
+		}
+	}
+
+	if min == "" && max == "" {
+		return RangeConstraint{}, errors.New("invalid range constraint: no bounds")
+	}
+
+	return RangeConstraint{min, max}, nil
+}
+
+// SatisfiesConstraint returns true if value is null, or is a number within the range
+func (rc RangeConstraint) SatisfiesConstraint(value types.Value) bool {
+	if types.IsNull(value) {
+		return true
+	}
+
+	r, ok := numericRat(value)
+
+	if !ok {
+		return false
+	}
+
+	if rc.Min != "" {
+		if min, err := types.ParseDecimal(rc.Min); err != nil || r.Cmp(min.Rat()) < 0 {
+			return false
+		}
+	}
+
+	if rc.Max != "" {
+		if max, err := types.ParseDecimal(rc.Max); err != nil || r.Cmp(max.Rat()) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetConstraintType returns "range"
+func (rc RangeConstraint) GetConstraintType() string {
+	return RangeConstraintType
+}
+
+// GetConstraintParams returns the bounds of the range which are set
+func (rc RangeConstraint) GetConstraintParams() map[string]string {
+	params := make(map[string]string)
+	if rc.Min != "" {
+		params[rangeMinParam] = rc.Min
+	}
+	if rc.Max != "" {
+		params[rangeMaxParam] = rc.Max
+	}
+
+	return params
+}
+
+// String returns a useful description of the constraint
+func (rc RangeConstraint) String() string {
+	switch {
+	case rc.Max == "":
+		return "At least " + rc.Min
+	case rc.Min == "":
+		return "At most " + rc.Max
+	default:
+		return fmt.Sprintf("Between %s and %s", rc.Min, rc.Max)
+	}
+}
+
+// LengthConstraint validates that a string value has at most the given number of characters. Null values satisfy the
+// constraint.
+type LengthConstraint struct {
+	Length uint64
+}
+
+// SatisfiesConstraint returns true if value is null, or is a string no longer than the maximum length
+func (lc LengthConstraint) SatisfiesConstraint(value types.Value) bool {
+	if types.IsNull(value) {
+		return true
+	}
+
+	str, ok := value.(types.String)
+	return ok && uint64(utf8.RuneCountInString(string(str))) <= lc.Length
+}
+
+// GetConstraintType returns "length"
+func (lc LengthConstraint) GetConstraintType() string {
+	return LengthConstraintType
+}
+
+// GetConstraintParams returns the maximum length
+func (lc LengthConstraint) GetConstraintParams() map[string]string {
+	return map[string]string{lengthParam: strconv.FormatUint(lc.Length, 10)}
+}
+
+// String returns a useful description of the constraint
+func (lc LengthConstraint) String() string {
+	return fmt.Sprintf("At most %d characters", lc.Length)
+}
+
+// EnumConstraint validates that a string value is one of a set of values. Null values satisfy the constraint.
+type EnumConstraint struct {
+	Values []string
+}
+
+// SatisfiesConstraint returns true if value is null, or is a string equal to one of the enum's values
+func (ec EnumConstraint) SatisfiesConstraint(value types.Value) bool {
+	if types.IsNull(value) {
+		return true
+	}
+
+	str, ok := value.(types.String)
+
+	if !ok {
+		return false
+	}
+
+	for _, v := range ec.Values {
+		if string(str) == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetConstraintType returns "enum"
+func (ec EnumConstraint) GetConstraintType() string {
+	return EnumConstraintType
+}
+
+// GetConstraintParams returns the enum's values as a JSON array
+func (ec EnumConstraint) GetConstraintParams() map[string]string {
+	data, err := json.Marshal(ec.Values)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return map[string]string{enumValuesParam: string(data)}
+}
+
+// String returns a useful description of the constraint
+func (ec EnumConstraint) String() string {
+	return "One of '" + strings.Join(ec.Values, "', '") + "'"
+}
+
+// UniqueConstraint marks a column whose non-null values must be different in every row of a table. As it depends on
+// the other rows of the table, every value satisfies it on its own, and it is checked when the rows of a table are
+// updated.
+type UniqueConstraint struct{}
+
+// SatisfiesConstraint returns true, as uniqueness can't be checked for a single value
+func (uc UniqueConstraint) SatisfiesConstraint(value types.Value) bool {
+	return true
+}
+
+// GetConstraintType returns "unique"
+func (uc UniqueConstraint) GetConstraintType() string {
+	return UniqueConstraintType
+}
+
+// GetConstraintParams returns nil as this constraint does not require any parameters.
+func (uc UniqueConstraint) GetConstraintParams() map[string]string {
+	return nil
+}
+
+// String returns a useful description of the constraint
+func (uc UniqueConstraint) String() string {
+	return "Unique"
+}
+
+// numericRat returns the value given as a big.Rat if it is a number.
+func numericRat(value types.Value) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case types.Int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case types.Uint:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(v))), true
+	case types.Float:
+		r := new(big.Rat).SetFloat64(float64(v))
+		return r, r != nil
+	case types.Decimal:
+		return v.Rat(), true
+	}
+
+	return nil, false
+}
+
 // ColConstraintsAreEqual validates two ColConstraint slices are identical.
 func ColConstraintsAreEqual(a, b []ColConstraint) bool {
 	if len(a) != len(b) {
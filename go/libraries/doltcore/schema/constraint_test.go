@@ -17,6 +17,9 @@ package schema
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -65,3 +68,93 @@ func TestColConstraintsAreEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestConstraintsSatisfied(t *testing.T) {
+	rng, err := NewRangeConstraint("-1.5", "10")
+	require.NoError(t, err)
+	minOnly, err := NewRangeConstraint("0", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		cnst      ColConstraint
+		val       types.Value
+		satisfies bool
+	}{
+		{"default", DefaultConstraint{"5"}, types.NullValue, true},
+		{"range int", rng, types.Int(10), true},
+		{"range int above", rng, types.Int(11), false},
+		{"range float below", rng, types.Float(-1.6), false},
+		{"range uint", rng, types.Uint(0), true},
+		{"range decimal", rng, mustDecimal(t, "-1.50"), true},
+		{"range null", rng, types.NullValue, true},
+		{"range not a number", rng, types.String("1"), false},
+		{"range min only", minOnly, types.Int(1 << 40), true},
+		{"range min only below", minOnly, types.Int(-1), false},
+		{"length", LengthConstraint{3}, types.String("äöü"), true},
+		{"length too long", LengthConstraint{3}, types.String("abcd"), false},
+		{"length null", LengthConstraint{3}, nil, true},
+		{"enum", EnumConstraint{[]string{"a", "b"}}, types.String("b"), true},
+		{"enum not a value", EnumConstraint{[]string{"a", "b"}}, types.String("B"), false},
+		{"enum null", EnumConstraint{[]string{"a", "b"}}, types.NullValue, true},
+		{"unique", UniqueConstraint{}, types.Int(1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.satisfies, tt.cnst.SatisfiesConstraint(tt.val))
+		})
+	}
+}
+
+func TestColConstraintFromTypeAndParams(t *testing.T) {
+	rng, err := NewRangeConstraint("", "100")
+	require.NoError(t, err)
+	check, err := NewCheckConstraint("a > 0 or a is null")
+	require.NoError(t, err)
+
+	constraints := []ColConstraint{
+		NotNullConstraint{},
+		DefaultConstraint{"it's"},
+		rng,
+		LengthConstraint{255},
+		EnumConstraint{[]string{"x", "y, z", `"q"`}},
+		UniqueConstraint{},
+		check,
+	}
+
+	for _, cnst := range constraints {
+		t.Run(cnst.GetConstraintType(), func(t *testing.T) {
+			decoded, err := ColConstraintFromTypeAndParams(cnst.GetConstraintType(), cnst.GetConstraintParams())
+			require.NoError(t, err)
+			assert.Equal(t, cnst, decoded)
+		})
+	}
+
+	_, err = ColConstraintFromTypeAndParams("not_a_constraint", nil)
+	assert.Error(t, err)
+	_, err = ColConstraintFromTypeAndParams(RangeConstraintType, map[string]string{rangeMinParam: "abc"})
+	assert.Error(t, err)
+}
+
+func TestColumnDefaultValue(t *testing.T) {
+	col := NewColumnWithTypeParams("d", 0, types.DecimalKind, false, map[string]string{PrecisionParam: "5", ScaleParam: "2"}, DefaultConstraint{"1.005"})
+	val, err := col.DefaultValue()
+	require.NoError(t, err)
+	assert.Equal(t, mustDecimal(t, "1.01"), val)
+
+	col = NewColumn("i", 0, types.IntKind, false, DefaultConstraint{"abc"})
+	_, err = col.DefaultValue()
+	assert.Error(t, err)
+
+	col = NewColumn("i", 0, types.IntKind, false)
+	val, err = col.DefaultValue()
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func mustDecimal(t *testing.T, s string) types.Decimal {
+	d, err := types.ParseDecimal(s)
+	require.NoError(t, err)
+	return d
+}
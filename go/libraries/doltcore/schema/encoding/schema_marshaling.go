@@ -36,6 +36,8 @@ type encodedColumn struct {
 	IsPartOfPK bool `noms:"is_part_of_pk" json:"is_part_of_pk"`
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
+
+	TypeParams map[string]string `noms:"type_params,omitempty" json:"type_params,omitempty"`
 }
 
 func encodeAllColConstraints(constraints []schema.ColConstraint) []encodedConstraint {
@@ -48,19 +50,24 @@ func encodeAllColConstraints(constraints []schema.ColConstraint) []encodedConstr
 	return nomsConstraints
 }
 
-func decodeAllColConstraint(encConstraints []encodedConstraint) []schema.ColConstraint {
+func decodeAllColConstraint(encConstraints []encodedConstraint) ([]schema.ColConstraint, error) {
 	if len(encConstraints) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	constraints := make([]schema.ColConstraint, len(encConstraints))
 
 	for i, nc := range encConstraints {
-		c := schema.ColConstraintFromTypeAndParams(nc.Type, nc.Params)
+		c, err := nc.decodeColConstraint()
+
+		if err != nil {
+			return nil, err
+		}
+
 		constraints[i] = c
 	}
 
-	return constraints
+	return constraints, nil
 }
 
 func encodeColumn(col schema.Column) encodedColumn {
@@ -69,12 +76,18 @@ func encodeColumn(col schema.Column) encodedColumn {
 		col.Name,
 		col.KindString(),
 		col.IsPartOfPK,
-		encodeAllColConstraints(col.Constraints)}
+		encodeAllColConstraints(col.Constraints),
+		col.TypeParams}
 }
 
-func (nfd encodedColumn) decodeColumn() schema.Column {
-	colConstraints := decodeAllColConstraint(nfd.Constraints)
-	return schema.NewColumn(nfd.Name, nfd.Tag, schema.LwrStrToKind[nfd.Kind], nfd.IsPartOfPK, colConstraints...)
+func (nfd encodedColumn) decodeColumn() (schema.Column, error) {
+	colConstraints, err := decodeAllColConstraint(nfd.Constraints)
+
+	if err != nil {
+		return schema.InvalidCol, err
+	}
+
+	return schema.NewColumnWithTypeParams(nfd.Name, nfd.Tag, schema.LwrStrToKind[nfd.Kind], nfd.IsPartOfPK, nfd.TypeParams, colConstraints...), nil
 }
 
 type encodedConstraint struct {
@@ -86,7 +99,7 @@ func encodeColConstraint(constraint schema.ColConstraint) encodedConstraint {
 	return encodedConstraint{constraint.GetConstraintType(), constraint.GetConstraintParams()}
 }
 
-func (encCnst encodedConstraint) decodeColConstraint() schema.ColConstraint {
+func (encCnst encodedConstraint) decodeColConstraint() (schema.ColConstraint, error) {
 	return schema.ColConstraintFromTypeAndParams(encCnst.Type, encCnst.Params)
 }
 
@@ -118,7 +131,12 @@ func (sd schemaData) decodeSchema() (schema.Schema, error) {
 	cols := make([]schema.Column, numCols)
 
 	for i, col := range sd.Columns {
-		cols[i] = col.decodeColumn()
+		var err error
+		cols[i], err = col.decodeColumn()
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	colColl, err := schema.NewColCollection(cols...)
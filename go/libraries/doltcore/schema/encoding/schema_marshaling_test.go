@@ -33,11 +33,17 @@ const expectedSQL = `CREATE TABLE %s (
 );`
 
 func createTestSchema() schema.Schema {
+	ageRange, _ := schema.NewRangeConstraint("0", "150")
+	statusCheck, _ := schema.NewCheckConstraint("status <> 'deleted'")
+
 	columns := []schema.Column{
 		schema.NewColumn("id", 4, types.UUIDKind, true, schema.NotNullConstraint{}),
-		schema.NewColumn("first", 1, types.StringKind, false),
+		schema.NewColumn("first", 1, types.StringKind, false, schema.LengthConstraint{Length: 32}),
 		schema.NewColumn("last", 2, types.StringKind, false, schema.NotNullConstraint{}),
-		schema.NewColumn("age", 3, types.UintKind, false),
+		schema.NewColumn("age", 3, types.UintKind, false, ageRange),
+		schema.NewColumnWithTypeParams("balance", 5, types.DecimalKind, false, map[string]string{"precision": "10", "scale": "2"}, schema.DefaultConstraint{Value: "0.00"}),
+		schema.NewColumn("status", 6, types.StringKind, false, schema.EnumConstraint{Values: []string{"active", "deleted"}}, statusCheck),
+		schema.NewColumn("email", 7, types.StringKind, false, schema.UniqueConstraint{}),
 	}
 
 	colColl, _ := schema.NewColCollection(columns...)
@@ -15,15 +15,55 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 // SchemaAsCreateStmt takes a Schema and returns a string representing a SQL create table command that could be used to
 // create this table
 func SchemaAsCreateStmt(tableName string, sch schema.Schema) string {
+	return schemaAsCreateStmt(tableName, sch, nil)
+}
+
+// TableAsCreateStmt is like SchemaAsCreateStmt, but also includes the foreign keys declared on the table with the name
+// given in the root given.
+func TableAsCreateStmt(ctx context.Context, root *doltdb.RootValue, tableName string, sch schema.Schema) (string, error) {
+	fks, _, err := root.GetForeignKeysForTable(ctx, tableName)
+
+	if err != nil {
+		return "", err
+	}
+
+	constraints := make([]string, len(fks))
+	for i, fk := range fks {
+		refSch := sch
+		if fk.ReferencedTable != tableName {
+			refTable, ok, err := root.GetTable(ctx, fk.ReferencedTable)
+
+			if err != nil {
+				return "", err
+			}
+
+			refSch = nil
+			if ok {
+				if refSch, err = refTable.GetSchema(ctx); err != nil {
+					return "", err
+				}
+			}
+		}
+
+		constraints[i] = FmtForeignKey(fk, sch, refSch)
+	}
+
+	return schemaAsCreateStmt(tableName, sch, constraints), nil
+}
+
+func schemaAsCreateStmt(tableName string, sch schema.Schema, constraints []string) string {
 	sb := &strings.Builder{}
 	fmt.Fprintf(sb, "CREATE TABLE %s (\n", QuoteIdentifier(tableName))
 
@@ -41,31 +81,32 @@ func SchemaAsCreateStmt(tableName string, sch schema.Schema) string {
 		return false
 	})
 
-	firstPK := true
-	err := sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		if firstPK {
-			sb.WriteString(",\n  primary key (")
-			firstPK = false
-		} else {
-			sb.WriteRune(',')
-		}
-		sb.WriteString(QuoteIdentifier(col.Name))
-		return false, nil
-	})
+	sb.WriteString(",\n  ")
+	sb.WriteString(FmtPrimaryKey(sch))
 
-	// TODO: fix panics
-	if err != nil {
-		panic(err)
+	for _, constraint := range constraints {
+		sb.WriteString(",\n  ")
+		sb.WriteString(constraint)
 	}
 
-	sb.WriteString(")\n);")
+	sb.WriteString("\n);")
 	return sb.String()
 }
 
+// FmtPrimaryKey returns the primary key clause of a sql create table statement for the schema given.
+func FmtPrimaryKey(sch schema.Schema) string {
+	var pkNames []string
+	for _, col := range sch.GetPKCols().GetColumns() {
+		pkNames = append(pkNames, QuoteIdentifier(col.Name))
+	}
+
+	return "primary key (" + strings.Join(pkNames, ",") + ")"
+}
+
 // FmtCol converts a column to a string with a given indent space count, name width, and type width.  If nameWidth or
 // typeWidth are 0 or less than the length of the name or type, then the length of the name or type will be used
 func FmtCol(indent, nameWidth, typeWidth int, col schema.Column) string {
-	return FmtColWithNameAndType(indent, nameWidth, typeWidth, col.Name, DoltToSQLType[col.Kind], col)
+	return FmtColWithNameAndType(indent, nameWidth, typeWidth, col.Name, ColumnSQLType(col), col)
 }
 
 // FmtColWithNameAndType creates a string representing a column within a sql create table statement with a given indent
@@ -76,16 +117,61 @@ func FmtColWithNameAndType(indent, nameWidth, typeWidth int, colName, typeStr st
 	fmtStr := fmt.Sprintf("%%%ds%%%ds %%%ds", indent, nameWidth, typeWidth)
 	colStr := fmt.Sprintf(fmtStr, "", colName, typeStr)
 
+	// Constraints are written in the order the SQL parser expects them, with the check constraints it doesn't understand
+	// written last
+	var checks []string
+	if !col.IsNullable() {
+		colStr += " not null"
+	}
+
 	for _, cnst := range col.Constraints {
-		switch cnst.GetConstraintType() {
-		case schema.NotNullConstraintType:
-			colStr += " not null"
+		switch cnst := cnst.(type) {
+		case schema.NotNullConstraint, schema.UniqueConstraint, schema.LengthConstraint, schema.EnumConstraint:
+			// formatted elsewhere
+		case schema.DefaultConstraint:
+			colStr += " default " + fmtDefaultValue(col, cnst)
+		case schema.RangeConstraint:
+			checks = append(checks, fmtRangeCheck(colName, cnst))
+		case schema.CheckConstraint:
+			checks = append(checks, cnst.Format(strings.Trim(colName, "`")))
 		default:
 			panic("FmtColWithNameAndType doesn't know how to format constraint type: " + cnst.GetConstraintType())
 		}
 	}
 
-	return colStr + fmt.Sprintf(" comment 'tag:%d'", col.Tag)
+	if col.IsUnique() {
+		colStr += " unique"
+	}
+
+	colStr += fmt.Sprintf(" comment 'tag:%d'", col.Tag)
+
+	for _, check := range checks {
+		colStr += " check (" + check + ")"
+	}
+
+	return colStr
+}
+
+// fmtDefaultValue returns the default value of the column given as a SQL literal.
+func fmtDefaultValue(col schema.Column, cnst schema.DefaultConstraint) string {
+	switch col.Kind {
+	case types.StringKind, types.UUIDKind, types.TimestampKind, types.BlobKind:
+		return "'" + strings.Replace(cnst.Value, "'", "''", -1) + "'"
+	default:
+		return cnst.Value
+	}
+}
+
+// fmtRangeCheck returns the check constraint expression equivalent to the range constraint given on the column named.
+func fmtRangeCheck(colName string, rc schema.RangeConstraint) string {
+	switch {
+	case rc.Max == "":
+		return fmt.Sprintf("%s >= %s", colName, rc.Min)
+	case rc.Min == "":
+		return fmt.Sprintf("%s <= %s", colName, rc.Max)
+	default:
+		return fmt.Sprintf("%s between %s and %s", colName, rc.Min, rc.Max)
+	}
 }
 
 // Quotes the identifier given with backticks.
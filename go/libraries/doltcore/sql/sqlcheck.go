@@ -0,0 +1,145 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// The SQL parser doesn't accept CHECK constraints, so we remove them from statements before parsing them and parse
+// their expressions ourselves. A check constraint may be optionally named, and when it's declared at the table level
+// it's separated from the previous definition by a comma, which must be removed along with it.
+var checkPrefixRegex = regexp.MustCompile("(?is)(?:,\\s*)?(?:\\bconstraint(?:\\s+(?:`[^`]*`|\\w+))?\\s+)?$")
+
+// Parse parses the query given like sqlparser.Parse, but also accepts DDL statements with column and table CHECK
+// constraints, which are left out of the statement returned.
+func Parse(query string) (sqlparser.Statement, error) {
+	stripped, _, err := extractCheckConstraints(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlparser.Parse(stripped)
+}
+
+// ParseStrictDDL parses the DDL statement given like sqlparser.ParseStrictDDL, but also accepts column and table CHECK
+// constraints, which are left out of the statement returned.
+func ParseStrictDDL(query string) (sqlparser.Statement, error) {
+	query, _, err := extractCheckConstraints(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlparser.ParseStrictDDL(query)
+}
+
+// extractCheckConstraints returns the query given with every CHECK constraint removed, along with the expressions of
+// the constraints removed.
+func extractCheckConstraints(query string) (string, []string, error) {
+	var checks []string
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			continue
+		case !isCheckKeywordAt(query, i):
+			continue
+		}
+
+		open := i + len("check")
+		for open < len(query) && unicode.IsSpace(rune(query[open])) {
+			open++
+		}
+
+		if open == len(query) || query[open] != '(' {
+			continue
+		}
+
+		end, err := matchingParen(query, open)
+		if err != nil {
+			return "", nil, err
+		}
+
+		checks = append(checks, strings.TrimSpace(query[open+1:end]))
+
+		start := i
+		if loc := checkPrefixRegex.FindStringIndex(query[:i]); loc != nil {
+			start = loc[0]
+		}
+
+		query = query[:start] + " " + query[end+1:]
+		i = start
+	}
+
+	return query, checks, nil
+}
+
+// isCheckKeywordAt returns whether the unquoted word starting at the index given in the query is the CHECK keyword.
+func isCheckKeywordAt(query string, i int) bool {
+	const keyword = "check"
+	if i+len(keyword) > len(query) || !strings.EqualFold(query[i:i+len(keyword)], keyword) {
+		return false
+	}
+
+	isWordChar := func(c byte) bool {
+		return c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+	}
+
+	if i > 0 && isWordChar(query[i-1]) {
+		return false
+	}
+
+	return i+len(keyword) == len(query) || !isWordChar(query[i+len(keyword)])
+}
+
+// matchingParen returns the index of the parenthesis which closes the one at the index given, skipping over quoted
+// strings and identifiers.
+func matchingParen(query string, open int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := open; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, errFmt("Unterminated check constraint: '%v'", query[open:])
+}
@@ -18,11 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
@@ -41,6 +43,13 @@ const (
 	colKey
 )
 
+// The SQL parser accepts modify column and primary key statements, but doesn't give us any of their details, so we parse
+// them ourselves.
+var alterModifyColumnRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+modify\s+(?:column\s+)?(.*?)\s*;?\s*$`)
+var alterChangePrimaryKeyRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+drop\s+primary\s+key\s*,\s*add\s+primary\s+key\s*\((.*)\)\s*;?\s*$`)
+var alterDropPrimaryKeyRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+drop\s+primary\s+key\s*;?\s*$`)
+var alterAddPrimaryKeyRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+add\s+primary\s+key\s*\((.*)\)\s*;?\s*$`)
+
 var ErrNoPrimaryKeyColumns = errors.New("at least one primary key column must be specified")
 var tagCommentPrefix = "tag:"
 
@@ -80,6 +89,19 @@ func ExecuteDrop(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue,
 		}
 	}
 
+	for _, tableName := range filtered {
+		_, referencing, err := root.GetForeignKeysForTable(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fk := range referencing {
+			if !containsString(filtered, fk.Table) {
+				return nil, errFmt("Cannot drop table '%v', which is referenced by foreign key '%v' of table '%v'", tableName, fk.Name, fk.Table)
+			}
+		}
+	}
+
 	var err error
 	if root, err = root.RemoveTables(ctx, filtered...); err != nil {
 		return nil, err
@@ -97,10 +119,16 @@ func ExecuteCreate(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValu
 
 	// Unlike other SQL statements, DDL statements can have an error but still return a statement from Parse().
 	// Callers should call ParseStrictDDL themselves if they want to verify a DDL statement parses correctly.
-	_, err := sqlparser.ParseStrictDDL(query)
+	query, checks, err := extractCheckConstraints(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt, err := sqlparser.ParseStrictDDL(query)
 	if err != nil {
 		return nil, nil, err
 	}
+	ddl = stmt.(*sqlparser.DDL)
 
 	tableName := ddl.Table.Name.String()
 	if !doltdb.IsValidTableName(tableName) {
@@ -130,12 +158,16 @@ func ExecuteCreate(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValu
 
 	spec := ddl.TableSpec
 
-	sch, err := getSchema(spec)
+	sch, err := getSchema(spec, checks)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	schVal, err := encoding.MarshalAsNomsValue(ctx, root.VRW(), sch)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	m, err := types.NewMap(ctx, root.VRW())
 
 	if err != nil {
@@ -154,20 +186,72 @@ func ExecuteCreate(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValu
 		return nil, nil, err
 	}
 
+	if root, _, err = addForeignKeys(ctx, root, tableName, spec.Constraints); err != nil {
+		return nil, nil, err
+	}
+
 	return root, sch, nil
 }
 
+// SchemaFromCreateStatement returns the name of the table created by the given CREATE TABLE statement and its schema.
+func SchemaFromCreateStatement(query string) (string, schema.Schema, error) {
+	query, checks, err := extractCheckConstraints(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	stmt, err := sqlparser.ParseStrictDDL(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.Action != sqlparser.CreateStr || ddl.TableSpec == nil {
+		return "", nil, errFmt("Not a CREATE TABLE statement: '%v'", query)
+	}
+
+	sch, err := getSchema(ddl.TableSpec, checks)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ddl.Table.Name.String(), sch, nil
+}
+
 // ExecuteAlter executes the given alter table statement and returns the new root value of the database.
 func ExecuteAlter(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, ddl *sqlparser.DDL, query string) (*doltdb.RootValue, error) {
 	// Unlike other SQL statements, DDL statements can have an error but still return a statement from Parse().
 	// Callers should call ParseStrictDDL themselves if they want to verify a DDL statement parses correctly.
-	_, err := sqlparser.ParseStrictDDL(query)
+	stmt, err := ParseStrictDDL(query)
 	if err != nil {
 		return nil, err
 	}
+	ddl = stmt.(*sqlparser.DDL)
 
 	switch ddl.Action {
 	case sqlparser.AlterStr:
+		// Index statements are parsed as alter statements with no details
+		if stmt, ok := ParseIndexDDL(query); ok {
+			return ExecuteIndexDDL(ctx, db, root, stmt)
+		}
+		if m := alterModifyColumnRegex.FindStringSubmatch(query); m != nil {
+			return modifyColumn(ctx, db, root, m[1], m[2])
+		}
+		if m := alterChangePrimaryKeyRegex.FindStringSubmatch(query); m != nil {
+			return changePrimaryKey(ctx, db, root, m[1], splitColumns(m[2]))
+		}
+		if m := alterDropPrimaryKeyRegex.FindStringSubmatch(query); m != nil {
+			return nil, errFmt("Tables must have a primary key. Use ALTER TABLE %v DROP PRIMARY KEY, ADD PRIMARY KEY (...) to change it", m[1])
+		}
+		if m := alterAddPrimaryKeyRegex.FindStringSubmatch(query); m != nil {
+			return nil, errFmt("Table '%v' already has a primary key. Use ALTER TABLE %v DROP PRIMARY KEY, ADD PRIMARY KEY (...) to change it", m[1], m[1])
+		}
+		if m := alterAddForeignKeyRegex.FindStringSubmatch(query); m != nil {
+			return addForeignKey(ctx, root, m[1], m[2])
+		}
+		if m := alterDropForeignKeyRegex.FindStringSubmatch(query); m != nil {
+			return dropForeignKey(ctx, root, m[1], m[2])
+		}
 		return executeAlter(ctx, db, root, ddl, query)
 	case sqlparser.RenameStr:
 		return executeRename(ctx, db, root, ddl, query)
@@ -225,7 +309,11 @@ func executeAlter(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue
 
 	switch ddl.ColumnAction {
 	case sqlparser.AddStr:
-		return addColumn(ctx, db, root, tableName, ddl.TableSpec)
+		_, checks, err := extractCheckConstraints(query)
+		if err != nil {
+			return nil, err
+		}
+		return addColumn(ctx, db, root, tableName, ddl.TableSpec, checks)
 	case sqlparser.DropStr:
 		return dropColumn(ctx, db, root, tableName, ddl.Column)
 	case sqlparser.RenameStr:
@@ -264,6 +352,17 @@ func dropColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue,
 		return nil, err
 	}
 
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if schCol, ok := sch.GetAllCols().GetByName(col.String()); ok {
+		if err := validateNoForeignKeys(ctx, root, tableName, schCol); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedTable, err := alterschema.DropColumn(ctx, db, table, col.String())
 	if err != nil {
 		if err == schema.ErrColNotFound {
@@ -275,8 +374,114 @@ func dropColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue,
 	return root.PutTable(ctx, db, tableName, updatedTable)
 }
 
+// modifyColumn changes the column of the table named to the column definition given, converting the column's values in
+// every row of the table. Returns the new root value, or an error if one occurs.
+func modifyColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, tableName string, colDefStr string) (*doltdb.RootValue, error) {
+	if err := validateTable(ctx, root, tableName); err != nil {
+		return nil, err
+	}
+
+	// Parse the column definition as the only column of a create statement to get its details
+	createStmt, checks, err := extractCheckConstraints("create table modified (" + colDefStr + ")")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := sqlparser.ParseStrictDDL(createStmt)
+	if err != nil {
+		return nil, errFmt("Invalid column definition: '%v'", colDefStr)
+	}
+
+	spec := stmt.(*sqlparser.DDL).TableSpec
+	if spec == nil || len(spec.Columns) != 1 {
+		return nil, errFmt("Invalid column definition: '%v'", colDefStr)
+	}
+
+	table, _, err := root.GetTable(ctx, tableName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := table.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	colDef := spec.Columns[0]
+	oldCol, ok := sch.GetAllCols().GetByName(colDef.Name.String())
+	if !ok {
+		return nil, errFmt(UnknownColumnErrFmt, colDef.Name.String())
+	}
+
+	col, _, err := getColumn(colDef, spec.Indexes, oldCol.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if col, err = applyCheckConstraints(col, checks); err != nil {
+		return nil, err
+	}
+	if err = validateCheckColumns([]schema.Column{col}, checks); err != nil {
+		return nil, err
+	}
+	if col.IsPartOfPK && !oldCol.IsPartOfPK {
+		return nil, errFmt("Adding primary keys is not supported")
+	}
+
+	convFn := GetLosslessTypeConversionFn(oldCol.Kind, col.Kind)
+	if convFn == nil {
+		return nil, errFmt("Cannot convert column '%v' from %v to %v", col.Name, ColumnSQLType(oldCol), ColumnSQLType(col))
+	}
+
+	updatedTable, err := alterschema.ModifyColumn(ctx, db, table, col, alterschema.ConvFunc(convFn))
+	if err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, db, tableName, updatedTable)
+}
+
+// changePrimaryKey makes the columns named the primary key of the table named, re-keying every row of the table. Returns
+// the new root value, or an error if one occurs.
+func changePrimaryKey(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, tableName string, pkColNames []string) (*doltdb.RootValue, error) {
+	if err := validateTable(ctx, root, tableName); err != nil {
+		return nil, err
+	}
+
+	table, _, err := root.GetTable(ctx, tableName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := table.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, colName := range pkColNames {
+		if _, ok := sch.GetAllCols().GetByName(colName); !ok {
+			return nil, errFmt(UnknownColumnErrFmt, colName)
+		} else if seen[colName] {
+			return nil, errFmt("Column '%v' appears more than once in the primary key", colName)
+		}
+
+		seen[colName] = true
+	}
+
+	updatedTable, err := alterschema.ChangePrimaryKey(ctx, db, table, pkColNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, db, tableName, updatedTable)
+}
+
 // addColumn adds the column given to the table named. Returns the new root value and new schema, or an error if one occurs.
-func addColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, tableName string, spec *sqlparser.TableSpec) (*doltdb.RootValue, error) {
+func addColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, tableName string, spec *sqlparser.TableSpec, checks []string) (*doltdb.RootValue, error) {
 	table, _, err := root.GetTable(ctx, tableName)
 
 	if err != nil {
@@ -296,16 +501,17 @@ func addColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, t
 	if err != nil {
 		return nil, err
 	}
+	if col, err = applyCheckConstraints(col, checks); err != nil {
+		return nil, err
+	}
+	if err = validateCheckColumns([]schema.Column{col}, checks); err != nil {
+		return nil, err
+	}
 	if col.IsPartOfPK {
 		return nil, errFmt("Adding primary keys is not supported")
 	}
 
-	nullable := alterschema.NotNull
-	if col.IsNullable() {
-		nullable = alterschema.Null
-	}
-
-	updatedTable, err := alterschema.AddColumnToTable(ctx, db, table, col.Tag, col.Name, col.Kind, nullable, defaultVal)
+	updatedTable, err := alterschema.AddColumn(ctx, db, table, col, defaultVal)
 	if err != nil {
 		return nil, err
 	}
@@ -313,18 +519,20 @@ func addColumn(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, t
 	return root.PutTable(ctx, db, tableName, updatedTable)
 }
 
-// getSchema returns the schema corresponding to the TableSpec given
-func getSchema(spec *sqlparser.TableSpec) (schema.Schema, error) {
+// getSchema returns the schema corresponding to the TableSpec and check constraint expressions given
+func getSchema(spec *sqlparser.TableSpec, checks []string) (schema.Schema, error) {
 	cols := make([]schema.Column, len(spec.Columns))
 
 	var tag uint64
 	var seenPk bool
 	for i, colDef := range spec.Columns {
-		// TODO: support default value
 		col, _, err := getColumn(colDef, spec.Indexes, tag)
 		if err != nil {
 			return nil, err
 		}
+		if col, err = applyCheckConstraints(col, checks); err != nil {
+			return nil, err
+		}
 		if col.IsPartOfPK {
 			seenPk = true
 		}
@@ -335,6 +543,10 @@ func getSchema(spec *sqlparser.TableSpec) (schema.Schema, error) {
 		return nil, ErrNoPrimaryKeyColumns
 	}
 
+	if err := validateCheckColumns(cols, checks); err != nil {
+		return nil, err
+	}
+
 	colColl, err := schema.NewColCollection(cols...)
 	if err != nil {
 		return nil, err
@@ -361,18 +573,23 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 	// Primary key info can either be specified in the column's type info (for in-line declarations), or in a slice of
 	// indexes attached to the table def. We have to check both places to find if a column is part of the primary key
 	isPkey := colDef.Type.KeyOpt == colKeyPrimary
+	isUnique := colDef.Type.KeyOpt == colKeyUnique || colDef.Type.KeyOpt == colKeyUniqueKey
 	notNull := bool(colDef.Type.NotNull)
 
-	if !isPkey {
-	OuterLoop:
-		for _, index := range indexes {
+	for _, index := range indexes {
+		for _, indexCol := range index.Columns {
+			if !indexCol.Column.Equal(colDef.Name) {
+				continue
+			}
+
 			if index.Info.Primary {
-				for _, indexCol := range index.Columns {
-					if indexCol.Column.Equal(colDef.Name) {
-						isPkey = true
-						break OuterLoop
-					}
+				isPkey = true
+			} else if index.Info.Unique {
+				// Only single column unique constraints can be enforced by a column constraint
+				if len(index.Columns) > 1 {
+					return errColumn("Unique constraints on more than one column are not supported")
 				}
+				isUnique = true
 			}
 		}
 	}
@@ -381,6 +598,10 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 	if isPkey || notNull {
 		constraints = append(constraints, schema.NotNullConstraint{})
 	}
+	// Primary keys are unique by definition, so they don't need a unique constraint
+	if isUnique && !isPkey {
+		constraints = append(constraints, schema.UniqueConstraint{})
+	}
 
 	commentTag := extractTag(columnType)
 	if commentTag != schema.InvalidTag {
@@ -388,6 +609,7 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 	}
 
 	var colKind types.NomsKind
+	var typeParams map[string]string
 	switch columnType.Type {
 
 	// integer-like types
@@ -403,33 +625,78 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 		colKind = types.UUIDKind
 
 	// string-like types
-	// TODO: enforce length constraints for string types
 	// TODO: support different charsets
-	case TEXT, TINYTEXT, MEDIUMTEXT, LONGTEXT, CHAR, VARCHAR:
+	case TEXT, TINYTEXT, MEDIUMTEXT, LONGTEXT:
+		colKind = types.StringKind
+
+	// length-limited string types
+	case CHAR, VARCHAR:
 		colKind = types.StringKind
 
+		if columnType.Length != nil {
+			length, err := strconv.ParseUint(string(columnType.Length.Val), 10, 64)
+			if err != nil {
+				return errColumn("Invalid length %v for column %v", string(columnType.Length.Val), colDef.Name.String())
+			}
+			constraints = append(constraints, schema.LengthConstraint{Length: length})
+		}
+
+	// enums are strings limited to the values given
+	case ENUM:
+		colKind = types.StringKind
+
+		values := make([]string, len(columnType.EnumValues))
+		for i, enumVal := range columnType.EnumValues {
+			values[i] = unquoteEnumValue(enumVal)
+		}
+		constraints = append(constraints, schema.EnumConstraint{Values: values})
+
 	// blob-like types
 	case BLOB, TINYBLOB, MEDIUMBLOB, LONGBLOB:
 		colKind = types.BlobKind
 
 	// float-like types
-	case FLOAT_TYPE, DOUBLE, DECIMAL:
+	case FLOAT_TYPE, DOUBLE:
 		colKind = types.FloatKind
 
+	// exact numeric types
+	case DECIMAL:
+		precision, scale := "10", "0"
+		if columnType.Length != nil {
+			precision = string(columnType.Length.Val)
+		}
+		if columnType.Scale != nil {
+			scale = string(columnType.Scale.Val)
+		}
+
+		if p, err := strconv.Atoi(precision); err != nil || p < 1 || p > 65 {
+			return errColumn("Invalid decimal precision %v", precision)
+		} else if s, err := strconv.Atoi(scale); err != nil || s < 0 || s > 30 || s > p {
+			return errColumn("Invalid decimal scale %v", scale)
+		}
+
+		colKind = types.DecimalKind
+		typeParams = map[string]string{schema.PrecisionParam: precision, schema.ScaleParam: scale}
+
 	// bool-like types
 	case BIT, BOOLEAN, BOOL:
 		colKind = types.BoolKind
 
-	// time-like types (not yet supported in noms, but should be)
-	case DATE, TIME, DATETIME, TIMESTAMP, YEAR:
-		return errColumn("Date and time types aren't supported")
+	// time-like types
+	case DATE, DATETIME, TIMESTAMP:
+		colKind = types.TimestampKind
+		typeParams = map[string]string{schema.SQLTypeParam: columnType.Type}
+
+	// time types without a date aren't supported
+	case TIME, YEAR:
+		return errColumn("TIME and YEAR types are not supported")
 
 	// binary string types, need to support differently from normal strings
 	case BINARY, VARBINARY:
 		return errColumn("BINARY and VARBINARY types are not supported")
 
 	// unsupported types
-	case ENUM, SET, JSON, GEOMETRY, POINT, LINESTRING, POLYGON, GEOMETRYCOLLECTION, MULTIPOINT, MULTILINESTRING, MULTIPOLYGON:
+	case SET, JSON, GEOMETRY, POINT, LINESTRING, POLYGON, GEOMETRYCOLLECTION, MULTIPOINT, MULTILINESTRING, MULTIPOLYGON:
 		return errColumn("Unsupported column type %v", columnType.Type)
 
 	// unrecognized types
@@ -437,7 +704,7 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 		return errColumn("Unrecognized column type %v", columnType.Type)
 	}
 
-	column := schema.NewColumn(colDef.Name.String(), tag, colKind, isPkey, constraints...)
+	column := schema.NewColumnWithTypeParams(colDef.Name.String(), tag, colKind, isPkey, typeParams, constraints...)
 
 	if colDef.Type.Default == nil {
 		return column, nil, nil
@@ -451,10 +718,19 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 		return schema.InvalidCol, nil, err
 	}
 
+	// MySQL accepts quoted defaults for numeric columns, and mysqldump always quotes them
+	if lit, ok := colDef.Type.Default.(*sqlparser.SQLVal); ok && lit.Type == sqlparser.StrVal && (isNumericKind(colKind) || colKind == types.BoolKind) {
+		if val, err := doltcore.StringToValue(string(lit.Val), colKind); err == nil && !types.IsNull(val) {
+			getter = LiteralValueGetter(val)
+		}
+	}
+
 	// TODO: type conversion. This doesn't work at all for uint columns (parser always thinks integer literals are int,
 	//  not uint)
 	if getter.NomsKind != colKind {
-		return errColumn("Type mismatch for default value of column %v: '%v'", column.Name, nodeToString(colDef.Type.Default))
+		if getter, err = ConversionValueGetter(getter, colKind); err != nil {
+			return errColumn("Type mismatch for default value of column %v: '%v'", column.Name, nodeToString(colDef.Type.Default))
+		}
 	}
 
 	if err = getter.Init(fakeResolver{}); err != nil {
@@ -479,9 +755,105 @@ func getColumn(colDef *sqlparser.ColumnDefinition, indexes []*sqlparser.IndexDef
 		return schema.InvalidCol, nil, err
 	}
 
+	if defaultVal, err = column.NormalizeValue(defaultVal); err != nil {
+		return schema.InvalidCol, nil, err
+	}
+
+	if !types.IsNull(defaultVal) {
+		defaultCnst, err := schema.NewDefaultConstraint(defaultVal)
+		if err != nil {
+			return schema.InvalidCol, nil, err
+		}
+		column.Constraints = append(column.Constraints, defaultCnst)
+	}
+
 	return column, defaultVal, nil
 }
 
+// unquoteEnumValue returns the value of an enum element as given by the SQL parser, which leaves its quotes intact.
+func unquoteEnumValue(enumVal string) string {
+	if len(enumVal) >= 2 && (enumVal[0] == '\'' || enumVal[0] == '"') && enumVal[len(enumVal)-1] == enumVal[0] {
+		quote := enumVal[:1]
+		return strings.Replace(enumVal[1:len(enumVal)-1], quote+quote, quote, -1)
+	}
+
+	return enumVal
+}
+
+// applyCheckConstraints returns the column given with the check constraints on it among the expressions given added.
+// Checks that only bound a numeric column's value are added as range constraints. Returns an error if any expression
+// isn't a supported check constraint, or if the column's default value doesn't satisfy its constraints.
+func applyCheckConstraints(col schema.Column, checks []string) (schema.Column, error) {
+	for _, check := range checks {
+		cc, err := schema.NewCheckConstraint(check)
+		if err != nil {
+			return schema.InvalidCol, errFmt("Invalid check constraint '%v': %v", check, err.Error())
+		}
+
+		if cc.ColumnName() != col.Name {
+			continue
+		}
+
+		if rng, ok := cc.AsRange(); ok && isNumericKind(col.Kind) {
+			col.Constraints = append(col.Constraints, rng)
+			continue
+		}
+
+		if err := cc.ValidateKind(col.Kind); err != nil {
+			return schema.InvalidCol, errFmt("Invalid check constraint '%v': %v", check, err.Error())
+		}
+
+		col.Constraints = append(col.Constraints, cc)
+	}
+
+	defaultVal, err := col.DefaultValue()
+	if err != nil {
+		return schema.InvalidCol, err
+	}
+
+	if defaultVal != nil {
+		for _, cnst := range col.Constraints {
+			if !cnst.SatisfiesConstraint(defaultVal) {
+				return schema.InvalidCol, errFmt("Invalid default value for column '%v': %v", col.Name, cnst)
+			}
+		}
+	}
+
+	return col, nil
+}
+
+// validateCheckColumns returns an error if any of the check constraint expressions given refers to a column other than
+// those given.
+func validateCheckColumns(cols []schema.Column, checks []string) error {
+	for _, check := range checks {
+		cc, err := schema.NewCheckConstraint(check)
+		if err != nil {
+			return errFmt("Invalid check constraint '%v': %v", check, err.Error())
+		}
+
+		found := false
+		for _, col := range cols {
+			found = found || col.Name == cc.ColumnName()
+		}
+
+		if !found {
+			return errFmt(UnknownColumnErrFmt, cc.ColumnName())
+		}
+	}
+
+	return nil
+}
+
+// isNumericKind returns whether the kind given is one of the numeric kinds.
+func isNumericKind(kind types.NomsKind) bool {
+	switch kind {
+	case types.IntKind, types.UintKind, types.FloatKind, types.DecimalKind:
+		return true
+	default:
+		return false
+	}
+}
+
 // Extracts the optional comment tag from a column type defn, or InvalidTag if it can't be extracted
 func extractTag(columnType sqlparser.ColumnType) uint64 {
 	if columnType.Comment == nil {
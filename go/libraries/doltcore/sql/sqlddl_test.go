@@ -118,7 +118,11 @@ func TestExecuteCreate(t *testing.T) {
 							c24 smallint unsigned,
 							c25 mediumint unsigned,
 							c26 bigint unsigned,
-              c27 uuid)`,
+              c27 uuid,
+							c28 date,
+							c29 datetime,
+							c30 timestamp,
+							c31 decimal(8,3))`,
 			expectedSchema: dtestutils.CreateSchema(
 				schema.NewColumn("c0", 0, types.IntKind, true, schema.NotNullConstraint{}),
 				schema.NewColumn("c1", 1, types.IntKind, false),
@@ -138,16 +142,20 @@ func TestExecuteCreate(t *testing.T) {
 				schema.NewColumn("c15", 15, types.BlobKind, false),
 				schema.NewColumn("c16", 16, types.StringKind, false),
 				schema.NewColumn("c17", 17, types.StringKind, false),
-				schema.NewColumn("c18", 18, types.StringKind, false),
+				schema.NewColumn("c18", 18, types.StringKind, false, schema.LengthConstraint{Length: 80}),
 				schema.NewColumn("c19", 19, types.FloatKind, false),
 				schema.NewColumn("c20", 20, types.FloatKind, false),
-				schema.NewColumn("c21", 21, types.FloatKind, false),
+				schema.NewColumnWithTypeParams("c21", 21, types.DecimalKind, false, map[string]string{schema.PrecisionParam: "10", schema.ScaleParam: "0"}),
 				schema.NewColumn("c22", 22, types.UintKind, false),
 				schema.NewColumn("c23", 23, types.UintKind, false),
 				schema.NewColumn("c24", 24, types.UintKind, false),
 				schema.NewColumn("c25", 25, types.UintKind, false),
 				schema.NewColumn("c26", 26, types.UintKind, false),
 				schema.NewColumn("c27", 27, types.UUIDKind, false),
+				schema.NewColumnWithTypeParams("c28", 28, types.TimestampKind, false, map[string]string{schema.SQLTypeParam: schema.DateSQLType}),
+				schema.NewColumnWithTypeParams("c29", 29, types.TimestampKind, false, map[string]string{schema.SQLTypeParam: schema.DatetimeSQLType}),
+				schema.NewColumnWithTypeParams("c30", 30, types.TimestampKind, false, map[string]string{schema.SQLTypeParam: schema.TimestampSQLType}),
+				schema.NewColumnWithTypeParams("c31", 31, types.DecimalKind, false, map[string]string{schema.PrecisionParam: "8", schema.ScaleParam: "3"}),
 			),
 		},
 		{
@@ -156,7 +164,7 @@ func TestExecuteCreate(t *testing.T) {
 			expectedSchema: dtestutils.CreateSchema(
 				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
 				schema.NewColumn("age", 1, types.IntKind, true, schema.NotNullConstraint{}),
-				schema.NewColumn("first", 2, types.StringKind, false),
+				schema.NewColumn("first", 2, types.StringKind, false, schema.LengthConstraint{Length: 80}),
 				schema.NewColumn("is_married", 3, types.BoolKind, false)),
 		},
 		{
@@ -165,7 +173,7 @@ func TestExecuteCreate(t *testing.T) {
 			expectedSchema: dtestutils.CreateSchema(
 				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
 				schema.NewColumn("age", 1, types.IntKind, true, schema.NotNullConstraint{}),
-				schema.NewColumn("first", 2, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("first", 2, types.StringKind, false, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 80}),
 				schema.NewColumn("is_married", 3, types.BoolKind, false)),
 		},
 		{
@@ -174,7 +182,7 @@ func TestExecuteCreate(t *testing.T) {
 			expectedSchema: dtestutils.CreateSchema(
 				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
 				schema.NewColumn("age", 1, types.IntKind, true, schema.NotNullConstraint{}),
-				schema.NewColumn("timestamp", 2, types.StringKind, false),
+				schema.NewColumn("timestamp", 2, types.StringKind, false, schema.LengthConstraint{Length: 80}),
 				schema.NewColumn("is married", 3, types.BoolKind, false)),
 		},
 		{
@@ -191,6 +199,59 @@ func TestExecuteCreate(t *testing.T) {
 				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
 				schema.NewColumn("age", 1, types.IntKind, false)),
 		},
+		{
+			name: "Test column constraints",
+			query: `create table testTable (
+							id int primary key,
+							age int default 18 check (age between 0 and 150),
+							name varchar(20) not null unique,
+							stage enum('new', 'done') default 'new',
+							score float,
+							constraint positive_score check (score > 0 or score is null))`,
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("age", 1, types.IntKind, false, schema.DefaultConstraint{Value: "18"}, schema.RangeConstraint{Min: "0", Max: "150"}),
+				schema.NewColumn("name", 2, types.StringKind, false, schema.NotNullConstraint{}, schema.UniqueConstraint{}, schema.LengthConstraint{Length: 20}),
+				schema.NewColumn("stage", 3, types.StringKind, false, schema.EnumConstraint{Values: []string{"new", "done"}}, schema.DefaultConstraint{Value: "new"}),
+				schema.NewColumn("score", 4, types.FloatKind, false, mustCheck("`score` > 0 or `score` is null"))),
+		},
+		{
+			name:  "Test unique index",
+			query: "create table testTable (id int primary key, name varchar(20), unique key (name))",
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", 1, types.StringKind, false, schema.UniqueConstraint{}, schema.LengthConstraint{Length: 20})),
+		},
+		{
+			name:        "Test multi-column unique index",
+			query:       "create table testTable (id int primary key, first varchar(20), last varchar(20), unique key (first, last))",
+			expectedErr: "Unique constraints on more than one column are not supported",
+		},
+		{
+			name:        "Test check constraint on unknown column",
+			query:       "create table testTable (id int primary key, age int, check (height > 0))",
+			expectedErr: "Unknown column: 'height'",
+		},
+		{
+			name:        "Test check constraint on more than one column",
+			query:       "create table testTable (id int primary key, age int, check (age > id))",
+			expectedErr: "Invalid check constraint",
+		},
+		{
+			name:        "Test check constraint type mismatch",
+			query:       "create table testTable (id int primary key, name varchar(20) check (name > 5))",
+			expectedErr: "Invalid check constraint",
+		},
+		{
+			name:        "Test default violating check constraint",
+			query:       "create table testTable (id int primary key, age int default 200 check (age <= 150))",
+			expectedErr: "Invalid default value for column 'age'",
+		},
+		{
+			name:        "Test default not in enum",
+			query:       "create table testTable (id int primary key, stage enum('new', 'done') default 'old')",
+			expectedErr: "Invalid default value for column 'stage'",
+		},
 		// Real world examples for regression testing
 		// TODO: need type conversion for defaults to work here (uint to int)
 		// 		{
@@ -217,13 +278,13 @@ func TestExecuteCreate(t *testing.T) {
   PRIMARY KEY (code)
 );`,
 			expectedSchema: dtestutils.CreateSchema(
-				schema.NewColumn("code", 0, types.StringKind, true, schema.NotNullConstraint{}),
-				schema.NewColumn("iso_code_2", 1, types.StringKind, false, schema.NotNullConstraint{}),
-				schema.NewColumn("iso_code_3", 2, types.StringKind, false),
-				schema.NewColumn("iso_country", 3, types.StringKind, false, schema.NotNullConstraint{}),
-				schema.NewColumn("country", 4, types.StringKind, false, schema.NotNullConstraint{}),
-				schema.NewColumn("lat", 5, types.FloatKind, false, schema.NotNullConstraint{}),
-				schema.NewColumn("lon", 6, types.FloatKind, false, schema.NotNullConstraint{})),
+				schema.NewColumn("code", 0, types.StringKind, true, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 4}, schema.DefaultConstraint{}),
+				schema.NewColumn("iso_code_2", 1, types.StringKind, false, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 2}, schema.DefaultConstraint{}),
+				schema.NewColumn("iso_code_3", 2, types.StringKind, false, schema.LengthConstraint{Length: 3}, schema.DefaultConstraint{}),
+				schema.NewColumn("iso_country", 3, types.StringKind, false, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 255}, schema.DefaultConstraint{}),
+				schema.NewColumn("country", 4, types.StringKind, false, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 255}, schema.DefaultConstraint{}),
+				schema.NewColumn("lat", 5, types.FloatKind, false, schema.NotNullConstraint{}, schema.DefaultConstraint{Value: "0"}),
+				schema.NewColumn("lon", 6, types.FloatKind, false, schema.NotNullConstraint{}, schema.DefaultConstraint{Value: "0"})),
 		},
 	}
 
@@ -334,6 +395,35 @@ func TestExecuteDrop(t *testing.T) {
 	}
 }
 
+func TestCreateStmtRoundTrip(t *testing.T) {
+	query := `create table testTable (
+					id int primary key,
+					age int default 18 check (age between 0 and 150),
+					height float check (height <= 3),
+					name varchar(20) not null default 'it''s' unique,
+					stage enum('new', 'done') default 'new',
+					added datetime default '2019-01-01 00:00:00',
+					score float,
+					constraint positive_score check (score > 0 or score is null))`
+
+	dEnv := dtestutils.CreateTestEnv()
+	ctx := context.Background()
+	root, _ := dEnv.WorkingRoot(ctx)
+
+	stmt, err := sqlparser.Parse(query)
+	require.NoError(t, err)
+	root, sch, err := ExecuteCreate(ctx, dEnv.DoltDB, root, stmt.(*sqlparser.DDL), query)
+	require.NoError(t, err)
+
+	createStmt := SchemaAsCreateStmt("roundTrip", sch)
+	stmt, err = sqlparser.Parse(createStmt)
+	require.NoError(t, err)
+	_, roundTripSch, err := ExecuteCreate(ctx, dEnv.DoltDB, root, stmt.(*sqlparser.DDL), createStmt)
+	require.NoError(t, err, createStmt)
+
+	assert.Equal(t, sch, roundTripSch, createStmt)
+}
+
 func TestAddColumn(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -346,21 +436,21 @@ func TestAddColumn(t *testing.T) {
 			name:  "alter add column not null",
 			query: "alter table people add (newColumn varchar(80) not null default 'default' comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schema.NewColumn("newColumn", 100, types.StringKind, false, schema.NotNullConstraint{})),
+				schema.NewColumn("newColumn", 100, types.StringKind, false, schema.NotNullConstraint{}, schema.LengthConstraint{Length: 80}, schema.DefaultConstraint{Value: "default"})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.String("default")),
 		},
 		{
 			name:  "alter add column not null with expression default",
 			query: "alter table people add (newColumn int not null default 2+2/2 comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schema.NewColumn("newColumn", 100, types.IntKind, false, schema.NotNullConstraint{})),
+				schema.NewColumn("newColumn", 100, types.IntKind, false, schema.NotNullConstraint{}, schema.DefaultConstraint{Value: "3"})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.Int(3)),
 		},
 		{
 			name:  "alter add column not null with negative expression",
 			query: "alter table people add (newColumn float not null default -1.1 comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schema.NewColumn("newColumn", 100, types.FloatKind, false, schema.NotNullConstraint{})),
+				schema.NewColumn("newColumn", 100, types.FloatKind, false, schema.NotNullConstraint{}, schema.DefaultConstraint{Value: "-1.1"})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.Float(-1.1)),
 		},
 		{
@@ -389,9 +479,31 @@ func TestAddColumn(t *testing.T) {
 			name:  "alter add column with optional column keyword",
 			query: "alter table people add column (newColumn varchar(80) comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schema.NewColumn("newColumn", 100, types.StringKind, false)),
+				schema.NewColumn("newColumn", 100, types.StringKind, false, schema.LengthConstraint{Length: 80})),
 			expectedRows: AllPeopleRows,
 		},
+		{
+			name:        "alter add column with constraints",
+			query:       "alter table people add (newColumn int not null default 5 unique comment 'tag:100' check (newColumn in (5, 10)))",
+			expectedErr: "Duplicate value 5 for unique column 'newColumn'",
+		},
+		{
+			name:  "alter add column with check constraint",
+			query: "alter table people add (newColumn int not null default 5 comment 'tag:100' check (newColumn in (5, 10)))",
+			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
+				schema.NewColumn("newColumn", 100, types.IntKind, false, schema.NotNullConstraint{}, schema.DefaultConstraint{Value: "5"}, mustCheck("`newColumn` in (5, 10)"))),
+			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.Int(5)),
+		},
+		{
+			name:        "alter add column with default violating check constraint",
+			query:       "alter table people add (newColumn int default 1 comment 'tag:100' check (newColumn >= 5))",
+			expectedErr: "Invalid default value for column 'newColumn'",
+		},
+		{
+			name:        "alter add column with check constraint on another column",
+			query:       "alter table people add (newColumn int comment 'tag:100' check (age >= 5))",
+			expectedErr: "Unknown column: 'age'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -401,7 +513,7 @@ func TestAddColumn(t *testing.T) {
 			ctx := context.Background()
 			root, _ := dEnv.WorkingRoot(ctx)
 
-			sqlStatement, err := sqlparser.Parse(tt.query)
+			sqlStatement, err := Parse(tt.query)
 			require.NoError(t, err)
 
 			s := sqlStatement.(*sqlparser.DDL)
@@ -454,31 +566,11 @@ func TestUnsupportedAlterStatements(t *testing.T) {
 			query:       "alter table people add index myidx on (id, first)",
 			expectedErr: "Unsupported",
 		},
-		{
-			name:        "create index",
-			query:       "create index myidx on people (id, first)",
-			expectedErr: "Unsupported",
-		},
-		{
-			name:        "alter drop index",
-			query:       "alter table people drop index myidx",
-			expectedErr: "Unsupported",
-		},
-		{
-			name:        "drop index",
-			query:       "drop index myidx on people",
-			expectedErr: "Unsupported",
-		},
 		{
 			name:        "alter change column",
 			query:       "alter table people change id newId (varchar(80) not null)",
 			expectedErr: "Unsupported",
 		},
-		{
-			name:        "alter add foreign key",
-			query:       "alter table appearances add constraint people_id_ref foreign key (id) references people (id)",
-			expectedErr: "Unsupported",
-		},
 	}
 
 	for _, tt := range tests {
@@ -588,6 +680,272 @@ func TestDropColumn(t *testing.T) {
 	}
 }
 
+func TestModifyColumn(t *testing.T) {
+	ageToFloat := func(val types.Value) types.Value {
+		return types.Float(float64(val.(types.Int)))
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedSchema schema.Schema
+		expectedRows   []row.Row
+		expectedErr    string
+	}{
+		{
+			name:           "alter modify column type",
+			query:          "alter table people modify age float",
+			expectedSchema: modifySchemaColumn(PeopleTestSchema, schema.NewColumn("age", AgeTag, types.FloatKind, false)),
+			expectedRows:   convertColumnValues(t, modifySchemaColumn(PeopleTestSchema, schema.NewColumn("age", AgeTag, types.FloatKind, false)), AgeTag, ageToFloat, AllPeopleRows...),
+		},
+		{
+			name:           "alter modify column with optional column keyword",
+			query:          "alter table people modify column age bigint not null",
+			expectedSchema: modifySchemaColumn(PeopleTestSchema, schema.NewColumn("age", AgeTag, types.IntKind, false, schema.NotNullConstraint{})),
+			expectedRows:   AllPeopleRows,
+		},
+		{
+			name:           "alter modify primary key column",
+			query:          "alter table people modify id bigint",
+			expectedSchema: PeopleTestSchema,
+			expectedRows:   AllPeopleRows,
+		},
+		{
+			name:        "no conversion to string",
+			query:       "alter table people modify `uuid` varchar(36) not null",
+			expectedErr: "Cannot convert column 'uuid'",
+		},
+		{
+			name:        "null values in not null column",
+			query:       "alter table people modify `uuid` uuid not null",
+			expectedErr: "could not be converted for column 'uuid'",
+		},
+		{
+			name:        "unsupported conversion",
+			query:       "alter table people modify first int",
+			expectedErr: "Cannot convert column 'first' from longtext to int",
+		},
+		{
+			name:           "alter modify column adding constraints",
+			query:          "alter table people modify age int default 1 check (age between 0 and 100)",
+			expectedSchema: modifySchemaColumn(PeopleTestSchema, schema.NewColumn("age", AgeTag, types.IntKind, false, schema.DefaultConstraint{Value: "1"}, schema.RangeConstraint{Min: "0", Max: "100"})),
+			expectedRows:   AllPeopleRows,
+		},
+		{
+			name:        "values violating new constraint",
+			query:       "alter table people modify first varchar(4) not null",
+			expectedErr: "could not be converted for column 'first'",
+		},
+		{
+			name:        "table not found",
+			query:       "alter table notFound modify age float",
+			expectedErr: "Unknown table: 'notFound'",
+		},
+		{
+			name:        "column not found",
+			query:       "alter table people modify notFound float",
+			expectedErr: "Unknown column: 'notFound'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			CreateTestDatabase(dEnv, t)
+			ctx := context.Background()
+			root, _ := dEnv.WorkingRoot(ctx)
+
+			sqlStatement, err := sqlparser.Parse(tt.query)
+			require.NoError(t, err)
+
+			s := sqlStatement.(*sqlparser.DDL)
+
+			updatedRoot, err := ExecuteAlter(ctx, dEnv.DoltDB, root, s, tt.query)
+
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NotNil(t, updatedRoot)
+			table, _, err := updatedRoot.GetTable(ctx, PeopleTableName)
+			assert.NoError(t, err)
+			sch, err := table.GetSchema(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSchema, sch)
+
+			rowData, err := table.GetRowData(ctx)
+			assert.NoError(t, err)
+			var foundRows []row.Row
+			err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+				r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+				assert.NoError(t, err)
+				foundRows = append(foundRows, r)
+				return false, nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRows, foundRows)
+		})
+	}
+}
+
+// modifySchemaColumn returns the schema given with the column with the same tag as the one given replaced by it.
+func modifySchemaColumn(sch schema.Schema, newCol schema.Column) schema.Schema {
+	var cols []schema.Column
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if tag == newCol.Tag {
+			col = newCol
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		panic(err)
+	}
+
+	return schema.SchemaFromCols(colColl)
+}
+
+// convertColumnValues returns the rows given with the non-null values of the column with the tag given converted by
+// the function given.
+func convertColumnValues(t *testing.T, sch schema.Schema, tag uint64, convFn func(types.Value) types.Value, rs ...row.Row) []row.Row {
+	var converted []row.Row
+	for _, r := range rs {
+		if val, ok := r.GetColVal(tag); ok && !types.IsNull(val) {
+			var err error
+			r, err = r.SetColVal(tag, convFn(val), sch)
+			require.NoError(t, err)
+		}
+		converted = append(converted, r)
+	}
+
+	return converted
+}
+
+func TestChangePrimaryKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedSchema schema.Schema
+		expectedErr    string
+	}{
+		{
+			name:  "alter change primary key",
+			query: "alter table people drop primary key, add primary key (first)",
+			expectedSchema: modifySchemaColumn(modifySchemaColumn(PeopleTestSchema,
+				schema.NewColumn("first", FirstTag, types.StringKind, true, schema.NotNullConstraint{})),
+				schema.NewColumn("id", IdTag, types.IntKind, false, schema.NotNullConstraint{})),
+		},
+		{
+			name:  "alter change primary key to multiple columns",
+			query: "alter table people drop primary key, add primary key (last, first)",
+			expectedSchema: modifySchemaColumn(modifySchemaColumn(modifySchemaColumn(PeopleTestSchema,
+				schema.NewColumn("first", FirstTag, types.StringKind, true, schema.NotNullConstraint{})),
+				schema.NewColumn("last", LastTag, types.StringKind, true, schema.NotNullConstraint{})),
+				schema.NewColumn("id", IdTag, types.IntKind, false, schema.NotNullConstraint{})),
+		},
+		{
+			name:  "alter add nullable column to primary key",
+			query: "alter table people drop primary key, add primary key (id, age)",
+			expectedSchema: modifySchemaColumn(PeopleTestSchema,
+				schema.NewColumn("age", AgeTag, types.IntKind, true, schema.NotNullConstraint{})),
+		},
+		{
+			name:        "duplicate keys",
+			query:       "alter table people drop primary key, add primary key (last)",
+			expectedErr: "can't be keyed by the new primary key",
+		},
+		{
+			name:        "null values",
+			query:       "alter table people drop primary key, add primary key (`uuid`)",
+			expectedErr: "NULL value in primary key column(s) uuid",
+		},
+		{
+			name:        "column not found",
+			query:       "alter table people drop primary key, add primary key (notFound)",
+			expectedErr: "Unknown column: 'notFound'",
+		},
+		{
+			name:        "column repeated",
+			query:       "alter table people drop primary key, add primary key (first, first)",
+			expectedErr: "Column 'first' appears more than once in the primary key",
+		},
+		{
+			name:        "table not found",
+			query:       "alter table notFound drop primary key, add primary key (first)",
+			expectedErr: "Unknown table: 'notFound'",
+		},
+		{
+			name:        "drop primary key",
+			query:       "alter table people drop primary key",
+			expectedErr: "Tables must have a primary key",
+		},
+		{
+			name:        "add primary key",
+			query:       "alter table people add primary key (first)",
+			expectedErr: "Table 'people' already has a primary key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			CreateTestDatabase(dEnv, t)
+			ctx := context.Background()
+			root, _ := dEnv.WorkingRoot(ctx)
+
+			sqlStatement, err := sqlparser.Parse(tt.query)
+			require.NoError(t, err)
+
+			s := sqlStatement.(*sqlparser.DDL)
+
+			updatedRoot, err := ExecuteAlter(ctx, dEnv.DoltDB, root, s, tt.query)
+
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NotNil(t, updatedRoot)
+			table, _, err := updatedRoot.GetTable(ctx, PeopleTableName)
+			assert.NoError(t, err)
+			sch, err := table.GetSchema(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSchema, sch)
+
+			rowData, err := table.GetRowData(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(len(AllPeopleRows)), rowData.Len())
+
+			for _, r := range AllPeopleRows {
+				vals, err := row.GetTaggedVals(r)
+				require.NoError(t, err)
+				expectedRow, err := row.New(types.Format_7_18, sch, vals)
+				require.NoError(t, err)
+
+				key, err := expectedRow.NomsMapKey(sch).Value(ctx)
+				require.NoError(t, err)
+				value, ok, err := rowData.MaybeGet(ctx, key)
+				require.NoError(t, err)
+				require.True(t, ok)
+
+				foundRow, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+				require.NoError(t, err)
+				assert.True(t, row.AreEqual(expectedRow, foundRow, sch))
+			}
+		})
+	}
+}
+
 func TestRenameColumn(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -829,3 +1187,12 @@ func TestRenameTable(t *testing.T) {
 		})
 	}
 }
+
+func mustCheck(expr string) schema.CheckConstraint {
+	cc, err := schema.NewCheckConstraint(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return cc
+}
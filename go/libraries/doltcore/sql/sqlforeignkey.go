@@ -0,0 +1,292 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+)
+
+var alterAddForeignKeyRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+add\s+((?:constraint\s+\S+\s+)?foreign\s+key\b.*?)\s*;?\s*$`)
+var alterDropForeignKeyRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+drop\s+foreign\s+key\s+` + identRegexStr + `\s*;?\s*$`)
+
+// addForeignKeys adds the foreign keys defined by the constraints given to the table with the name given, and returns
+// the updated root along with the foreign keys added. The rows of the table aren't checked against them.
+func addForeignKeys(ctx context.Context, root *doltdb.RootValue, tableName string, constraints []*sqlparser.ConstraintDefinition) (*doltdb.RootValue, []doltdb.ForeignKey, error) {
+	var fks []doltdb.ForeignKey
+	for _, constraint := range constraints {
+		def, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition)
+		if !ok {
+			return nil, nil, errFmt("Unsupported constraint: '%v'", sqlparser.String(constraint))
+		}
+
+		fk, err := foreignKeyFromDefinition(ctx, root, tableName, constraint.Name, def)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if root, err = root.PutForeignKey(ctx, fk); err != nil {
+			return nil, nil, err
+		}
+
+		fks = append(fks, fk)
+	}
+
+	return root, fks, nil
+}
+
+// addForeignKey adds the foreign key defined by the constraint given, as in ALTER TABLE table ADD constraint, to the
+// table with the name given, and returns the updated root. The table's rows must satisfy the new foreign key.
+func addForeignKey(ctx context.Context, root *doltdb.RootValue, tableName string, constraintStr string) (*doltdb.RootValue, error) {
+	if err := validateTable(ctx, root, tableName); err != nil {
+		return nil, err
+	}
+
+	// The parser only accepts foreign keys in CREATE TABLE statements
+	stmt, err := sqlparser.ParseStrictDDL(fmt.Sprintf("create table %s (`fk_placeholder` int, %s)", QuoteIdentifier(tableName), constraintStr))
+	if err != nil {
+		return nil, errFmt("Invalid foreign key definition: '%v'", constraintStr)
+	}
+
+	constraints := stmt.(*sqlparser.DDL).TableSpec.Constraints
+	root, fks, err := addForeignKeys(ctx, root, tableName, constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	violations, err := root.FindForeignKeyViolations(ctx, fks)
+	if err != nil {
+		return nil, err
+	} else if len(violations) > 0 {
+		return nil, errFmt("Cannot add foreign key: %v", doltdb.ForeignKeyViolationError{ForeignKeyViolation: violations[0]})
+	}
+
+	return root, nil
+}
+
+// dropForeignKey removes the foreign key with the name given from the table with the name given, and returns the
+// updated root.
+func dropForeignKey(ctx context.Context, root *doltdb.RootValue, tableName string, fkName string) (*doltdb.RootValue, error) {
+	if err := validateTable(ctx, root, tableName); err != nil {
+		return nil, err
+	}
+
+	fk, ok, err := root.GetForeignKey(ctx, fkName)
+	if err != nil {
+		return nil, err
+	} else if !ok || fk.Table != tableName {
+		return nil, errFmt("Unknown foreign key '%v' on table '%v'", fkName, tableName)
+	}
+
+	return root.RemoveForeignKey(ctx, fkName)
+}
+
+// foreignKeyFromDefinition returns the foreign key with the definition given for the table with the name given, which
+// must already be in the root given. If the name given is empty, a new one is generated.
+func foreignKeyFromDefinition(ctx context.Context, root *doltdb.RootValue, tableName, name string, def *sqlparser.ForeignKeyDefinition) (doltdb.ForeignKey, error) {
+	for _, action := range []sqlparser.ReferenceAction{def.OnDelete, def.OnUpdate} {
+		if action != sqlparser.DefaultAction && action != sqlparser.Restrict && action != sqlparser.NoAction {
+			return doltdb.ForeignKey{}, errFmt("Unsupported foreign key action '%v'. Only RESTRICT and NO ACTION are supported", sqlparser.String(action))
+		}
+	}
+
+	var err error
+	if name == "" {
+		if name, err = newForeignKeyName(ctx, root, tableName); err != nil {
+			return doltdb.ForeignKey{}, err
+		}
+	} else if _, ok, err := root.GetForeignKey(ctx, name); err != nil {
+		return doltdb.ForeignKey{}, err
+	} else if ok {
+		return doltdb.ForeignKey{}, errFmt("Foreign key '%v' already exists", name)
+	}
+
+	refTableName := def.ReferencedTable.Name.String()
+	if err := validateTable(ctx, root, refTableName); err != nil {
+		return doltdb.ForeignKey{}, err
+	}
+
+	sch, err := getTableSchema(ctx, root, tableName)
+	if err != nil {
+		return doltdb.ForeignKey{}, err
+	}
+
+	refSch, err := getTableSchema(ctx, root, refTableName)
+	if err != nil {
+		return doltdb.ForeignKey{}, err
+	}
+
+	if len(def.Source) != len(def.ReferencedColumns) {
+		return doltdb.ForeignKey{}, errFmt("Foreign key '%v' must have the same number of columns as it references", name)
+	}
+
+	cols, err := getColumnsByName(sch, def.Source)
+	if err != nil {
+		return doltdb.ForeignKey{}, err
+	}
+
+	refCols, err := getColumnsByName(refSch, def.ReferencedColumns)
+	if err != nil {
+		return doltdb.ForeignKey{}, err
+	}
+
+	fk := doltdb.ForeignKey{Name: name, Table: tableName, ReferencedTable: refTableName}
+	for i := range cols {
+		if cols[i].Kind != refCols[i].Kind {
+			return doltdb.ForeignKey{}, errFmt("Column '%v' of foreign key '%v' must have the same type as the referenced column '%v'", cols[i].Name, name, refCols[i].Name)
+		}
+
+		fk.Tags = append(fk.Tags, cols[i].Tag)
+		fk.ReferencedTags = append(fk.ReferencedTags, refCols[i].Tag)
+	}
+
+	if !isUniqueKey(refSch, fk.ReferencedTags) {
+		return doltdb.ForeignKey{}, errFmt("Foreign key '%v' must reference the primary key or a unique column of table '%v'", name, refTableName)
+	}
+
+	return fk, nil
+}
+
+// isUniqueKey returns whether the columns with the tags given, in any order, are the primary key of the schema given,
+// or are a single column with a unique constraint.
+func isUniqueKey(sch schema.Schema, tags []uint64) bool {
+	if len(tags) == 1 {
+		if col, ok := sch.GetAllCols().GetByTag(tags[0]); ok && col.IsUnique() {
+			return true
+		}
+	}
+
+	pkTags := sch.GetPKCols().Tags
+	if len(tags) != len(pkTags) {
+		return false
+	}
+
+	for _, tag := range tags {
+		if _, ok := sch.GetPKCols().GetByTag(tag); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newForeignKeyName returns a name for a new foreign key on the table given which isn't used by any other foreign key.
+func newForeignKeyName(ctx context.Context, root *doltdb.RootValue, tableName string) (string, error) {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_ibfk_%d", tableName, i)
+		if _, ok, err := root.GetForeignKey(ctx, name); err != nil {
+			return "", err
+		} else if !ok {
+			return name, nil
+		}
+	}
+}
+
+// validateNoForeignKeys returns an error if the column given, of the table with the name given, is used by a foreign
+// key.
+func validateNoForeignKeys(ctx context.Context, root *doltdb.RootValue, tableName string, col schema.Column) error {
+	declared, referencing, err := root.GetForeignKeysForTable(ctx, tableName)
+	if err != nil {
+		return err
+	}
+
+	for _, fk := range declared {
+		if containsTag(fk.Tags, col.Tag) || (fk.ReferencedTable == tableName && containsTag(fk.ReferencedTags, col.Tag)) {
+			return errFmt("Cannot drop column '%v', which is used by foreign key '%v'", col.Name, fk.Name)
+		}
+	}
+
+	for _, fk := range referencing {
+		if containsTag(fk.ReferencedTags, col.Tag) {
+			return errFmt("Cannot drop column '%v', which is used by foreign key '%v'", col.Name, fk.Name)
+		}
+	}
+
+	return nil
+}
+
+// FmtForeignKey returns the foreign key given as a constraint definition for a CREATE TABLE statement, using the
+// schemas of its table and the table it references. The referenced schema is nil if the table doesn't exist.
+func FmtForeignKey(fk doltdb.ForeignKey, sch, refSch schema.Schema) string {
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)", QuoteIdentifier(fk.Name),
+		fmtColumnList(sch, fk.Tags), QuoteIdentifier(fk.ReferencedTable), fmtColumnList(refSch, fk.ReferencedTags))
+}
+
+func fmtColumnList(sch schema.Schema, tags []uint64) string {
+	colNames := make([]string, len(tags))
+	for i, tag := range tags {
+		if sch == nil {
+			colNames[i] = fmt.Sprintf("<tag %d>", tag)
+		} else if col, ok := sch.GetAllCols().GetByTag(tag); ok {
+			colNames[i] = QuoteIdentifier(col.Name)
+		} else {
+			colNames[i] = fmt.Sprintf("<tag %d>", tag)
+		}
+	}
+
+	return strings.Join(colNames, ", ")
+}
+
+func getTableSchema(ctx context.Context, root *doltdb.RootValue, tableName string) (schema.Schema, error) {
+	table, ok, err := root.GetTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errFmt(UnknownTableErrFmt, tableName)
+	}
+
+	return table.GetSchema(ctx)
+}
+
+func getColumnsByName(sch schema.Schema, colIdents sqlparser.Columns) ([]schema.Column, error) {
+	cols := make([]schema.Column, len(colIdents))
+	for i, colIdent := range colIdents {
+		col, ok := sch.GetAllCols().GetByName(colIdent.String())
+		if !ok {
+			return nil, errFmt(UnknownColumnErrFmt, colIdent.String())
+		}
+
+		cols[i] = col
+	}
+
+	return cols, nil
+}
+
+func containsTag(tags []uint64, tag uint64) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
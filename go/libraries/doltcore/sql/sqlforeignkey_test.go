@@ -0,0 +1,237 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestForeignKeyDDL(t *testing.T) {
+	tests := []struct {
+		name        string
+		queries     []string
+		expectedFks []doltdb.ForeignKey
+		expectedErr string
+	}{
+		{
+			name: "create table with foreign keys",
+			queries: []string{`create table quotes (
+				id int primary key,
+				character_id int,
+				episode_id int,
+				foreign key (character_id) references people(id),
+				constraint quote_ep foreign key (episode_id) references episodes (id) on delete restrict)`},
+			expectedFks: []doltdb.ForeignKey{
+				{Name: "quote_ep", Table: "quotes", Tags: []uint64{2}, ReferencedTable: EpisodesTableName, ReferencedTags: []uint64{EpisodeIdTag}},
+				{Name: "quotes_ibfk_1", Table: "quotes", Tags: []uint64{1}, ReferencedTable: PeopleTableName, ReferencedTags: []uint64{IdTag}},
+			},
+		},
+		{
+			name:        "self referencing foreign key",
+			queries:     []string{"create table tree (id int primary key, parent int, foreign key (parent) references tree(id))"},
+			expectedFks: []doltdb.ForeignKey{{Name: "tree_ibfk_1", Table: "tree", Tags: []uint64{1}, ReferencedTable: "tree", ReferencedTags: []uint64{0}}},
+		},
+		{
+			name:    "foreign key on multiple columns",
+			queries: []string{"create table notes (id int primary key, c int, e int, foreign key (e, c) references appearances(episode_id, character_id))"},
+			expectedFks: []doltdb.ForeignKey{
+				{Name: "notes_ibfk_1", Table: "notes", Tags: []uint64{2, 1}, ReferencedTable: AppearancesTableName, ReferencedTags: []uint64{AppEpTag, AppCharacterTag}},
+			},
+		},
+		{
+			name:        "unknown referenced table",
+			queries:     []string{"create table quotes (id int primary key, c int, foreign key (c) references characters(id))"},
+			expectedErr: "Unknown table: 'characters'",
+		},
+		{
+			name:        "unknown referenced column",
+			queries:     []string{"create table quotes (id int primary key, c int, foreign key (c) references people(character_id))"},
+			expectedErr: "Unknown column: 'character_id'",
+		},
+		{
+			name:        "mismatched types",
+			queries:     []string{"create table quotes (id int primary key, c varchar(10), foreign key (c) references people(id))"},
+			expectedErr: "Column 'c' of foreign key 'quotes_ibfk_1' must have the same type as the referenced column 'id'",
+		},
+		{
+			name:        "mismatched column counts",
+			queries:     []string{"create table quotes (id int primary key, c int, foreign key (c) references appearances(character_id, episode_id))"},
+			expectedErr: "must have the same number of columns",
+		},
+		{
+			name:        "referenced columns not a key",
+			queries:     []string{"create table quotes (id int primary key, a int, foreign key (a) references people(age))"},
+			expectedErr: "must reference the primary key or a unique column of table 'people'",
+		},
+		{
+			name:        "partial primary key",
+			queries:     []string{"create table quotes (id int primary key, c int, foreign key (c) references appearances(character_id))"},
+			expectedErr: "must reference the primary key or a unique column",
+		},
+		{
+			name:        "unsupported action",
+			queries:     []string{"create table quotes (id int primary key, c int, foreign key (c) references people(id) on delete cascade)"},
+			expectedErr: "Unsupported foreign key action 'cascade'",
+		},
+		{
+			name: "duplicate name",
+			queries: []string{
+				"create table quotes (id int primary key, c int, constraint fk foreign key (c) references people(id))",
+				"create table quotes2 (id int primary key, c int, constraint fk foreign key (c) references people(id))",
+			},
+			expectedErr: "Foreign key 'fk' already exists",
+		},
+		{
+			name: "alter table add and drop foreign keys",
+			queries: []string{
+				"alter table appearances add constraint app_people foreign key (character_id) references people(id)",
+				"alter table appearances add foreign key (`episode_id`) references `episodes` (`id`);",
+				"alter table appearances drop foreign key app_people",
+			},
+			expectedFks: []doltdb.ForeignKey{
+				{Name: "appearances_ibfk_1", Table: AppearancesTableName, Tags: []uint64{AppEpTag}, ReferencedTable: EpisodesTableName, ReferencedTags: []uint64{EpisodeIdTag}},
+			},
+		},
+		{
+			name:        "alter table add foreign key violated by existing rows",
+			queries:     []string{"alter table people add foreign key (age) references episodes(id)"},
+			expectedErr: "Cannot add foreign key: Foreign key 'people_ibfk_1' violated",
+		},
+		{
+			name:        "alter table drop unknown foreign key",
+			queries:     []string{"alter table people drop foreign key fk"},
+			expectedErr: "Unknown foreign key 'fk' on table 'people'",
+		},
+		{
+			name: "drop referenced table",
+			queries: []string{
+				"alter table appearances add foreign key (character_id) references people(id)",
+				"drop table people",
+			},
+			expectedErr: "Cannot drop table 'people', which is referenced by foreign key 'appearances_ibfk_1' of table 'appearances'",
+		},
+		{
+			name: "drop referencing and referenced tables",
+			queries: []string{
+				"alter table appearances add foreign key (character_id) references people(id)",
+				"drop table people, appearances",
+			},
+		},
+		{
+			name: "drop referencing column",
+			queries: []string{
+				"alter table appearances add foreign key (character_id) references people(id)",
+				"alter table people drop column id",
+			},
+			expectedErr: "Cannot drop column 'id', which is used by foreign key 'appearances_ibfk_1'",
+		},
+		{
+			name: "rename referenced table",
+			queries: []string{
+				"alter table appearances add foreign key (character_id) references people(id)",
+				"rename table people to characters",
+			},
+			expectedFks: []doltdb.ForeignKey{
+				{Name: "appearances_ibfk_1", Table: AppearancesTableName, Tags: []uint64{AppCharacterTag}, ReferencedTable: "characters", ReferencedTags: []uint64{IdTag}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			CreateTestDatabase(dEnv, t)
+			ctx := context.Background()
+			root, _ := dEnv.WorkingRoot(ctx)
+
+			var err error
+			for _, query := range tt.queries {
+				if root, err = executeDDL(ctx, dEnv.DoltDB, root, query); err != nil {
+					break
+				}
+			}
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			fks, err := root.GetForeignKeys(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFks, fks)
+		})
+	}
+}
+
+func TestForeignKeyCreateStmt(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	CreateTestDatabase(dEnv, t)
+	ctx := context.Background()
+	root, _ := dEnv.WorkingRoot(ctx)
+
+	query := "create table quotes (id int primary key, c int, e int, constraint q_app foreign key (c, e) references appearances (character_id, episode_id))"
+	root, err := executeDDL(ctx, dEnv.DoltDB, root, query)
+	require.NoError(t, err)
+
+	tbl, _, err := root.GetTable(ctx, "quotes")
+	require.NoError(t, err)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+
+	createStmt, err := TableAsCreateStmt(ctx, root, "quotes", sch)
+	require.NoError(t, err)
+	assert.Contains(t, createStmt, "CONSTRAINT `q_app` FOREIGN KEY (`c`, `e`) REFERENCES `appearances` (`character_id`, `episode_id`)")
+
+	fks, err := root.GetForeignKeys(ctx)
+	require.NoError(t, err)
+
+	root, err = root.RemoveTables(ctx, "quotes")
+	require.NoError(t, err)
+	root, err = executeDDL(ctx, dEnv.DoltDB, root, createStmt)
+	require.NoError(t, err, createStmt)
+
+	roundTripFks, err := root.GetForeignKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, fks, roundTripFks)
+}
+
+func executeDDL(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, query string) (*doltdb.RootValue, error) {
+	stmt, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ddl := stmt.(*sqlparser.DDL)
+	switch ddl.Action {
+	case sqlparser.CreateStr:
+		root, _, err = ExecuteCreate(ctx, db, root, ddl, query)
+		return root, err
+	case sqlparser.DropStr:
+		return ExecuteDrop(ctx, db, root, ddl, query)
+	default:
+		return ExecuteAlter(ctx, db, root, ddl, query)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+)
+
+// The SQL parser accepts index statements, but doesn't give us any of their details, so we parse them ourselves.
+const identRegexStr = "`?([a-zA-Z_][-_0-9a-zA-Z]*)`?"
+
+var createIndexRegex = regexp.MustCompile(`(?is)^\s*create\s+index\s+` + identRegexStr + `\s+on\s+` + identRegexStr + `\s*\((.*)\)\s*;?\s*$`)
+var dropIndexRegex = regexp.MustCompile(`(?is)^\s*drop\s+index\s+` + identRegexStr + `\s+on\s+` + identRegexStr + `\s*;?\s*$`)
+var alterAddIndexRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+add\s+(?:index|key)\s+` + identRegexStr + `\s*\((.*)\)\s*;?\s*$`)
+var alterDropIndexRegex = regexp.MustCompile(`(?is)^\s*alter\s+table\s+` + identRegexStr + `\s+drop\s+(?:index|key)\s+` + identRegexStr + `\s*;?\s*$`)
+var identRegex = regexp.MustCompile("^" + identRegexStr + "$")
+
+// IndexDDL is a statement that creates or drops a secondary index.
+type IndexDDL struct {
+	// Either sqlparser.CreateStr or sqlparser.DropStr
+	Action    string
+	IndexName string
+	TableName string
+	// The indexed columns, for a create statement
+	Columns []string
+}
+
+// ParseIndexDDL parses the query given as a statement that creates or drops a secondary index. The forms supported
+// are CREATE INDEX name ON table (cols), DROP INDEX name ON table, ALTER TABLE table ADD INDEX name (cols), and ALTER
+// TABLE table DROP INDEX name. Returns false if the query isn't an index statement.
+func ParseIndexDDL(query string) (*IndexDDL, bool) {
+	if m := createIndexRegex.FindStringSubmatch(query); m != nil {
+		return &IndexDDL{Action: sqlparser.CreateStr, IndexName: m[1], TableName: m[2], Columns: splitColumns(m[3])}, true
+	} else if m := dropIndexRegex.FindStringSubmatch(query); m != nil {
+		return &IndexDDL{Action: sqlparser.DropStr, IndexName: m[1], TableName: m[2]}, true
+	} else if m := alterAddIndexRegex.FindStringSubmatch(query); m != nil {
+		return &IndexDDL{Action: sqlparser.CreateStr, IndexName: m[2], TableName: m[1], Columns: splitColumns(m[3])}, true
+	} else if m := alterDropIndexRegex.FindStringSubmatch(query); m != nil {
+		return &IndexDDL{Action: sqlparser.DropStr, IndexName: m[2], TableName: m[1]}, true
+	}
+
+	return nil, false
+}
+
+func splitColumns(colList string) []string {
+	var cols []string
+	for _, col := range strings.Split(colList, ",") {
+		col = strings.TrimSpace(col)
+		if m := identRegex.FindStringSubmatch(col); m != nil {
+			col = m[1]
+		}
+		cols = append(cols, col)
+	}
+
+	return cols
+}
+
+// ExecuteIndexDDL executes the given index statement and returns the new root value of the database.
+func ExecuteIndexDDL(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValue, stmt *IndexDDL) (*doltdb.RootValue, error) {
+	if err := validateTable(ctx, root, stmt.TableName); err != nil {
+		return nil, err
+	}
+
+	table, _, err := root.GetTable(ctx, stmt.TableName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch stmt.Action {
+	case sqlparser.CreateStr:
+		table, err = createIndex(ctx, table, stmt)
+	case sqlparser.DropStr:
+		table, err = table.DropIndex(ctx, stmt.IndexName)
+		if err == doltdb.ErrIndexNotFound {
+			err = errFmt("Unknown index '%v' on table '%v'", stmt.IndexName, stmt.TableName)
+		}
+	default:
+		err = errFmt("Unsupported index action: '%v'", stmt.Action)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, db, stmt.TableName, table)
+}
+
+// createIndex creates the index described on the table given and returns the updated table.
+func createIndex(ctx context.Context, table *doltdb.Table, stmt *IndexDDL) (*doltdb.Table, error) {
+	sch, err := table.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]uint64, len(stmt.Columns))
+	seen := make(map[uint64]bool)
+	for i, colName := range stmt.Columns {
+		col, ok := sch.GetAllCols().GetByName(colName)
+		if !ok {
+			return nil, errFmt(UnknownColumnErrFmt, colName)
+		} else if seen[col.Tag] {
+			return nil, errFmt("Column '%v' appears more than once in index '%v'", colName, stmt.IndexName)
+		}
+
+		tags[i] = col.Tag
+		seen[col.Tag] = true
+	}
+
+	if tagsEqual(tags, sch.GetPKCols().Tags) {
+		return nil, errFmt("Index '%v' duplicates the primary key of table '%v'", stmt.IndexName, stmt.TableName)
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		if strings.EqualFold(idx.Name, stmt.IndexName) {
+			return nil, errFmt("Index '%v' already exists on table '%v'", stmt.IndexName, stmt.TableName)
+		} else if tagsEqual(tags, idx.Tags) {
+			return nil, errFmt("Index '%v' on the same columns already exists on table '%v'", idx.Name, stmt.TableName)
+		}
+	}
+
+	return table.CreateIndex(ctx, stmt.IndexName, tags)
+}
+
+func tagsEqual(tags, other []uint64) bool {
+	if len(tags) != len(other) {
+		return false
+	}
+
+	for i := range tags {
+		if tags[i] != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestParseIndexDDL(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected *IndexDDL
+	}{
+		{"create index idx on people (first)", &IndexDDL{sqlparser.CreateStr, "idx", "people", []string{"first"}}},
+		{"CREATE INDEX `idx` ON `people` (`first`, last);", &IndexDDL{sqlparser.CreateStr, "idx", "people", []string{"first", "last"}}},
+		{"drop index idx on people", &IndexDDL{sqlparser.DropStr, "idx", "people", nil}},
+		{"alter table people add index idx (age)", &IndexDDL{sqlparser.CreateStr, "idx", "people", []string{"age"}}},
+		{"alter table people add key idx (age, rating)", &IndexDDL{sqlparser.CreateStr, "idx", "people", []string{"age", "rating"}}},
+		{"alter table people drop index idx", &IndexDDL{sqlparser.DropStr, "idx", "people", nil}},
+		{"alter table people drop column idx", nil},
+		{"create table idx (id int primary key)", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			stmt, ok := ParseIndexDDL(tt.query)
+			assert.Equal(t, tt.expected != nil, ok)
+			assert.Equal(t, tt.expected, stmt)
+		})
+	}
+}
+
+func TestExecuteIndexDDL(t *testing.T) {
+	tests := []struct {
+		name            string
+		queries         []string
+		expectedIndexes []doltdb.Index
+		expectedErr     string
+	}{
+		{
+			name:            "create index",
+			queries:         []string{"create index idx on people (first)"},
+			expectedIndexes: []doltdb.Index{{Name: "idx", Tags: []uint64{FirstTag}}},
+		},
+		{
+			name:            "alter add multi-column index",
+			queries:         []string{"alter table people add index idx (last, first)"},
+			expectedIndexes: []doltdb.Index{{Name: "idx", Tags: []uint64{LastTag, FirstTag}}},
+		},
+		{
+			name:            "create two indexes",
+			queries:         []string{"create index idx2 on people (age)", "create index idx1 on people (first)"},
+			expectedIndexes: []doltdb.Index{{Name: "idx1", Tags: []uint64{FirstTag}}, {Name: "idx2", Tags: []uint64{AgeTag}}},
+		},
+		{
+			name:    "drop index",
+			queries: []string{"create index idx on people (first)", "drop index idx on people"},
+		},
+		{
+			name:    "alter drop index",
+			queries: []string{"create index idx on people (first)", "alter table people drop index idx"},
+		},
+		{
+			name:        "duplicate name",
+			queries:     []string{"create index idx on people (first)", "create index idx on people (age)"},
+			expectedErr: "Index 'idx' already exists on table 'people'",
+		},
+		{
+			name:        "duplicate columns",
+			queries:     []string{"create index idx on people (first)", "create index idx2 on people (first)"},
+			expectedErr: "Index 'idx' on the same columns already exists",
+		},
+		{
+			name:        "primary key columns",
+			queries:     []string{"create index idx on people (id)"},
+			expectedErr: "duplicates the primary key",
+		},
+		{
+			name:        "unknown column",
+			queries:     []string{"create index idx on people (notFound)"},
+			expectedErr: "Unknown column: 'notFound'",
+		},
+		{
+			name:        "repeated column",
+			queries:     []string{"create index idx on people (first, first)"},
+			expectedErr: "Column 'first' appears more than once",
+		},
+		{
+			name:        "unknown table",
+			queries:     []string{"create index idx on notFound (first)"},
+			expectedErr: "Unknown table: 'notFound'",
+		},
+		{
+			name:        "drop unknown index",
+			queries:     []string{"drop index idx on people"},
+			expectedErr: "Unknown index 'idx' on table 'people'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			CreateTestDatabase(dEnv, t)
+			ctx := context.Background()
+			root, _ := dEnv.WorkingRoot(ctx)
+
+			var err error
+			for _, query := range tt.queries {
+				sqlStatement, parseErr := sqlparser.Parse(query)
+				require.NoError(t, parseErr)
+
+				var updatedRoot *doltdb.RootValue
+				updatedRoot, err = ExecuteAlter(ctx, dEnv.DoltDB, root, sqlStatement.(*sqlparser.DDL), query)
+				if err != nil {
+					break
+				}
+				root = updatedRoot
+			}
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			table, _, err := root.GetTable(ctx, PeopleTableName)
+			require.NoError(t, err)
+			indexes, err := table.GetIndexes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIndexes, indexes)
+
+			// Indexes should be updated by writes to the table
+			updatedRoot, err := ExecuteDelete(ctx, dEnv.DoltDB, root, mustParseDelete(t, "delete from people where id = 0"), "")
+			require.NoError(t, err)
+			updatedTable, _, err := updatedRoot.Root.GetTable(ctx, PeopleTableName)
+			require.NoError(t, err)
+
+			for _, idx := range tt.expectedIndexes {
+				idxData, err := updatedTable.GetIndexRowData(ctx, idx.Name)
+				require.NoError(t, err)
+				assert.Equal(t, uint64(len(AllPeopleRows)-1), idxData.Len())
+			}
+		})
+	}
+}
+
+func mustParseDelete(t *testing.T, query string) *sqlparser.Delete {
+	s, err := sqlparser.Parse(query)
+	require.NoError(t, err)
+	return s.(*sqlparser.Delete)
+}
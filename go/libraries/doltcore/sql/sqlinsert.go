@@ -58,45 +58,9 @@ func ExecuteBatchInsert(
 	replace := s.Action == sqlparser.ReplaceStr
 	ignore := s.Ignore != ""
 
-	// Get the list of columns to insert into. We support both naked inserts (no column list specified) as well as
-	// explicit column lists.
-	var cols []schema.Column
-	if s.Columns == nil || len(s.Columns) == 0 {
-		cols = tableSch.GetAllCols().GetColumns()
-	} else {
-		cols = make([]schema.Column, len(s.Columns))
-		for i, colName := range s.Columns {
-			for _, c := range cols {
-				if c.Name == colName.String() {
-					return nil, fmt.Errorf("Repeated column: '%v'", c.Name)
-				}
-			}
-
-			col, ok := tableSch.GetAllCols().GetByName(colName.String())
-			if !ok {
-				return nil, fmt.Errorf(UnknownColumnErrFmt, colName)
-			}
-			cols[i] = col
-		}
-	}
-
-	var rows []row.Row // your boat
-
-	switch queryRows := s.Rows.(type) {
-	case sqlparser.Values:
-		var err error
-		rows, err = prepareInsertVals(root.VRW().Format(), cols, &queryRows, tableSch)
-		if err != nil {
-			return nil, err
-		}
-	case *sqlparser.Select:
-		return nil, fmt.Errorf("Insert as select not supported")
-	case *sqlparser.ParenSelect:
-		return nil, fmt.Errorf("Parenthesized select expressions in insert not supported")
-	case *sqlparser.Union:
-		return nil, fmt.Errorf("Union not supported")
-	default:
-		return nil, fmt.Errorf("Unrecognized type for insert: %v", queryRows)
+	rows, err := InsertRows(root.VRW().Format(), s, tableSch)
+	if err != nil {
+		return nil, err
 	}
 
 	// Perform the insert
@@ -141,6 +105,53 @@ func ExecuteBatchInsert(
 	return &result, nil
 }
 
+// InsertRows returns the rows inserted by the given insert statement into a table with the schema given, which must be
+// an insert of literal values.
+func InsertRows(nbf *types.NomsBinFormat, s *sqlparser.Insert, tableSch schema.Schema) ([]row.Row, error) {
+	// Get the list of columns to insert into. We support both naked inserts (no column list specified) as well as
+	// explicit column lists.
+	var cols []schema.Column
+	if s.Columns == nil || len(s.Columns) == 0 {
+		cols = tableSch.GetAllCols().GetColumns()
+	} else {
+		cols = make([]schema.Column, len(s.Columns))
+		for i, colName := range s.Columns {
+			for _, c := range cols {
+				if c.Name == colName.String() {
+					return nil, fmt.Errorf("Repeated column: '%v'", c.Name)
+				}
+			}
+
+			col, ok := tableSch.GetAllCols().GetByName(colName.String())
+			if !ok {
+				return nil, fmt.Errorf(UnknownColumnErrFmt, colName)
+			}
+			cols[i] = col
+		}
+	}
+
+	var rows []row.Row // your boat
+
+	switch queryRows := s.Rows.(type) {
+	case sqlparser.Values:
+		var err error
+		rows, err = prepareInsertVals(nbf, cols, &queryRows, tableSch)
+		if err != nil {
+			return nil, err
+		}
+	case *sqlparser.Select:
+		return nil, fmt.Errorf("Insert as select not supported")
+	case *sqlparser.ParenSelect:
+		return nil, fmt.Errorf("Parenthesized select expressions in insert not supported")
+	case *sqlparser.Union:
+		return nil, fmt.Errorf("Union not supported")
+	default:
+		return nil, fmt.Errorf("Unrecognized type for insert: %v", queryRows)
+	}
+
+	return rows, nil
+}
+
 // ExecuteInsert executes the given select insert statement and returns the result.
 func ExecuteInsert(
 	ctx context.Context,
@@ -311,10 +322,44 @@ func makeRow(nbf *types.NomsBinFormat, columns []schema.Column, tableSch schema.
 		case *sqlparser.GroupConcatExpr:
 			return errInsertRow("Group concat expressions not supported in insert values: %v", nodeToString(tuple))
 		case *sqlparser.Default:
-			return errInsertRow("Unrecognized expression: %v", nodeToString(tuple))
+			defaultVal, err := column.DefaultValue()
+			if err != nil {
+				return nil, err
+			}
+			if defaultVal != nil {
+				taggedVals[column.Tag] = defaultVal
+			}
 		default:
 			return errInsertRow("Unrecognized expression: %v", nodeToString(tuple))
 		}
+
+		if val, ok := taggedVals[column.Tag]; ok {
+			normalized, err := column.NormalizeValue(val)
+			if err != nil {
+				return nil, err
+			}
+			taggedVals[column.Tag] = normalized
+		}
+	}
+
+	// Columns not given values take their default values
+	err := tableSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		for _, column := range columns {
+			if column.Tag == tag {
+				return false, nil
+			}
+		}
+
+		defaultVal, err := col.DefaultValue()
+		if err == nil && defaultVal != nil {
+			taggedVals[tag] = defaultVal
+		}
+
+		return false, err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return row.New(nbf, tableSch, taggedVals)
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"vitess.io/vitess/go/vt/sqlparser"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
@@ -382,3 +383,194 @@ func rowsEqual(expected, actual row.Row) (bool, string) {
 	}
 	return eq, diff
 }
+
+func TestExecuteInsertTimestampsAndDecimals(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	root = executeTestStatement(t, dEnv.DoltDB, root, "create table payments (id int primary key, day date, paid_at datetime, amount decimal(6,2))")
+
+	_, err = executeTestStatementWithErr(dEnv.DoltDB, root, "insert into payments (id, amount) values (0, 12345.6)")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+
+	_, err = executeTestStatementWithErr(dEnv.DoltDB, root, "insert into payments (id, day) values (0, 'not a date')")
+	require.Error(t, err)
+
+	root = executeTestStatement(t, dEnv.DoltDB, root, `insert into payments (id, day, paid_at, amount) values
+		(1, '2019-08-01 10:00:00', '2019-08-01 12:30:00', 12.345),
+		(2, '2019-09-01', '2019-09-01T08:00:00Z', -5),
+		(3, '2019-10-01', '2019-10-01 00:00:00', '0.5')`)
+	root = executeTestStatement(t, dEnv.DoltDB, root, "update payments set amount = 1.005 where amount = -5.0")
+	root = executeTestStatement(t, dEnv.DoltDB, root, "delete from payments where paid_at > '2019-09-15'")
+
+	tbl, _, err := root.GetTable(ctx, "payments")
+	require.NoError(t, err)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	rowData, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+
+	var actual [][]string
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		require.NoError(t, err)
+
+		var strs []string
+		for _, tag := range sch.GetAllCols().Tags {
+			val, _ := r.GetColVal(tag)
+			str, err := types.EncodedValue(ctx, val)
+			require.NoError(t, err)
+			strs = append(strs, str)
+		}
+
+		actual = append(actual, strs)
+		return nil
+	})
+	require.NoError(t, err)
+
+	expected := [][]string{
+		{"1", "2019-08-01 00:00:00", "2019-08-01 12:30:00", "12.35"},
+		{"2", "2019-09-01 00:00:00", "2019-09-01 08:00:00", "1.01"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func executeTestStatement(t *testing.T, db *doltdb.DoltDB, root *doltdb.RootValue, query string) *doltdb.RootValue {
+	root, err := executeTestStatementWithErr(db, root, query)
+	require.NoError(t, err)
+	return root
+}
+
+func executeTestStatementWithErr(db *doltdb.DoltDB, root *doltdb.RootValue, query string) (*doltdb.RootValue, error) {
+	ctx := context.Background()
+	sqlStatement, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s := sqlStatement.(type) {
+	case *sqlparser.DDL:
+		root, _, err = ExecuteCreate(ctx, db, root, s, query)
+		return root, err
+	case *sqlparser.Insert:
+		result, err := ExecuteInsert(ctx, db, root, s)
+		if err != nil {
+			return nil, err
+		}
+		return result.Root, nil
+	case *sqlparser.Update:
+		result, err := ExecuteUpdate(ctx, db, root, s, query)
+		if err != nil {
+			return nil, err
+		}
+		return result.Root, nil
+	case *sqlparser.Delete:
+		result, err := ExecuteDelete(ctx, db, root, s, query)
+		if err != nil {
+			return nil, err
+		}
+		return result.Root, nil
+	default:
+		return nil, errFmt("unsupported statement: %v", query)
+	}
+}
+
+func TestExecuteInsertConstraints(t *testing.T) {
+	const createAccounts = `create table accounts (
+		id int primary key,
+		name varchar(5) not null unique,
+		stage enum('open', 'closed') default 'open',
+		balance int default 0 check (balance >= 0),
+		tier int,
+		check (tier in (1, 2, 3)))`
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedRow row.TaggedValues
+		expectedErr string
+	}{
+		{
+			name:        "defaults for omitted columns",
+			query:       `insert into accounts (id, name) values (1, "ann")`,
+			expectedRow: row.TaggedValues{0: types.Int(1), 1: types.String("ann"), 2: types.String("open"), 3: types.Int(0)},
+		},
+		{
+			name:        "default keyword",
+			query:       `insert into accounts (id, name, stage, balance, tier) values (1, "ann", default, 10, default)`,
+			expectedRow: row.TaggedValues{0: types.Int(1), 1: types.String("ann"), 2: types.String("open"), 3: types.Int(10)},
+		},
+		{
+			name:        "explicit null overrides default",
+			query:       `insert into accounts (id, name, stage, tier) values (1, "ann", null, 2)`,
+			expectedRow: row.TaggedValues{0: types.Int(1), 1: types.String("ann"), 3: types.Int(0), 4: types.Int(2)},
+		},
+		{
+			name:        "string too long",
+			query:       `insert into accounts (id, name) values (1, "annabelle")`,
+			expectedErr: "Constraint failed for column 'name': At most 5 characters",
+		},
+		{
+			name:        "value not in enum",
+			query:       `insert into accounts (id, name, stage) values (1, "ann", "frozen")`,
+			expectedErr: "Constraint failed for column 'stage': One of 'open', 'closed'",
+		},
+		{
+			name:        "value out of range",
+			query:       `insert into accounts (id, name, balance) values (1, "ann", -1)`,
+			expectedErr: "Constraint failed for column 'balance': At least 0",
+		},
+		{
+			name:        "check constraint failure",
+			query:       `insert into accounts (id, name, tier) values (1, "ann", 4)`,
+			expectedErr: "Constraint failed for column 'tier': Check (`tier` in (1, 2, 3))",
+		},
+		{
+			name:        "duplicate unique value",
+			query:       `insert into accounts (id, name) values (1, "ann"), (2, "ann")`,
+			expectedErr: `Duplicate value "ann" for unique column 'name'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			ctx := context.Background()
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+
+			createStmt, _ := sqlparser.Parse(createAccounts)
+			root, sch, err := ExecuteCreate(ctx, dEnv.DoltDB, root, createStmt.(*sqlparser.DDL), createAccounts)
+			require.NoError(t, err)
+
+			sqlStatement, err := sqlparser.Parse(tt.query)
+			require.NoError(t, err)
+
+			result, err := ExecuteInsert(ctx, dEnv.DoltDB, root, sqlStatement.(*sqlparser.Insert))
+
+			if len(tt.expectedErr) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			table, _, err := result.Root.GetTable(ctx, "accounts")
+			require.NoError(t, err)
+
+			expectedRow, err := row.New(types.Format_7_18, sch, tt.expectedRow)
+			require.NoError(t, err)
+
+			foundRow, ok, err := table.GetRowByPKVals(ctx, row.TaggedValues{0: types.Int(1)}, sch)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			eq, diff := rowsEqual(expectedRow, foundRow)
+			assert.True(t, eq, "Rows not equals, found diff %v", diff)
+		})
+	}
+}
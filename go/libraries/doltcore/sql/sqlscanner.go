@@ -0,0 +1,132 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// MaxStatementSize is the largest statement, in bytes, that a statement scanner will return. Dumps written by mysqldump
+// insert many rows with each statement, so this is much larger than a bufio.Scanner allows by default.
+const MaxStatementSize = 256 * 1024 * 1024
+
+// NewStatementScanner returns a bufio.Scanner which returns each SQL statement read from the reader given as a token.
+func NewStatementScanner(rd io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxStatementSize)
+	scanner.Split(ScanStatements)
+
+	return scanner
+}
+
+// ScanStatements is a split function for a bufio.Scanner that returns each SQL statement in the input as a token,
+// without its terminating semicolon. Semicolons in quoted strings and identifiers and in comments don't end statements.
+// Statements which have nothing in them other than comments are skipped, including MySQL's versioned /*!...*/ comments,
+// which are only used in dumps to set options of a MySQL session.
+func ScanStatements(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	hasCode := false
+	for i := 0; i < len(data); {
+		end, complete := tokenEnd(data, i, atEOF)
+
+		if !complete {
+			// the token at i continues beyond the data read so far
+			return start, nil, nil
+		}
+
+		switch c := data[i]; {
+		case c == ';':
+			if hasCode {
+				return end, bytes.TrimSpace(data[start:i]), nil
+			}
+
+			// skip the empty statement, which is consumed along with the next statement returned
+			start = end
+		case isSpace(c) || isCommentStart(data, i):
+		default:
+			hasCode = true
+		}
+
+		i = end
+	}
+
+	if !atEOF {
+		return start, nil, nil
+	} else if !hasCode {
+		return len(data), nil, nil
+	}
+
+	return len(data), bytes.TrimSpace(data[start:]), nil
+}
+
+// tokenEnd returns the index after the end of the quoted string or identifier, or the comment, that starts at index i,
+// or i+1 if none does. Returns false if the data ends before it does, unless atEOF is true.
+func tokenEnd(data []byte, i int, atEOF bool) (int, bool) {
+	switch c := data[i]; {
+	case c == '\'' || c == '"' || c == '`':
+		for j := i + 1; j < len(data); j++ {
+			if data[j] == '\\' && c != '`' {
+				j++
+			} else if data[j] == c {
+				return j + 1, true
+			}
+		}
+	case c == '#' || c == '-':
+		if c == '-' && i+2 >= len(data) && !atEOF {
+			return i, false
+		} else if !isCommentStart(data, i) {
+			return i + 1, true
+		}
+
+		if j := bytes.IndexByte(data[i:], '\n'); j >= 0 {
+			return i + j + 1, true
+		}
+	case c == '/':
+		if i+1 >= len(data) {
+			return i + 1, atEOF
+		} else if data[i+1] != '*' {
+			return i + 1, true
+		}
+
+		if j := bytes.Index(data[i+2:], []byte("*/")); j >= 0 {
+			return i + 2 + j + 2, true
+		}
+	default:
+		return i + 1, true
+	}
+
+	return len(data), atEOF
+}
+
+// isCommentStart returns whether a comment starts at index i of the data given.
+func isCommentStart(data []byte, i int) bool {
+	switch data[i] {
+	case '#':
+		return true
+	case '-':
+		// a double dash only starts a comment if it's followed by whitespace
+		return i+1 < len(data) && data[i+1] == '-' && (i+2 == len(data) || isSpace(data[i+2]))
+	case '/':
+		return i+1 < len(data) && data[i+1] == '*'
+	}
+
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "simple statements",
+			input:    "select 1; select 2;\nselect 3",
+			expected: []string{"select 1", "select 2", "select 3"},
+		},
+		{
+			name:     "semicolons in quotes",
+			input:    "insert into t values ('a;b', \"c;d\"); select `e;f` from t;",
+			expected: []string{"insert into t values ('a;b', \"c;d\")", "select `e;f` from t"},
+		},
+		{
+			name:     "escaped quotes",
+			input:    `insert into t values ('it\'s; here', 'it''s; there', "\"; "); select 1;`,
+			expected: []string{`insert into t values ('it\'s; here', 'it''s; there', "\"; ")`, "select 1"},
+		},
+		{
+			name:     "semicolons in comments",
+			input:    "-- a comment; with a semicolon\nselect 1 /* another; one */ + 1; # and; another\nselect 2;",
+			expected: []string{"-- a comment; with a semicolon\nselect 1 /* another; one */ + 1", "# and; another\nselect 2"},
+		},
+		{
+			name:     "comment only statements are skipped",
+			input:    "-- a dump\n/*!40101 SET NAMES utf8mb4 */;\n\n;select 1;\n-- done\n",
+			expected: []string{"select 1"},
+		},
+		{
+			name:     "double dash without a space is not a comment",
+			input:    "select 1--1; select 2",
+			expected: []string{"select 1--1", "select 2"},
+		},
+		{
+			name:     "unterminated string",
+			input:    "select 1; select 'abc;",
+			expected: []string{"select 1", "select 'abc;"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// reading one byte at a time makes the scanner request more data at every point in the input
+			for _, rd := range []io.Reader{strings.NewReader(test.input), iotest.OneByteReader(strings.NewReader(test.input))} {
+				scanner := NewStatementScanner(rd)
+
+				var actual []string
+				for scanner.Scan() {
+					actual = append(actual, scanner.Text())
+				}
+
+				require.NoError(t, scanner.Err())
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
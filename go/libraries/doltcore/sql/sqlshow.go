@@ -116,7 +116,11 @@ func BuildShowPipeline(ctx context.Context, root *doltdb.RootValue, show *sqlpar
 			return nil, nil, err
 		}
 
-		schemaStr := SchemaAsCreateStmt(tableName, sch)
+		schemaStr, err := TableAsCreateStmt(ctx, root, tableName, sch)
+
+		if err != nil {
+			return nil, nil, err
+		}
 
 		resultSch := showCreateTableSchema()
 		rows, err := toRows(root.VRW().Format(), ([][]string{{tableName, schemaStr}}), resultSch)
@@ -215,15 +219,21 @@ func describeColumn(nbf *types.NomsBinFormat, col schema.Column) (row.Row, error
 	keyStr := ""
 	if col.IsPartOfPK {
 		keyStr = "PRI"
+	} else if col.IsUnique() {
+		keyStr = "UNI"
+	}
+	defaultStr := "NULL"
+	if cnst, ok := col.GetConstraint(schema.DefaultConstraintType); ok {
+		defaultStr = cnst.(schema.DefaultConstraint).Value
 	}
 
 	taggedVals := row.TaggedValues{
 		0: types.String(col.Name),
-		1: types.String(DoltToSQLType[col.Kind]),
+		1: types.String(ColumnSQLType(col)),
 		2: types.String(nullStr),
 		3: types.String(keyStr),
-		4: types.String("NULL"), // TODO: when schemas store defaults, use them here
-		5: types.String(""),     // Extra column reserved for future use
+		4: types.String(defaultStr),
+		5: types.String(""), // Extra column reserved for future use
 	}
 	return row.New(nbf, showColumnsSchema(), taggedVals)
 }
@@ -460,7 +460,7 @@ var BasicSelectTests = []SelectTest{
 	{
 		Name:            "select *, -column, string type",
 		Query:           "select * from people where -first = 'Homer'",
-		ExpectedErr:     "Unsupported type for unary - operation: varchar",
+		ExpectedErr:     "Unsupported type for unary - operation: longtext",
 		SkipOnSqlEngine: true,
 	},
 	{
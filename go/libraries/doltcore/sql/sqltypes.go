@@ -15,16 +15,56 @@
 package sql
 
 import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 var DoltToSQLType = map[types.NomsKind]string{
-	types.StringKind: VARCHAR + "(1024)", // we don't actually enforce string lengths, but mysql requires them
-	types.BoolKind:   BOOL,
-	types.FloatKind:  FLOAT_TYPE,
-	types.IntKind:    INT,
-	types.UintKind:   INT + " " + UNSIGNED,
-	types.UUIDKind:   UUID,
+	types.StringKind:    LONGTEXT,
+	types.BoolKind:      BOOL,
+	types.FloatKind:     FLOAT_TYPE,
+	types.IntKind:       INT,
+	types.UintKind:      INT + " " + UNSIGNED,
+	types.UUIDKind:      UUID,
+	types.TimestampKind: TIMESTAMP,
+	types.DecimalKind:   DECIMAL,
+}
+
+// ColumnSQLType returns the SQL type of the column given, including any parameters of the type such as the precision and
+// scale of a decimal column.
+func ColumnSQLType(col schema.Column) string {
+	switch col.Kind {
+	case types.TimestampKind:
+		if sqlType, ok := col.TypeParams[schema.SQLTypeParam]; ok {
+			return sqlType
+		}
+	case types.StringKind:
+		if cnst, ok := col.GetConstraint(schema.EnumConstraintType); ok {
+			values := make([]string, len(cnst.(schema.EnumConstraint).Values))
+			for i, val := range cnst.(schema.EnumConstraint).Values {
+				values[i] = "'" + strings.Replace(val, "'", "''", -1) + "'"
+			}
+			return fmt.Sprintf("%s(%s)", ENUM, strings.Join(values, ","))
+		}
+
+		if cnst, ok := col.GetConstraint(schema.LengthConstraintType); ok {
+			return fmt.Sprintf("%s(%d)", VARCHAR, cnst.(schema.LengthConstraint).Length)
+		}
+	case types.DecimalKind:
+		precision, pOk := col.TypeParams[schema.PrecisionParam]
+		scale, sOk := col.TypeParams[schema.ScaleParam]
+
+		if pOk && sOk {
+			return fmt.Sprintf("%s(%s,%s)", DECIMAL, precision, scale)
+		}
+	}
+
+	return DoltToSQLType[col.Kind]
 }
 
 // TypeConversionFn is a function that converts one noms value to another of a different type in a guaranteed fashion,
@@ -43,33 +83,47 @@ var convFuncMap = map[types.NomsKind]map[types.NomsKind]TypeConversionFn{
 		types.NullKind: convToNullFunc,
 	},
 	types.UintKind: {
-		types.UintKind:  identityConvFunc,
-		types.IntKind:   convUintToInt,
-		types.FloatKind: convUintToFloat,
-		types.NullKind:  convToNullFunc,
+		types.UintKind:    identityConvFunc,
+		types.IntKind:     convUintToInt,
+		types.FloatKind:   convUintToFloat,
+		types.DecimalKind: convUintToDecimal,
+		types.NullKind:    convToNullFunc,
 	},
 	types.IntKind: {
-		types.UintKind:  convIntToUint,
-		types.IntKind:   identityConvFunc,
-		types.FloatKind: convIntToFloat,
-		types.NullKind:  convToNullFunc,
+		types.UintKind:    convIntToUint,
+		types.IntKind:     identityConvFunc,
+		types.FloatKind:   convIntToFloat,
+		types.DecimalKind: convIntToDecimal,
+		types.NullKind:    convToNullFunc,
 	},
 	types.FloatKind: {
-		types.FloatKind: identityConvFunc,
-		types.NullKind:  convToNullFunc,
+		types.FloatKind:   identityConvFunc,
+		types.DecimalKind: convFloatToDecimal,
+		types.NullKind:    convToNullFunc,
 	},
 	types.BoolKind: {
 		types.BoolKind: identityConvFunc,
 		types.NullKind: convToNullFunc,
 	},
+	types.TimestampKind: {
+		types.TimestampKind: identityConvFunc,
+		types.NullKind:      convToNullFunc,
+	},
+	types.DecimalKind: {
+		types.DecimalKind: identityConvFunc,
+		types.FloatKind:   convDecimalToFloat,
+		types.NullKind:    convToNullFunc,
+	},
 	types.NullKind: {
-		types.StringKind: convToNullFunc,
-		types.UUIDKind:   convToNullFunc,
-		types.UintKind:   convToNullFunc,
-		types.IntKind:    convToNullFunc,
-		types.FloatKind:  convToNullFunc,
-		types.BoolKind:   convToNullFunc,
-		types.NullKind:   convToNullFunc,
+		types.StringKind:    convToNullFunc,
+		types.UUIDKind:      convToNullFunc,
+		types.UintKind:      convToNullFunc,
+		types.IntKind:       convToNullFunc,
+		types.FloatKind:     convToNullFunc,
+		types.BoolKind:      convToNullFunc,
+		types.TimestampKind: convToNullFunc,
+		types.DecimalKind:   convToNullFunc,
+		types.NullKind:      convToNullFunc,
 	},
 }
 
@@ -85,6 +139,52 @@ func GetTypeConversionFn(srcKind, destKind types.NomsKind) TypeConversionFn {
 	return convFunc
 }
 
+// outOfRangeFuncMap holds functions that report whether a value can't be converted to the destination kind without
+// changing it, for conversions which are otherwise lossless in both directions.
+var outOfRangeFuncMap = map[types.NomsKind]map[types.NomsKind]func(types.Value) bool{
+	types.UintKind: {
+		types.IntKind: func(val types.Value) bool { return uint64(val.(types.Uint)) > math.MaxInt64 },
+	},
+	types.IntKind: {
+		types.UintKind: func(val types.Value) bool { return int64(val.(types.Int)) < 0 },
+	},
+}
+
+// GetLosslessTypeConversionFn returns a TypeConversionFn like GetTypeConversionFn, except that the function returned
+// gives nil for any value that would be changed by the conversion, such as a negative int converted to a uint. Values
+// are checked by converting them back to the source kind, so conversions with no conversion back aren't checked.
+func GetLosslessTypeConversionFn(srcKind, destKind types.NomsKind) TypeConversionFn {
+	convFunc := GetTypeConversionFn(srcKind, destKind)
+	reverseFunc := GetTypeConversionFn(destKind, srcKind)
+	outOfRange := outOfRangeFuncMap[srcKind][destKind]
+
+	if convFunc == nil || reverseFunc == nil {
+		return convFunc
+	}
+
+	return func(val types.Value) types.Value {
+		if val == nil {
+			return nil
+		}
+
+		if outOfRange != nil && outOfRange(val) {
+			return nil
+		}
+
+		converted := convFunc(val)
+
+		if converted == nil {
+			return nil
+		}
+
+		if reversed := reverseFunc(converted); reversed == nil || !valuesAreEqual(reversed, val) {
+			return nil
+		}
+
+		return converted
+	}
+}
+
 func identityConvFunc(value types.Value) types.Value {
 	return value
 }
@@ -128,3 +228,43 @@ func convIntToFloat(val types.Value) types.Value {
 	n := int64(val.(types.Int))
 	return types.Float(float64(n))
 }
+
+func convUintToDecimal(val types.Value) types.Value {
+	if val == nil {
+		return nil
+	}
+
+	n := uint64(val.(types.Uint))
+	return types.NewDecimal(new(big.Int).SetUint64(n), 0)
+}
+
+func convIntToDecimal(val types.Value) types.Value {
+	if val == nil {
+		return nil
+	}
+
+	n := int64(val.(types.Int))
+	return types.NewDecimal(big.NewInt(n), 0)
+}
+
+func convFloatToDecimal(val types.Value) types.Value {
+	if val == nil {
+		return nil
+	}
+
+	// NaN and infinities have no decimal representation
+	d, err := types.DecimalFromFloat(float64(val.(types.Float)))
+	if err != nil {
+		return nil
+	}
+
+	return d
+}
+
+func convDecimalToFloat(val types.Value) types.Value {
+	if val == nil {
+		return nil
+	}
+
+	return types.Float(val.(types.Decimal).Float64())
+}
@@ -163,6 +163,11 @@ func ExecuteUpdate(ctx context.Context, db *doltdb.DoltDB, root *doltdb.RootValu
 			currVal, _ := r.GetColVal(tag)
 			val := getter.Get(r)
 
+			col, _ := tableSch.GetAllCols().GetByTag(tag)
+			if val, err = col.NormalizeValue(val); err != nil {
+				return nil, err
+			}
+
 			if (currVal == nil && val != nil) || (currVal != nil && !currVal.Equals(val)) {
 				anyColChanged = true
 			}
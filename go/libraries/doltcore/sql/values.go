@@ -16,6 +16,7 @@ package sql
 
 import (
 	"context"
+	"math/big"
 	"strconv"
 
 	"github.com/google/uuid"
@@ -78,6 +79,9 @@ type RowValGetter struct {
 	// getFn returns the value for this getter for the row given. Clients should call the interface method Get() rather
 	// than calling this method directly.
 	getFn func(r row.Row) types.Value
+	// The value returned by this getter if it's a literal, or nil otherwise. Literals can be converted to more types than
+	// other values, since any conversion error can be reported before the getter is used.
+	literalVal types.Value
 	// Whether this value has been initialized.
 	inited bool
 	// Clients should use these interface methods, rather than getFn and initFn directly.
@@ -116,6 +120,14 @@ func ConversionValueGetter(getter *RowValGetter, destKind types.NomsKind) (*RowV
 	}
 
 	converterFn := GetTypeConversionFn(getter.NomsKind, destKind)
+	if converterFn == nil && getter.literalVal != nil {
+		if val, err := convertLiteral(getter.literalVal, destKind); err != nil {
+			return nil, err
+		} else if val != nil {
+			return LiteralValueGetter(val), nil
+		}
+	}
+
 	if converterFn == nil {
 		return nil, errFmt("Type mismatch: cannot convert from %v to %v",
 			DoltToSQLType[getter.NomsKind], DoltToSQLType[destKind])
@@ -136,13 +148,39 @@ func ConversionValueGetter(getter *RowValGetter, destKind types.NomsKind) (*RowV
 // Returns a new RowValGetter for the literal value given.
 func LiteralValueGetter(value types.Value) *RowValGetter {
 	return &RowValGetter{
-		NomsKind: value.Kind(),
+		NomsKind:   value.Kind(),
+		literalVal: value,
 		getFn: func(r row.Row) types.Value {
 			return value
 		},
 	}
 }
 
+// convertLiteral converts a literal value to the kind given when that conversion can fail, such as parsing a string as
+// a timestamp. Returns nil if there is no such conversion between the kinds.
+func convertLiteral(val types.Value, destKind types.NomsKind) (types.Value, error) {
+	switch destKind {
+	case types.TimestampKind:
+		if str, ok := val.(types.String); ok {
+			ts, err := types.ParseTimestamp(string(str))
+			if err != nil {
+				return nil, errFmt("Type mismatch: %v", err.Error())
+			}
+			return ts, nil
+		}
+	case types.DecimalKind:
+		if str, ok := val.(types.String); ok {
+			d, err := types.ParseDecimal(string(str))
+			if err != nil {
+				return nil, errFmt("Type mismatch: %v", err.Error())
+			}
+			return d, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // Returns a RowValGetter for the column given, or an error
 func getterForColumn(qc QualifiedColumn, inputSchemas map[string]schema.Schema) (*RowValGetter, error) {
 	tableSch, ok := inputSchemas[qc.TableName]
@@ -277,11 +315,11 @@ func getterFor(expr sqlparser.Expr, inputSchemas map[string]schema.Schema, alias
 		switch e.Operator {
 		case sqlparser.EqualStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				return left.Equals(right)
+				return valuesAreEqual(left, right)
 			}
 		case sqlparser.LessThanStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				isLess, err := left.Less(nbf, right)
+				isLess, err := valueIsLess(nbf, left, right)
 
 				if err != nil {
 					panic(err)
@@ -291,7 +329,7 @@ func getterFor(expr sqlparser.Expr, inputSchemas map[string]schema.Schema, alias
 			}
 		case sqlparser.GreaterThanStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				isLess, err := right.Less(nbf, left)
+				isLess, err := valueIsLess(nbf, right, left)
 
 				if err != nil {
 					panic(err)
@@ -301,7 +339,7 @@ func getterFor(expr sqlparser.Expr, inputSchemas map[string]schema.Schema, alias
 			}
 		case sqlparser.LessEqualStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				isLess, err := right.Less(nbf, left)
+				isLess, err := valueIsLess(nbf, right, left)
 
 				if err != nil {
 					panic(err)
@@ -311,7 +349,7 @@ func getterFor(expr sqlparser.Expr, inputSchemas map[string]schema.Schema, alias
 			}
 		case sqlparser.GreaterEqualStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				isLess, err := left.Less(nbf, right)
+				isLess, err := valueIsLess(nbf, left, right)
 
 				if err != nil {
 					panic(err)
@@ -321,7 +359,7 @@ func getterFor(expr sqlparser.Expr, inputSchemas map[string]schema.Schema, alias
 			}
 		case sqlparser.NotEqualStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
-				return !left.Equals(right)
+				return !valuesAreEqual(left, right)
 			}
 		case sqlparser.InStr:
 			predicate = func(nbf *types.NomsBinFormat, left, right types.Value) bool {
@@ -652,6 +690,29 @@ func getterForBinaryExpr(e *sqlparser.BinaryExpr, inputSchemas map[string]schema
 	return getter, nil
 }
 
+// valuesAreEqual returns whether two values are equal. Unlike Value.Equals, decimals are compared by their numeric
+// values, so that 1.5 equals 1.50.
+func valuesAreEqual(left, right types.Value) bool {
+	if leftDec, ok := left.(types.Decimal); ok {
+		if rightDec, ok := right.(types.Decimal); ok {
+			return leftDec.Cmp(rightDec) == 0
+		}
+	}
+
+	return left.Equals(right)
+}
+
+// valueIsLess returns whether the left value is less than the right one, comparing decimals by their numeric values.
+func valueIsLess(nbf *types.NomsBinFormat, left, right types.Value) (bool, error) {
+	if leftDec, ok := left.(types.Decimal); ok {
+		if rightDec, ok := right.(types.Decimal); ok {
+			return leftDec.Cmp(rightDec) < 0, nil
+		}
+	}
+
+	return left.Less(nbf, right)
+}
+
 // Attempts to divine a value and type from the given SQLVal expression. Returns the value or an error.
 // The most specific possible type is returned, e.g. Float over Int. Unsigned values are never returned.
 func divineNomsValueFromSQLVal(val *sqlparser.SQLVal) (types.Value, error) {
@@ -702,6 +763,8 @@ func extractNomsValueFromSQLVal(val *sqlparser.SQLVal, kind types.NomsKind) (typ
 			return types.Float(intVal), nil
 		case types.UintKind:
 			return types.Uint(intVal), nil
+		case types.DecimalKind:
+			return types.NewDecimal(big.NewInt(intVal), 0), nil
 		default:
 			return nil, errFmt("Type mismatch: numeric value but non-numeric column: %v", nodeToString(val))
 		}
@@ -714,6 +777,9 @@ func extractNomsValueFromSQLVal(val *sqlparser.SQLVal, kind types.NomsKind) (typ
 		switch kind {
 		case types.FloatKind:
 			return types.Float(floatVal), nil
+		case types.DecimalKind:
+			// parse the literal itself rather than the float, which may not be exact
+			return types.ParseDecimal(string(val.Val))
 		default:
 			return nil, errFmt("Type mismatch: float value but non-float column: %v", nodeToString(val))
 		}
@@ -729,6 +795,18 @@ func extractNomsValueFromSQLVal(val *sqlparser.SQLVal, kind types.NomsKind) (typ
 				return nil, errFmt("Type mismatch: string value but non-string column: %v", nodeToString(val))
 			}
 			return types.UUID(id), nil
+		case types.TimestampKind:
+			ts, err := types.ParseTimestamp(strVal)
+			if err != nil {
+				return nil, errFmt("Type mismatch: string value is not a valid timestamp: %v", nodeToString(val))
+			}
+			return ts, nil
+		case types.DecimalKind:
+			d, err := types.ParseDecimal(strVal)
+			if err != nil {
+				return nil, errFmt("Type mismatch: string value is not a valid decimal: %v", nodeToString(val))
+			}
+			return d, nil
 		default:
 			return nil, errFmt("Type mismatch: string value but non-string column: %v", nodeToString(val))
 		}
@@ -755,7 +833,7 @@ func extractNomsValueFromUnaryExpr(expr *sqlparser.UnaryExpr, kind types.NomsKin
 	switch expr.Operator {
 	case sqlparser.UPlusStr:
 		switch kind {
-		case types.UintKind, types.IntKind, types.FloatKind:
+		case types.UintKind, types.IntKind, types.FloatKind, types.DecimalKind:
 			return val, nil
 		default:
 			return nil, errFmt("Unsupported type for unary + operator: %v", nodeToString(expr))
@@ -768,6 +846,10 @@ func extractNomsValueFromUnaryExpr(expr *sqlparser.UnaryExpr, kind types.NomsKin
 			return types.Int(-1 * val.(types.Int)), nil
 		case types.FloatKind:
 			return types.Float(-1 * val.(types.Float)), nil
+		case types.DecimalKind:
+			d := val.(types.Decimal)
+			unscaled := d.Unscaled()
+			return types.NewDecimal(unscaled.Neg(unscaled), d.Scale()), nil
 		default:
 			return nil, errFmt("Unsupported type for unary - operator: %v", nodeToString(expr))
 		}
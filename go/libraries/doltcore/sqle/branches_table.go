@@ -0,0 +1,102 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+)
+
+// BranchesTableName is the name of the system table which lists the branches of the repository.
+const BranchesTableName = "dolt_branches"
+
+// BranchesTable is a read-only sql.Table which lists the branches of the repository, along with the commit at the head
+// of each.
+type BranchesTable struct {
+	db *Database
+}
+
+// NewBranchesTable returns the branches table for the database given.
+func NewBranchesTable(db *Database) *BranchesTable {
+	return &BranchesTable{db: db}
+}
+
+// Name returns the name of the table.
+func (bt *BranchesTable) Name() string {
+	return BranchesTableName
+}
+
+// String returns the name of the table.
+func (bt *BranchesTable) String() string {
+	return BranchesTableName
+}
+
+// Schema returns the schema of the table.
+func (bt *BranchesTable) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "name", Type: sql.Text, Source: BranchesTableName},
+		{Name: "hash", Type: sql.Text, Source: BranchesTableName},
+		{Name: "latest_committer", Type: sql.Text, Source: BranchesTableName},
+		{Name: "latest_committer_email", Type: sql.Text, Source: BranchesTableName},
+		{Name: "latest_commit_date", Type: sql.Timestamp, Source: BranchesTableName},
+		{Name: "latest_commit_message", Type: sql.Text, Source: BranchesTableName},
+	}
+}
+
+// Partitions returns the single partition of the table.
+func (bt *BranchesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows returns a row for each branch.
+func (bt *BranchesTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	branches, err := bt.db.ddb.GetBranches(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, 0, len(branches))
+	for _, branch := range branches {
+		cs, err := doltdb.NewCommitSpec("HEAD", branch.String())
+
+		if err != nil {
+			return nil, err
+		}
+
+		cm, err := bt.db.ddb.Resolve(ctx, cs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, sql.NewRow(branch.GetPath(), h.String(), meta.Name, meta.Email, meta.Time(), meta.Description))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
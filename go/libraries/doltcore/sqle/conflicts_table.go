@@ -0,0 +1,341 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ConflictsTablePrefix is the prefix of the names of the system tables which show the merge conflicts of a table. The
+// conflicts of the table people are in dolt_conflicts_people.
+const ConflictsTablePrefix = "dolt_conflicts_"
+
+const (
+	conflictBasePrefix  = "base_"
+	conflictOurPrefix   = "our_"
+	conflictTheirPrefix = "their_"
+)
+
+// ErrConflictNotUpdatable is returned when an update to a conflicts table changes anything but the our_ columns.
+var ErrConflictNotUpdatable = errors.New("only the our_ columns of a conflict can be updated")
+
+// ConflictsTable is a sql.Table which has a row for every row of a user table that is in conflict after a merge. Each
+// row has the values of the conflicting row's columns in the merge base (base_<column>), in the working set
+// (our_<column>) and in the commit merged in (their_<column>). The values of a side which doesn't have the row are
+// NULL. Only columns in the table's current schema are included.
+//
+// Conflicts are resolved by writing to the table. Updating the our_ columns of a conflict writes the row to the
+// working set, and setting them all to NULL removes it. Deleting a conflict marks it resolved, keeping the row in the
+// working set as it is.
+type ConflictsTable struct {
+	tableName string
+	sch       schema.Schema
+	db        *Database
+}
+
+// NewConflictsTable returns the conflicts table for the table with the name and schema given.
+func NewConflictsTable(tableName string, sch schema.Schema, db *Database) *ConflictsTable {
+	return &ConflictsTable{tableName: tableName, sch: sch, db: db}
+}
+
+// Name returns the name of the table.
+func (ct *ConflictsTable) Name() string {
+	return ConflictsTablePrefix + ct.tableName
+}
+
+// String returns the name of the table.
+func (ct *ConflictsTable) String() string {
+	return ct.Name()
+}
+
+// Schema returns the schema of the table.
+func (ct *ConflictsTable) Schema() sql.Schema {
+	name := ct.Name()
+	cols := ct.sch.GetAllCols()
+	sqlSch := make(sql.Schema, 0, 3*cols.Size())
+
+	for _, prefix := range []string{conflictBasePrefix, conflictOurPrefix, conflictTheirPrefix} {
+		_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			sqlCol := doltColToSqlCol(name, col)
+			sqlCol.Name = prefix + col.Name
+			sqlCol.Nullable = true
+			sqlSch = append(sqlSch, sqlCol)
+			return false, nil
+		})
+	}
+
+	return sqlSch
+}
+
+// Partitions returns the single partition of the table.
+func (ct *ConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows returns a row for every conflict of the table.
+func (ct *ConflictsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	tbl, err := ct.table(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	baseSch, _, theirSch, err := tbl.GetConflictSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, conflicts, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	err = conflicts.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		cnf, err := doltdb.ConflictFromTuple(value.(types.Tuple))
+
+		if err != nil {
+			return true, err
+		}
+
+		ours, _, err := tbl.GetRow(ctx, key.(types.Tuple), ct.sch)
+
+		if err != nil {
+			return true, err
+		}
+
+		base, err := doltdb.ConflictRow(key, cnf.Base, baseSch)
+
+		if err != nil {
+			return true, err
+		}
+
+		theirs, err := doltdb.ConflictRow(key, cnf.MergeValue, theirSch)
+
+		if err != nil {
+			return true, err
+		}
+
+		r := make(sql.Row, 0, 3*ct.sch.GetAllCols().Size())
+		for _, sideRow := range []row.Row{base, ours, theirs} {
+			if r, err = ct.appendColVals(r, sideRow); err != nil {
+				return true, err
+			}
+		}
+
+		rows = append(rows, r)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Update writes the values of the our_ columns of the new row given to the working set. Setting them all to NULL
+// removes the row from the working set. The conflict isn't resolved until it's deleted.
+func (ct *ConflictsTable) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error {
+	numCols := ct.sch.GetAllCols().Size()
+	for i := range oldRow {
+		if (i < numCols || i >= 2*numCols) && !reflect.DeepEqual(oldRow[i], newRow[i]) {
+			return ErrConflictNotUpdatable
+		}
+	}
+
+	oldKey, err := ct.conflictKey(ctx, oldRow)
+
+	if err != nil {
+		return err
+	}
+
+	userTable, err := ct.userTable(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	newOurs := newRow[numCols : 2*numCols]
+	if !isNullRow(newOurs) {
+		newKey, _, err := userTable.nomsKeyAndValue(ctx, newOurs, false)
+
+		if err != nil {
+			return err
+		}
+
+		if !oldKey.Equals(newKey) {
+			return fmt.Errorf("the primary key of a conflict in table '%v' can't be changed", ct.tableName)
+		}
+
+		return userTable.Replace(ctx, newOurs)
+	}
+
+	if oldOurs := oldRow[numCols : 2*numCols]; !isNullRow(oldOurs) {
+		return userTable.Delete(ctx, oldOurs)
+	}
+
+	return nil
+}
+
+// Delete marks the conflict given resolved, leaving the working set as it is.
+func (ct *ConflictsTable) Delete(ctx *sql.Context, r sql.Row) error {
+	if ct.db.readOnly {
+		return ErrReadOnlyDatabase
+	}
+
+	key, err := ct.conflictKey(ctx, r)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, err := ct.table(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	schemas, conflicts, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	conflicts, err = conflicts.Edit().Remove(key).Map(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, err = tbl.SetConflicts(ctx, schemas, conflicts)
+
+	if err != nil {
+		return err
+	}
+
+	newRoot, err := ct.db.root.PutTable(ctx, ct.db.ddb, ct.tableName, tbl)
+
+	if err != nil {
+		return err
+	}
+
+	ct.db.root = newRoot
+
+	return nil
+}
+
+// Replace returns an error, as conflicts can only be created by merges.
+func (ct *ConflictsTable) Replace(*sql.Context, sql.Row) error {
+	return fmt.Errorf("rows can't be added to table '%v'", ct.Name())
+}
+
+// table returns the user table as it is in the database's current root.
+func (ct *ConflictsTable) table(ctx context.Context) (*doltdb.Table, error) {
+	tbl, ok, err := ct.db.root.GetTable(ctx, ct.tableName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(ct.tableName)
+	}
+
+	return tbl, nil
+}
+
+// userTable returns a DoltTable for writing the user table. Every row written by a statement goes through the same
+// DoltTable, so the table already edited by the statement is returned if there is one, and otherwise a new one for the
+// table as it is in the database's current root.
+func (ct *ConflictsTable) userTable(ctx context.Context) (*DoltTable, error) {
+	for _, t := range ct.db.edited {
+		if t.name == ct.tableName {
+			return t, nil
+		}
+	}
+
+	tbl, err := ct.table(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoltTable{name: ct.tableName, table: tbl, sch: ct.sch, db: ct.db}, nil
+}
+
+// conflictKey returns the key of the conflict given, taking the values of the primary key columns from whichever side
+// of the conflict has the row.
+func (ct *ConflictsTable) conflictKey(ctx context.Context, r sql.Row) (types.Value, error) {
+	numCols := ct.sch.GetAllCols().Size()
+	pkVals := make(sql.Row, numCols)
+
+	for i := 0; i < numCols; i++ {
+		if !ct.sch.GetAllCols().GetByIndex(i).IsPartOfPK {
+			continue
+		}
+
+		for _, offset := range []int{numCols, 2 * numCols, 0} {
+			if r[offset+i] != nil {
+				pkVals[i] = r[offset+i]
+				break
+			}
+		}
+	}
+
+	pkRow, err := SqlRowToDoltRow(ct.db.root.VRW().Format(), pkVals, ct.sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pkRow.NomsMapKey(ct.sch).Value(ctx)
+}
+
+// appendColVals appends the values of the row given for each column of the table's schema, or NULLs if the row is nil.
+func (ct *ConflictsTable) appendColVals(vals sql.Row, r row.Row) (sql.Row, error) {
+	if r == nil {
+		return append(vals, make(sql.Row, ct.sch.GetAllCols().Size())...), nil
+	}
+
+	sqlRow, err := doltRowToSqlRow(r, ct.sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(vals, sqlRow...), nil
+}
+
+// isNullRow returns whether all the values of the row given are NULL.
+func isNullRow(r sql.Row) bool {
+	for _, val := range r {
+		if val != nil {
+			return false
+		}
+	}
+
+	return true
+}
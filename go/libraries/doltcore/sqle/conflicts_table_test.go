@@ -0,0 +1,160 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestConflictsTable(t *testing.T) {
+	tests := []struct {
+		name              string
+		queries           []string
+		expectedErr       error
+		expectedConflicts []sql.Row
+		expectedAges      []sql.Row
+	}{
+		{
+			name:              "no writes",
+			expectedConflicts: []sql.Row{{int64(0), int64(40), int64(41), int64(42)}, {int64(1), int64(38), int64(39), nil}},
+			expectedAges:      []sql.Row{{int64(0), int64(41)}, {int64(1), int64(39)}, {int64(2), int64(10)}},
+		},
+		{
+			name:              "update ours",
+			queries:           []string{"update dolt_conflicts_people set our_age = their_age where base_id = 0"},
+			expectedConflicts: []sql.Row{{int64(0), int64(40), int64(42), int64(42)}, {int64(1), int64(38), int64(39), nil}},
+			expectedAges:      []sql.Row{{int64(0), int64(42)}, {int64(1), int64(39)}, {int64(2), int64(10)}},
+		},
+		{
+			name: "remove ours",
+			queries: []string{`update dolt_conflicts_people set our_id = null, our_first = null, our_last = null,
+				our_is_married = null, our_age = null, our_rating = null, our_uuid = null, our_num_episodes = null
+				where their_id is null`},
+			expectedConflicts: []sql.Row{{int64(0), int64(40), int64(41), int64(42)}, {int64(1), int64(38), nil, nil}},
+			expectedAges:      []sql.Row{{int64(0), int64(41)}, {int64(2), int64(10)}},
+		},
+		{
+			name:              "resolve",
+			queries:           []string{"update dolt_conflicts_people set our_age = 43 where base_id = 0", "delete from dolt_conflicts_people where base_id = 0"},
+			expectedConflicts: []sql.Row{{int64(1), int64(38), int64(39), nil}},
+			expectedAges:      []sql.Row{{int64(0), int64(43)}, {int64(1), int64(39)}, {int64(2), int64(10)}},
+		},
+		{
+			name:              "update all ours",
+			queries:           []string{"update dolt_conflicts_people set our_age = 99"},
+			expectedConflicts: []sql.Row{{int64(0), int64(40), int64(99), int64(42)}, {int64(1), int64(38), int64(99), nil}},
+			expectedAges:      []sql.Row{{int64(0), int64(99)}, {int64(1), int64(99)}, {int64(2), int64(10)}},
+		},
+		{
+			name:         "update and resolve all",
+			queries:      []string{"update dolt_conflicts_people set our_age = 43", "delete from dolt_conflicts_people"},
+			expectedAges: []sql.Row{{int64(0), int64(43)}, {int64(1), int64(43)}, {int64(2), int64(10)}},
+		},
+		{
+			name:         "resolve all",
+			queries:      []string{"delete from dolt_conflicts_people"},
+			expectedAges: []sql.Row{{int64(0), int64(41)}, {int64(1), int64(39)}, {int64(2), int64(10)}},
+		},
+		{
+			name:        "update theirs",
+			queries:     []string{"update dolt_conflicts_people set their_age = 43"},
+			expectedErr: ErrConflictNotUpdatable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := createConflicts(t)
+			engine, db := newTestEngine(t, dEnv)
+			sqlCtx := sql.NewContext(context.Background())
+
+			var err error
+			for _, query := range tt.queries {
+				if _, err = executeWrite(sqlCtx, engine, db, query); err != nil {
+					break
+				}
+			}
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, dEnv.UpdateWorkingRoot(context.Background(), db.Root()))
+
+			conflicts := querySystemTables(t, dEnv, "select base_id, base_age, our_age, their_age from dolt_conflicts_people")
+			assert.ElementsMatch(t, tt.expectedConflicts, conflicts)
+
+			ages := querySystemTables(t, dEnv, "select id, age from people where id < 3")
+			assert.ElementsMatch(t, tt.expectedAges, ages)
+		})
+	}
+}
+
+func TestConflictsTableReplace(t *testing.T) {
+	dEnv := createConflicts(t)
+	engine, db := newTestEngine(t, dEnv)
+
+	_, err := executeWrite(sql.NewContext(context.Background()), engine, db, "replace into dolt_conflicts_people (base_id) values (5)")
+	assert.Error(t, err)
+}
+
+// createConflicts creates the test database and merges two branches into it which both change Homer's age. One of
+// them changes Marge's age, and the other removes her.
+func createConflicts(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	CreateTestDatabase(dEnv, t)
+	commitWrites(t, dEnv, "create tables")
+	require.NoError(t, actions.CreateBranch(ctx, dEnv, "other", "master", false))
+
+	executeWrites(t, dEnv, "update people set age = 41 where id = 0", "update people set age = 39 where id = 1")
+	commitWrites(t, dEnv, "update people")
+
+	require.NoError(t, actions.CheckoutBranch(ctx, dEnv, "other"))
+	executeWrites(t, dEnv, "update people set age = 42 where id = 0", "delete from people where id = 1")
+	commitWrites(t, dEnv, "update people on other")
+	require.NoError(t, actions.CheckoutBranch(ctx, dEnv, "master"))
+
+	head, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+	require.NoError(t, err)
+	cs, err := doltdb.NewCommitSpec("other", "master")
+	require.NoError(t, err)
+	other, err := dEnv.DoltDB.Resolve(ctx, cs)
+	require.NoError(t, err)
+
+	root, _, err := actions.MergeCommits(ctx, dEnv.DoltDB, head, other, nil)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+
+	return dEnv
+}
+
+func commitWrites(t *testing.T, dEnv *env.DoltEnv, msg string) {
+	require.NoError(t, actions.StageAllTables(context.Background(), dEnv, false))
+	require.NoError(t, actions.CommitStaged(context.Background(), dEnv, msg, false))
+}
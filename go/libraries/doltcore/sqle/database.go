@@ -16,10 +16,13 @@ package sqle
 
 import (
 	"context"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/src-d/go-mysql-server/sql"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 )
 
 // Database implements sql.Database for a dolt DB.
@@ -27,13 +30,24 @@ type Database struct {
 	sql.Database
 	name string
 	root *doltdb.RootValue
+	ddb  *doltdb.DoltDB
+	rsr  env.RepoStateReader
+
+	// readOnly is true for revision databases, whose tables can't be written
+	readOnly bool
+
+	// edited holds the tables written to by the current statement, whose edits are written to the root by Flush
+	edited []*DoltTable
 }
 
-// NewDatabase returns a new dolt databae to use in queries.
-func NewDatabase(name string, root *doltdb.RootValue) *Database {
+// NewDatabase returns a new dolt databae to use in queries. The repo state given is used to find the current branch,
+// whose history is exposed by the system tables.
+func NewDatabase(name string, root *doltdb.RootValue, ddb *doltdb.DoltDB, rsr env.RepoStateReader) *Database {
 	return &Database{
 		name: name,
 		root: root,
+		ddb:  ddb,
+		rsr:  rsr,
 	}
 }
 
@@ -42,7 +56,9 @@ func (db *Database) Name() string {
 	return db.name
 }
 
-// Tables returns the tables in this database, currently exactly the same tables as in the current working root.
+// Tables returns the tables in this database: the tables in the current working root, the read-only system tables
+// which expose the history of the current branch, and the conflicts table of each table with merge conflicts. A user
+// table with the same name as a system table hides it.
 func (db *Database) Tables() map[string]sql.Table {
 	ctx := context.Background()
 
@@ -54,6 +70,7 @@ func (db *Database) Tables() map[string]sql.Table {
 		panic(err)
 	}
 
+	systemTables := []sql.Table{NewLogTable(db), NewBranchesTable(db)}
 	for _, name := range tableNames {
 		table, ok, err := db.root.GetTable(ctx, name)
 
@@ -71,8 +88,69 @@ func (db *Database) Tables() map[string]sql.Table {
 		if err != nil {
 			panic(err)
 		}
-		tables[name] = &DoltTable{name: name, table: table, sch: sch}
+		tables[name] = &DoltTable{name: name, table: table, sch: sch, db: db}
+		systemTables = append(systemTables, NewDiffTable(name, sch, db))
+
+		// A table whose conflicts can't be read is still listed, without a conflicts table
+		if has, err := table.HasConflicts(); err != nil {
+			logrus.Warnf("failed to read the conflicts of table '%s': %v", name, err)
+		} else if has {
+			systemTables = append(systemTables, NewConflictsTable(name, sch, db))
+		}
+	}
+
+	for _, table := range systemTables {
+		if _, ok := tables[table.Name()]; !ok {
+			tables[table.Name()] = table
+		}
 	}
 
 	return tables
 }
+
+// Root returns the root value for this database. Writes to the database's tables are reflected in the root returned
+// once the database is flushed.
+func (db *Database) Root() *doltdb.RootValue {
+	return db.root
+}
+
+// SetRoot sets the root value for this database, discarding any writes which haven't been flushed. Callers use this to
+// roll back writes that failed part way through.
+func (db *Database) SetRoot(newRoot *doltdb.RootValue) {
+	for _, t := range db.edited {
+		t.ed = nil
+	}
+
+	db.edited = nil
+	db.root = newRoot
+}
+
+// Flush writes the rows written to the database's tables by the current statement to the database's root value. Writes
+// are collected until the statement is done so that each table's row map is rebuilt once rather than once per row.
+func (db *Database) Flush(ctx context.Context) error {
+	edited := db.edited
+	db.edited = nil
+
+	for i, t := range edited {
+		if err := t.flush(ctx); err != nil {
+			for _, unflushed := range edited[i+1:] {
+				unflushed.ed = nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// headCommit returns the commit at the head of the current branch.
+func (db *Database) headCommit(ctx context.Context) (*doltdb.Commit, error) {
+	return db.ddb.Resolve(ctx, db.rsr.CWBHeadSpec())
+}
+
+// isSystemTable returns whether the name given is the name of a system table.
+func isSystemTable(name string) bool {
+	return name == LogTableName || name == BranchesTableName || strings.HasPrefix(name, DiffTablePrefix) ||
+		strings.HasPrefix(name, ConflictsTablePrefix)
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	dsql "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+)
+
+// ExecuteIndexDDL executes the given statement that creates or drops a secondary index, then updates the engine's
+// catalog so that later queries use the new set of indexes. The engine's own CREATE INDEX statement isn't used,
+// because it requires a driver name and builds indexes asynchronously. On error, the database's root is left
+// unchanged.
+func ExecuteIndexDDL(ctx *sql.Context, engine *sqle.Engine, db *Database, stmt *dsql.IndexDDL) error {
+	root := db.Root()
+	newRoot, err := dsql.ExecuteIndexDDL(ctx.Context, db.ddb, root, stmt)
+	if err != nil {
+		return err
+	}
+
+	db.SetRoot(newRoot)
+
+	driver, ok := engine.Catalog.IndexDriver(doltIndexDriverID).(*DoltIndexDriver)
+	if !ok || driver.db != db {
+		return nil
+	}
+
+	switch stmt.Action {
+	case sqlparser.CreateStr:
+		idx, err := driver.LoadIndex(stmt.TableName, stmt.IndexName)
+		if err != nil {
+			db.SetRoot(root)
+			return err
+		}
+
+		created, ready, err := engine.Catalog.AddIndex(idx)
+		if err != nil {
+			db.SetRoot(root)
+			return err
+		}
+
+		// The index was built when it was written to the table, so it's ready for use right away
+		close(created)
+		<-ready
+	case sqlparser.DropStr:
+		done, err := engine.Catalog.DeleteIndex(db.name, indexID(stmt.TableName, stmt.IndexName), true)
+		if sql.ErrIndexNotFound.Is(err) {
+			return nil
+		} else if err != nil {
+			db.SetRoot(root)
+			return err
+		}
+
+		<-done
+	}
+
+	return nil
+}
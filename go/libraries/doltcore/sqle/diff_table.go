@@ -0,0 +1,388 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// DiffTablePrefix is the prefix of the names of the system tables which show how a table changed over the history of
+// the current branch. The changes to the table people are in dolt_diff_people.
+const DiffTablePrefix = "dolt_diff_"
+
+const (
+	diffToPrefix   = "to_"
+	diffFromPrefix = "from_"
+
+	// WorkingCommitName is used in place of a commit hash for changes in the working set, which aren't committed.
+	WorkingCommitName = "WORKING"
+
+	diffTypeAdded    = "added"
+	diffTypeModified = "modified"
+	diffTypeRemoved  = "removed"
+)
+
+// DiffTable is a read-only sql.Table which has a row for every row of a user table that changed in each commit in the
+// history of the current branch, and in the working set. Each row has the values of the changed row's columns after
+// the change (to_<column>) and before it (from_<column>), along with the commits on either side of the change. Only
+// columns in the table's current schema are included.
+type DiffTable struct {
+	tableName string
+	sch       schema.Schema
+	db        *Database
+}
+
+// NewDiffTable returns the diff table for the table with the name and schema given.
+func NewDiffTable(tableName string, sch schema.Schema, db *Database) *DiffTable {
+	return &DiffTable{tableName: tableName, sch: sch, db: db}
+}
+
+// Name returns the name of the table.
+func (dt *DiffTable) Name() string {
+	return DiffTablePrefix + dt.tableName
+}
+
+// String returns the name of the table.
+func (dt *DiffTable) String() string {
+	return dt.Name()
+}
+
+// Schema returns the schema of the table.
+func (dt *DiffTable) Schema() sql.Schema {
+	name := dt.Name()
+	cols := dt.sch.GetAllCols()
+	sqlSch := make(sql.Schema, 0, 2*cols.Size()+5)
+
+	for _, prefix := range []string{diffToPrefix, diffFromPrefix} {
+		_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			sqlCol := doltColToSqlCol(name, col)
+			sqlCol.Name = prefix + col.Name
+			sqlCol.Nullable = true
+			sqlSch = append(sqlSch, sqlCol)
+			return false, nil
+		})
+	}
+
+	return append(sqlSch,
+		&sql.Column{Name: "to_commit", Type: sql.Text, Source: name},
+		&sql.Column{Name: "to_commit_date", Type: sql.Timestamp, Nullable: true, Source: name},
+		&sql.Column{Name: "from_commit", Type: sql.Text, Nullable: true, Source: name},
+		&sql.Column{Name: "from_commit_date", Type: sql.Timestamp, Nullable: true, Source: name},
+		&sql.Column{Name: "diff_type", Type: sql.Text, Source: name},
+	)
+}
+
+// Partitions returns the single partition of the table.
+func (dt *DiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows returns a row for every change to the table, starting with the changes in the working set and then
+// going back through the history of the current branch. Merge commits are diffed against their first parent. The
+// commits are walked, and the rows of each diff are read, as the rows are returned.
+func (dt *DiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	head, err := dt.db.headCommit(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	headState, err := newDiffCommitState(head)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := commitwalk.GetTopologicalOrderIterator(ctx, dt.db.ddb, headHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &diffRowIter{
+		dt:      dt,
+		ctx:     ctx,
+		working: diffCommitState{root: dt.db.root, name: WorkingCommitName},
+		head:    headState,
+		commits: commits,
+	}, nil
+}
+
+// diffRowIter is the iterator over the rows of a DiffTable. It diffs the working set against the head commit, and then
+// each commit against its first parent, reading the rows of one diff at a time.
+type diffRowIter struct {
+	dt  *DiffTable
+	ctx *sql.Context
+
+	working       diffCommitState
+	head          diffCommitState
+	diffedWorking bool
+	commits       commitwalk.CommitItr
+
+	to, from diffCommitState
+	ad       *diff.AsyncDiffer
+	rows     []sql.Row
+}
+
+// Next returns the next row of the table, or an io.EOF error if there aren't any more.
+func (itr *diffRowIter) Next() (sql.Row, error) {
+	for {
+		if len(itr.rows) > 0 {
+			r := itr.rows[0]
+			itr.rows = itr.rows[1:]
+			return r, nil
+		}
+
+		if itr.ad != nil && !itr.ad.IsDone() {
+			diffs, err := itr.ad.GetDiffs(1024, time.Second)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range diffs {
+				r, err := itr.dt.diffRow(d.KeyValue, d.NewValue, d.OldValue, itr.to, itr.from)
+
+				if err != nil {
+					return nil, err
+				}
+
+				itr.rows = append(itr.rows, r)
+			}
+
+			continue
+		}
+
+		if ok, err := itr.nextDiff(); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, io.EOF
+		}
+	}
+}
+
+// nextDiff starts diffing the next pair of states which differ in the table, and returns false if there are none.
+func (itr *diffRowIter) nextDiff() (bool, error) {
+	itr.closeDiff()
+
+	for {
+		var to, from diffCommitState
+		if !itr.diffedWorking {
+			to, from = itr.working, itr.head
+			itr.diffedWorking = true
+		} else {
+			cm, ok, err := itr.commits(itr.ctx)
+
+			if err != nil || !ok {
+				return false, err
+			}
+
+			to, from, err = itr.dt.commitDiffStates(itr.ctx, cm)
+
+			if err != nil {
+				return false, err
+			}
+		}
+
+		toData, err := itr.dt.rowData(itr.ctx, to.root)
+
+		if err != nil {
+			return false, err
+		}
+
+		fromData, err := itr.dt.rowData(itr.ctx, from.root)
+
+		if err != nil {
+			return false, err
+		}
+
+		if toData.Equals(fromData) {
+			continue
+		}
+
+		itr.to, itr.from = to, from
+		itr.ad = diff.NewAsyncDiffer(1024)
+		itr.ad.Start(itr.ctx, toData, fromData)
+		return true, nil
+	}
+}
+
+func (itr *diffRowIter) closeDiff() {
+	if itr.ad != nil {
+		itr.ad.Close()
+		itr.ad = nil
+	}
+}
+
+// Close stops the diff being read.
+func (itr *diffRowIter) Close() error {
+	itr.closeDiff()
+	return nil
+}
+
+// commitDiffStates returns the states of the repository after the commit given, and before it in its first parent.
+func (dt *DiffTable) commitDiffStates(ctx context.Context, cm *doltdb.Commit) (to, from diffCommitState, err error) {
+	to, err = newDiffCommitState(cm)
+
+	if err != nil {
+		return diffCommitState{}, diffCommitState{}, err
+	}
+
+	if numParents, err := cm.NumParents(); err != nil {
+		return diffCommitState{}, diffCommitState{}, err
+	} else if numParents > 0 {
+		parent, err := dt.db.ddb.ResolveParent(ctx, cm, 0)
+
+		if err != nil {
+			return diffCommitState{}, diffCommitState{}, err
+		}
+
+		from, err = newDiffCommitState(parent)
+
+		if err != nil {
+			return diffCommitState{}, diffCommitState{}, err
+		}
+	}
+
+	return to, from, nil
+}
+
+// diffCommitState is the state of the repository on one side of a diff. A nil root is an empty repository, and nil
+// names and dates are NULL.
+type diffCommitState struct {
+	root *doltdb.RootValue
+	name interface{}
+	date interface{}
+}
+
+func newDiffCommitState(cm *doltdb.Commit) (diffCommitState, error) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return diffCommitState{}, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return diffCommitState{}, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return diffCommitState{}, err
+	}
+
+	return diffCommitState{root: root, name: h.String(), date: meta.Time()}, nil
+}
+
+// rowData returns the rows of the table in the root given, or an empty map if the root is nil or doesn't have the
+// table.
+func (dt *DiffTable) rowData(ctx context.Context, root *doltdb.RootValue) (types.Map, error) {
+	if root != nil {
+		table, ok, err := root.GetTable(ctx, dt.tableName)
+
+		if err != nil {
+			return types.EmptyMap, err
+		}
+
+		if ok {
+			return table.GetRowData(ctx)
+		}
+	}
+
+	return types.NewMap(ctx, dt.db.ddb.ValueReadWriter())
+}
+
+// taggedValsForDiff returns the values of the columns of a row in a diff, by tag. The values are read without a schema,
+// since the schema of the table may have changed since the row was written.
+func taggedValsForDiff(key, val types.Value) (row.TaggedValues, error) {
+	keyVals, err := row.ParseTaggedValues(key.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonKeyVals, err := row.ParseTaggedValues(val.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for tag, v := range nonKeyVals {
+		keyVals[tag] = v
+	}
+
+	return keyVals, nil
+}
+
+// diffRow returns the SQL row for a change to the row of the table with the key given. A nil |newVal| or |oldVal| means
+// the row doesn't exist on that side of the change.
+func (dt *DiffTable) diffRow(key, newVal, oldVal types.Value, to, from diffCommitState) (sql.Row, error) {
+	var toVals, fromVals row.TaggedValues
+	var err error
+	diffType := diffTypeModified
+
+	if newVal != nil {
+		toVals, err = taggedValsForDiff(key, newVal)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		diffType = diffTypeRemoved
+	}
+
+	if oldVal != nil {
+		fromVals, err = taggedValsForDiff(key, oldVal)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		diffType = diffTypeAdded
+	}
+
+	cols := dt.sch.GetAllCols()
+	sqlRow := make(sql.Row, 0, 2*cols.Size()+5)
+
+	for _, vals := range []row.TaggedValues{toVals, fromVals} {
+		_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			val, _ := vals.Get(tag)
+			sqlRow = append(sqlRow, doltColValToSqlColVal(val))
+			return false, nil
+		})
+	}
+
+	return append(sqlRow, to.name, to.date, from.name, from.date, diffType), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestDiffTable(t *testing.T) {
+	dEnv := createHistory(t)
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedRows []sql.Row
+	}{
+		{
+			name:         "working set",
+			query:        "select to_id, to_first, from_id, from_commit_date is null, diff_type from dolt_diff_people where to_commit = 'WORKING'",
+			expectedRows: []sql.Row{{int64(10), "Moe", nil, false, "added"}},
+		},
+		{
+			name:  "modified and removed",
+			query: "select to_id, to_age, from_id, from_age, diff_type from dolt_diff_people where diff_type <> 'added'",
+			expectedRows: []sql.Row{
+				{int64(0), int64(41), int64(0), int64(40), "modified"},
+				{nil, nil, int64(1), int64(38), "removed"},
+			},
+		},
+		{
+			name:         "all changes",
+			query:        "select count(*) from dolt_diff_people",
+			expectedRows: []sql.Row{{int64(len(AllPeopleRows) + 3)}},
+		},
+		{
+			name:         "first change",
+			query:        "select to_id, to_commit from dolt_diff_people limit 1",
+			expectedRows: []sql.Row{{int64(10), WorkingCommitName}},
+		},
+		{
+			name:         "table created",
+			query:        "select count(*) from dolt_diff_episodes where diff_type = 'added' and from_commit is not null",
+			expectedRows: []sql.Row{{int64(len(AllEpsRows))}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := querySystemTables(t, dEnv, tt.query)
+			assert.ElementsMatch(t, tt.expectedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// The SQL engine can parse and execute INSERT statements on its own, but not UPDATE, DELETE or REPLACE. The functions
+// in this file implement those statements by rewriting them as SELECT statements that the engine evaluates, then
+// applying the resulting rows to the target table.
+
+// ExecuteUpdate executes the update statement given against the database given, using the engine given to evaluate
+// the statement's expressions. Returns the number of rows updated. On error, the database's root is left unchanged.
+func ExecuteUpdate(ctx *sql.Context, engine *sqle.Engine, db *Database, s *sqlparser.Update) (int, error) {
+	table, err := getWritableTable(db, s.TableExprs)
+	if err != nil {
+		return 0, err
+	}
+
+	numCols := len(table.Schema())
+	selectExprs := sqlparser.SelectExprs{&sqlparser.StarExpr{}}
+	colIndexes := make([]int, len(s.Exprs))
+	for i, updateExpr := range s.Exprs {
+		colIndexes[i] = table.Schema().IndexOf(updateExpr.Name.Name.String(), table.Name())
+		if colIndexes[i] < 0 {
+			return 0, fmt.Errorf("unknown column '%v' in table '%v'", updateExpr.Name.Name.String(), table.Name())
+		}
+
+		selectExprs = append(selectExprs, &sqlparser.AliasedExpr{Expr: updateExpr.Expr})
+	}
+
+	rows, err := selectRows(ctx, engine, &sqlparser.Select{
+		SelectExprs: selectExprs,
+		From:        s.TableExprs,
+		Where:       s.Where,
+		OrderBy:     s.OrderBy,
+		Limit:       s.Limit,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return applyRows(ctx, db, rows, func(r sql.Row) error {
+		oldRow := r[:numCols]
+		newRow := oldRow.Copy()
+		for i, idx := range colIndexes {
+			newRow[idx] = r[numCols+i]
+		}
+
+		return table.Update(ctx, oldRow, newRow)
+	})
+}
+
+// ExecuteDelete executes the delete statement given against the database given, using the engine given to evaluate
+// the statement's where clause. Returns the number of rows deleted. On error, the database's root is left unchanged.
+func ExecuteDelete(ctx *sql.Context, engine *sqle.Engine, db *Database, s *sqlparser.Delete) (int, error) {
+	if len(s.Targets) > 0 {
+		return 0, errors.New("multiple-table delete statements are not supported")
+	}
+
+	table, err := getWritableTable(db, s.TableExprs)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := selectRows(ctx, engine, &sqlparser.Select{
+		SelectExprs: sqlparser.SelectExprs{&sqlparser.StarExpr{}},
+		From:        s.TableExprs,
+		Where:       s.Where,
+		OrderBy:     s.OrderBy,
+		Limit:       s.Limit,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return applyRows(ctx, db, rows, func(r sql.Row) error {
+		return table.Delete(ctx, r)
+	})
+}
+
+// ExecuteReplace executes the given insert statement with the REPLACE action against the database given. Rows with the
+// same primary key as a row being inserted are overwritten. Returns the number of rows written. On error, the
+// database's root is left unchanged.
+func ExecuteReplace(ctx *sql.Context, engine *sqle.Engine, db *Database, s *sqlparser.Insert) (int, error) {
+	if s.Action != sqlparser.ReplaceStr {
+		return 0, fmt.Errorf("expected a replace statement, got %v", s.Action)
+	}
+
+	table, err := getWritableTable(db, sqlparser.TableExprs{&sqlparser.AliasedTableExpr{Expr: s.Table}})
+	if err != nil {
+		return 0, err
+	}
+
+	tableSch := table.Schema()
+	colIndexes := make([]int, len(tableSch))
+	if len(s.Columns) == 0 {
+		for i := range tableSch {
+			colIndexes[i] = i
+		}
+	} else {
+		colIndexes = colIndexes[:len(s.Columns)]
+		for i, colName := range s.Columns {
+			colIndexes[i] = tableSch.IndexOf(colName.String(), table.Name())
+			if colIndexes[i] < 0 {
+				return 0, fmt.Errorf("unknown column '%v' in table '%v'", colName.String(), table.Name())
+			}
+		}
+	}
+
+	var selects []*sqlparser.Select
+	switch insertRows := s.Rows.(type) {
+	case sqlparser.Values:
+		for _, tuple := range insertRows {
+			selectExprs := make(sqlparser.SelectExprs, len(tuple))
+			for i, expr := range tuple {
+				selectExprs[i] = &sqlparser.AliasedExpr{Expr: expr}
+			}
+
+			selects = append(selects, &sqlparser.Select{SelectExprs: selectExprs, From: dualTableExprs})
+		}
+	case *sqlparser.Select:
+		selects = append(selects, insertRows)
+	default:
+		return 0, fmt.Errorf("unsupported source of rows for replace: %v", sqlparser.String(s.Rows))
+	}
+
+	var rows []sql.Row
+	for _, sel := range selects {
+		selectedRows, err := selectRows(ctx, engine, sel)
+		if err != nil {
+			return 0, err
+		}
+
+		rows = append(rows, selectedRows...)
+	}
+
+	return applyRows(ctx, db, rows, func(r sql.Row) error {
+		if len(r) != len(colIndexes) {
+			return fmt.Errorf("expected %d values in replace but got %d", len(colIndexes), len(r))
+		}
+
+		newRow := make(sql.Row, len(tableSch))
+		for i, idx := range colIndexes {
+			newRow[idx] = r[i]
+		}
+
+		return table.Replace(ctx, newRow)
+	})
+}
+
+// dualTableExprs is the FROM clause for a select of literal values.
+var dualTableExprs = sqlparser.TableExprs{&sqlparser.AliasedTableExpr{Expr: sqlparser.TableName{Name: sqlparser.NewTableIdent("dual")}}}
+
+// writableTable is a table that UPDATE, DELETE and REPLACE statements can be applied to.
+type writableTable interface {
+	sql.Table
+	Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error
+	Delete(ctx *sql.Context, r sql.Row) error
+	Replace(ctx *sql.Context, r sql.Row) error
+}
+
+var _ writableTable = (*DoltTable)(nil)
+var _ writableTable = (*ConflictsTable)(nil)
+
+// getWritableTable returns the writable table named by the table expressions given, which must name exactly one table.
+func getWritableTable(db *Database, tableExprs sqlparser.TableExprs) (writableTable, error) {
+	if len(tableExprs) != 1 {
+		return nil, errors.New("writing to multiple tables in a single statement is not supported")
+	}
+
+	aliasedTableExpr, ok := tableExprs[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported table expression: %v", sqlparser.String(tableExprs[0]))
+	}
+
+	tableName, ok := aliasedTableExpr.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, fmt.Errorf("unsupported table expression: %v", sqlparser.String(aliasedTableExpr))
+	}
+
+	if !tableName.Qualifier.IsEmpty() && !strings.EqualFold(tableName.Qualifier.String(), db.Name()) {
+		return nil, fmt.Errorf("unknown database '%v'", tableName.Qualifier.String())
+	}
+
+	for name, table := range db.Tables() {
+		if strings.EqualFold(name, tableName.Name.String()) {
+			if wt, ok := table.(writableTable); ok {
+				return wt, nil
+			}
+
+			return nil, fmt.Errorf("table '%v' is read-only", name)
+		}
+	}
+
+	return nil, sql.ErrTableNotFound.New(tableName.Name.String())
+}
+
+// selectRows runs the select statement given on the engine and returns all the resulting rows.
+func selectRows(ctx *sql.Context, engine *sqle.Engine, sel *sqlparser.Select) ([]sql.Row, error) {
+	_, rowIter, err := engine.Query(ctx, sqlparser.String(sel))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for {
+		r, err := rowIter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			_ = rowIter.Close()
+			return nil, err
+		}
+
+		rows = append(rows, r)
+	}
+
+	return rows, rowIter.Close()
+}
+
+// applyRows calls the write function given for each row, then flushes the database, returning the number of rows
+// written. If any write fails, the database's root value is restored to its value before the first write.
+func applyRows(ctx context.Context, db *Database, rows []sql.Row, write func(sql.Row) error) (int, error) {
+	root := db.Root()
+	for i, r := range rows {
+		if err := write(r); err != nil {
+			db.SetRoot(root)
+			return i, err
+		}
+	}
+
+	if err := db.Flush(ctx); err != nil {
+		db.SetRoot(root)
+		return 0, err
+	}
+
+	return len(rows), nil
+}
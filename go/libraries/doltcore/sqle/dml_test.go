@@ -0,0 +1,239 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+type writeTest struct {
+	// The name of this test. Names should be unique and descriptive.
+	Name string
+	// The write query to run
+	Query string
+	// The number of rows the query is expected to write
+	ExpectedCount int
+	// The rows expected in the people table after the write
+	ExpectedRows []row.Row
+	// An expected error. If set, the root value must be unchanged by the query.
+	ExpectedErr bool
+}
+
+var writeTests = []writeTest{
+	{
+		Name:          "insert one row",
+		Query:         `insert into people (id, first, last, is_married, age, rating) values (7, "Maggie", "Simpson", false, 1, 5.5)`,
+		ExpectedCount: 1,
+		// The engine fills in unnamed columns with the zero value of their type
+		ExpectedRows: Rs(Homer, Marge, Bart, Lisa, Moe, Barney,
+			MutateRow(NewPeopleRow(7, "Maggie", "Simpson", false, 1, 5.5), NumEpisodesTag, uint64(0))),
+	},
+	{
+		Name:        "insert duplicate primary key",
+		Query:       `insert into people (id, first, last) values (0, "Homer", "Simpson")`,
+		ExpectedErr: true,
+	},
+	{
+		Name:        "insert duplicate primary keys in one statement",
+		Query:       `insert into people (id, first, last) values (7, "Maggie", "Simpson"), (7, "Maggie", "Simpson")`,
+		ExpectedErr: true,
+	},
+	{
+		Name:        "insert null into not null column",
+		Query:       `insert into people (id, first, last) values (7, null, "Simpson")`,
+		ExpectedErr: true,
+	},
+	{
+		Name:          "update one column",
+		Query:         `update people set first = "Homer J." where id = 0`,
+		ExpectedCount: 1,
+		ExpectedRows:  Rs(MutateRow(Homer, FirstTag, "Homer J."), Marge, Bart, Lisa, Moe, Barney),
+	},
+	{
+		Name:          "update with expression",
+		Query:         `update people set age = age + 1 where last = "Simpson"`,
+		ExpectedCount: 4,
+		ExpectedRows: Rs(
+			MutateRow(Homer, AgeTag, 41),
+			MutateRow(Marge, AgeTag, 39),
+			MutateRow(Bart, AgeTag, 11),
+			MutateRow(Lisa, AgeTag, 9),
+			Moe,
+			Barney,
+		),
+	},
+	{
+		Name:          "update primary key",
+		Query:         `update people set id = 10 where id = 0`,
+		ExpectedCount: 1,
+		ExpectedRows:  Rs(MutateRow(Homer, IdTag, 10), Marge, Bart, Lisa, Moe, Barney),
+	},
+	{
+		Name:        "update to duplicate primary key",
+		Query:       `update people set id = 1 where id = 0`,
+		ExpectedErr: true,
+	},
+	{
+		Name:          "update every primary key",
+		Query:         `update people set id = id + 10`,
+		ExpectedCount: 6,
+		ExpectedRows: Rs(
+			MutateRow(Homer, IdTag, 10),
+			MutateRow(Marge, IdTag, 11),
+			MutateRow(Bart, IdTag, 12),
+			MutateRow(Lisa, IdTag, 13),
+			MutateRow(Moe, IdTag, 14),
+			MutateRow(Barney, IdTag, 15),
+		),
+	},
+	{
+		Name:        "update unknown column",
+		Query:       `update people set not_a_column = 1`,
+		ExpectedErr: true,
+	},
+	{
+		Name:          "delete where",
+		Query:         `delete from people where age > 39`,
+		ExpectedCount: 3,
+		ExpectedRows:  Rs(Marge, Bart, Lisa),
+	},
+	{
+		Name:          "delete all",
+		Query:         `delete from people`,
+		ExpectedCount: 6,
+		ExpectedRows:  Rs(),
+	},
+	{
+		Name:          "replace existing and new rows",
+		Query:         `replace into people (id, first, last, age) values (0, "Homer", "Simpson", 41), (7, "Maggie", "Simpson", 1)`,
+		ExpectedCount: 2,
+		ExpectedRows: Rs(
+			MutateRow(Homer, AgeTag, 41, IsMarriedTag, nil, RatingTag, nil),
+			Marge, Bart, Lisa, Moe, Barney,
+			MutateRow(NewPeopleRow(7, "Maggie", "Simpson", false, 1, 0), IsMarriedTag, nil, RatingTag, nil),
+		),
+	},
+}
+
+func TestWrites(t *testing.T) {
+	for _, test := range writeTests {
+		t.Run(test.Name, func(t *testing.T) {
+			testWriteQuery(t, test)
+		})
+	}
+}
+
+func testWriteQuery(t *testing.T, test writeTest) {
+	dEnv := dtestutils.CreateTestEnv()
+	CreateTestDatabase(dEnv, t)
+
+	ctx := context.Background()
+	root, _ := dEnv.WorkingRoot(ctx)
+	db := NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+	engine := sqle.NewDefault()
+	engine.AddDatabase(db)
+	sqlCtx := sql.NewContext(ctx)
+
+	count, err := executeWrite(sqlCtx, engine, db, test.Query)
+	if test.ExpectedErr {
+		require.Error(t, err)
+		assert.Equal(t, root, db.Root())
+		return
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, test.ExpectedCount, count)
+
+	actualRows, err := GetAllRows(db.Root(), PeopleTableName)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, test.ExpectedRows, actualRows)
+}
+
+// executeWrite runs the write query given the same way the SQL server does, and returns the number of rows written.
+func executeWrite(ctx *sql.Context, engine *sqle.Engine, db *Database, query string) (int, error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return 0, err
+	}
+
+	switch s := stmt.(type) {
+	case *sqlparser.Update:
+		return ExecuteUpdate(ctx, engine, db, s)
+	case *sqlparser.Delete:
+		return ExecuteDelete(ctx, engine, db, s)
+	case *sqlparser.Insert:
+		if s.Action == sqlparser.ReplaceStr {
+			return ExecuteReplace(ctx, engine, db, s)
+		}
+	}
+
+	root := db.Root()
+	_, iter, err := engine.Query(ctx, query)
+	if err != nil {
+		db.SetRoot(root)
+		return 0, err
+	}
+
+	r, err := iter.Next()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := iter.Next(); err != io.EOF {
+		return 0, err
+	}
+
+	if err := db.Flush(ctx); err != nil {
+		db.SetRoot(root)
+		return 0, err
+	}
+
+	return int(r[0].(int64)), nil
+}
+
+func TestDecimalValues(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+
+	// this value can't be represented exactly by a float64
+	exact, err := types.ParseDecimal("12345678901234567.89")
+	require.NoError(t, err)
+
+	sch := dtestutils.CreateSchema(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("price", 1, types.DecimalKind, false),
+	)
+	dtestutils.CreateTestTable(t, dEnv, "prices", sch, dtestutils.NewRow(sch, types.Int(1), exact))
+
+	rows := querySystemTables(t, dEnv, "select price from prices")
+	assert.Equal(t, []sql.Row{{"12345678901234567.89"}}, rows)
+
+	executeWrites(t, dEnv, `update prices set price = "0.10000000000000000001" where id = 1`)
+	rows = querySystemTables(t, dEnv, "select price from prices")
+	assert.Equal(t, []sql.Row{{"0.10000000000000000001"}}, rows)
+}
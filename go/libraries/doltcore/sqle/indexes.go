@@ -17,45 +17,57 @@ package sqle
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
+	"strings"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/analyzer"
+	"github.com/src-d/go-mysql-server/sql/expression"
+	"github.com/src-d/go-mysql-server/sql/plan"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
-// IndexDriver implementation. Not ready for prime time.
+// The name of the index on a table's primary key, which every table has.
+const primaryKeyIndexName = "primaryKey"
 
+const doltIndexDriverID = "doltDbIndexDriver"
+
+// DoltIndexDriver is an IndexDriver that exposes the primary key and secondary indexes of dolt tables to the engine.
+// Indexes are created and dropped with dolt's own statements, so the engine's index creation functions aren't
+// supported.
 type DoltIndexDriver struct {
 	db *Database
 }
 
+// NewDoltIndexDriver returns a new index driver for the database given.
 func NewDoltIndexDriver(database *Database) *DoltIndexDriver {
 	return &DoltIndexDriver{database}
 }
 
 func (*DoltIndexDriver) ID() string {
-	return "doltDbIndexDriver"
+	return doltIndexDriverID
 }
 
 func (*DoltIndexDriver) Create(db, table, id string, expressions []sql.Expression, config map[string]string) (sql.Index, error) {
-	panic("creating indexes not supported")
+	return nil, errors.New("creating indexes with a driver is not supported, use CREATE INDEX name ON table (columns)")
 }
 
 func (i *DoltIndexDriver) Save(*sql.Context, sql.Index, sql.PartitionIndexKeyValueIter) error {
-	panic("saving indexes not supported")
+	return errors.New("saving indexes with a driver is not supported")
 }
 
 func (i *DoltIndexDriver) Delete(sql.Index, sql.PartitionIter) error {
-	panic("deleting indexes not supported")
+	return errors.New("deleting indexes with a driver is not supported, use DROP INDEX name ON table")
 }
 
+// LoadAll returns the primary key index and all secondary indexes of the table given.
 func (i *DoltIndexDriver) LoadAll(db, table string) ([]sql.Index, error) {
 	if db != i.db.name {
-		panic("Unexpected db: " + db)
+		return nil, nil
 	}
 
 	tbl, ok, err := i.db.root.GetTable(context.TODO(), table)
@@ -65,7 +77,12 @@ func (i *DoltIndexDriver) LoadAll(db, table string) ([]sql.Index, error) {
 	}
 
 	if !ok {
-		panic(fmt.Sprintf("No table found with name %s", table))
+		// System tables don't have indexes
+		if isSystemTable(table) {
+			return nil, nil
+		}
+
+		return nil, sql.ErrTableNotFound.New(table)
 	}
 
 	sch, err := tbl.GetSchema(context.TODO())
@@ -74,72 +91,218 @@ func (i *DoltIndexDriver) LoadAll(db, table string) ([]sql.Index, error) {
 		return nil, err
 	}
 
-	return []sql.Index{&doltIndex{sch, table, i.db, i}}, nil
+	indexes := []sql.Index{i.newIndex(table, sch, primaryKeyIndexName, sch.GetPKCols().Tags)}
+
+	secondaryIndexes, err := tbl.GetIndexes(context.TODO())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range secondaryIndexes {
+		indexes = append(indexes, i.newIndex(table, sch, idx.Name, idx.Tags))
+	}
+
+	return indexes, nil
 }
 
+// LoadIndex returns the secondary index with the name given on the table given.
+func (i *DoltIndexDriver) LoadIndex(table, name string) (sql.Index, error) {
+	tbl, ok, err := i.db.root.GetTable(context.TODO(), table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(table)
+	}
+
+	sch, err := tbl.GetSchema(context.TODO())
+
+	if err != nil {
+		return nil, err
+	}
+
+	idx, ok, err := tbl.GetIndex(context.TODO(), name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, sql.ErrIndexNotFound.New(name)
+	}
+
+	return i.newIndex(table, sch, idx.Name, idx.Tags), nil
+}
+
+func (i *DoltIndexDriver) newIndex(tableName string, sch schema.Schema, name string, tags []uint64) *doltIndex {
+	cols := make([]schema.Column, len(tags))
+	for j, tag := range tags {
+		cols[j], _ = sch.GetAllCols().GetByTag(tag)
+	}
+
+	return &doltIndex{
+		name:      name,
+		cols:      cols,
+		tableName: tableName,
+		db:        i.db,
+		driver:    i,
+	}
+}
+
+// doltIndex is either the primary key index of a table or one of its secondary indexes. Lookups on the index return
+// all rows whose indexed values fall within a range. Lookups on a subset of an index's columns are less precise than
+// lookups on all of them, but the engine always filters the rows returned by a lookup, so supersets are fine.
 type doltIndex struct {
-	sch       schema.Schema
+	name      string
+	cols      []schema.Column
 	tableName string
 	db        *Database
 	driver    *DoltIndexDriver
 }
 
+var _ sql.AscendIndex = (*doltIndex)(nil)
+var _ sql.DescendIndex = (*doltIndex)(nil)
+
+func (di *doltIndex) isPrimaryKey() bool {
+	return di.name == primaryKeyIndexName
+}
+
+// Get returns a lookup for the rows whose indexed values equal the key given.
 func (di *doltIndex) Get(key ...interface{}) (sql.IndexLookup, error) {
-	taggedVals, err := keyColsToTuple(di.sch, key)
-	if err != nil {
+	return di.newLookup(key, true, key, true)
+}
+
+// AscendGreaterOrEqual returns a lookup for the rows whose indexed values are greater than or equal to the keys given.
+func (di *doltIndex) AscendGreaterOrEqual(keys ...interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(keys, true, nil, false)
+}
+
+// AscendLessThan returns a lookup for the rows whose indexed values are less than the keys given.
+func (di *doltIndex) AscendLessThan(keys ...interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(nil, false, keys, false)
+}
+
+// AscendRange returns a lookup for the rows whose indexed values are greater than or equal to the first keys given
+// and less than the second.
+func (di *doltIndex) AscendRange(greaterOrEqual, lessThan []interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(greaterOrEqual, true, lessThan, false)
+}
+
+// DescendGreater returns a lookup for the rows whose indexed values are greater than the keys given.
+func (di *doltIndex) DescendGreater(keys ...interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(keys, false, nil, false)
+}
+
+// DescendLessOrEqual returns a lookup for the rows whose indexed values are less than or equal to the keys given.
+func (di *doltIndex) DescendLessOrEqual(keys ...interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(nil, false, keys, true)
+}
+
+// DescendRange returns a lookup for the rows whose indexed values are less than or equal to the first keys given and
+// greater than the second.
+func (di *doltIndex) DescendRange(lessOrEqual, greaterThan []interface{}) (sql.IndexLookup, error) {
+	return di.newLookup(greaterThan, false, lessOrEqual, true)
+}
+
+// newLookup returns a lookup with the bounds given. A nil bound is unbounded. If any of the keys can't be converted
+// exactly to the kind of its column, no lookup is returned, which tells the engine not to use the index.
+func (di *doltIndex) newLookup(lower []interface{}, lowerInclusive bool, upper []interface{}, upperInclusive bool) (sql.IndexLookup, error) {
+	lowerTuple, ok, err := di.keysToTuple(lower)
+	if err != nil || !ok {
 		return nil, err
 	}
 
-	return &doltIndexLookup{di, taggedVals}, nil
-}
+	upperTuple, ok, err := di.keysToTuple(upper)
+	if err != nil || !ok {
+		return nil, err
+	}
 
-func keyColsToTuple(sch schema.Schema, key []interface{}) (row.TaggedValues, error) {
-	if sch.GetPKCols().Size() != len(key) {
-		return nil, errors.New("key must specify all columns")
+	return &doltIndexLookup{
+		idx:            di,
+		lower:          lowerTuple,
+		lowerInclusive: lowerInclusive,
+		upper:          upperTuple,
+		upperInclusive: upperInclusive,
+	}, nil
+}
+
+// keysToTuple returns a tuple of tags and values for the keys given, which are the values of this index's columns in
+// order. The tuple is a prefix of the map keys of the index's rows. Returns false if the keys can't be used to look up
+// rows in the index.
+func (di *doltIndex) keysToTuple(keys []interface{}) (*types.Tuple, bool, error) {
+	if keys == nil {
+		return nil, true, nil
 	}
 
-	var i int
-	taggedVals := make(row.TaggedValues)
-	err := sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		taggedVals[tag] = keyColToValue(key[i], col)
-		i++
-		return false, nil
-	})
+	if len(keys) == 0 || len(keys) > len(di.cols) {
+		return nil, false, nil
+	}
+
+	vals := make([]types.Value, 0, 2*len(keys))
+	for i, key := range keys {
+		val, ok, err := keyToValue(key, di.cols[i])
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		vals = append(vals, types.Uint(di.cols[i].Tag), val)
+	}
+
+	tuple, err := types.NewTuple(di.db.root.VRW().Format(), vals...)
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return taggedVals, nil
+	return &tuple, true, nil
 }
 
-func keyColToValue(v interface{}, column schema.Column) types.Value {
-	// TODO: type conversion
-	switch column.Kind {
-	case types.BoolKind:
-		return types.Bool(v.(bool))
-	case types.IntKind:
-		return types.Int(v.(int64))
-	case types.FloatKind:
-		return types.Float(v.(float64))
-	case types.UintKind:
-		return types.Uint(v.(uint64))
-	case types.UUIDKind:
-		panic("Implement me")
-	case types.StringKind:
-		return types.String(v.(string))
-	default:
-		panic("unhandled type")
+// keyToValue converts the key given to a value of the kind of the column given. Returns false if the key is NULL or
+// can't be converted without changing its value.
+func keyToValue(key interface{}, col schema.Column) (types.Value, bool, error) {
+	if key == nil {
+		return nil, false, nil
+	}
+
+	val, err := sqlValToNomsValOfKind(key, col.Kind)
+	if err != nil || val == nil {
+		return nil, false, nil
+	}
+
+	origVal := SqlValToNomsVal(key)
+	if origVal.Kind() == col.Kind {
+		return val, true, nil
 	}
+
+	// Make sure the conversion is lossless, e.g. 1.5 shouldn't look up rows with an integer column of 1
+	convBack := doltcore.GetConvFunc(col.Kind, origVal.Kind())
+	if convBack == nil {
+		return nil, false, nil
+	}
+
+	roundTripped, err := convBack(val)
+	if err != nil || roundTripped == nil || !roundTripped.Equals(origVal) {
+		return nil, false, nil
+	}
+
+	return val, true, nil
 }
 
 func (*doltIndex) Has(partition sql.Partition, key ...interface{}) (bool, error) {
 	// appears to be unused for the moment
-	panic("implement me")
+	return false, errors.New("Has is not supported on dolt indexes")
 }
 
 func (di *doltIndex) ID() string {
-	return fmt.Sprintf("%s:primaryKey", di.tableName)
+	return indexID(di.tableName, di.name)
+}
+
+// indexID returns the ID of the index given. The engine's catalog expects IDs in lower case when deleting indexes.
+func indexID(tableName, indexName string) string {
+	return strings.ToLower(tableName + ":" + indexName)
 }
 
 func (di *doltIndex) Database() string {
@@ -150,35 +313,17 @@ func (di *doltIndex) Table() string {
 	return di.tableName
 }
 
+// Expressions returns the expression strings needed for this index to work. This needs to match the implementation in
+// the sql engine, which requires $table.$column
 func (di *doltIndex) Expressions() []string {
-	strs, err := primaryKeytoIndexStrings(di.tableName, di.sch)
-
-	// TODO: fix panics
-	if err != nil {
-		panic(err)
+	strs := make([]string, len(di.cols))
+	for i, col := range di.cols {
+		strs[i] = di.tableName + "." + col.Name
 	}
 
 	return strs
 }
 
-// Returns the expression strings needed for this index to work. This needs to match the implementation in the sql
-// engine, which requires $table.$column
-func primaryKeytoIndexStrings(tableName string, sch schema.Schema) ([]string, error) {
-	colNames := make([]string, sch.GetPKCols().Size())
-	var i int
-	err := sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		colNames[i] = tableName + "." + col.Name
-		i++
-		return true, nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return colNames, nil
-}
-
 func (di *doltIndex) Driver() string {
 	return di.driver.ID()
 }
@@ -223,9 +368,14 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (s
 	return idt.indexLookup.RowIter(ctx)
 }
 
+// doltIndexLookup is a range of an index's keys. Either bound may be nil, which means the range is unbounded on that
+// side. Bounds may be prefixes of the index's keys, in which case every key with that prefix is on the bound.
 type doltIndexLookup struct {
-	idx *doltIndex
-	key row.TaggedValues
+	idx            *doltIndex
+	lower          *types.Tuple
+	lowerInclusive bool
+	upper          *types.Tuple
+	upperInclusive bool
 }
 
 func (il *doltIndexLookup) Indexes() []string {
@@ -236,45 +386,259 @@ func (il *doltIndexLookup) Indexes() []string {
 // by wrapping tables via the WithIndexLookup method. The iterator that this method returns yields []byte instead of
 // sql.Row and its purpose is yet unclear.
 func (il *doltIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, error) {
-	panic("implement me")
+	return nil, errors.New("index values are not supported on dolt indexes")
 }
 
-// RowIter returns a row iterator for this index lookup. The iterator will return the single matching row for the index.
+// RowIter returns a row iterator for this index lookup. The iterator will return all rows in the lookup's range.
 func (il *doltIndexLookup) RowIter(ctx *sql.Context) (sql.RowIter, error) {
-	return &indexLookupRowIterAdapter{indexLookup: il, ctx: ctx}, nil
-}
+	table, ok, err := il.idx.db.root.GetTable(ctx.Context, il.idx.tableName)
 
-type indexLookupRowIterAdapter struct {
-	indexLookup *doltIndexLookup
-	ctx         *sql.Context
-	i           int
-}
+	if err != nil {
+		return nil, err
+	}
 
-func (i *indexLookupRowIterAdapter) Next() (sql.Row, error) {
-	if i.i > 0 {
-		return nil, io.EOF
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(il.idx.tableName)
 	}
 
-	i.i++
-	table, _, err := i.indexLookup.idx.db.root.GetTable(i.ctx.Context, i.indexLookup.idx.tableName)
+	sch, err := table.GetSchema(ctx.Context)
 
 	if err != nil {
 		return nil, err
 	}
 
-	r, ok, err := table.GetRowByPKVals(i.ctx.Context, i.indexLookup.key, i.indexLookup.idx.sch)
+	rowData, err := table.GetRowData(ctx.Context)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if !ok {
-		return nil, io.EOF
+	// The primary key index is the row data itself. Secondary indexes map their keys to primary keys.
+	idxData := rowData
+	if !il.idx.isPrimaryKey() {
+		idxData, err = table.GetIndexRowData(ctx.Context, il.idx.name)
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return doltRowToSqlRow(r, i.indexLookup.idx.sch)
+	var mapIter types.MapIterator
+	if il.lower != nil {
+		mapIter, err = idxData.IteratorFrom(ctx.Context, *il.lower)
+	} else {
+		mapIter, err = idxData.Iterator(ctx.Context)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexLookupRowIter{
+		indexLookup: il,
+		ctx:         ctx,
+		sch:         sch,
+		rowData:     rowData,
+		mapIter:     mapIter,
+	}, nil
+}
+
+type indexLookupRowIter struct {
+	indexLookup *doltIndexLookup
+	ctx         *sql.Context
+	sch         schema.Schema
+	rowData     types.Map
+	mapIter     types.MapIterator
+}
+
+func (itr *indexLookupRowIter) Next() (sql.Row, error) {
+	il := itr.indexLookup
+	for {
+		key, val, err := itr.mapIter.Next(itr.ctx.Context)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if key == nil {
+			return nil, io.EOF
+		}
+
+		keyTuple := key.(types.Tuple)
+		if il.lower != nil && !il.lowerInclusive {
+			if onLower, err := tupleHasPrefix(keyTuple, *il.lower); err != nil {
+				return nil, err
+			} else if onLower {
+				continue
+			}
+		}
+
+		if il.upper != nil {
+			if onUpper, err := tupleHasPrefix(keyTuple, *il.upper); err != nil {
+				return nil, err
+			} else if onUpper {
+				if !il.upperInclusive {
+					return nil, io.EOF
+				}
+			} else if isLess, err := il.upper.Less(itr.rowData.Format(), keyTuple); err != nil {
+				return nil, err
+			} else if isLess {
+				return nil, io.EOF
+			}
+		}
+
+		pk := keyTuple
+		if !il.idx.isPrimaryKey() {
+			pk = val.(types.Tuple)
+			val, _, err = itr.rowData.MaybeGet(itr.ctx.Context, pk)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if val == nil {
+				return nil, errors.New("index " + il.idx.name + " refers to a row that doesn't exist")
+			}
+		}
+
+		r, err := row.FromNoms(itr.sch, pk, val.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return doltRowToSqlRow(r, itr.sch)
+	}
 }
 
-func (*indexLookupRowIterAdapter) Close() error {
+func (*indexLookupRowIter) Close() error {
 	return nil
 }
+
+// tupleHasPrefix returns whether the first values of the tuple given are the values of the prefix given.
+func tupleHasPrefix(t, prefix types.Tuple) (bool, error) {
+	if t.Len() < prefix.Len() {
+		return false, nil
+	}
+
+	for i := uint64(0); i < prefix.Len(); i++ {
+		val, err := t.Get(i)
+
+		if err != nil {
+			return false, err
+		}
+
+		prefixVal, err := prefix.Get(i)
+
+		if err != nil {
+			return false, err
+		}
+
+		if !val.Equals(prefixVal) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// NewAnalyzer returns the analyzer to use for queries against dolt databases. It's the default analyzer, with a rule
+// that rewrites the filter expressions that the default analyzer doesn't handle correctly when looking up rows in an
+// index.
+func NewAnalyzer(c *sql.Catalog) *analyzer.Analyzer {
+	return analyzer.NewBuilder(c).AddPreAnalyzeRule("rewrite_filters_for_indexes", rewriteFiltersForIndexes).Build()
+}
+
+// rewriteFiltersForIndexes rewrites filter expressions so that index lookups return the right rows:
+//   - Lookups for OR expressions can skip rows that match only one side, so ORs are hidden from the analyzer.
+//   - Lookups for IN expressions with a single value fail, so INs are hidden from the analyzer. Lookups for INs with
+//     more than one value aren't supported by our indexes anyway.
+//   - Comparisons with a constant on the left side are looked up as though the operator were reversed, so the operands
+//     are swapped to put the column on the left.
+func rewriteFiltersForIndexes(_ *sql.Context, _ *analyzer.Analyzer, n sql.Node) (sql.Node, error) {
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		rewritten, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			switch e := e.(type) {
+			case *expression.Or:
+				return &unindexedOr{e}, nil
+			case *expression.In:
+				return &unindexedIn{e}, nil
+			case *expression.GreaterThan:
+				if constantOnLeft(e) {
+					return expression.NewLessThan(e.Right(), e.Left()), nil
+				}
+			case *expression.GreaterThanOrEqual:
+				if constantOnLeft(e) {
+					return expression.NewLessThanOrEqual(e.Right(), e.Left()), nil
+				}
+			case *expression.LessThan:
+				if constantOnLeft(e) {
+					return expression.NewGreaterThan(e.Right(), e.Left()), nil
+				}
+			case *expression.LessThanOrEqual:
+				if constantOnLeft(e) {
+					return expression.NewGreaterThanOrEqual(e.Right(), e.Left()), nil
+				}
+			}
+
+			return e, nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewFilter(rewritten, filter.Child), nil
+	})
+}
+
+// constantOnLeft returns whether only the right operand of the comparison given refers to columns.
+func constantOnLeft(c expression.Comparer) bool {
+	return !hasColumns(c.Left()) && hasColumns(c.Right())
+}
+
+func hasColumns(e sql.Expression) bool {
+	found := false
+	expression.Inspect(e, func(e sql.Expression) bool {
+		switch e.(type) {
+		case *expression.UnresolvedColumn, *expression.GetField:
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// unindexedOr is an OR expression that the analyzer doesn't recognize when assigning indexes.
+type unindexedOr struct {
+	*expression.Or
+}
+
+func (o *unindexedOr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	or, err := o.Or.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unindexedOr{or.(*expression.Or)}, nil
+}
+
+// unindexedIn is an IN expression that the analyzer doesn't recognize when assigning indexes.
+type unindexedIn struct {
+	*expression.In
+}
+
+func (in *unindexedIn) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	newIn, err := in.In.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unindexedIn{newIn.(*expression.In)}, nil
+}
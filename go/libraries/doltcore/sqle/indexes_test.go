@@ -0,0 +1,264 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/parse"
+	"github.com/src-d/go-mysql-server/sql/plan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	dsql "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestIndexLookups(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expectedIds []int64
+		usesIndex   bool
+	}{
+		{
+			name:        "primary key",
+			query:       "select id from people where id = 2",
+			expectedIds: []int64{2},
+			usesIndex:   true,
+		},
+		{
+			name:        "multi-column primary key",
+			query:       "select episode_id from appearances where character_id = 0 and episode_id = 2",
+			expectedIds: []int64{2},
+			usesIndex:   true,
+		},
+		{
+			name:        "equals",
+			query:       "select id from people where age = 40",
+			expectedIds: []int64{0, 5},
+			usesIndex:   true,
+		},
+		{
+			name:        "greater than",
+			query:       "select id from people where age > 38",
+			expectedIds: []int64{0, 4, 5},
+			usesIndex:   true,
+		},
+		{
+			name:        "greater than or equal",
+			query:       "select id from people where age >= 38",
+			expectedIds: []int64{0, 1, 4, 5},
+			usesIndex:   true,
+		},
+		{
+			name:        "less than",
+			query:       "select id from people where age < 38",
+			expectedIds: []int64{2, 3},
+			usesIndex:   true,
+		},
+		{
+			name:        "less than or equal",
+			query:       "select id from people where 38 >= age",
+			expectedIds: []int64{1, 2, 3},
+			usesIndex:   true,
+		},
+		{
+			name:        "in with one value",
+			query:       "select id from people where age in (10)",
+			expectedIds: []int64{2},
+		},
+		{
+			name:        "between",
+			query:       "select id from people where age between 10 and 40",
+			expectedIds: []int64{0, 1, 2, 5},
+		},
+		{
+			name:        "multi-column index prefix",
+			query:       "select id from people where last = 'Simpson'",
+			expectedIds: []int64{0, 1, 2, 3},
+		},
+		{
+			name:        "multi-column index",
+			query:       "select id from people where last = 'Simpson' and first = 'Bart'",
+			expectedIds: []int64{2},
+			usesIndex:   true,
+		},
+		{
+			name:        "multi-column index with extra condition",
+			query:       "select id from people where last = 'Simpson' and first = 'Bart' and age < 5",
+			expectedIds: nil,
+			usesIndex:   true,
+		},
+		{
+			name:        "lossy conversion",
+			query:       "select id from people where age = 40.5",
+			expectedIds: nil,
+		},
+		{
+			name:        "or",
+			query:       "select id from people where age = 40 or last = 'Szyslak'",
+			expectedIds: []int64{0, 4, 5},
+		},
+		{
+			name:        "or with primary key",
+			query:       "select id from people where age = 10 or id = 4",
+			expectedIds: []int64{2, 4},
+		},
+		{
+			name:        "or inside and",
+			query:       "select id from people where (age = 10 or id = 4) and is_married = false",
+			expectedIds: []int64{2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := dtestutils.CreateTestEnv()
+			CreateTestDatabase(dEnv, t)
+			ctx := context.Background()
+			root, _ := dEnv.WorkingRoot(ctx)
+
+			for _, query := range []string{"create index idx_age on people (age)", "create index idx_name on people (last, first)"} {
+				stmt, ok := dsql.ParseIndexDDL(query)
+				require.True(t, ok)
+				var err error
+				root, err = dsql.ExecuteIndexDDL(ctx, dEnv.DoltDB, root, stmt)
+				require.NoError(t, err)
+			}
+
+			db := NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+			catalog := sql.NewCatalog()
+			engine := sqle.New(catalog, NewAnalyzer(catalog), nil)
+			engine.AddDatabase(db)
+			engine.Catalog.RegisterIndexDriver(NewDoltIndexDriver(db))
+			require.NoError(t, engine.Init())
+
+			ids, usedIndex, err := queryIds(sql.NewContext(ctx), engine, tt.query)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedIds, ids)
+			if tt.usesIndex {
+				assert.True(t, usedIndex)
+			}
+		})
+	}
+}
+
+func TestExecuteIndexDDL(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	CreateTestDatabase(dEnv, t)
+	ctx := context.Background()
+	root, _ := dEnv.WorkingRoot(ctx)
+
+	db := NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, NewAnalyzer(catalog), nil)
+	engine.AddDatabase(db)
+	engine.Catalog.RegisterIndexDriver(NewDoltIndexDriver(db))
+	require.NoError(t, engine.Init())
+	sqlCtx := sql.NewContext(ctx)
+
+	query := "select id from people where age = 40"
+	ids, usedIndex, err := queryIds(sqlCtx, engine, query)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{0, 5}, ids)
+	assert.False(t, usedIndex)
+
+	stmt, _ := dsql.ParseIndexDDL("create index idx_age on people (age)")
+	require.NoError(t, ExecuteIndexDDL(sqlCtx, engine, db, stmt))
+	ids, usedIndex, err = queryIds(sqlCtx, engine, query)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{0, 5}, ids)
+	assert.True(t, usedIndex)
+
+	// Writes through the engine should be visible to index lookups
+	_, err = executeWrite(sqlCtx, engine, db, "update people set age = 40 where id = 2")
+	require.NoError(t, err)
+	ids, _, err = queryIds(sqlCtx, engine, query)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{0, 2, 5}, ids)
+
+	// A failed statement shouldn't change the root or the catalog
+	rootBefore := db.Root()
+	require.Error(t, ExecuteIndexDDL(sqlCtx, engine, db, stmt))
+	assert.Equal(t, rootBefore, db.Root())
+
+	stmt, _ = dsql.ParseIndexDDL("drop index idx_age on people")
+	require.NoError(t, ExecuteIndexDDL(sqlCtx, engine, db, stmt))
+	ids, usedIndex, err = queryIds(sqlCtx, engine, query)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{0, 2, 5}, ids)
+	assert.False(t, usedIndex)
+}
+
+// queryIds runs the query given, which must select a single integer column, and returns the values selected. Also
+// returns whether any table in the query was read through an index lookup.
+func queryIds(ctx *sql.Context, engine *sqle.Engine, query string) ([]int64, bool, error) {
+	parsed, err := parse.Parse(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	analyzed, err := engine.Analyzer.Analyze(ctx, parsed)
+	if err != nil {
+		return nil, false, err
+	}
+
+	usedIndex := false
+	plan.Inspect(analyzed, func(node sql.Node) bool {
+		rt, ok := node.(*plan.ResolvedTable)
+		if !ok {
+			return true
+		}
+
+		// The engine wraps tables to track query progress
+		table := rt.Table
+		for table != nil {
+			if _, ok := table.(*IndexedDoltTable); ok {
+				usedIndex = true
+			}
+
+			wrapper, ok := table.(sql.TableWrapper)
+			if !ok {
+				break
+			}
+			table = wrapper.Underlying()
+		}
+
+		return true
+	})
+
+	iter, err := analyzed.RowIter(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var ids []int64
+	var r sql.Row
+	for r, err = iter.Next(); err == nil; r, err = iter.Next() {
+		ids = append(ids, r[0].(int64))
+	}
+
+	if err != io.EOF {
+		return nil, false, err
+	}
+
+	return ids, usedIndex, iter.Close()
+}
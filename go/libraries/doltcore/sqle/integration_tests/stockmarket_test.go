@@ -20180,7 +20180,7 @@ func TestJoin(t *testing.T) {
 	root, err = sqletestutil.ExecuteSql(dEnv, root, insertRows)
 	require.NoError(t, err)
 
-	rows, err := sqletestutil.ExecuteSelect(root,
+	rows, err := sqletestutil.ExecuteSelect(dEnv, root,
 		`select Type, d.Symbol, Country, TradingDate, Open, High, Low, Close, Volume, OpenInt, Name, Sector, IPOYear
 						from daily_summary d join symbols t on d.Symbol = t.Symbol`)
 	// TODO: fix me
@@ -20188,7 +20188,7 @@ func TestJoin(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 5210, len(rows))
 
-	expectedJoinRows, err := sqletestutil.ExecuteSelect(root,
+	expectedJoinRows, err := sqletestutil.ExecuteSelect(dEnv, root,
 		`select * from join_result order by symbol, country, date`)
 	require.NoError(t, err)
 	assertResultRowsEqual(t, expectedJoinRows, rows)
@@ -20228,6 +20228,6 @@ func TestExplain(t *testing.T) {
 	root, err = sqletestutil.ExecuteSql(dEnv, root, createTables)
 	require.NoError(t, err)
 
-	_, err = sqletestutil.ExecuteSelect(root, "explain format = tree select * from daily_summary d join symbols t on d.Symbol = t.Symbol")
+	_, err = sqletestutil.ExecuteSelect(dEnv, root, "explain format = tree select * from daily_summary d join symbols t on d.Symbol = t.Symbol")
 	require.NoError(t, err)
 }
@@ -0,0 +1,101 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+)
+
+// LogTableName is the name of the system table which lists the commits in the history of the current branch.
+const LogTableName = "dolt_log"
+
+// LogTable is a read-only sql.Table which lists the commits reachable from the head of the current branch, newest
+// first.
+type LogTable struct {
+	db *Database
+}
+
+// NewLogTable returns the log table for the database given.
+func NewLogTable(db *Database) *LogTable {
+	return &LogTable{db: db}
+}
+
+// Name returns the name of the table.
+func (lt *LogTable) Name() string {
+	return LogTableName
+}
+
+// String returns the name of the table.
+func (lt *LogTable) String() string {
+	return LogTableName
+}
+
+// Schema returns the schema of the table.
+func (lt *LogTable) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "commit_hash", Type: sql.Text, Source: LogTableName},
+		{Name: "committer", Type: sql.Text, Source: LogTableName},
+		{Name: "email", Type: sql.Text, Source: LogTableName},
+		{Name: "date", Type: sql.Timestamp, Source: LogTableName},
+		{Name: "message", Type: sql.Text, Source: LogTableName},
+	}
+}
+
+// Partitions returns the single partition of the table.
+func (lt *LogTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows returns a row for each commit in the history of the current branch.
+func (lt *LogTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	head, err := lt.db.headCommit(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := commitwalk.GetTopologicalOrderCommits(ctx, lt.db.ddb, headHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, 0, len(commits))
+	for _, cm := range commits {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, sql.NewRow(h.String(), meta.Name, meta.Email, meta.Time(), meta.Description))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestLogAndBranchesTables(t *testing.T) {
+	dEnv := createHistory(t)
+
+	head, err := dEnv.DoltDB.Resolve(context.Background(), dEnv.RepoState.CWBHeadSpec())
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(context.Background(), ref.NewBranchRef("other"), head))
+
+	rows := querySystemTables(t, dEnv, "select message, committer from dolt_log")
+	assert.Equal(t, []sql.Row{
+		{"update people", "billy bob"},
+		{"create tables", "billy bob"},
+		{"Data repository created.", "billy bob"},
+	}, rows)
+
+	rows = querySystemTables(t, dEnv, "select name, latest_commit_message from dolt_branches")
+	assert.ElementsMatch(t, []sql.Row{{"master", "update people"}, {"other", "update people"}}, rows)
+}
+
+// createHistory creates the test database and commits it, then updates and deletes a row in a second commit, and
+// finally inserts a row without committing it.
+func createHistory(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	CreateTestDatabase(dEnv, t)
+	require.NoError(t, actions.StageAllTables(ctx, dEnv, false))
+	require.NoError(t, actions.CommitStaged(ctx, dEnv, "create tables", false))
+
+	executeWrites(t, dEnv, "update people set age = 41 where id = 0", "delete from people where id = 1")
+	require.NoError(t, actions.StageAllTables(ctx, dEnv, false))
+	require.NoError(t, actions.CommitStaged(ctx, dEnv, "update people", false))
+
+	executeWrites(t, dEnv, "insert into people (id, first, last) values (10, 'Moe', 'Szyslak')")
+
+	return dEnv
+}
+
+// newTestEngine returns an engine for the working root of the environment given.
+func newTestEngine(t *testing.T, dEnv *env.DoltEnv) (*sqle.Engine, *Database) {
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+
+	db := NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, NewAnalyzer(catalog), nil)
+	engine.AddDatabase(db)
+	engine.Catalog.RegisterIndexDriver(NewDoltIndexDriver(db))
+	require.NoError(t, engine.Init())
+
+	return engine, db
+}
+
+// executeWrites runs the write queries given and updates the working root with the result.
+func executeWrites(t *testing.T, dEnv *env.DoltEnv, queries ...string) {
+	engine, db := newTestEngine(t, dEnv)
+	sqlCtx := sql.NewContext(context.Background())
+
+	for _, query := range queries {
+		_, err := executeWrite(sqlCtx, engine, db, query)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, dEnv.UpdateWorkingRoot(context.Background(), db.Root()))
+}
+
+// querySystemTables runs the query given and returns the resulting rows.
+func querySystemTables(t *testing.T, dEnv *env.DoltEnv, query string) []sql.Row {
+	engine, _ := newTestEngine(t, dEnv)
+	_, iter, err := engine.Query(sql.NewContext(context.Background()), query)
+	require.NoError(t, err)
+
+	var rows []sql.Row
+	var r sql.Row
+	for r, err = iter.Next(); err == nil; r, err = iter.Next() {
+		rows = append(rows, r)
+	}
+
+	require.Equal(t, io.EOF, err)
+	require.NoError(t, iter.Close())
+
+	return rows
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+)
+
+// RevisionDelimiter separates the name of a database from a revision in the name of a revision database. The tables of
+// the revision database dolt/feature are the tables of the database dolt as of the head of the branch feature.
+const RevisionDelimiter = "/"
+
+// NewRevisionDatabase returns a read-only database with the tables of the database given as of the revision given,
+// which may be anything that resolves to a commit: a branch, a tag, a commit hash or an ancestor spec like HEAD~1. The
+// system tables of the revision database show the history of the revision rather than the current branch.
+func NewRevisionDatabase(ctx context.Context, db *Database, revision string) (*Database, error) {
+	cs, err := doltdb.NewCommitSpec(revision, db.rsr.CWBHeadRef().String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := resolveRoot(ctx, db.ddb, cs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{
+		name:     db.name + RevisionDelimiter + revision,
+		root:     root,
+		ddb:      db.ddb,
+		rsr:      revisionRepoState{db.rsr, cs},
+		readOnly: true,
+	}, nil
+}
+
+// revisionRepoState is the repo state of a revision database, whose head is the revision rather than the head of the
+// current branch.
+type revisionRepoState struct {
+	env.RepoStateReader
+	spec *doltdb.CommitSpec
+}
+
+func (rs revisionRepoState) CWBHeadSpec() *doltdb.CommitSpec {
+	return rs.spec
+}
+
+func resolveRoot(ctx context.Context, ddb *doltdb.DoltDB, cs *doltdb.CommitSpec) (*doltdb.RootValue, error) {
+	cm, err := ddb.Resolve(ctx, cs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cm.GetRootValue()
+}
+
+// queryToken is a token of a SQL query, along with its offsets in the query.
+type queryToken struct {
+	typ        int
+	val        string
+	start, end int
+}
+
+// tokenizeQuery returns the tokens of the query given. Tokenization stops at the first invalid token, leaving the error
+// to be reported by the parser.
+func tokenizeQuery(query string) []queryToken {
+	var tokens []queryToken
+	tkn := sqlparser.NewStringTokenizer(query)
+	prevEnd := 0
+
+	for {
+		typ, val := tkn.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			return tokens
+		}
+
+		// The tokenizer's position is one past the character that follows the token
+		end := tkn.Position - 1
+		start := prevEnd
+		for start < end && strings.ContainsRune(" \t\r\n", rune(query[start])) {
+			start++
+		}
+
+		tokens = append(tokens, queryToken{typ, string(val), start, end})
+		prevEnd = end
+	}
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// RewriteAsOf rewrites every table in the query given that's selected AS OF a revision as a table of the revision
+// database for that revision, so that the engine can execute the query. For example, in a database named dolt, the
+// table in SELECT * FROM people AS OF 'feature' becomes `dolt/feature`.`people`. The unquoted revision database name
+// in USE dolt/feature is quoted. Other queries are returned unchanged.
+func RewriteAsOf(query string, dbName string) (string, error) {
+	tokens := tokenizeQuery(query)
+
+	if len(tokens) > 1 && tokens[0].typ == sqlparser.USE {
+		name := strings.TrimRight(strings.TrimSpace(query[tokens[1].start:]), ";")
+		if strings.Contains(name, RevisionDelimiter) && !strings.HasPrefix(name, "`") {
+			return "USE " + quoteIdentifier(strings.TrimSpace(name)), nil
+		}
+
+		return query, nil
+	}
+
+	var rewritten strings.Builder
+	copied := 0
+	for i := 1; i+2 < len(tokens); i++ {
+		as, of, revision := tokens[i], tokens[i+1], tokens[i+2]
+		if as.typ != sqlparser.AS || of.typ != sqlparser.ID || !strings.EqualFold(of.val, "of") || revision.typ != sqlparser.STRING {
+			continue
+		}
+
+		table := tokens[i-1]
+		if table.typ != sqlparser.ID {
+			return "", fmt.Errorf("AS OF must follow a table name")
+		}
+
+		start, db := table.start, dbName
+		if i >= 3 && tokens[i-2].typ == '.' && tokens[i-3].typ == sqlparser.ID {
+			start, db = tokens[i-3].start, tokens[i-3].val
+		}
+
+		if !strings.EqualFold(db, dbName) {
+			return "", fmt.Errorf("AS OF isn't supported for tables in database '%s'", db)
+		}
+
+		rewritten.WriteString(query[copied:start])
+		rewritten.WriteString(quoteIdentifier(dbName+RevisionDelimiter+revision.val) + "." + quoteIdentifier(table.val))
+		copied = revision.end
+	}
+
+	if copied == 0 {
+		return query, nil
+	}
+
+	rewritten.WriteString(query[copied:])
+	return rewritten.String(), nil
+}
+
+// RevisionDatabases returns a new revision database for each revision database of the database given that's referenced
+// in the query given, to be added to the catalog of an engine that executes the query alone. Revisions are resolved
+// for each query, so that a query sees the current head of a branch, and the revision databases read by one query are
+// never changed by another.
+func RevisionDatabases(ctx context.Context, db *Database, query string) ([]*Database, error) {
+	prefix := strings.ToLower(db.name + RevisionDelimiter)
+	seen := make(map[string]bool)
+
+	var revisionDBs []*Database
+	for _, token := range tokenizeQuery(query) {
+		name := strings.ToLower(token.val)
+		if token.typ != sqlparser.ID || !strings.HasPrefix(name, prefix) || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		revision := token.val[len(prefix):]
+		revisionDB, err := NewRevisionDatabase(ctx, db, revision)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve revision '%s': %v", revision, err)
+		}
+
+		revisionDBs = append(revisionDBs, revisionDB)
+	}
+
+	return revisionDBs, nil
+}
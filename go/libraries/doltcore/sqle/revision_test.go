@@ -0,0 +1,108 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestRewriteAsOf(t *testing.T) {
+	tests := []struct {
+		query       string
+		expected    string
+		expectedErr string
+	}{
+		{"select * from people", "select * from people", ""},
+		{"select * from people as of 'feature'", "select * from `dolt/feature`.`people`", ""},
+		{"select * from dolt.people AS OF 'HEAD~1' where id = 1", "select * from `dolt/HEAD~1`.`people` where id = 1", ""},
+		{"select * from people as of 'a' join appearances as of 'b'", "select * from `dolt/a`.`people` join `dolt/b`.`appearances`", ""},
+		{"select 'as of' from people", "select 'as of' from people", ""},
+		{"use dolt/feature", "USE `dolt/feature`", ""},
+		{"use `dolt/feature`", "use `dolt/feature`", ""},
+		{"use dolt", "use dolt", ""},
+		{"select * from (select 1) as of 'feature'", "", "AS OF must follow a table name"},
+		{"select * from other.people as of 'feature'", "", "database 'other'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rewritten, err := RewriteAsOf(tt.query, "dolt")
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rewritten)
+		})
+	}
+}
+
+func TestAsOfQueries(t *testing.T) {
+	dEnv := createHistory(t)
+	ctx := context.Background()
+	sqlCtx := sql.NewContext(ctx)
+
+	countPeople := func(query string) int {
+		engine, db := newTestEngine(t, dEnv)
+		rewritten, err := RewriteAsOf(query, db.Name())
+		require.NoError(t, err)
+		revisionDBs, err := RevisionDatabases(ctx, db, rewritten)
+		require.NoError(t, err)
+		for _, revisionDB := range revisionDBs {
+			engine.AddDatabase(revisionDB)
+		}
+
+		_, iter, err := engine.Query(sqlCtx, rewritten)
+		require.NoError(t, err)
+		r, err := iter.Next()
+		require.NoError(t, err)
+		_, err = iter.Next()
+		require.Equal(t, io.EOF, err)
+		require.NoError(t, iter.Close())
+
+		return int(r[0].(int64))
+	}
+
+	assert.Equal(t, len(AllPeopleRows), countPeople("select count(*) from people"))
+	assert.Equal(t, len(AllPeopleRows)-1, countPeople("select count(*) from people as of 'HEAD'"))
+	assert.Equal(t, len(AllPeopleRows), countPeople("select count(*) from people as of 'HEAD~1'"))
+	assert.Equal(t, len(AllPeopleRows), countPeople("select count(*) from `dolt/master~1`.people"))
+	assert.Equal(t, 2, countPeople("select count(*) from dolt_log as of 'HEAD~1'"))
+
+	engine, db := newTestEngine(t, dEnv)
+	_, err := RevisionDatabases(ctx, db, "select * from `dolt/notFound`.people")
+	assert.Error(t, err)
+
+	// each revision database referenced is resolved once
+	revisionDBs, err := RevisionDatabases(ctx, db, "select * from `dolt/HEAD`.people join `DOLT/head`.people")
+	require.NoError(t, err)
+	assert.Len(t, revisionDBs, 1)
+
+	revisionDB, err := NewRevisionDatabase(ctx, db, "HEAD")
+	require.NoError(t, err)
+	_, err = executeWrite(sqlCtx, engine, revisionDB, "delete from people")
+	assert.Equal(t, ErrReadOnlyDatabase, err)
+}
@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/src-d/go-mysql-server/sql"
@@ -95,13 +96,32 @@ func doltRowToSqlRow(doltRow row.Row, sch schema.Schema) (sql.Row, error) {
 	return sql.NewRow(colVals...), nil
 }
 
-// Returns a Dolt row representation for SQL row given
+// Returns a Dolt row representation for SQL row given. The values of the SQL row are expected in the same order as the
+// columns of the schema, and are converted to the kinds of their columns where necessary.
 func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Schema) (row.Row, error) {
+	allCols := doltSchema.GetAllCols()
+	if len(r) != allCols.Size() {
+		return nil, fmt.Errorf("expected %d values for row but got %d", allCols.Size(), len(r))
+	}
+
 	taggedVals := make(row.TaggedValues)
 	for i, val := range r {
-		if val != nil {
-			taggedVals[uint64(i)] = SqlValToNomsVal(val)
+		if val == nil {
+			continue
+		}
+
+		col := allCols.GetByIndex(i)
+		nomsVal, err := sqlValToNomsValOfKind(val, col.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for column '%v': %v", col.Name, err)
 		}
+
+		nomsVal, err = col.NormalizeValue(nomsVal)
+		if err != nil {
+			return nil, err
+		}
+
+		taggedVals[col.Tag] = nomsVal
 	}
 
 	return row.New(nbf, doltSchema, taggedVals)
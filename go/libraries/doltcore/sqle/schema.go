@@ -50,10 +50,15 @@ func SqlSchemaToDoltSchema(sqlSchema sql.Schema) schema.Schema {
 
 // doltColToSqlCol returns the SQL column corresponding to the dolt column given.
 func doltColToSqlCol(tableName string, col schema.Column) *sql.Column {
+	var defaultVal interface{}
+	if val, err := col.DefaultValue(); err == nil && val != nil {
+		defaultVal = doltColValToSqlColVal(val)
+	}
+
 	return &sql.Column{
 		Name:     col.Name,
-		Type:     nomsTypeToSqlType(col.Kind),
-		Default:  nil,
+		Type:     doltColToSqlType(col),
+		Default:  defaultVal,
 		Nullable: col.IsNullable(),
 		Source:   tableName,
 	}
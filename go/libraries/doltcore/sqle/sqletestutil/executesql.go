@@ -41,7 +41,7 @@ func ExecuteSql(dEnv *env.DoltEnv, root *doltdb.RootValue, statements string) (*
 			continue
 		}
 
-		sqlStatement, err := sqlparser.Parse(query)
+		sqlStatement, err := dsql.Parse(query)
 		if err != nil {
 			return nil, err
 		}
@@ -58,7 +58,7 @@ func ExecuteSql(dEnv *env.DoltEnv, root *doltdb.RootValue, statements string) (*
 			if root, err = batcher.Commit(context.Background()); err != nil {
 				return nil, err
 			}
-			_, execErr = sqlparser.ParseStrictDDL(query)
+			_, execErr = dsql.ParseStrictDDL(query)
 			if execErr != nil {
 				return nil, fmt.Errorf("Error parsing DDL: %v.", execErr.Error())
 			}
@@ -107,10 +107,10 @@ func sqlDDL(dEnv *env.DoltEnv, root *doltdb.RootValue, ddl *sqlparser.DDL, query
 }
 
 // Executes the select statement given and returns the resulting rows, or an error if one is encountered.
-// This uses the index functionality, which is not ready for prime time. Use with caution.
-func ExecuteSelect(root *doltdb.RootValue, query string) ([]sql.Row, error) {
-	db := dsqle.NewDatabase("dolt", root)
-	engine := sqle.NewDefault()
+func ExecuteSelect(dEnv *env.DoltEnv, root *doltdb.RootValue, query string) ([]sql.Row, error) {
+	db := dsqle.NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, dsqle.NewAnalyzer(catalog), nil)
 	engine.AddDatabase(db)
 	engine.Catalog.RegisterIndexDriver(dsqle.NewDoltIndexDriver(db))
 	_ = engine.Init()
@@ -26,6 +26,7 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	. "github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
@@ -86,7 +87,7 @@ func testSelectQuery(t *testing.T, test SelectTest) {
 	}
 
 	root, _ := dEnv.WorkingRoot(context.Background())
-	actualRows, sch, err := executeSelect(context.Background(), test.ExpectedSchema, root, test.Query)
+	actualRows, sch, err := executeSelect(context.Background(), dEnv, test.ExpectedSchema, root, test.Query)
 	if len(test.ExpectedErr) > 0 {
 		require.Error(t, err)
 		// Too much work to synchronize error messages between the two implementations, so for now we'll just assert that an error occurred.
@@ -102,9 +103,10 @@ func testSelectQuery(t *testing.T, test SelectTest) {
 
 // Runs the query given and returns the result. The schema result of the query's execution is currently ignored, and
 // the targetSchema given is used to prepare all rows.
-func executeSelect(ctx context.Context, targetSch schema.Schema, root *doltdb.RootValue, query string) ([]row.Row, schema.Schema, error) {
-	db := NewDatabase("dolt", root)
-	engine := sqle.NewDefault()
+func executeSelect(ctx context.Context, dEnv *env.DoltEnv, targetSch schema.Schema, root *doltdb.RootValue, query string) ([]row.Row, schema.Schema, error) {
+	db := NewDatabase("dolt", root, dEnv.DoltDB, dEnv.RepoState)
+	catalog := sql.NewCatalog()
+	engine := sqle.New(catalog, NewAnalyzer(catalog), nil)
 	engine.AddDatabase(db)
 	engine.Catalog.RegisterIndexDriver(&DoltIndexDriver{db})
 	engine.Init()
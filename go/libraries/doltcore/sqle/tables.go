@@ -23,18 +23,40 @@ import (
 	"github.com/src-d/go-mysql-server/sql"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
+// ErrDuplicatePrimaryKey is returned when a write would create a second row with an existing primary key.
+var ErrDuplicatePrimaryKey = errors.New("duplicate primary key given")
+
+// ErrReadOnlyDatabase is returned when a write is attempted to a table of a read-only database.
+var ErrReadOnlyDatabase = errors.New("database is read-only")
+
+const constraintFailedFmt = "constraint failed for column '%v': %v"
+
 // DoltTable implements the sql.Table interface and gives access to dolt table rows and schema.
 type DoltTable struct {
 	name  string
 	table *doltdb.Table
 	sch   schema.Schema
+	db    *Database
+
+	// ed holds the edits made to the table's rows by the current statement until the database is flushed
+	ed *rowEditor
 }
 
+var _ sql.Inserter = (*DoltTable)(nil)
+
 // Implements sql.IndexableTable
 func (t *DoltTable) WithIndexLookup(lookup sql.IndexLookup) sql.Table {
+	// Indexes return no lookup for keys they can't look up, which means all rows need to be scanned
+	if lookup == nil {
+		return t
+	}
+
 	dil, ok := lookup.(*doltIndexLookup)
 	if !ok {
 		panic(fmt.Sprintf("Unrecognized indexLookup %T", lookup))
@@ -96,6 +118,229 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIte
 	return newRowIterator(t, ctx)
 }
 
+// Insert adds the given row to the table and updates the database's root value. Returns an error if a row with the
+// same primary key already exists. Implements sql.Inserter.
+func (t *DoltTable) Insert(ctx *sql.Context, sqlRow sql.Row) error {
+	return t.putRow(ctx, sqlRow, false)
+}
+
+// Replace adds the given row to the table, overwriting any existing row with the same primary key.
+func (t *DoltTable) Replace(ctx *sql.Context, sqlRow sql.Row) error {
+	return t.putRow(ctx, sqlRow, true)
+}
+
+// Update replaces the row given with the new values given. If the primary key of the row changes, the old row is
+// removed, and it's an error for the new key to already exist in the table.
+func (t *DoltTable) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error {
+	oldKey, _, err := t.nomsKeyAndValue(ctx, oldRow, false)
+	if err != nil {
+		return err
+	}
+
+	newKey, newVal, err := t.nomsKeyAndValue(ctx, newRow, false)
+	if err != nil {
+		return err
+	}
+
+	ed, err := t.editor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !oldKey.Equals(newKey) {
+		if has, err := ed.has(ctx, newKey); err != nil {
+			return err
+		} else if has {
+			return ErrDuplicatePrimaryKey
+		}
+
+		if err := ed.remove(oldKey); err != nil {
+			return err
+		}
+	}
+
+	return ed.set(newKey, newVal)
+}
+
+// Delete removes the row given from the table. Only the primary key columns of the row are considered.
+func (t *DoltTable) Delete(ctx *sql.Context, sqlRow sql.Row) error {
+	key, _, err := t.nomsKeyAndValue(ctx, sqlRow, false)
+	if err != nil {
+		return err
+	}
+
+	ed, err := t.editor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ed.remove(key)
+}
+
+// putRow writes the row given to the table, failing on an existing primary key unless replace is true. The SQL engine
+// gives columns omitted from an insert null values, so null values are replaced with the columns' default values.
+func (t *DoltTable) putRow(ctx *sql.Context, sqlRow sql.Row, replace bool) error {
+	key, val, err := t.nomsKeyAndValue(ctx, sqlRow, true)
+	if err != nil {
+		return err
+	}
+
+	ed, err := t.editor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !replace {
+		if has, err := ed.has(ctx, key); err != nil {
+			return err
+		} else if has {
+			return ErrDuplicatePrimaryKey
+		}
+	}
+
+	return ed.set(key, val)
+}
+
+// nomsKeyAndValue converts the SQL row given to the noms key and value used to store it in the table's row map,
+// checking it against the table's column constraints. Null values are replaced with default values if withDefaults is
+// true.
+func (t *DoltTable) nomsKeyAndValue(ctx context.Context, sqlRow sql.Row, withDefaults bool) (types.Value, types.Value, error) {
+	r, err := SqlRowToDoltRow(t.table.Format(), sqlRow, t.sch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if withDefaults {
+		if r, err = row.ApplyDefaults(r, t.sch); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if isValid, err := row.IsValid(r, t.sch); err != nil {
+		return nil, nil, err
+	} else if !isValid {
+		col, constraint, err := row.GetInvalidConstraint(r, t.sch)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, fmt.Errorf(constraintFailedFmt, col.Name, constraint)
+	}
+
+	key, err := r.NomsMapKey(t.sch).Value(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	val, err := r.NomsMapValue(t.sch).Value(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, val, nil
+}
+
+// editor returns the editor of the table's rows for the current statement, creating it on the first write. The table
+// is added to the database's tables with edits, which are written to the database's root when it's flushed.
+func (t *DoltTable) editor(ctx context.Context) (*rowEditor, error) {
+	if t.db.readOnly {
+		return nil, ErrReadOnlyDatabase
+	}
+
+	if t.ed == nil {
+		rowData, err := t.table.GetRowData(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		t.ed = newRowEditor(rowData)
+		t.db.edited = append(t.db.edited, t)
+	}
+
+	return t.ed, nil
+}
+
+// flush writes the edits made to the table's rows back to the table, and the table back to the database's root value.
+// The row map is rebuilt once for all the edits.
+func (t *DoltTable) flush(ctx context.Context) error {
+	if t.ed == nil {
+		return nil
+	}
+
+	rowData, err := t.ed.ed.Map(ctx)
+	t.ed = nil
+
+	if err != nil {
+		return err
+	}
+
+	updatedTable, err := t.table.UpdateRows(ctx, rowData)
+	if err != nil {
+		return err
+	}
+
+	newRoot, err := t.db.root.PutTable(ctx, t.db.ddb, t.name, updatedTable)
+	if err != nil {
+		return err
+	}
+
+	t.table = updatedTable
+	t.db.root = newRoot
+
+	return nil
+}
+
+// rowEditor collects the edits made to a table's row map. It keeps track of the keys it has set and removed, as a
+// types.MapEditor can't be read from, so that writes see the rows written before them.
+type rowEditor struct {
+	rowData types.Map
+	ed      *types.MapEditor
+	// written holds whether each key set or removed is present after the edits
+	written map[hash.Hash]bool
+}
+
+func newRowEditor(rowData types.Map) *rowEditor {
+	return &rowEditor{rowData, rowData.Edit(), make(map[hash.Hash]bool)}
+}
+
+// has returns whether there is a row with the key given once the edits are applied.
+func (re *rowEditor) has(ctx context.Context, key types.Value) (bool, error) {
+	h, err := key.Hash(re.rowData.Format())
+	if err != nil {
+		return false, err
+	}
+
+	if present, ok := re.written[h]; ok {
+		return present, nil
+	}
+
+	return re.rowData.Has(ctx, key)
+}
+
+func (re *rowEditor) set(key, val types.Value) error {
+	h, err := key.Hash(re.rowData.Format())
+	if err != nil {
+		return err
+	}
+
+	re.ed.Set(key, val)
+	re.written[h] = true
+
+	return nil
+}
+
+func (re *rowEditor) remove(key types.Value) error {
+	h, err := key.Hash(re.rowData.Format())
+	if err != nil {
+		return err
+	}
+
+	re.ed.Remove(key)
+	re.written[h] = false
+
+	return nil
+}
+
 // doltTablePartitionIter, an object that knows how to return the single partition exactly once.
 type doltTablePartitionIter struct {
 	sql.PartitionIter
@@ -16,10 +16,13 @@ package sqle
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/src-d/go-mysql-server/sql"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -38,11 +41,27 @@ func nomsTypeToSqlType(kind types.NomsKind) sql.Type {
 		return sql.Int64
 	case types.UintKind:
 		return sql.Uint64
+	case types.TimestampKind:
+		return sql.Timestamp
+	case types.DecimalKind:
+		// there is no exact decimal type in the SQL engine, so decimals are given as their exact text rather than as floats,
+		// which would round them
+		return sql.Text
 	default:
 		panic(fmt.Sprintf("Unexpected kind %v", kind))
 	}
 }
 
+// doltColToSqlType returns the SQL type of the dolt column given, which depends on the column's type params as well as
+// its kind for date columns.
+func doltColToSqlType(col schema.Column) sql.Type {
+	if col.Kind == types.TimestampKind && col.TypeParams[schema.SQLTypeParam] == schema.DateSQLType {
+		return sql.Date
+	}
+
+	return nomsTypeToSqlType(col.Kind)
+}
+
 func SqlTypeToNomsKind(t sql.Type) types.NomsKind {
 	switch t {
 	case sql.Boolean:
@@ -56,6 +75,8 @@ func SqlTypeToNomsKind(t sql.Type) types.NomsKind {
 		return types.IntKind
 	case sql.Uint64:
 		return types.UintKind
+	case sql.Timestamp, sql.Date:
+		return types.TimestampKind
 	default:
 		panic(fmt.Sprintf("Unexpected type %v", t))
 	}
@@ -75,6 +96,10 @@ func nomsValToSqlVal(val types.Value) interface{} {
 		return convertInt(val.(types.Int))
 	case types.UintKind:
 		return convertUint(val.(types.Uint))
+	case types.TimestampKind:
+		return convertTimestamp(val.(types.Timestamp))
+	case types.DecimalKind:
+		return convertDecimal(val.(types.Decimal))
 	default:
 		panic(fmt.Sprintf("Unexpected kind %v", val.Kind()))
 	}
@@ -117,11 +142,29 @@ func SqlValToNomsVal(val interface{}) types.Value {
 			return types.UUID(u)
 		}
 		return types.String(e)
+	case time.Time:
+		return types.NewTimestamp(e)
 	default:
 		panic(fmt.Sprintf("Unexpected type <%T> val <%v>", val, val))
 	}
 }
 
+// sqlValToNomsValOfKind converts the SQL value given to a noms value of the kind given, or returns an error if there is
+// no conversion between the two.
+func sqlValToNomsValOfKind(val interface{}, kind types.NomsKind) (types.Value, error) {
+	nomsVal := SqlValToNomsVal(val)
+	if nomsVal.Kind() == kind {
+		return nomsVal, nil
+	}
+
+	convFunc := doltcore.GetConvFunc(nomsVal.Kind(), kind)
+	if convFunc == nil {
+		return nil, fmt.Errorf("cannot convert %v to %v", types.KindToString[nomsVal.Kind()], types.KindToString[kind])
+	}
+
+	return convFunc(nomsVal)
+}
+
 func convertUUID(u types.UUID) interface{} {
 	return u.String()
 }
@@ -145,3 +188,11 @@ func convertFloat(f types.Float) interface{} {
 func convertBool(b types.Bool) interface{} {
 	return bool(b)
 }
+
+func convertTimestamp(t types.Timestamp) interface{} {
+	return time.Time(t)
+}
+
+func convertDecimal(d types.Decimal) interface{} {
+	return d.String()
+}
@@ -44,6 +44,10 @@ func StringToValue(s string, kind types.NomsKind) (types.Value, error) {
 		return stringToUint(s)
 	case types.UUIDKind:
 		return stringToUUID(s)
+	case types.TimestampKind:
+		return stringToTimestamp(s)
+	case types.DecimalKind:
+		return stringToDecimal(s)
 	case types.NullKind:
 		return types.NullValue, nil
 	}
@@ -120,3 +124,31 @@ func stringToUUID(s string) (types.Value, error) {
 
 	return types.UUID(u), nil
 }
+
+func stringToTimestamp(s string) (types.Value, error) {
+	if len(s) == 0 {
+		return types.NullValue, nil
+	}
+
+	t, err := types.ParseTimestamp(s)
+
+	if err != nil {
+		return types.Timestamp{}, ConversionError{types.StringKind, types.TimestampKind, err}
+	}
+
+	return t, nil
+}
+
+func stringToDecimal(s string) (types.Value, error) {
+	if len(s) == 0 {
+		return types.NullValue, nil
+	}
+
+	d, err := types.ParseDecimal(s)
+
+	if err != nil {
+		return types.Decimal{}, ConversionError{types.StringKind, types.DecimalKind, err}
+	}
+
+	return d, nil
+}
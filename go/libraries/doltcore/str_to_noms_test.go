@@ -15,7 +15,9 @@
 package doltcore
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -44,6 +46,10 @@ var tests = []strToNomsTypeTests{
 		false},
 	{"0", types.UintKind, types.Uint(0), false},
 	{"", types.NullKind, types.NullValue, false},
+	{"2019-08-01", types.TimestampKind, types.NewTimestamp(time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)), false},
+	{"2019-08-01 12:30:00.25", types.TimestampKind, types.NewTimestamp(time.Date(2019, 8, 1, 12, 30, 0, 250000000, time.UTC)), false},
+	{"1.50", types.DecimalKind, types.NewDecimal(big.NewInt(150), 2), false},
+	{"", types.DecimalKind, types.NullValue, false},
 
 	{"test failure", types.FloatKind, nil, true},
 	{"test failure", types.BoolKind, nil, true},
@@ -53,6 +59,8 @@ var tests = []strToNomsTypeTests{
 	{"-1", types.UintKind, nil, true},
 	{"0123456789abcdeffedcba9876543210abc", types.UUIDKind, nil, true},
 	{"0", types.UUIDKind, nil, true},
+	{"08/01/2019", types.TimestampKind, nil, true},
+	{"1.5.0", types.DecimalKind, nil, true},
 }
 
 func TestStrConversion(t *testing.T) {
@@ -63,7 +71,7 @@ func TestStrConversion(t *testing.T) {
 			t.Errorf("Conversion of \"%s\" returned unexpected error: %v", test.s, err)
 		}
 
-		if err == nil && val != test.expVal {
+		if err == nil && !test.expVal.Equals(val) {
 			t.Errorf("Conversion of \"%s\" returned unexpected error: %v", test.s, err)
 		}
 	}
@@ -0,0 +1,181 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// JSONLinesReader is a TableReadCloser which reads rows from JSON Lines data, which has a JSON object for each row,
+// usually on a line of its own. The objects are decoded one at a time as rows are read, so the data is never held in
+// memory all at once.
+//
+// The fields of an object are read into the columns with the same names, and fields with no column are ignored. A
+// field holding an object is flattened, so that its fields are read into the columns named by their paths, such as
+// "address.city", unless there is a column with the field's own name, which is given the object's JSON text.
+type JSONLinesReader struct {
+	nbf    *types.NomsBinFormat
+	closer io.Closer
+	dec    *json.Decoder
+	sch    schema.Schema
+	count  int
+}
+
+// OpenJSONLinesReader opens a reader of the rows of the JSON Lines file at the path given.
+func OpenJSONLinesReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONLinesReader, error) {
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLinesReader(nbf, r, sch)
+}
+
+// NewJSONLinesReader creates a reader of the rows of the JSON Lines data read from the io.ReadCloser given.
+func NewJSONLinesReader(nbf *types.NomsBinFormat, r io.ReadCloser, sch schema.Schema) (*JSONLinesReader, error) {
+	if sch == nil {
+		return nil, errors.New("schema must be provided")
+	}
+
+	dec := json.NewDecoder(bufio.NewReaderSize(r, ReadBufSize))
+	// numbers are converted from their text so that large integers aren't rounded to floats
+	dec.UseNumber()
+
+	return &JSONLinesReader{nbf, r, dec, sch, 0}, nil
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (jsonr *JSONLinesReader) GetSchema() schema.Schema {
+	return jsonr.sch
+}
+
+// ReadRow reads a row from a table. If there is a bad row the returned error will be non nil, and calling IsBadRow(err)
+// will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row,
+// or fail.
+func (jsonr *JSONLinesReader) ReadRow(ctx context.Context) (row.Row, error) {
+	var obj map[string]interface{}
+	err := jsonr.dec.Decode(&obj)
+	jsonr.count++
+
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if _, ok := err.(*json.UnmarshalTypeError); ok {
+		// the decoder has read past the value which isn't an object, so reading can continue after it
+		return nil, table.NewBadRow(nil, fmt.Sprintf("row %d is not a JSON object", jsonr.count))
+	} else if err != nil {
+		return nil, err
+	}
+
+	taggedVals := make(row.TaggedValues)
+	err = setFields(jsonr.sch.GetAllCols(), taggedVals, "", obj)
+
+	if err != nil {
+		return nil, table.NewBadRow(nil, fmt.Sprintf("row %d: %v", jsonr.count, err))
+	}
+
+	r, err := row.New(jsonr.nbf, jsonr.sch, taggedVals)
+
+	if err != nil {
+		return nil, table.NewBadRow(nil, fmt.Sprintf("row %d: %v", jsonr.count, err))
+	}
+
+	return r, nil
+}
+
+// Close should release resources being held
+func (jsonr *JSONLinesReader) Close(ctx context.Context) error {
+	if jsonr.closer != nil {
+		err := jsonr.closer.Close()
+		jsonr.closer = nil
+
+		return err
+	}
+
+	return errors.New("already closed")
+}
+
+// setFields sets the values of the columns named by the fields of the object given, with the prefix given added to the
+// field names, flattening any objects in fields which don't have a column of their own.
+func setFields(cols *schema.ColCollection, taggedVals row.TaggedValues, prefix string, obj map[string]interface{}) error {
+	for k, v := range obj {
+		name := prefix + k
+		col, ok := cols.GetByName(name)
+
+		if nested, isObj := v.(map[string]interface{}); isObj && !ok {
+			err := setFields(cols, taggedVals, name+".", nested)
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		} else if !ok || v == nil {
+			continue
+		}
+
+		val, err := jsonToValue(v, col.Kind)
+
+		if err != nil {
+			return fmt.Errorf("invalid value for column '%s': %v", name, err)
+		}
+
+		taggedVals[col.Tag] = val
+	}
+
+	return nil
+}
+
+// jsonToValue converts a decoded JSON value to a noms value of the kind given. Objects and arrays are given as their
+// JSON text.
+func jsonToValue(v interface{}, kind types.NomsKind) (types.Value, error) {
+	var val types.Value
+	switch v := v.(type) {
+	case string:
+		val = types.String(v)
+	case json.Number:
+		val = types.String(v.String())
+	case bool:
+		val = types.Bool(v)
+	default:
+		data, err := json.Marshal(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		val = types.String(data)
+	}
+
+	convFunc := doltcore.GetConvFunc(val.Kind(), kind)
+
+	if convFunc == nil {
+		return nil, fmt.Errorf("cannot convert %v to %v", types.KindToString[val.Kind()], types.KindToString[kind])
+	}
+
+	return convFunc(val)
+}
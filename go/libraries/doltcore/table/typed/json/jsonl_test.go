@@ -0,0 +1,133 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var jsonlColColl, _ = schema.NewColCollection(
+	schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn("name", 1, types.StringKind, false),
+	schema.NewColumn("address.city", 2, types.StringKind, false),
+	schema.NewColumn("tags", 3, types.StringKind, false),
+	schema.NewColumn("big", 4, types.UintKind, false),
+)
+var jsonlSch = schema.SchemaFromCols(jsonlColColl)
+
+func newJSONLinesReader(t *testing.T, data string) *JSONLinesReader {
+	rd, err := NewJSONLinesReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader(data)), jsonlSch)
+	require.NoError(t, err)
+
+	return rd
+}
+
+func TestReadJSONLines(t *testing.T) {
+	data := `{"id": 1, "name": "Bill", "address": {"city": "Seattle", "zip": "98101"}, "big": 18446744073709551615}
+{"id": 2, "name": null, "tags": ["a", "b"], "other": {"x": 1}}
+
+{"id": 3, "address": "not an object"}`
+
+	rd := newJSONLinesReader(t, data)
+	rows, numBad, err := table.ReadAllRows(context.Background(), rd, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, numBad)
+	require.NoError(t, rd.Close(context.Background()))
+
+	expected := []row.TaggedValues{
+		{0: types.Int(1), 1: types.String("Bill"), 2: types.String("Seattle"), 4: types.Uint(18446744073709551615)},
+		{0: types.Int(2), 3: types.String(`["a","b"]`)},
+		{0: types.Int(3)},
+	}
+
+	require.Len(t, rows, len(expected))
+	for i, r := range rows {
+		expectedRow, err := row.New(types.Format_7_18, jsonlSch, expected[i])
+		require.NoError(t, err)
+		assert.True(t, row.AreEqual(expectedRow, r, jsonlSch), "row %d: %s", i, row.Fmt(context.Background(), r, jsonlSch))
+	}
+}
+
+func TestReadJSONLinesBadRows(t *testing.T) {
+	rd := newJSONLinesReader(t, "[1, 2]\n{\"id\": \"one\"}\n{\"id\": 3}\n{\"id\": ")
+
+	_, err := rd.ReadRow(context.Background())
+	assert.True(t, table.IsBadRow(err))
+
+	_, err = rd.ReadRow(context.Background())
+	assert.True(t, table.IsBadRow(err))
+
+	r, err := rd.ReadRow(context.Background())
+	require.NoError(t, err)
+	id, _ := r.GetColVal(0)
+	assert.Equal(t, types.Int(3), id)
+
+	// truncated data can't be read past, so it isn't a bad row
+	_, err = rd.ReadRow(context.Background())
+	assert.Error(t, err)
+	assert.False(t, table.IsBadRow(err))
+	assert.NotEqual(t, io.EOF, err)
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	wr, err := NewJSONLinesWriter(iohelp.NopWrCloser(&buf), jsonlSch)
+	require.NoError(t, err)
+
+	rows := []row.TaggedValues{
+		{0: types.Int(1), 1: types.String("Bill"), 2: types.String("Seattle")},
+		{0: types.Int(2), 4: types.Uint(18446744073709551615)},
+	}
+
+	for _, taggedVals := range rows {
+		r, err := row.New(types.Format_7_18, jsonlSch, taggedVals)
+		require.NoError(t, err)
+		require.NoError(t, wr.WriteRow(context.Background(), r))
+	}
+
+	require.NoError(t, wr.Close(context.Background()))
+	assert.Error(t, wr.Close(context.Background()))
+
+	expected := `{"address.city":"Seattle","id":1,"name":"Bill"}
+{"big":18446744073709551615,"id":2}
+`
+	assert.Equal(t, expected, buf.String())
+
+	// the rows written are read back unchanged
+	rd := newJSONLinesReader(t, buf.String())
+	readRows, _, err := table.ReadAllRows(context.Background(), rd, false)
+	require.NoError(t, err)
+	require.Len(t, readRows, len(rows))
+
+	for i, r := range readRows {
+		expectedRow, err := row.New(types.Format_7_18, jsonlSch, rows[i])
+		require.NoError(t, err)
+		assert.True(t, row.AreEqual(expectedRow, r, jsonlSch))
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+)
+
+// JSONLinesWriter is a TableWriteCloser which writes each row as a JSON object on a line of its own.
+type JSONLinesWriter struct {
+	closer io.Closer
+	bWr    *bufio.Writer
+	sch    schema.Schema
+}
+
+// OpenJSONLinesWriter opens a writer of JSON Lines to the file at the path given.
+func OpenJSONLinesWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*JSONLinesWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLinesWriter(wr, outSch)
+}
+
+// NewJSONLinesWriter creates a writer of JSON Lines to the io.WriteCloser given.
+func NewJSONLinesWriter(wr io.WriteCloser, outSch schema.Schema) (*JSONLinesWriter, error) {
+	return &JSONLinesWriter{wr, bufio.NewWriterSize(wr, WriteBufSize), outSch}, nil
+}
+
+// GetSchema gets the schema of the rows that this writer writes
+func (jsonw *JSONLinesWriter) GetSchema() schema.Schema {
+	return jsonw.sch
+}
+
+// WriteRow will write a row to a table
+func (jsonw *JSONLinesWriter) WriteRow(ctx context.Context, r row.Row) error {
+	data, err := rowToJSON(jsonw.sch, r)
+
+	if err != nil {
+		return err
+	}
+
+	err = iohelp.WriteAll(jsonw.bWr, data)
+
+	if err != nil {
+		return err
+	}
+
+	return jsonw.bWr.WriteByte('\n')
+}
+
+// Close should flush all writes, release resources being held
+func (jsonw *JSONLinesWriter) Close(ctx context.Context) error {
+	if jsonw.closer != nil {
+		errFl := jsonw.bWr.Flush()
+		errCl := jsonw.closer.Close()
+		jsonw.closer = nil
+
+		if errCl != nil {
+			return errCl
+		}
+
+		return errFl
+	}
+
+	return errors.New("already closed")
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -74,20 +75,10 @@ func (jsonw *JSONWriter) GetSchema() schema.Schema {
 
 // WriteRow will write a row to a table
 func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
-	allCols := jsonw.sch.GetAllCols()
-	colValMap := make(map[string]interface{}, allCols.Size())
-	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		val, ok := r.GetColVal(tag)
-		if ok && !types.IsNull(val) {
-			colValMap[col.Name] = val
-		}
-
-		return false, nil
-	})
+	data, err := rowToJSON(jsonw.sch, r)
 
-	data, err := marshalToJson(colValMap)
 	if err != nil {
-		return errors.New("marshaling did not work")
+		return err
 	}
 
 	if jsonw.rowsWritten != 0 {
@@ -130,6 +121,41 @@ func (jsonw *JSONWriter) Close(ctx context.Context) error {
 
 }
 
+// rowToJSON returns the JSON object encoding the non-null values of a row, keyed by column name.
+func rowToJSON(sch schema.Schema, r row.Row) ([]byte, error) {
+	allCols := sch.GetAllCols()
+	colValMap := make(map[string]interface{}, allCols.Size())
+	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if ok && !types.IsNull(val) {
+			switch val.Kind() {
+			case types.TimestampKind, types.DecimalKind:
+				// these kinds have no JSON encoding of their own, so they're written as strings
+				val, err = doltcore.GetConvFunc(val.Kind(), types.StringKind)(val)
+
+				if err != nil {
+					return true, err
+				}
+			}
+
+			colValMap[col.Name] = val
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := marshalToJson(colValMap)
+	if err != nil {
+		return nil, errors.New("marshaling did not work")
+	}
+
+	return data, nil
+}
+
 func marshalToJson(valMap interface{}) ([]byte, error) {
 	var jsonBytes []byte
 	var err error
@@ -0,0 +1,150 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noms
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
+	"github.com/liquidata-inc/dolt/go/store/types"
+	"github.com/liquidata-inc/dolt/go/store/types/edits"
+)
+
+// NomsMapReplacer is a TableWriter that replaces the contents of a noms types.Map with the rows written to it. Rows of
+// the original map which aren't written are deleted, and because the new map is built from the sorted rows, rows which
+// are written unchanged are stored in the same chunks as before. Once all rows are written Close() should be called and
+// GetMap will then return the new map.
+type NomsMapReplacer struct {
+	sch     schema.Schema
+	vrw     types.ValueReadWriter
+	m       types.Map
+	statsCB StatsCB
+
+	count int64
+	acc   *edits.AsyncSortedEdits
+
+	result *types.Map
+}
+
+// NewNomsMapReplacer creates a new NomsMapReplacer for a given map.
+func NewNomsMapReplacer(ctx context.Context, vrw types.ValueReadWriter, m types.Map, sch schema.Schema, statsCB StatsCB) *NomsMapReplacer {
+	if sch.GetPKCols().Size() == 0 {
+		panic("NomsMapReplacer requires a schema with a primary key.")
+	}
+
+	acc := edits.NewAsyncSortedEdits(vrw.Format(), 16*1024, 4, 2)
+	return &NomsMapReplacer{sch, vrw, m, statsCB, 0, acc, nil}
+}
+
+// GetSchema gets the schema of the rows that this writer writes
+func (nmr *NomsMapReplacer) GetSchema() schema.Schema {
+	return nmr.sch
+}
+
+// WriteRow will write a row to a table
+func (nmr *NomsMapReplacer) WriteRow(ctx context.Context, r row.Row) error {
+	if nmr.acc == nil {
+		return errors.New("Attempting to write after closing.")
+	}
+
+	nmr.acc.AddEdit(r.NomsMapKey(nmr.sch), r.NomsMapValue(nmr.sch))
+	nmr.count++
+
+	return nil
+}
+
+// Close should flush all writes, release resources being held
+func (nmr *NomsMapReplacer) Close(ctx context.Context) error {
+	if nmr.result != nil {
+		return errors.New("Already closed.")
+	} else if nmr.acc == nil {
+		return errors.New("Attempting to close after a failed close.")
+	}
+
+	sorted, err := nmr.acc.FinishedEditing()
+	nmr.acc = nil
+
+	if err != nil {
+		return err
+	}
+
+	m, err := types.NewMap(ctx, nmr.vrw)
+
+	if err != nil {
+		return err
+	}
+
+	m, _, err = types.ApplyEdits(ctx, sorted, m)
+
+	if err != nil {
+		return err
+	}
+
+	stats, err := replacementStats(ctx, nmr.m, m)
+
+	if err != nil {
+		return err
+	}
+
+	stats.SameVal = nmr.count - stats.Additions - stats.Modifications
+
+	if nmr.statsCB != nil {
+		nmr.statsCB(stats)
+	}
+
+	nmr.result = &m
+	return nil
+}
+
+// replacementStats returns the number of rows added, modified and deleted by replacing the rows of one map with those
+// of another.
+func replacementStats(ctx context.Context, oldRows, newRows types.Map) (types.AppliedEditStats, error) {
+	var stats types.AppliedEditStats
+
+	ae := atomicerr.New()
+	changeChan, stopChan := make(chan types.ValueChanged, 32), make(chan struct{})
+
+	go func() {
+		defer close(changeChan)
+		newRows.Diff(ctx, oldRows, ae, changeChan, stopChan)
+	}()
+
+	for change := range changeChan {
+		switch change.ChangeType {
+		case types.DiffChangeAdded:
+			stats.Additions++
+		case types.DiffChangeModified:
+			stats.Modifications++
+		case types.DiffChangeRemoved:
+			stats.Deletions++
+		}
+	}
+
+	close(stopChan)
+
+	if err := ae.Get(); err != nil {
+		return types.AppliedEditStats{}, err
+	}
+
+	return stats, nil
+}
+
+// GetMap retrieves the resulting types.Map once close is called
+func (nmr *NomsMapReplacer) GetMap() *types.Map {
+	return nmr.result
+}
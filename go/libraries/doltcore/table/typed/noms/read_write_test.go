@@ -99,6 +99,27 @@ func TestReadWrite(t *testing.T) {
 	testReadAndCompare(t, updatedMap, expectedRows)
 }
 
+func TestReplace(t *testing.T) {
+	db, _ := dbfactory.MemFactory{}.CreateDB(context.Background(), types.Format_7_18, nil, nil)
+
+	rows := createRows(t, false, false)
+	initialMapVal := testNomsMapCreator(t, db, rows)
+
+	var stats types.AppliedEditStats
+	mr := NewNomsMapReplacer(context.Background(), db, *initialMapVal, sch, func(s types.AppliedEditStats) { stats = s })
+	replacedMap := testNomsWriteCloser(t, mr, createRows(t, true, true))
+
+	testReadAndCompare(t, replacedMap, createRows(t, true, true))
+	assert.Equal(t, types.AppliedEditStats{Modifications: 2, Deletions: 1}, stats)
+
+	// replacing the rows with the same rows gives the same map
+	mr = NewNomsMapReplacer(context.Background(), db, *replacedMap, sch, func(s types.AppliedEditStats) { stats = s })
+	sameMap := testNomsWriteCloser(t, mr, createRows(t, true, true))
+
+	assert.True(t, sameMap.Equals(*replacedMap))
+	assert.Equal(t, types.AppliedEditStats{SameVal: 2}, stats)
+}
+
 func testNomsMapCreator(t *testing.T, vrw types.ValueReadWriter, rows []row.Row) *types.Map {
 	mc := NewNomsMapCreator(context.Background(), vrw, sch)
 	return testNomsWriteCloser(t, mc, rows)
@@ -0,0 +1,19 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet provides TableReadCloser and TableWriteCloser implementations for working with parquet files.
+//
+// Only flat parquet schemas are supported, i.e. files whose columns are neither nested in groups nor repeated. Rows are
+// read a batch at a time, so only the pages of the row groups being read are held in memory.
+package parquet
@@ -0,0 +1,126 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/xitongsys/parquet-go/source"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var errReadOnly = errors.New("parquet file is open for reading")
+var errWriteOnly = errors.New("parquet file is open for writing")
+
+// readFile is a source.ParquetFile that reads a file from a filesys.ReadableFS. The parquet reader opens the file once
+// for each column it reads, so that the columns can be read independently.
+type readFile struct {
+	path string
+	fs   filesys.ReadableFS
+	rd   io.ReadSeeker
+	cl   io.Closer
+}
+
+var _ source.ParquetFile = (*readFile)(nil)
+
+func openReadFile(path string, fs filesys.ReadableFS) (*readFile, error) {
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		return &readFile{path, fs, rs, r}, nil
+	}
+
+	// parquet files are read from their footer backwards, so a file system that can't seek has to be read in full
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &readFile{path, fs, bytes.NewReader(data), ioutil.NopCloser(nil)}, nil
+}
+
+// Open opens another reader of the file, or of the file with the name given relative to the file's directory, which
+// parquet files can use to store column chunks in other files.
+func (f *readFile) Open(name string) (source.ParquetFile, error) {
+	if name == "" {
+		name = f.path
+	} else {
+		name = filepath.Join(filepath.Dir(f.path), name)
+	}
+
+	return openReadFile(name, f.fs)
+}
+
+func (f *readFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errReadOnly
+}
+
+func (f *readFile) Read(p []byte) (int, error) {
+	return f.rd.Read(p)
+}
+
+func (f *readFile) Seek(offset int64, whence int) (int64, error) {
+	return f.rd.Seek(offset, whence)
+}
+
+func (f *readFile) Write(p []byte) (int, error) {
+	return 0, errReadOnly
+}
+
+func (f *readFile) Close() error {
+	return f.cl.Close()
+}
+
+// writeFile is a source.ParquetFile that writes a parquet file sequentially to an io.WriteCloser.
+type writeFile struct {
+	wr io.WriteCloser
+}
+
+var _ source.ParquetFile = (*writeFile)(nil)
+
+func (f *writeFile) Open(name string) (source.ParquetFile, error) {
+	return nil, errWriteOnly
+}
+
+func (f *writeFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errWriteOnly
+}
+
+func (f *writeFile) Read(p []byte) (int, error) {
+	return 0, errWriteOnly
+}
+
+func (f *writeFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, errWriteOnly
+}
+
+func (f *writeFile) Write(p []byte) (int, error) {
+	return f.wr.Write(p)
+}
+
+func (f *writeFile) Close() error {
+	return f.wr.Close()
+}
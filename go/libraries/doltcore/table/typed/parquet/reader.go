@@ -0,0 +1,148 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ReadBatchSize is the number of rows read from each column of the file at a time
+var ReadBatchSize = 1024
+
+// ParquetReader is a TableReadCloser which reads the rows of a parquet file. The schema of the rows is inferred from the
+// parquet schema of the file.
+type ParquetReader struct {
+	nbf     *types.NomsBinFormat
+	file    *readFile
+	pRd     *reader.ParquetReader
+	sch     schema.Schema
+	pCols   []parquetCol
+	numRows int64
+	rowsRd  int64
+	batch   [][]interface{}
+	ind     int
+}
+
+// OpenParquetReader opens a reader of the parquet file at the path given.
+func OpenParquetReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS) (*ParquetReader, error) {
+	file, err := openReadFile(path, fs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pRd, err := reader.NewParquetColumnReader(file, 1)
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	sch, pCols, err := schemaFromParquet(pRd.SchemaHandler)
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &ParquetReader{nbf: nbf, file: file, pRd: pRd, sch: sch, pCols: pCols, numRows: pRd.GetNumRows()}, nil
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (pr *ParquetReader) GetSchema() schema.Schema {
+	return pr.sch
+}
+
+// ReadRow reads a row from a table. If there is a bad row the returned error will be non nil, and calling IsBadRow(err)
+// will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row,
+// or fail.
+func (pr *ParquetReader) ReadRow(ctx context.Context) (row.Row, error) {
+	if pr.batch == nil || pr.ind == len(pr.batch[0]) {
+		err := pr.readBatch()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	taggedVals := make(row.TaggedValues, len(pr.pCols))
+	for i, pc := range pr.pCols {
+		val, err := fromParquet(pc, pr.batch[i][pr.ind])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if val != nil {
+			taggedVals[pc.tag] = val
+		}
+	}
+
+	pr.ind++
+
+	return row.New(pr.nbf, pr.sch, taggedVals)
+}
+
+// readBatch reads the values of the next ReadBatchSize rows of each column, or returns io.EOF if all rows have been read
+func (pr *ParquetReader) readBatch() error {
+	num := pr.numRows - pr.rowsRd
+
+	if num <= 0 {
+		return io.EOF
+	} else if num > int64(ReadBatchSize) {
+		num = int64(ReadBatchSize)
+	}
+
+	batch := make([][]interface{}, len(pr.pCols))
+	for i := range pr.pCols {
+		vals, _, _, err := pr.pRd.ReadColumnByIndex(int64(i), num)
+
+		if err != nil {
+			return err
+		} else if int64(len(vals)) != num {
+			return errors.New("parquet column '" + pr.pCols[i].name + "' has fewer values than the file has rows")
+		}
+
+		batch[i] = vals
+	}
+
+	pr.batch = batch
+	pr.ind = 0
+	pr.rowsRd += num
+
+	return nil
+}
+
+// Close should release resources being held
+func (pr *ParquetReader) Close(ctx context.Context) error {
+	if pr.file != nil {
+		pr.pRd.ReadStop()
+		err := pr.file.Close()
+		pr.file = nil
+
+		return err
+	}
+
+	return errors.New("already closed")
+}
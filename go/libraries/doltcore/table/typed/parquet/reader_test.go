@@ -0,0 +1,157 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	idTag uint64 = iota
+	nameTag
+	activeTag
+	scoreTag
+	balanceTag
+	visitsTag
+	createdTag
+	birthdayTag
+	tokenTag
+)
+
+func testSchema() schema.Schema {
+	colColl, _ := schema.NewColCollection(
+		schema.NewColumn("id", idTag, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", nameTag, types.StringKind, false),
+		schema.NewColumn("active", activeTag, types.BoolKind, false),
+		schema.NewColumn("score", scoreTag, types.FloatKind, false),
+		schema.NewColumnWithTypeParams("balance", balanceTag, types.DecimalKind, false,
+			map[string]string{schema.PrecisionParam: "10", schema.ScaleParam: "2"}),
+		schema.NewColumn("visits", visitsTag, types.UintKind, false),
+		schema.NewColumn("created", createdTag, types.TimestampKind, false),
+		schema.NewColumnWithTypeParams("birthday", birthdayTag, types.TimestampKind, false,
+			map[string]string{schema.SQLTypeParam: schema.DateSQLType}),
+		schema.NewColumn("token", tokenTag, types.UUIDKind, false),
+	)
+
+	return schema.SchemaFromCols(colColl)
+}
+
+func testRows(t *testing.T, sch schema.Schema, numRows int) []row.Row {
+	var rows []row.Row
+	for i := 0; i < numRows; i++ {
+		vals := row.TaggedValues{idTag: types.Int(i - numRows/2)}
+
+		// every third row has null values
+		if i%3 != 0 {
+			vals[nameTag] = types.String("name " + string('a'+rune(i%26)))
+			vals[activeTag] = types.Bool(i%2 == 0)
+			vals[scoreTag] = types.Float(float64(i) / 4)
+			vals[balanceTag] = types.NewDecimal(big.NewInt(int64(i*7919-50000)), 2)
+			vals[visitsTag] = types.Uint(uint64(i) * 1000000007)
+			vals[createdTag] = types.NewTimestamp(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * 1001 * time.Microsecond * 3600))
+			vals[birthdayTag] = types.NewTimestamp(time.Date(1950+i%100, 1+time.Month(i%12), 1+i%28, 0, 0, 0, 0, time.UTC))
+			vals[tokenTag] = types.UUID(uuid.NewSHA1(uuid.NameSpaceOID, []byte{byte(i)}))
+		}
+
+		r, err := row.New(types.Format_7_18, sch, vals)
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	return rows
+}
+
+func TestRoundTrip(t *testing.T) {
+	defer func(size int) { ReadBatchSize = size }(ReadBatchSize)
+	ReadBatchSize = 7
+
+	const path = "/data/file.parquet"
+	ctx := context.Background()
+	sch := testSchema()
+	rows := testRows(t, sch, 100)
+
+	fs := filesys.NewInMemFS(nil, nil, "/")
+	wr, err := OpenParquetWriter(path, fs, sch)
+	require.NoError(t, err)
+
+	for _, r := range rows {
+		require.NoError(t, wr.WriteRow(ctx, r))
+	}
+
+	require.NoError(t, wr.Close(ctx))
+	assert.Error(t, wr.Close(ctx))
+
+	rd, err := OpenParquetReader(types.Format_7_18, path, fs)
+	require.NoError(t, err)
+
+	// the schema read has the same columns, without a primary key
+	rdSch := rd.GetSchema()
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		rdCol, ok := rdSch.GetAllCols().GetByTag(tag)
+		require.True(t, ok)
+		assert.Equal(t, col.Name, rdCol.Name)
+		assert.Equal(t, col.Kind, rdCol.Kind)
+		assert.False(t, rdCol.IsPartOfPK)
+		assert.Equal(t, col.TypeParams, rdCol.TypeParams)
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	for _, r := range rows {
+		actual, err := rd.ReadRow(ctx)
+		require.NoError(t, err)
+		assert.True(t, row.AreEqual(r, actual, rdSch), "expected %s, got %s", row.Fmt(ctx, r, sch), row.Fmt(ctx, actual, rdSch))
+	}
+
+	_, err = rd.ReadRow(ctx)
+	assert.Equal(t, io.EOF, err)
+
+	require.NoError(t, rd.Close(ctx))
+	assert.Error(t, rd.Close(ctx))
+}
+
+func TestUnwritableSchemas(t *testing.T) {
+	for _, cols := range [][]schema.Column{
+		{schema.NewColumn("a.b", 0, types.IntKind, true)},
+		{schema.NewColumn("name", 0, types.IntKind, true), schema.NewColumn("Name", 1, types.IntKind, false)},
+	} {
+		colColl, err := schema.NewColCollection(cols...)
+		require.NoError(t, err)
+
+		fs := filesys.NewInMemFS(nil, nil, "/")
+		_, err = OpenParquetWriter("/file.parquet", fs, schema.SchemaFromCols(colColl))
+		assert.Error(t, err)
+	}
+}
+
+func TestDecimalBytes(t *testing.T) {
+	for _, i := range []int64{0, 1, -1, 127, 128, -128, -129, 255, 256, -32768, 1 << 40, -(1 << 40)} {
+		b := bigIntToBytes(big.NewInt(i))
+		assert.Equal(t, i, bytesToBigInt(b).Int64(), "%d encoded as %x", i, b)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/common"
+	pqt "github.com/xitongsys/parquet-go/parquet"
+	pqschema "github.com/xitongsys/parquet-go/schema"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// rootName is the name of the root group of the parquet schemas written
+const rootName = "schema"
+
+// parquetCol describes how the values of a column are stored in a parquet file
+type parquetCol struct {
+	name string
+	tag  uint64
+	// typ is the physical type of the column's values
+	typ pqt.Type
+	// convType is the converted type of the column, which says how the physical values are interpreted, or nil
+	convType *pqt.ConvertedType
+	// logType is the logical type of the column, for the logical types that have no converted type, or nil
+	logType *pqt.LogicalType
+	// scale is the scale of a decimal column
+	scale int32
+}
+
+func (pc parquetCol) is(convType pqt.ConvertedType) bool {
+	return pc.convType != nil && *pc.convType == convType
+}
+
+// isDate returns whether the timestamp column given only holds dates.
+func isDate(col schema.Column) bool {
+	return col.TypeParams[schema.SQLTypeParam] == schema.DateSQLType
+}
+
+// schemaFromParquet returns the schema of the rows of a parquet file with the schema given, along with the way the
+// values of each of its columns are stored. Column tags are assigned in order. Parquet files have no primary key, so no
+// column is part of the primary key, which must be given when creating a table from the file. Returns an error if the
+// schema has nested or repeated columns.
+func schemaFromParquet(sh *pqschema.SchemaHandler) (schema.Schema, []parquetCol, error) {
+	var cols []schema.Column
+	var pCols []parquetCol
+	for i, el := range sh.SchemaElements[1:] {
+		// the reader renames the schema elements to their internal names, so the names are read from the infos
+		name := sh.Infos[i+1].ExName
+
+		if el.GetNumChildren() > 0 || el.GetRepetitionType() == pqt.FieldRepetitionType_REPEATED {
+			return nil, nil, fmt.Errorf("column '%s' is nested or repeated, which is not supported", name)
+		}
+
+		kind, typeParams, err := kindFromParquet(el)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("column '%s': %s", name, err.Error())
+		}
+
+		tag := uint64(len(cols))
+		cols = append(cols, schema.NewColumnWithTypeParams(name, tag, kind, false, typeParams))
+		pCols = append(pCols, parquetCol{name, tag, el.GetType(), el.ConvertedType, el.LogicalType, el.GetScale()})
+	}
+
+	if len(cols) == 0 {
+		return nil, nil, fmt.Errorf("parquet file has no columns")
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schema.UnkeyedSchemaFromCols(colColl), pCols, nil
+}
+
+func kindFromParquet(el *pqt.SchemaElement) (types.NomsKind, map[string]string, error) {
+	if el.ConvertedType != nil {
+		switch el.GetConvertedType() {
+		case pqt.ConvertedType_UINT_8, pqt.ConvertedType_UINT_16, pqt.ConvertedType_UINT_32, pqt.ConvertedType_UINT_64:
+			return types.UintKind, nil, nil
+		case pqt.ConvertedType_DATE:
+			return types.TimestampKind, map[string]string{schema.SQLTypeParam: schema.DateSQLType}, nil
+		case pqt.ConvertedType_TIMESTAMP_MILLIS, pqt.ConvertedType_TIMESTAMP_MICROS:
+			return types.TimestampKind, nil, nil
+		case pqt.ConvertedType_DECIMAL:
+			return types.DecimalKind, map[string]string{
+				schema.PrecisionParam: strconv.Itoa(int(el.GetPrecision())),
+				schema.ScaleParam:     strconv.Itoa(int(el.GetScale())),
+			}, nil
+		case pqt.ConvertedType_INTERVAL:
+			return types.NullKind, nil, fmt.Errorf("parquet type %s is not supported", el.GetConvertedType())
+		}
+	} else if lt := el.LogicalType; lt != nil {
+		switch {
+		case lt.UUID != nil:
+			return types.UUIDKind, nil, nil
+		case lt.TIMESTAMP != nil:
+			return types.TimestampKind, nil, nil
+		case lt.INTEGER != nil && !lt.INTEGER.IsSigned:
+			return types.UintKind, nil, nil
+		}
+	}
+
+	switch el.GetType() {
+	case pqt.Type_BOOLEAN:
+		return types.BoolKind, nil, nil
+	case pqt.Type_INT32, pqt.Type_INT64:
+		return types.IntKind, nil, nil
+	case pqt.Type_INT96:
+		// INT96 is only used for the timestamps written by older versions of Impala, Hive and Spark
+		return types.TimestampKind, nil, nil
+	case pqt.Type_FLOAT, pqt.Type_DOUBLE:
+		return types.FloatKind, nil, nil
+	default:
+		return types.StringKind, nil, nil
+	}
+}
+
+// parquetColsFromSchema returns the way the values of each of the columns of the schema given are stored in the parquet
+// files written. Decimal columns with a precision and scale are stored as parquet decimals, and other decimal columns
+// are stored as strings, as parquet decimals must have a fixed scale.
+func parquetColsFromSchema(sch schema.Schema) ([]parquetCol, error) {
+	var pCols []parquetCol
+	inNames := set.NewStrSet(nil)
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		// the parquet library uses column names as paths separated by dots, with the first letter upper cased
+		inName := common.HeadToUpper(col.Name)
+		if strings.Contains(col.Name, ".") {
+			return true, fmt.Errorf("column '%s' can't be written to a parquet file as its name contains a '.'", col.Name)
+		} else if inNames.Contains(inName) {
+			return true, fmt.Errorf("column '%s' can't be written to a parquet file as its name only differs from another column's by the case of its first letter", col.Name)
+		}
+
+		inNames.Add(inName)
+
+		pc := parquetCol{name: col.Name, tag: tag}
+		switch col.Kind {
+		case types.BoolKind:
+			pc.typ = pqt.Type_BOOLEAN
+		case types.IntKind:
+			pc.typ, pc.convType = pqt.Type_INT64, pqt.ConvertedTypePtr(pqt.ConvertedType_INT_64)
+		case types.UintKind:
+			pc.typ, pc.convType = pqt.Type_INT64, pqt.ConvertedTypePtr(pqt.ConvertedType_UINT_64)
+		case types.FloatKind:
+			pc.typ = pqt.Type_DOUBLE
+		case types.StringKind:
+			pc.typ, pc.convType = pqt.Type_BYTE_ARRAY, pqt.ConvertedTypePtr(pqt.ConvertedType_UTF8)
+		case types.UUIDKind:
+			pc.typ, pc.logType = pqt.Type_FIXED_LEN_BYTE_ARRAY, &pqt.LogicalType{UUID: pqt.NewUUIDType()}
+		case types.TimestampKind:
+			if isDate(col) {
+				pc.typ, pc.convType = pqt.Type_INT32, pqt.ConvertedTypePtr(pqt.ConvertedType_DATE)
+			} else {
+				pc.typ, pc.convType = pqt.Type_INT64, pqt.ConvertedTypePtr(pqt.ConvertedType_TIMESTAMP_MICROS)
+			}
+		case types.DecimalKind:
+			if _, ok := col.TypeParams[schema.PrecisionParam]; !ok {
+				pc.typ, pc.convType = pqt.Type_BYTE_ARRAY, pqt.ConvertedTypePtr(pqt.ConvertedType_UTF8)
+				break
+			}
+
+			scale, err := strconv.Atoi(col.TypeParams[schema.ScaleParam])
+
+			if err != nil {
+				return true, fmt.Errorf("column '%s' has an invalid scale", col.Name)
+			}
+
+			pc.typ, pc.convType = pqt.Type_BYTE_ARRAY, pqt.ConvertedTypePtr(pqt.ConvertedType_DECIMAL)
+			pc.scale = int32(scale)
+		default:
+			return true, fmt.Errorf("column '%s' of type %s can't be written to a parquet file", col.Name, col.KindString())
+		}
+
+		pCols = append(pCols, pc)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pCols, nil
+}
+
+// schemaElements returns the elements of the parquet schema of a file with the columns given.
+func schemaElements(sch schema.Schema, pCols []parquetCol) ([]*pqt.SchemaElement, error) {
+	root := pqt.NewSchemaElement()
+	root.Name = rootName
+	root.NumChildren = int32Ptr(int32(len(pCols)))
+	root.RepetitionType = pqt.FieldRepetitionTypePtr(pqt.FieldRepetitionType_REQUIRED)
+
+	elements := []*pqt.SchemaElement{root}
+	for _, pc := range pCols {
+		col, _ := sch.GetAllCols().GetByTag(pc.tag)
+
+		el := pqt.NewSchemaElement()
+		el.Name = pc.name
+		el.Type = pqt.TypePtr(pc.typ)
+		el.ConvertedType = pc.convType
+		el.LogicalType = pc.logType
+		// all columns are written as optional, including primary key columns, which is how the rows are marshaled
+		el.RepetitionType = pqt.FieldRepetitionTypePtr(pqt.FieldRepetitionType_OPTIONAL)
+
+		if pc.typ == pqt.Type_FIXED_LEN_BYTE_ARRAY {
+			el.TypeLength = int32Ptr(16)
+		}
+
+		if pc.is(pqt.ConvertedType_DECIMAL) {
+			precision, err := strconv.Atoi(col.TypeParams[schema.PrecisionParam])
+
+			if err != nil {
+				return nil, fmt.Errorf("column '%s' has an invalid precision", col.Name)
+			}
+
+			el.Precision = int32Ptr(int32(precision))
+			el.Scale = int32Ptr(pc.scale)
+		}
+
+		elements = append(elements, el)
+	}
+
+	return elements, nil
+}
+
+// setColumnInfos sets the type information the parquet library uses to encode the values of each column, which it only
+// sets itself when the schema is created from the tags of a struct or from CSV metadata.
+func setColumnInfos(sh *pqschema.SchemaHandler, elements []*pqt.SchemaElement) {
+	sh.Infos[0].RepetitionType = pqt.FieldRepetitionType_REQUIRED
+
+	for i, el := range elements[1:] {
+		info := sh.Infos[i+1]
+		info.RepetitionType = el.GetRepetitionType()
+		info.Type = el.GetType().String()
+
+		if el.ConvertedType != nil {
+			info.Type = el.GetConvertedType().String()
+			info.BaseType = el.GetType().String()
+		}
+
+		info.Length = el.GetTypeLength()
+		info.Scale = el.GetScale()
+		info.Precision = el.GetPrecision()
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
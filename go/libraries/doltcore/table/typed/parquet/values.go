@@ -0,0 +1,205 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	pqt "github.com/xitongsys/parquet-go/parquet"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const secsPerDay = 24 * 60 * 60
+
+// julianUnixEpoch is the julian day number of 1970-01-01, which INT96 timestamps count days from
+const julianUnixEpoch = 2440588
+
+// fromParquet converts a value read from a parquet column into a noms value, returning nil for null values.
+func fromParquet(pc parquetCol, val interface{}) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	switch v := val.(type) {
+	case bool:
+		return types.Bool(v), nil
+	case int32:
+		switch {
+		case pc.is(pqt.ConvertedType_DATE):
+			return types.NewTimestamp(time.Unix(int64(v)*secsPerDay, 0)), nil
+		case pc.is(pqt.ConvertedType_DECIMAL):
+			return types.NewDecimal(big.NewInt(int64(v)), pc.scale), nil
+		case pc.isUnsigned():
+			return types.Uint(uint32(v)), nil
+		}
+
+		return types.Int(v), nil
+	case int64:
+		if unit := pc.timestampUnit(); unit != 0 {
+			return types.NewTimestamp(time.Unix(v/int64(time.Second/unit), (v%int64(time.Second/unit))*int64(unit))), nil
+		}
+
+		switch {
+		case pc.is(pqt.ConvertedType_DECIMAL):
+			return types.NewDecimal(big.NewInt(v), pc.scale), nil
+		case pc.isUnsigned():
+			return types.Uint(uint64(v)), nil
+		}
+
+		return types.Int(v), nil
+	case float32:
+		return types.Float(v), nil
+	case float64:
+		return types.Float(v), nil
+	case string:
+		switch {
+		case pc.typ == pqt.Type_INT96:
+			return int96ToTimestamp(v)
+		case pc.is(pqt.ConvertedType_DECIMAL):
+			return types.NewDecimal(bytesToBigInt([]byte(v)), pc.scale), nil
+		case pc.logType != nil && pc.logType.UUID != nil && len(v) == 16:
+			var u types.UUID
+			copy(u[:], v)
+			return u, nil
+		}
+
+		return types.String(v), nil
+	}
+
+	return nil, fmt.Errorf("unexpected value '%v' in parquet column '%s'", val, pc.name)
+}
+
+// toParquet converts a noms value into the value to write to a parquet column, returning nil for null values.
+func toParquet(pc parquetCol, val types.Value) (interface{}, error) {
+	if types.IsNull(val) {
+		return nil, nil
+	}
+
+	switch v := val.(type) {
+	case types.Bool:
+		return bool(v), nil
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return int64(v), nil
+	case types.Float:
+		return float64(v), nil
+	case types.String:
+		return string(v), nil
+	case types.UUID:
+		return string(v[:]), nil
+	case types.Timestamp:
+		t := time.Time(v)
+		if pc.is(pqt.ConvertedType_DATE) {
+			days := t.Unix() / secsPerDay
+			if t.Unix()%secsPerDay < 0 {
+				days--
+			}
+
+			return int32(days), nil
+		}
+
+		return t.Unix()*int64(time.Second/time.Microsecond) + int64(t.Nanosecond())/int64(time.Microsecond), nil
+	case types.Decimal:
+		if pc.is(pqt.ConvertedType_DECIMAL) {
+			return string(bigIntToBytes(v.Round(pc.scale).Unscaled())), nil
+		}
+
+		return v.String(), nil
+	}
+
+	return nil, fmt.Errorf("value of type %s can't be written to parquet column '%s'", val.Kind(), pc.name)
+}
+
+func (pc parquetCol) isUnsigned() bool {
+	switch {
+	case pc.is(pqt.ConvertedType_UINT_8), pc.is(pqt.ConvertedType_UINT_16), pc.is(pqt.ConvertedType_UINT_32),
+		pc.is(pqt.ConvertedType_UINT_64):
+		return true
+	}
+
+	return pc.convType == nil && pc.logType != nil && pc.logType.INTEGER != nil && !pc.logType.INTEGER.IsSigned
+}
+
+// timestampUnit returns the unit of the values of a timestamp column, or 0 if the column doesn't hold timestamps.
+func (pc parquetCol) timestampUnit() time.Duration {
+	switch {
+	case pc.is(pqt.ConvertedType_TIMESTAMP_MILLIS):
+		return time.Millisecond
+	case pc.is(pqt.ConvertedType_TIMESTAMP_MICROS):
+		return time.Microsecond
+	case pc.convType == nil && pc.logType != nil && pc.logType.TIMESTAMP != nil:
+		unit := pc.logType.TIMESTAMP.Unit
+
+		switch {
+		case unit == nil:
+			break
+		case unit.IsSetMILLIS():
+			return time.Millisecond
+		case unit.IsSetMICROS():
+			return time.Microsecond
+		case unit.IsSetNANOS():
+			return time.Nanosecond
+		}
+	}
+
+	return 0
+}
+
+// int96ToTimestamp converts an INT96 timestamp, which is the number of nanoseconds since midnight followed by the julian
+// day number, both little endian, to a noms timestamp.
+func int96ToTimestamp(s string) (types.Value, error) {
+	if len(s) != 12 {
+		return nil, fmt.Errorf("invalid INT96 timestamp")
+	}
+
+	nanos := binary.LittleEndian.Uint64([]byte(s[:8]))
+	days := int64(binary.LittleEndian.Uint32([]byte(s[8:]))) - julianUnixEpoch
+
+	return types.NewTimestamp(time.Unix(days*secsPerDay, int64(nanos))), nil
+}
+
+// bytesToBigInt converts the big endian two's complement bytes of a parquet decimal to an integer.
+func bytesToBigInt(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+
+	return i
+}
+
+// bigIntToBytes converts an integer to the big endian two's complement bytes of a parquet decimal.
+func bigIntToBytes(i *big.Int) []byte {
+	if i.Sign() >= 0 {
+		b := i.Bytes()
+
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			// a leading zero byte is needed for the sign bit
+			b = append([]byte{0}, b...)
+		}
+
+		return b
+	}
+
+	// the two's complement of a negative number is 2^(8n) + i for the smallest n that leaves the sign bit set
+	n := (new(big.Int).Not(i).BitLen())/8 + 1
+	return new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), uint(n*8)), i).Bytes()
+}
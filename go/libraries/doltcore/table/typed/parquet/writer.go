@@ -0,0 +1,133 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/xitongsys/parquet-go/marshal"
+	pqschema "github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+// RowGroupSize is the approximate size in bytes of the row groups written, which are buffered in memory until they're
+// complete
+var RowGroupSize int64 = 64 * 1024 * 1024
+
+// ParquetWriter is a TableWriteCloser which writes rows to a parquet file. The columns of the file are optional, and have
+// the types that the kinds of the schema's columns are mapped to.
+type ParquetWriter struct {
+	file  *writeFile
+	pWr   *writer.ParquetWriter
+	sch   schema.Schema
+	pCols []parquetCol
+}
+
+// OpenParquetWriter creates the parquet file at the path given, and returns a writer of rows with the schema given to it.
+func OpenParquetWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*ParquetWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewParquetWriter(wr, outSch)
+}
+
+// NewParquetWriter returns a writer of rows with the schema given, which writes a parquet file to the io.WriteCloser given.
+func NewParquetWriter(wr io.WriteCloser, outSch schema.Schema) (*ParquetWriter, error) {
+	pCols, err := parquetColsFromSchema(outSch)
+
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+
+	elements, err := schemaElements(outSch, pCols)
+
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+
+	file := &writeFile{wr}
+	pWr, err := writer.NewParquetWriter(file, nil, 4)
+
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+
+	// rows are written as slices of column values, as the CSV writer of the parquet library writes them
+	pWr.SchemaHandler = pqschema.NewSchemaHandlerFromSchemaList(elements)
+	setColumnInfos(pWr.SchemaHandler, elements)
+	pWr.Footer.Schema = append(pWr.Footer.Schema, elements...)
+	pWr.MarshalFunc = marshal.MarshalCSV
+	pWr.RowGroupSize = RowGroupSize
+
+	return &ParquetWriter{file, pWr, outSch, pCols}, nil
+}
+
+// GetSchema gets the schema of the rows that this writer writes
+func (pw *ParquetWriter) GetSchema() schema.Schema {
+	return pw.sch
+}
+
+// WriteRow will write a row to a table
+func (pw *ParquetWriter) WriteRow(ctx context.Context, r row.Row) error {
+	vals := make([]interface{}, len(pw.pCols))
+	for i, pc := range pw.pCols {
+		val, _ := r.GetColVal(pc.tag)
+
+		var err error
+		vals[i], err = toParquet(pc, val)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return pw.pWr.Write(vals)
+}
+
+// Close should flush all writes, release resources being held
+func (pw *ParquetWriter) Close(ctx context.Context) error {
+	if pw.file != nil {
+		errSt := pw.pWr.WriteStop()
+		errCl := pw.file.Close()
+		pw.file = nil
+
+		if errSt != nil {
+			return errSt
+		}
+
+		return errCl
+	}
+
+	return errors.New("already closed")
+}
@@ -0,0 +1,299 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrNoInferredPK is returned by InferSchema when no primary key was given and no column has a unique value in every
+// row.
+var ErrNoInferredPK = errors.New("no column has a unique value in every row to use as the primary key")
+
+// inferredKinds are the kinds that columns can be inferred to have, in order of preference. Columns whose values can't
+// all be converted to one of them are strings.
+var inferredKinds = []types.NomsKind{types.IntKind, types.UintKind, types.FloatKind, types.BoolKind, types.UUIDKind}
+
+// colInference holds what has been learned about a column from the rows read so far.
+type colInference struct {
+	col schema.Column
+	// possible says whether each of inferredKinds is still possible for the column
+	possible []bool
+	hasNull  bool
+}
+
+func (ci *colInference) update(val types.Value) {
+	if types.IsNull(val) {
+		ci.hasNull = true
+		return
+	}
+
+	str := string(val.(types.String))
+	for i, kind := range inferredKinds {
+		if ci.possible[i] {
+			_, err := doltcore.StringToValue(str, kind)
+			ci.possible[i] = err == nil
+		}
+	}
+}
+
+func (ci *colInference) kind() types.NomsKind {
+	for i, kind := range inferredKinds {
+		if ci.possible[i] {
+			return kind
+		}
+	}
+
+	return types.StringKind
+}
+
+// InferSchema infers a typed schema for the untyped rows of a csv file. Each column is given the first of the int,
+// uint, float, bool and uuid kinds that all of its values can be converted to, or the string kind if there is none,
+// and is not null if none of its values are empty.
+//
+// The file is read twice using readers returned by openRd, once to infer the types of the columns and once to check
+// the primary key. If pkCols is empty, the first column with a unique, non-empty value in every row is chosen as the
+// primary key, and ErrNoInferredPK is returned if there is none. Otherwise the columns given must have a unique
+// combination of non-empty values in every row. Rows which can't be parsed are skipped.
+func InferSchema(ctx context.Context, nbf *types.NomsBinFormat, openRd func() (table.TableReadCloser, error), pkCols []string) (schema.Schema, error) {
+	rd, err := openRd()
+
+	if err != nil {
+		return nil, err
+	}
+
+	inSch := rd.GetSchema()
+	inferences := make([]*colInference, inSch.GetAllCols().Size())
+	for i := range inferences {
+		inferences[i] = &colInference{col: inSch.GetAllCols().GetByIndex(i), possible: make([]bool, len(inferredKinds))}
+
+		for j := range inferredKinds {
+			inferences[i].possible[j] = true
+		}
+	}
+
+	for _, pkCol := range pkCols {
+		if _, ok := inSch.GetAllCols().GetByName(pkCol); !ok {
+			rd.Close(ctx)
+			return nil, fmt.Errorf("primary key column '%s' is not in the file", pkCol)
+		}
+	}
+
+	err = readUntypedRows(ctx, rd, func(r row.Row) error {
+		for _, ci := range inferences {
+			val, _ := r.GetColVal(ci.col.Tag)
+			ci.update(val)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []schema.Column
+	for _, ci := range inferences {
+		col := schema.NewColumn(ci.col.Name, ci.col.Tag, ci.kind(), false)
+
+		if !ci.hasNull {
+			col.Constraints = []schema.ColConstraint{schema.NotNullConstraint{}}
+		}
+
+		cols = append(cols, col)
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkCols) == 0 {
+		pkCols, err = choosePK(ctx, nbf, openRd, colColl)
+	} else {
+		err = validatePK(ctx, nbf, openRd, colColl, pkCols)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cols {
+		for _, pkCol := range pkCols {
+			if cols[i].Name == pkCol {
+				cols[i].IsPartOfPK = true
+				cols[i].Constraints = []schema.ColConstraint{schema.NotNullConstraint{}}
+			}
+		}
+	}
+
+	colColl, err = schema.NewColCollection(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.SchemaFromCols(colColl), nil
+}
+
+// choosePK returns the name of the first of the columns given which is not null and has a unique value in every row.
+func choosePK(ctx context.Context, nbf *types.NomsBinFormat, openRd func() (table.TableReadCloser, error), colColl *schema.ColCollection) ([]string, error) {
+	var candidates []schema.Column
+	for _, col := range colColl.GetColumns() {
+		if !col.IsNullable() {
+			candidates = append(candidates, col)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoInferredPK
+	}
+
+	seen := make([]map[hash.Hash]bool, len(candidates))
+	for i := range seen {
+		seen[i] = make(map[hash.Hash]bool)
+	}
+
+	rd, err := openRd()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = readUntypedRows(ctx, rd, func(r row.Row) error {
+		for i, col := range candidates {
+			if seen[i] == nil {
+				continue
+			}
+
+			h, err := typedValueHash(nbf, r, col)
+
+			if err != nil {
+				return err
+			}
+
+			if seen[i][h] {
+				// the column has duplicate values so it can't be the primary key
+				seen[i] = nil
+			} else {
+				seen[i][h] = true
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, col := range candidates {
+		if seen[i] != nil {
+			return []string{col.Name}, nil
+		}
+	}
+
+	return nil, ErrNoInferredPK
+}
+
+// validatePK returns an error if the primary key columns given don't have a unique combination of non-null values in
+// every row.
+func validatePK(ctx context.Context, nbf *types.NomsBinFormat, openRd func() (table.TableReadCloser, error), colColl *schema.ColCollection, pkCols []string) error {
+	for _, pkCol := range pkCols {
+		if col, _ := colColl.GetByName(pkCol); col.IsNullable() {
+			return fmt.Errorf("primary key column '%s' is empty in some rows", pkCol)
+		}
+	}
+
+	rd, err := openRd()
+
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	return readUntypedRows(ctx, rd, func(r row.Row) error {
+		var key []byte
+		var vals []string
+		for _, pkCol := range pkCols {
+			col, _ := colColl.GetByName(pkCol)
+			h, err := typedValueHash(nbf, r, col)
+
+			if err != nil {
+				return err
+			}
+
+			key = append(key, h[:]...)
+
+			val, _ := r.GetColVal(col.Tag)
+			vals = append(vals, string(val.(types.String)))
+		}
+
+		if seen[string(key)] {
+			return fmt.Errorf("primary key (%s) has the duplicate value (%s)", strings.Join(pkCols, ", "), strings.Join(vals, ", "))
+		}
+
+		seen[string(key)] = true
+		return nil
+	})
+}
+
+// typedValueHash returns the hash of the value of the column given in an untyped row, converted to the column's kind,
+// so that values which are equal once converted, such as 1 and 01 for an int column, have the same hash.
+func typedValueHash(nbf *types.NomsBinFormat, r row.Row, col schema.Column) (hash.Hash, error) {
+	val, _ := r.GetColVal(col.Tag)
+	typedVal, err := doltcore.StringToValue(string(val.(types.String)), col.Kind)
+
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return typedVal.Hash(nbf)
+}
+
+// readUntypedRows calls cb with each row read from rd, skipping bad rows, and closes rd.
+func readUntypedRows(ctx context.Context, rd table.TableReadCloser, cb func(r row.Row) error) error {
+	defer rd.Close(ctx)
+
+	for {
+		r, err := rd.ReadRow(ctx)
+
+		if err == io.EOF {
+			return nil
+		} else if table.IsBadRow(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		err = cb(r)
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var InferenceDB = `id,name,age,score,active,token,big,code
+1,Bill Billerson,32,1.5,true,a3f1c0e2-4d5b-4b8a-9c1e-0f2d3e4a5b6c,18446744073709551615,007
+2,Rob Robertson,,2,false,b3f1c0e2-4d5b-4b8a-9c1e-0f2d3e4a5b6c,1,7
+3,John Johnson,21,-3.25,true,c3f1c0e2-4d5b-4b8a-9c1e-0f2d3e4a5b6c,2,x
+3,Bill Billerson,44,2,false,d3f1c0e2-4d5b-4b8a-9c1e-0f2d3e4a5b6c,3,8
+`
+
+func inferenceOpener(csvStr string) func() (table.TableReadCloser, error) {
+	return func() (table.TableReadCloser, error) {
+		return NewCSVReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader(csvStr)), NewCSVInfo())
+	}
+}
+
+func TestInferSchema(t *testing.T) {
+	ctx := context.Background()
+	sch, err := InferSchema(ctx, types.Format_7_18, inferenceOpener(InferenceDB), nil)
+	require.NoError(t, err)
+
+	expected := []struct {
+		name     string
+		kind     types.NomsKind
+		nullable bool
+		isPK     bool
+	}{
+		{"id", types.IntKind, false, false},
+		{"name", types.StringKind, false, false},
+		{"age", types.IntKind, true, false},
+		{"score", types.FloatKind, false, false},
+		{"active", types.BoolKind, false, false},
+		{"token", types.UUIDKind, false, true},
+		{"big", types.UintKind, false, false},
+		{"code", types.StringKind, false, false},
+	}
+
+	require.Equal(t, len(expected), sch.GetAllCols().Size())
+	for i, exp := range expected {
+		col := sch.GetAllCols().GetByIndex(i)
+		assert.Equal(t, exp.name, col.Name)
+		assert.Equal(t, uint64(i), col.Tag)
+		assert.Equal(t, exp.kind, col.Kind, "column %s", col.Name)
+		assert.Equal(t, exp.nullable, col.IsNullable(), "column %s", col.Name)
+		assert.Equal(t, exp.isPK, col.IsPartOfPK, "column %s", col.Name)
+	}
+}
+
+func TestInferSchemaPK(t *testing.T) {
+	tests := []struct {
+		csvStr   string
+		pkCols   []string
+		expected []string
+		expErr   bool
+	}{
+		{InferenceDB, []string{"big"}, []string{"big"}, false},
+		{InferenceDB, []string{"id", "name"}, []string{"id", "name"}, false},
+		{InferenceDB, []string{"id"}, nil, true},
+		{InferenceDB, []string{"age"}, nil, true},
+		{InferenceDB, []string{"missing"}, nil, true},
+		// 01 and 1 are the same int, so the first column isn't unique
+		{"a,b\n1,x\n01,y\n", nil, []string{"b"}, false},
+		{"a,b\n1,x\n01,y\n", []string{"a"}, nil, true},
+		{"a,b\n1,x\n1,x\n", nil, nil, true},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		sch, err := InferSchema(ctx, types.Format_7_18, inferenceOpener(test.csvStr), test.pkCols)
+
+		if test.expErr {
+			assert.Error(t, err, "pk %v", test.pkCols)
+			continue
+		}
+
+		require.NoError(t, err)
+
+		var pkCols []string
+		for _, col := range sch.GetPKCols().GetColumns() {
+			pkCols = append(pkCols, col.Name)
+			assert.False(t, col.IsNullable())
+		}
+
+		assert.Equal(t, test.expected, pkCols)
+	}
+
+	_, err := InferSchema(ctx, types.Format_7_18, inferenceOpener("a,b\n1,x\n1,x\n"), nil)
+	assert.Equal(t, ErrNoInferredPK, err)
+}
@@ -99,46 +99,7 @@ func (w *SqlExportWriter) Close(ctx context.Context) error {
 }
 
 func (w *SqlExportWriter) insertStatementForRow(r row.Row) (string, error) {
-	var b strings.Builder
-	b.WriteString("INSERT INTO ")
-	b.WriteString(sql.QuoteIdentifier(w.tableName))
-	b.WriteString(" ")
-
-	b.WriteString("(")
-	var seenOne bool
-	err := w.sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		if seenOne {
-			b.WriteRune(',')
-		}
-		b.WriteString(sql.QuoteIdentifier(col.Name))
-		seenOne = true
-		return false, nil
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	b.WriteString(")")
-
-	b.WriteString(" VALUES (")
-	seenOne = false
-	_, err = r.IterSchema(w.sch, func(tag uint64, val types.Value) (stop bool, err error) {
-		if seenOne {
-			b.WriteRune(',')
-		}
-		b.WriteString(w.sqlString(val))
-		seenOne = true
-		return false, nil
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	b.WriteString(");")
-
-	return b.String(), nil
+	return InsertStatement(w.tableName, w.sch, r)
 }
 
 func (w *SqlExportWriter) dropCreateStatement() string {
@@ -151,7 +112,7 @@ func (w *SqlExportWriter) dropCreateStatement() string {
 	return b.String()
 }
 
-func (w *SqlExportWriter) sqlString(value types.Value) string {
+func sqlString(value types.Value) string {
 	if types.IsNull(value) {
 		return "NULL"
 	}
@@ -163,7 +124,7 @@ func (w *SqlExportWriter) sqlString(value types.Value) string {
 		} else {
 			return "FALSE"
 		}
-	case types.UUIDKind:
+	case types.UUIDKind, types.TimestampKind:
 		convFn := doltcore.GetConvFunc(value.Kind(), types.StringKind)
 		str, _ := convFn(value)
 		return doubleQuot + string(str.(types.String)) + doubleQuot
@@ -0,0 +1,202 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexport
+
+import (
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// InsertStatement returns a SQL statement that inserts the row given into the table given.
+func InsertStatement(tableName string, sch schema.Schema, r row.Row) (string, error) {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(sql.QuoteIdentifier(tableName))
+	b.WriteString(" ")
+
+	b.WriteString("(")
+	var seenOne bool
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if seenOne {
+			b.WriteRune(',')
+		}
+		b.WriteString(sql.QuoteIdentifier(col.Name))
+		seenOne = true
+		return false, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(")")
+
+	b.WriteString(" VALUES (")
+	seenOne = false
+	_, err = r.IterSchema(sch, func(tag uint64, val types.Value) (stop bool, err error) {
+		if seenOne {
+			b.WriteRune(',')
+		}
+		b.WriteString(sqlString(val))
+		seenOne = true
+		return false, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(");")
+
+	return b.String(), nil
+}
+
+// UpdateStatement returns a SQL statement that sets the non primary key columns of the row with the primary key of
+// newR to their values in newR. Only the columns whose values differ between oldR and newR are set, and an empty
+// string is returned if there are none.
+func UpdateStatement(tableName string, sch schema.Schema, oldR, newR row.Row) (string, error) {
+	var b strings.Builder
+	b.WriteString("UPDATE ")
+	b.WriteString(sql.QuoteIdentifier(tableName))
+	b.WriteString(" SET ")
+
+	var seenOne bool
+	err := sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		oldVal, _ := oldR.GetColVal(tag)
+		newVal, _ := newR.GetColVal(tag)
+
+		if valuesEqual(oldVal, newVal) {
+			return false, nil
+		}
+
+		if seenOne {
+			b.WriteRune(',')
+		}
+		b.WriteString(sql.QuoteIdentifier(col.Name))
+		b.WriteRune('=')
+		b.WriteString(sqlString(newVal))
+		seenOne = true
+		return false, nil
+	})
+
+	if err != nil || !seenOne {
+		return "", err
+	}
+
+	where, err := wherePKClause(sch, newR)
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(where)
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// DeleteStatement returns a SQL statement that deletes the row with the primary key of the row given.
+func DeleteStatement(tableName string, sch schema.Schema, r row.Row) (string, error) {
+	var b strings.Builder
+	b.WriteString("DELETE FROM ")
+	b.WriteString(sql.QuoteIdentifier(tableName))
+
+	where, err := wherePKClause(sch, r)
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(where)
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// DropTableStatement returns a SQL statement that drops the table given.
+func DropTableStatement(tableName string) string {
+	return "DROP TABLE " + sql.QuoteIdentifier(tableName) + ";"
+}
+
+// AlterTableAddColumnStatement returns a SQL statement that adds the column given to the table given.
+func AlterTableAddColumnStatement(tableName string, col schema.Column) string {
+	return "ALTER TABLE " + sql.QuoteIdentifier(tableName) + " ADD COLUMN (" + sql.FmtCol(0, 0, 0, col) + ");"
+}
+
+// AlterTableDropColumnStatement returns a SQL statement that drops the column with the name given from the table given.
+func AlterTableDropColumnStatement(tableName, colName string) string {
+	return "ALTER TABLE " + sql.QuoteIdentifier(tableName) + " DROP COLUMN " + sql.QuoteIdentifier(colName) + ";"
+}
+
+// AlterTableRenameColumnStatement returns a SQL statement that renames a column of the table given.
+func AlterTableRenameColumnStatement(tableName, oldColName, newColName string) string {
+	return "ALTER TABLE " + sql.QuoteIdentifier(tableName) + " RENAME COLUMN " + sql.QuoteIdentifier(oldColName) +
+		" TO " + sql.QuoteIdentifier(newColName) + ";"
+}
+
+// AlterTableChangeColumnStatement returns a SQL statement that replaces the definition of the column with the name
+// given by the definition of the column given.
+func AlterTableChangeColumnStatement(tableName, oldColName string, col schema.Column) string {
+	return "ALTER TABLE " + sql.QuoteIdentifier(tableName) + " CHANGE COLUMN " + sql.QuoteIdentifier(oldColName) +
+		" " + sql.FmtCol(0, 0, 0, col) + ";"
+}
+
+// AlterTablePrimaryKeyStatement returns a SQL statement that changes the primary key of the table given to the primary
+// key of the schema given.
+func AlterTablePrimaryKeyStatement(tableName string, sch schema.Schema) string {
+	var pkNames []string
+	for _, col := range sch.GetPKCols().GetColumns() {
+		pkNames = append(pkNames, sql.QuoteIdentifier(col.Name))
+	}
+
+	return "ALTER TABLE " + sql.QuoteIdentifier(tableName) + " DROP PRIMARY KEY, ADD PRIMARY KEY (" + strings.Join(pkNames, ", ") + ");"
+}
+
+func wherePKClause(sch schema.Schema, r row.Row) (string, error) {
+	var b strings.Builder
+	b.WriteString(" WHERE ")
+
+	var seenOne bool
+	err := sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if seenOne {
+			b.WriteString(" AND ")
+		}
+
+		val, _ := r.GetColVal(tag)
+		b.WriteString(sql.QuoteIdentifier(col.Name))
+		b.WriteRune('=')
+		b.WriteString(sqlString(val))
+		seenOne = true
+		return false, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func valuesEqual(v1, v2 types.Value) bool {
+	if types.IsNull(v1) || types.IsNull(v2) {
+		return types.IsNull(v1) && types.IsNull(v2)
+	}
+
+	return v1.Equals(v2)
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestRowStatements(t *testing.T) {
+	sch := dtestutils.CreateSchema(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.NewColumn("age", 2, types.UintKind, false),
+	)
+
+	oldRow := dtestutils.NewRow(sch, types.Int(1), types.String("some guy"), types.Uint(100))
+	newRow := dtestutils.NewRow(sch, types.Int(1), types.String(`"Mister Perfect"`), types.Uint(100))
+
+	stmt, err := InsertStatement("people", sch, newRow)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `people` (`id`,`name`,`age`) VALUES (1,\"\\\"Mister Perfect\\\"\",100);", stmt)
+
+	stmt, err = UpdateStatement("people", sch, oldRow, newRow)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE `people` SET `name`=\"\\\"Mister Perfect\\\"\" WHERE `id`=1;", stmt)
+
+	stmt, err = UpdateStatement("people", sch, oldRow, oldRow)
+	require.NoError(t, err)
+	assert.Equal(t, "", stmt)
+
+	stmt, err = DeleteStatement("people", sch, oldRow)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `people` WHERE `id`=1;", stmt)
+}
+
+func TestSchemaStatements(t *testing.T) {
+	col := schema.NewColumn("age", 2, types.UintKind, false, schema.NotNullConstraint{})
+
+	tests := []struct {
+		name     string
+		stmt     string
+		expected string
+	}{
+		{"drop table", DropTableStatement("people"), "DROP TABLE `people`;"},
+		{"add column", AlterTableAddColumnStatement("people", col), "ALTER TABLE `people` ADD COLUMN (`age` int unsigned not null comment 'tag:2');"},
+		{"drop column", AlterTableDropColumnStatement("people", "age"), "ALTER TABLE `people` DROP COLUMN `age`;"},
+		{"rename column", AlterTableRenameColumnStatement("people", "old_age", "age"), "ALTER TABLE `people` RENAME COLUMN `old_age` TO `age`;"},
+		{"change column", AlterTableChangeColumnStatement("people", "old_age", col), "ALTER TABLE `people` CHANGE COLUMN `old_age` `age` int unsigned not null comment 'tag:2';"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.stmt)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlimport provides a TableReadCloser which reads the rows of a table from a file of SQL statements, such as a
+// dump written by mysqldump or by dolt table export.
+package sqlimport
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrNoCreateTable is returned when opening a reader without a schema if the file has no CREATE TABLE statement for the
+// table being read.
+var ErrNoCreateTable = errors.New("no CREATE TABLE statement for the table was found")
+
+// SqlImportReader is a TableReadCloser which reads the rows inserted into a table by the INSERT and REPLACE statements
+// of a file of SQL statements. All other statements, and statements for other tables, are ignored.
+type SqlImportReader struct {
+	nbf       *types.NomsBinFormat
+	closer    io.Closer
+	scanner   *bufio.Scanner
+	tableName string
+	sch       schema.Schema
+	rows      []row.Row
+}
+
+// OpenSQLImportReader opens a reader of the rows of the table named in the SQL file at the path given. If sch is nil the
+// schema of the rows is read from the table's CREATE TABLE statement.
+func OpenSQLImportReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS, tableName string, sch schema.Schema) (*SqlImportReader, error) {
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLImportReader(nbf, r, tableName, sch)
+}
+
+// NewSQLImportReader returns a reader of the rows of the table named in the SQL statements read from the
+// io.ReadCloser given. If sch is nil the schema of the rows is read from the table's CREATE TABLE statement.
+func NewSQLImportReader(nbf *types.NomsBinFormat, r io.ReadCloser, tableName string, sch schema.Schema) (*SqlImportReader, error) {
+	rd := &SqlImportReader{nbf: nbf, closer: r, scanner: sql.NewStatementScanner(r), tableName: tableName, sch: sch}
+
+	if sch == nil {
+		err := rd.readSchema()
+
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+
+	return rd, nil
+}
+
+// readSchema reads statements up to the table's CREATE TABLE statement, which comes before any rows are inserted into
+// it, and sets the reader's schema from it.
+func (rd *SqlImportReader) readSchema() error {
+	for rd.scanner.Scan() {
+		query := rd.scanner.Text()
+
+		if sqlparser.Preview(query) != sqlparser.StmtDDL {
+			continue
+		}
+
+		// the parser returns the names of tables created by statements it can't fully parse, so errors are only
+		// reported for the table being read
+		stmt, _ := sql.Parse(query)
+		ddl, ok := stmt.(*sqlparser.DDL)
+
+		if !ok || ddl.Action != sqlparser.CreateStr || ddl.Table.Name.String() != rd.tableName {
+			continue
+		}
+
+		_, sch, err := sql.SchemaFromCreateStatement(query)
+
+		if err != nil {
+			return fmt.Errorf("error in the CREATE TABLE statement for table '%s': %v", rd.tableName, err)
+		}
+
+		rd.sch = sch
+		return nil
+	}
+
+	if err := rd.scanner.Err(); err != nil {
+		return err
+	}
+
+	return ErrNoCreateTable
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (rd *SqlImportReader) GetSchema() schema.Schema {
+	return rd.sch
+}
+
+// ReadRow reads a row from a table. If there is a bad row the returned error will be non nil, and calling IsBadRow(err)
+// will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row,
+// or fail.
+func (rd *SqlImportReader) ReadRow(ctx context.Context) (row.Row, error) {
+	for len(rd.rows) == 0 {
+		if !rd.scanner.Scan() {
+			if err := rd.scanner.Err(); err != nil {
+				return nil, err
+			}
+
+			return nil, io.EOF
+		}
+
+		query := rd.scanner.Text()
+
+		if p := sqlparser.Preview(query); p != sqlparser.StmtInsert && p != sqlparser.StmtReplace {
+			continue
+		}
+
+		stmt, err := sql.Parse(query)
+
+		if err != nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("error parsing SQL statement: %v", err))
+		}
+
+		ins, ok := stmt.(*sqlparser.Insert)
+
+		if !ok || ins.Table.Name.String() != rd.tableName {
+			continue
+		}
+
+		rd.rows, err = sql.InsertRows(rd.nbf, ins, rd.sch)
+
+		if err != nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("error in INSERT statement for table '%s': %v", rd.tableName, err))
+		}
+	}
+
+	r := rd.rows[0]
+	rd.rows = rd.rows[1:]
+
+	return r, nil
+}
+
+// Close should release resources being held
+func (rd *SqlImportReader) Close(ctx context.Context) error {
+	if rd.closer != nil {
+		err := rd.closer.Close()
+		rd.closer = nil
+
+		return err
+	}
+
+	return errors.New("already closed")
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlimport
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const dump = "-- MySQL dump 10.13\n" +
+	"/*!40101 SET NAMES utf8mb4 */;\n" +
+	"DROP TABLE IF EXISTS `people`;\n" +
+	"CREATE TABLE `people` (\n" +
+	"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(255) NOT NULL,\n" +
+	"  `age` int unsigned DEFAULT NULL,\n" +
+	"  `active` tinyint(1) NOT NULL DEFAULT '1',\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  KEY `name_idx` (`name`)\n" +
+	") ENGINE=InnoDB AUTO_INCREMENT=4 DEFAULT CHARSET=utf8mb4;\n" +
+	"CREATE TABLE `pets` (`id` int NOT NULL, PRIMARY KEY (`id`));\n" +
+	"LOCK TABLES `people` WRITE;\n" +
+	"INSERT INTO `pets` VALUES (1),(2);\n" +
+	"INSERT INTO `people` VALUES (1,'Bill O\\'Billerson; Sr.',32,1),(2,'Rob',NULL,0);\n" +
+	"UNLOCK TABLES;\n" +
+	"INSERT INTO people (name, id) VALUES ('John', 3);\n"
+
+func readAll(t *testing.T, rd *SqlImportReader) []row.Row {
+	var rows []row.Row
+	for {
+		r, err := rd.ReadRow(context.Background())
+
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	require.NoError(t, rd.Close(context.Background()))
+	return rows
+}
+
+func TestReadDump(t *testing.T) {
+	rd, err := NewSQLImportReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader(dump)), "people", nil)
+	require.NoError(t, err)
+
+	sch := rd.GetSchema()
+	idCol, _ := sch.GetAllCols().GetByName("id")
+	assert.True(t, idCol.IsPartOfPK)
+	ageCol, _ := sch.GetAllCols().GetByName("age")
+	assert.Equal(t, types.UintKind, ageCol.Kind)
+
+	rows := readAll(t, rd)
+	require.Len(t, rows, 3)
+
+	expected := []row.TaggedValues{
+		{0: types.Int(1), 1: types.String("Bill O'Billerson; Sr."), 2: types.Uint(32), 3: types.Int(1)},
+		{0: types.Int(2), 1: types.String("Rob"), 3: types.Int(0)},
+		// the active column takes its default value
+		{0: types.Int(3), 1: types.String("John"), 3: types.Int(1)},
+	}
+
+	for i, r := range rows {
+		expectedRow, err := row.New(types.Format_7_18, sch, expected[i])
+		require.NoError(t, err)
+		assert.True(t, row.AreEqual(expectedRow, r, sch), "row %d: %s", i, row.Fmt(context.Background(), r, sch))
+	}
+}
+
+func TestReadDumpWithSchema(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("id", 7, types.IntKind, true),
+		schema.NewColumn("name", 8, types.StringKind, false),
+	)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	rd, err := NewSQLImportReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader("INSERT INTO people VALUES (1, 'a');")), "people", sch)
+	require.NoError(t, err)
+	assert.Equal(t, sch, rd.GetSchema())
+
+	rows := readAll(t, rd)
+	require.Len(t, rows, 1)
+	name, _ := rows[0].GetColVal(8)
+	assert.Equal(t, types.String("a"), name)
+}
+
+func TestReadDumpErrors(t *testing.T) {
+	_, err := NewSQLImportReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader(dump)), "missing", nil)
+	assert.Equal(t, ErrNoCreateTable, err)
+
+	_, err = NewSQLImportReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader("CREATE TABLE t (id int);")), "t", nil)
+	assert.Error(t, err)
+
+	badInsert := "CREATE TABLE t (id int primary key);\nINSERT INTO t VALUES ('x');\nINSERT INTO t VALUES (1);"
+	rd, err := NewSQLImportReader(types.Format_7_18, ioutil.NopCloser(strings.NewReader(badInsert)), "t", nil)
+	require.NoError(t, err)
+
+	_, err = rd.ReadRow(context.Background())
+	assert.True(t, table.IsBadRow(err))
+
+	// reading continues after a bad statement
+	r, err := rd.ReadRow(context.Background())
+	require.NoError(t, err)
+	id, _ := r.GetColVal(0)
+	assert.Equal(t, types.Int(1), id)
+}
@@ -16,6 +16,7 @@ package doltcore
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/liquidata-inc/dolt/go/store/types"
@@ -64,61 +65,95 @@ type ConvFunc func(types.Value) (types.Value, error)
 
 var convFuncMap = map[types.NomsKind]map[types.NomsKind]ConvFunc{
 	types.StringKind: {
-		types.StringKind: identityConvFunc,
-		types.UUIDKind:   convStringToUUID,
-		types.UintKind:   convStringToUint,
-		types.IntKind:    convStringToInt,
-		types.FloatKind:  convStringToFloat,
-		types.BoolKind:   convStringToBool,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    identityConvFunc,
+		types.UUIDKind:      convStringToUUID,
+		types.UintKind:      convStringToUint,
+		types.IntKind:       convStringToInt,
+		types.FloatKind:     convStringToFloat,
+		types.BoolKind:      convStringToBool,
+		types.TimestampKind: convStringToTimestamp,
+		types.DecimalKind:   convStringToDecimal,
+		types.NullKind:      convToNullFunc},
 	types.UUIDKind: {
-		types.StringKind: convUUIDToString,
-		types.UUIDKind:   identityConvFunc,
-		types.UintKind:   nil,
-		types.IntKind:    nil,
-		types.FloatKind:  nil,
-		types.BoolKind:   nil,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convUUIDToString,
+		types.UUIDKind:      identityConvFunc,
+		types.UintKind:      nil,
+		types.IntKind:       nil,
+		types.FloatKind:     nil,
+		types.BoolKind:      nil,
+		types.TimestampKind: nil,
+		types.DecimalKind:   nil,
+		types.NullKind:      convToNullFunc},
 	types.UintKind: {
-		types.StringKind: convUintToString,
-		types.UUIDKind:   nil,
-		types.UintKind:   identityConvFunc,
-		types.IntKind:    convUintToInt,
-		types.FloatKind:  convUintToFloat,
-		types.BoolKind:   convUintToBool,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convUintToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      identityConvFunc,
+		types.IntKind:       convUintToInt,
+		types.FloatKind:     convUintToFloat,
+		types.BoolKind:      convUintToBool,
+		types.TimestampKind: nil,
+		types.DecimalKind:   convUintToDecimal,
+		types.NullKind:      convToNullFunc},
 	types.IntKind: {
-		types.StringKind: convIntToString,
-		types.UUIDKind:   nil,
-		types.UintKind:   convIntToUint,
-		types.IntKind:    identityConvFunc,
-		types.FloatKind:  convIntToFloat,
-		types.BoolKind:   convIntToBool,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convIntToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      convIntToUint,
+		types.IntKind:       identityConvFunc,
+		types.FloatKind:     convIntToFloat,
+		types.BoolKind:      convIntToBool,
+		types.TimestampKind: nil,
+		types.DecimalKind:   convIntToDecimal,
+		types.NullKind:      convToNullFunc},
 	types.FloatKind: {
-		types.StringKind: convFloatToString,
-		types.UUIDKind:   nil,
-		types.UintKind:   convFloatToUint,
-		types.IntKind:    convFloatToInt,
-		types.FloatKind:  identityConvFunc,
-		types.BoolKind:   convFloatToBool,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convFloatToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      convFloatToUint,
+		types.IntKind:       convFloatToInt,
+		types.FloatKind:     identityConvFunc,
+		types.BoolKind:      convFloatToBool,
+		types.TimestampKind: nil,
+		types.DecimalKind:   convFloatToDecimal,
+		types.NullKind:      convToNullFunc},
 	types.BoolKind: {
-		types.StringKind: convBoolToString,
-		types.UUIDKind:   nil,
-		types.UintKind:   convBoolToUint,
-		types.IntKind:    convBoolToInt,
-		types.FloatKind:  convBoolToFloat,
-		types.BoolKind:   identityConvFunc,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convBoolToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      convBoolToUint,
+		types.IntKind:       convBoolToInt,
+		types.FloatKind:     convBoolToFloat,
+		types.BoolKind:      identityConvFunc,
+		types.TimestampKind: nil,
+		types.DecimalKind:   nil,
+		types.NullKind:      convToNullFunc},
 	types.NullKind: {
-		types.StringKind: convToNullFunc,
-		types.UUIDKind:   convToNullFunc,
-		types.UintKind:   convToNullFunc,
-		types.IntKind:    convToNullFunc,
-		types.FloatKind:  convToNullFunc,
-		types.BoolKind:   convToNullFunc,
-		types.NullKind:   convToNullFunc},
+		types.StringKind:    convToNullFunc,
+		types.UUIDKind:      convToNullFunc,
+		types.UintKind:      convToNullFunc,
+		types.IntKind:       convToNullFunc,
+		types.FloatKind:     convToNullFunc,
+		types.BoolKind:      convToNullFunc,
+		types.TimestampKind: convToNullFunc,
+		types.DecimalKind:   convToNullFunc,
+		types.NullKind:      convToNullFunc},
+	types.TimestampKind: {
+		types.StringKind:    convTimestampToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      nil,
+		types.IntKind:       nil,
+		types.FloatKind:     nil,
+		types.BoolKind:      nil,
+		types.TimestampKind: identityConvFunc,
+		types.DecimalKind:   nil,
+		types.NullKind:      convToNullFunc},
+	types.DecimalKind: {
+		types.StringKind:    convDecimalToString,
+		types.UUIDKind:      nil,
+		types.UintKind:      convDecimalToUint,
+		types.IntKind:       convDecimalToInt,
+		types.FloatKind:     convDecimalToFloat,
+		types.BoolKind:      nil,
+		types.TimestampKind: nil,
+		types.DecimalKind:   identityConvFunc,
+		types.NullKind:      convToNullFunc},
 }
 
 // GetConvFunc takes in a source kind and a destination kind and returns a ConvFunc which can convert values of the
@@ -180,6 +215,22 @@ func convStringToUUID(val types.Value) (types.Value, error) {
 	return stringToUUID(string(val.(types.String)))
 }
 
+func convStringToTimestamp(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	return stringToTimestamp(string(val.(types.String)))
+}
+
+func convStringToDecimal(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	return stringToDecimal(string(val.(types.String)))
+}
+
 func convUUIDToString(val types.Value) (types.Value, error) {
 	if val == nil {
 		return nil, nil
@@ -226,6 +277,15 @@ func convUintToBool(val types.Value) (types.Value, error) {
 	return types.Bool(n != 0), nil
 }
 
+func convUintToDecimal(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	n := uint64(val.(types.Uint))
+	return types.NewDecimal(new(big.Int).SetUint64(n), 0), nil
+}
+
 func convIntToString(val types.Value) (types.Value, error) {
 	if val == nil {
 		return nil, nil
@@ -264,6 +324,15 @@ func convIntToBool(val types.Value) (types.Value, error) {
 	return types.Bool(n != 0), nil
 }
 
+func convIntToDecimal(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	n := int64(val.(types.Int))
+	return types.NewDecimal(big.NewInt(n), 0), nil
+}
+
 func convFloatToString(val types.Value) (types.Value, error) {
 	if val == nil {
 		return nil, nil
@@ -301,6 +370,21 @@ func convFloatToBool(val types.Value) (types.Value, error) {
 	return types.Bool(fl != 0), nil
 }
 
+func convFloatToDecimal(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	fl := float64(val.(types.Float))
+	d, err := types.DecimalFromFloat(fl)
+
+	if err != nil {
+		return types.Decimal{}, ConversionError{types.FloatKind, types.DecimalKind, err}
+	}
+
+	return d, nil
+}
+
 var trueValStr = types.String("true")
 var falseValStr = types.String("false")
 
@@ -368,3 +452,55 @@ func convBoolToFloat(val types.Value) (types.Value, error) {
 
 	return zeroFloatVal, nil
 }
+
+func convTimestampToString(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	return types.String(val.(types.Timestamp).String()), nil
+}
+
+func convDecimalToString(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	return types.String(val.(types.Decimal).String()), nil
+}
+
+func convDecimalToUint(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	n := val.(types.Decimal).Round(0).Unscaled()
+
+	if !n.IsUint64() {
+		return types.Uint(0), ConversionError{types.DecimalKind, types.UintKind, fmt.Errorf("%s is out of range", n.String())}
+	}
+
+	return types.Uint(n.Uint64()), nil
+}
+
+func convDecimalToInt(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	n := val.(types.Decimal).Round(0).Unscaled()
+
+	if !n.IsInt64() {
+		return types.Int(0), ConversionError{types.DecimalKind, types.IntKind, fmt.Errorf("%s is out of range", n.String())}
+	}
+
+	return types.Int(n.Int64()), nil
+}
+
+func convDecimalToFloat(val types.Value) (types.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	return types.Float(val.(types.Decimal).Float64()), nil
+}
@@ -15,7 +15,9 @@
 package doltcore
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -27,6 +29,8 @@ const (
 )
 
 var zeroUUID = uuid.Must(uuid.Parse(zeroUUIDStr))
+var testTimestamp = types.NewTimestamp(time.Date(2019, 8, 1, 12, 30, 0, 0, time.UTC))
+var testDecimal = types.NewDecimal(big.NewInt(-1250), 2)
 
 func TestConv(t *testing.T) {
 	tests := []struct {
@@ -82,6 +86,28 @@ func TestConv(t *testing.T) {
 		{types.Bool(true), types.Float(1), convBoolToFloat, false},
 		{types.Bool(false), types.Bool(false), identityConvFunc, false},
 		{types.Bool(true), types.NullValue, convToNullFunc, false},
+		{types.Bool(true), testDecimal, nil, false},
+
+		{types.String("2019-08-01 12:30:00"), testTimestamp, convStringToTimestamp, false},
+		{types.String("2019-08-01T12:30:00Z"), testTimestamp, convStringToTimestamp, false},
+		{types.String("yesterday"), types.Timestamp{}, convStringToTimestamp, true},
+		{types.String("-12.50"), testDecimal, convStringToDecimal, false},
+		{types.String("12.5.0"), types.Decimal{}, convStringToDecimal, true},
+		{types.Uint(12), types.NewDecimal(big.NewInt(12), 0), convUintToDecimal, false},
+		{types.Int(-12), types.NewDecimal(big.NewInt(-12), 0), convIntToDecimal, false},
+		{types.Float(-12.5), types.NewDecimal(big.NewInt(-125), 1), convFloatToDecimal, false},
+
+		{testTimestamp, types.String("2019-08-01 12:30:00"), convTimestampToString, false},
+		{testTimestamp, testTimestamp, identityConvFunc, false},
+		{testTimestamp, types.Int(0), nil, false},
+		{testTimestamp, types.NullValue, convToNullFunc, false},
+
+		{testDecimal, types.String("-12.50"), convDecimalToString, false},
+		{testDecimal, types.Int(-13), convDecimalToInt, false},
+		{testDecimal, types.Uint(0), convDecimalToUint, true},
+		{testDecimal, types.Float(-12.5), convDecimalToFloat, false},
+		{testDecimal, testDecimal, identityConvFunc, false},
+		{testDecimal, types.NullValue, convToNullFunc, false},
 	}
 
 	for _, test := range tests {
@@ -96,14 +122,14 @@ func TestConv(t *testing.T) {
 				t.Error("input:", test.input, "expected err:", test.expectErr, "actual err:", err != nil)
 			}
 
-			if !test.expectedOut.Equals(result) {
+			if !test.expectErr && !test.expectedOut.Equals(result) {
 				t.Error("input:", test.input, "expected result:", test.expectedOut, "actual result:", result)
 			}
 		}
 	}
 }
 
-var convertibleTypes = []types.NomsKind{types.StringKind, types.UUIDKind, types.UintKind, types.IntKind, types.FloatKind, types.BoolKind}
+var convertibleTypes = []types.NomsKind{types.StringKind, types.UUIDKind, types.UintKind, types.IntKind, types.FloatKind, types.BoolKind, types.TimestampKind, types.DecimalKind}
 
 func TestNullConversion(t *testing.T) {
 	for _, srcKind := range convertibleTypes {
@@ -23,6 +23,7 @@ package chunks
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/liquidata-inc/dolt/go/store/hash"
@@ -86,3 +87,17 @@ type ChunkStore interface {
 	// undefined and probably crashy.
 	io.Closer
 }
+
+// ErrUnsupportedOperation is returned by operations which aren't supported by a ChunkStore implementation.
+var ErrUnsupportedOperation = errors.New("operation not supported")
+
+// ChunkStoreGarbageCollector is a ChunkStore which can remove chunks that are no longer reachable.
+type ChunkStoreGarbageCollector interface {
+	ChunkStore
+
+	// MarkAndSweepChunks removes every chunk which isn't reachable from the
+	// store's root or from |extraRoots|. It must be safe to call concurrently
+	// with Commit. If the root changes while garbage is being collected,
+	// nothing is removed and an error is returned.
+	MarkAndSweepChunks(ctx context.Context, extraRoots []hash.Hash) error
+}
@@ -27,6 +27,7 @@ import (
 	"io"
 
 	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -95,9 +96,18 @@ type Database interface {
 	// of a conflict, Delete returns an 'ErrMergeNeeded' error.
 	Delete(ctx context.Context, ds Dataset) (Dataset, error)
 
+	// Tag stores an immutable reference to a commit. It takes a types.Ref to
+	// a Commit and a TagOptions with the metadata of the tag, and makes a new
+	// Tag struct the head of ds. Tags can't be moved once they're created, so
+	// if ds already has a head, Tag returns an 'ErrMergeNeeded' error.
+	// The returned Dataset is always the newest snapshot, regardless of
+	// success or failure, and Datasets() is updated to match backing storage
+	// upon return as well.
+	Tag(ctx context.Context, ds Dataset, commitRef types.Ref, opts TagOptions) (Dataset, error)
+
 	// SetHead ignores any lineage constraints (e.g. the current Head being in
 	// commit’s Parent set) and force-sets a mapping from datasetID: commit in
-	// this database.
+	// this database. newHeadRef may also refer to a Tag.
 	// All Values that have been written to this Database are guaranteed to be
 	// persistent after SetHead(). If the update cannot be performed, e.g.,
 	// because another process moved the current Head out from under you,
@@ -129,6 +139,13 @@ type Database interface {
 
 	Flush(ctx context.Context) error
 
+	// GC removes every chunk which isn't reachable from the Datasets of this
+	// Database or from |extraRoots|, which callers use for values they
+	// reference from outside of the Database. Returns
+	// chunks.ErrUnsupportedOperation if the ChunkStore that backs this
+	// Database doesn't support garbage collection.
+	GC(ctx context.Context, extraRoots ...hash.Hash) error
+
 	// chunkStore returns the ChunkStore used to read and write
 	// groups of values to the database efficiently. This interface is a low-
 	// level detail of the database that should infrequently be needed by
@@ -99,6 +99,30 @@ func (db *database) Flush(ctx context.Context) error {
 	return err
 }
 
+func (db *database) GC(ctx context.Context, extraRoots ...hash.Hash) error {
+	collector, ok := db.chunkStore().(chunks.ChunkStoreGarbageCollector)
+
+	if !ok {
+		return chunks.ErrUnsupportedOperation
+	}
+
+	// Make sure everything that's been written is persisted, so that it's
+	// visible to the collector.
+	err := db.Flush(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = collector.MarkAndSweepChunks(ctx, extraRoots)
+
+	if err != nil {
+		return err
+	}
+
+	return db.Rebase(ctx)
+}
+
 func (db *database) Datasets(ctx context.Context) (types.Map, error) {
 	rootHash, err := db.rt.Root(ctx)
 
@@ -166,7 +190,7 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, newHeadRef types.
 		}
 	}
 
-	commit, err := db.validateRefAsCommit(ctx, newHeadRef)
+	commit, err := db.validateRefAsCommitOrTag(ctx, newHeadRef)
 
 	if err != nil {
 		return err
@@ -386,6 +410,83 @@ func (db *database) doCommit(ctx context.Context, datasetID string, commit types
 	return tryCommitErr
 }
 
+func (db *database) Tag(ctx context.Context, ds Dataset, commitRef types.Ref, opts TagOptions) (Dataset, error) {
+	return db.doHeadUpdate(
+		ctx,
+		ds,
+		func(ds Dataset) error {
+			meta := opts.Meta
+			if meta.IsZeroValue() {
+				meta = types.EmptyStruct(ds.Database().Format())
+			}
+
+			if _, err := db.validateRefAsCommit(ctx, commitRef); err != nil {
+				return err
+			}
+
+			tag, err := NewTag(ctx, commitRef, meta)
+
+			if err != nil {
+				return err
+			}
+
+			return db.doTag(ctx, ds.ID(), tag)
+		},
+	)
+}
+
+// doTag manages concurrent access the single logical piece of mutable state: the current Root. It uses the same optimistic writing algorithm as doCommit (see above). Unlike a commit, a tag is never moved once it is written, so doTag returns an 'ErrMergeNeeded' error if the dataset already has a head.
+func (db *database) doTag(ctx context.Context, datasetID string, tag types.Struct) error {
+	if is, err := IsTag(tag); err != nil {
+		return err
+	} else if !is {
+		d.Panic("Can't tag with a non-Tag struct for dataset %s", datasetID)
+	}
+
+	var tryCommitErr error
+	for tryCommitErr = ErrOptimisticLockFailed; tryCommitErr == ErrOptimisticLockFailed; {
+		currentRootHash, err := db.rt.Root(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		currentDatasets, err := db.Datasets(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if has, err := currentDatasets.Has(ctx, types.String(datasetID)); err != nil {
+			return err
+		} else if has {
+			return ErrMergeNeeded
+		}
+
+		tagRef, err := db.WriteValue(ctx, tag) // will be orphaned if the tryCommitChunks() below fails
+
+		if err != nil {
+			return err
+		}
+
+		ref, err := types.ToRefOfValue(tagRef, db.Format())
+
+		if err != nil {
+			return err
+		}
+
+		currentDatasets, err = currentDatasets.Edit().Set(types.String(datasetID), ref).Map(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		tryCommitErr = db.tryCommitChunks(ctx, currentDatasets, currentRootHash)
+	}
+
+	return tryCommitErr
+}
+
 func (db *database) Delete(ctx context.Context, ds Dataset) (Dataset, error) {
 	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID()) })
 }
@@ -488,6 +589,38 @@ func (db *database) validateRefAsCommit(ctx context.Context, r types.Ref) (types
 	return v.(types.Struct), nil
 }
 
+func (db *database) validateRefAsCommitOrTag(ctx context.Context, r types.Ref) (types.Struct, error) {
+	v, err := db.ReadValue(ctx, r.TargetHash())
+
+	if err != nil {
+		return types.EmptyStruct(r.Format()), err
+	}
+
+	if v == nil {
+		panic(r.TargetHash().String() + " not found")
+	}
+
+	is, err := IsCommit(v)
+
+	if err != nil {
+		return types.EmptyStruct(r.Format()), err
+	}
+
+	if !is {
+		is, err = IsTag(v)
+
+		if err != nil {
+			return types.EmptyStruct(r.Format()), err
+		}
+	}
+
+	if !is {
+		panic("Not a commit or tag")
+	}
+
+	return v.(types.Struct), nil
+}
+
 func buildNewCommit(ctx context.Context, ds Dataset, v types.Value, opts CommitOptions) (types.Struct, error) {
 	parents := opts.Parents
 	if (parents == types.Set{}) {
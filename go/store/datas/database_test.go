@@ -704,3 +704,50 @@ func (suite *DatabaseSuite) TestMetaOption() {
 	c := mustHead(ds)
 	suite.Equal(types.String("arv"), mustGetValue(mustGetValue(c.MaybeGet("meta")).(types.Struct).MaybeGet("author")))
 }
+
+func (suite *DatabaseSuite) TestDatabaseTag() {
+	ctx := context.Background()
+	ds, err := suite.db.GetDataset(ctx, "ds")
+	suite.NoError(err)
+	ds, err = suite.db.CommitValue(ctx, ds, types.String("a"))
+	suite.NoError(err)
+	commitRef, ok, err := ds.MaybeHeadRef()
+	suite.NoError(err)
+	suite.True(ok)
+
+	tagDS, err := suite.db.GetDataset(ctx, "tag")
+	suite.NoError(err)
+	meta, err := types.NewStruct(suite.db.Format(), "metadata", types.StructData{"desc": types.String("v1")})
+	suite.NoError(err)
+	tagDS, err = suite.db.Tag(ctx, tagDS, commitRef, TagOptions{Meta: meta})
+	suite.NoError(err)
+
+	tag, ok := tagDS.MaybeHead()
+	suite.True(ok)
+	isTag, err := IsTag(tag)
+	suite.NoError(err)
+	suite.True(isTag)
+	tagged, ok, err := tag.MaybeGet(TagCommitRefField)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.True(commitRef.Equals(tagged))
+
+	// Tags can't be moved once they're created
+	ds, err = suite.db.CommitValue(ctx, ds, types.String("b"))
+	suite.NoError(err)
+	newCommitRef, _, err := ds.MaybeHeadRef()
+	suite.NoError(err)
+	_, err = suite.db.Tag(ctx, tagDS, newCommitRef, TagOptions{})
+	suite.Equal(ErrMergeNeeded, err)
+
+	// But they can be set as the head of another dataset, e.g. when they're pulled from a remote
+	otherDS, err := suite.db.GetDataset(ctx, "other")
+	suite.NoError(err)
+	tagRef, _, err := tagDS.MaybeHeadRef()
+	suite.NoError(err)
+	otherDS, err = suite.db.SetHead(ctx, otherDS, tagRef)
+	suite.NoError(err)
+	otherRef, _, err := otherDS.MaybeHeadRef()
+	suite.NoError(err)
+	suite.True(tagRef.Equals(otherRef))
+}
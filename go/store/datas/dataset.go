@@ -33,10 +33,12 @@ import (
 var DatasetRe = regexp.MustCompile(`[a-zA-Z0-9\-_/]+`)
 
 // DatasetFullRe is a regexp that matches a only a target string that is
-// entirely legal Dataset name.
-var DatasetFullRe = regexp.MustCompile("^" + DatasetRe.String() + "$")
+// entirely legal Dataset name. Dataset names may also contain '.', e.g.
+// refs/tags/v1.0, but those names can't be matched by DatasetRe since '.'
+// separates the components of a path.
+var DatasetFullRe = regexp.MustCompile(`^[a-zA-Z0-9\-_/.]+$`)
 
-// Dataset is a named Commit within a Database.
+// Dataset is a named Commit, or a named Tag of a Commit, within a Database.
 type Dataset struct {
 	db   Database
 	id   string
@@ -44,10 +46,19 @@ type Dataset struct {
 }
 
 func newDataset(db Database, id string, head types.Value) (Dataset, error) {
-	headNilOrIsCommit := head == nil
-	if !headNilOrIsCommit {
+	headNilOrIsCommitOrTag := head == nil
+	if !headNilOrIsCommitOrTag {
 		var err error
-		headNilOrIsCommit, err = IsCommit(head)
+		headNilOrIsCommitOrTag, err = IsCommit(head)
+
+		if err != nil {
+			return Dataset{}, err
+		}
+	}
+
+	if !headNilOrIsCommitOrTag {
+		var err error
+		headNilOrIsCommitOrTag, err = IsTag(head)
 
 		if err != nil {
 			return Dataset{}, err
@@ -55,7 +66,7 @@ func newDataset(db Database, id string, head types.Value) (Dataset, error) {
 	}
 
 	// precondition checks
-	d.PanicIfFalse(headNilOrIsCommit)
+	d.PanicIfFalse(headNilOrIsCommitOrTag)
 	return Dataset{db, id, head}, nil
 }
 
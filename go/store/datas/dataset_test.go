@@ -228,6 +228,7 @@ func TestIsValidDatasetName(t *testing.T) {
 		{"foo/bar", true},
 		{"f1", true},
 		{"1f", true},
+		{"refs/tags/v1.0", true},
 		{"", false},
 		{"f!!", false},
 	}
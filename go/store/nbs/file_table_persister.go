@@ -29,11 +29,21 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/liquidata-inc/dolt/go/store/d"
 )
 
-const tempTablePrefix = "nbs_table_"
+const (
+	tempTablePrefix   = "nbs_table_"
+	tempRetiredPrefix = "nbs_retired_"
+
+	// retiredTableFilesName is the file which lists the table files garbage collection has removed from the manifest
+	// but hasn't yet deleted.
+	retiredTableFilesName = "retired"
+)
 
 func newFSTablePersister(dir string, fc *fdCache, indexCache *indexCache) tablePersister {
 	d.PanicIfTrue(fc == nil)
@@ -207,3 +217,134 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 
 	return ftp.Open(ctx, name, plan.chunkCount, stats)
 }
+
+// retireTableFiles records the table files |retired|, which are no longer referenced by the manifest, in the list of
+// retired table files kept in retiredTableFilesName, along with when each was first retired. Table files on the list
+// which were retired at least |gracePeriod| ago are then deleted, and those in |live| are dropped from the list. The
+// directory's lock file is held while the list is read and written.
+func (ftp *fsTablePersister) retireTableFiles(ctx context.Context, retired []addr, live map[addr]bool, gracePeriod time.Duration) (err error) {
+	lck := newLock(ftp.dir)
+	err = lck.Lock()
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		unlockErr := lck.Unlock()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	retiredPath := filepath.Join(ftp.dir, retiredTableFilesName)
+	retiredAt, err := readRetiredTableFiles(retiredPath)
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, name := range retired {
+		if _, ok := retiredAt[name]; !ok {
+			retiredAt[name] = now
+		}
+	}
+
+	for name, t := range retiredAt {
+		if live[name] {
+			delete(retiredAt, name)
+		} else if now.Sub(t) >= gracePeriod {
+			err = os.Remove(filepath.Join(ftp.dir, name.String()))
+
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+
+			delete(retiredAt, name)
+		}
+	}
+
+	return writeRetiredTableFiles(ftp.dir, retiredPath, retiredAt)
+}
+
+// readRetiredTableFiles reads the list of retired table files at |path|, which is a colon separated list of table
+// file names, each followed by the unix time it was retired. A missing file is an empty list.
+func readRetiredTableFiles(path string) (map[addr]time.Time, error) {
+	retiredAt := make(map[addr]time.Time)
+	data, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return retiredAt, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(data) == 0 {
+		return retiredAt, nil
+	}
+
+	slices := strings.Split(string(data), ":")
+	if len(slices)%2 == 1 {
+		return nil, errors.New("corrupt list of retired table files")
+	}
+
+	for i := 0; i < len(slices); i += 2 {
+		name, err := parseAddr([]byte(slices[i]))
+
+		if err != nil {
+			return nil, err
+		}
+
+		secs, err := strconv.ParseInt(slices[i+1], 10, 64)
+
+		if err != nil {
+			return nil, err
+		}
+
+		retiredAt[name] = time.Unix(secs, 0)
+	}
+
+	return retiredAt, nil
+}
+
+// writeRetiredTableFiles writes the list of retired table files to a temporary file in |dir|, and renames it over
+// |path|.
+func writeRetiredTableFiles(dir, path string, retiredAt map[addr]time.Time) error {
+	tempName, err := func() (tempName string, ferr error) {
+		var temp *os.File
+		temp, ferr = ioutil.TempFile(dir, tempRetiredPrefix)
+
+		if ferr != nil {
+			return "", ferr
+		}
+
+		defer func() {
+			closeErr := temp.Close()
+
+			if ferr == nil {
+				ferr = closeErr
+			}
+		}()
+
+		strs := make([]string, 0, 2*len(retiredAt))
+		for name, t := range retiredAt {
+			strs = append(strs, name.String(), strconv.FormatInt(t.Unix(), 10))
+		}
+
+		_, ferr = io.WriteString(temp, strings.Join(strs, ":"))
+
+		if ferr != nil {
+			return "", ferr
+		}
+
+		return temp.Name(), nil
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tempName) // If we rename below, this will be a no-op
+
+	return os.Rename(tempName, path)
+}
@@ -0,0 +1,293 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// This file incorporates work covered by the following copyright and
+// permission notice:
+//
+// Copyright 2016 Attic Labs, Inc. All rights reserved.
+// Licensed under the Apache License, version 2.0:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrUncommittedChunks is returned by MarkAndSweepChunks when the store has chunks that haven't been committed yet.
+var ErrUncommittedChunks = errors.New("cannot collect garbage while there are uncommitted chunks")
+
+// ErrConcurrentManifestUpdate is returned by MarkAndSweepChunks when the manifest is updated by another process while
+// garbage is being collected.
+var ErrConcurrentManifestUpdate = errors.New("manifest was updated while collecting garbage")
+
+// gcGracePeriod is how long table files which garbage collection removes from the manifest are kept before they may be
+// deleted, so that processes which read the previous manifest can keep reading chunks from them in the meantime.
+var gcGracePeriod = time.Hour
+
+// tableFileDeleter is implemented by tablePersisters which can delete table files once they are no longer referenced
+// by the manifest.
+type tableFileDeleter interface {
+	// retireTableFiles records that the table files |retired| are no longer referenced by the manifest, and deletes
+	// the table files retired at least |gracePeriod| ago, by this or an earlier call, which aren't in |live|.
+	retireTableFiles(ctx context.Context, retired []addr, live map[addr]bool, gracePeriod time.Duration) error
+}
+
+// MarkAndSweepChunks removes every chunk from the store which isn't reachable from the store's root or from
+// |extraRoots|, which callers use for values that are referenced from outside of the store. The refs of each reachable
+// chunk are walked with types.WalkRefs, the reachable chunks are rewritten into new table files, and the manifest is
+// swapped to reference only those tables.
+//
+// Table files that are no longer referenced aren't deleted right away, since other processes, and other stores in this
+// one, may have read the previous manifest and still be reading chunks from them. If the store's persister supports it,
+// they are retired instead, and deleted by a later call once they've been retired for at least gcGracePeriod. A reader
+// which keeps using a manifest for longer than that, without reloading it, may find its table files missing.
+//
+// The store's locks are held for the duration, so calls to Put and Commit on this store block until garbage collection
+// completes. Returns ErrUncommittedChunks if there are novel chunks which haven't been committed, and
+// ErrConcurrentManifestUpdate if another process updates the manifest in the meantime. Nothing is removed in either
+// case.
+func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, extraRoots []hash.Hash) (err error) {
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	if nbs.mt != nil || nbs.tables.Novel() > 0 {
+		return ErrUncommittedChunks
+	}
+
+	exists, upstream, err := nbs.mm.Fetch(ctx, nbs.stats)
+
+	if err != nil {
+		return err
+	} else if !exists {
+		return nil
+	}
+
+	nbs.upstream = upstream
+	nbs.tables, err = nbs.tables.Rebase(ctx, upstream.specs, nbs.stats)
+
+	if err != nil {
+		return err
+	}
+
+	nbf, err := types.GetFormatForVersionString(upstream.vers)
+
+	if err != nil {
+		return err
+	}
+
+	specs, err := nbs.copyReachableChunks(ctx, nbf, append([]hash.Hash{upstream.root}, extraRoots...))
+
+	if err != nil {
+		return err
+	}
+
+	newContents := manifestContents{
+		vers:  upstream.vers,
+		root:  upstream.root,
+		lock:  generateLockHash(upstream.root, specs),
+		specs: specs,
+	}
+
+	updated, err := nbs.mm.Update(ctx, upstream.lock, newContents, nbs.stats, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if newContents.lock != updated.lock {
+		// Optimistic lock failure. Someone else moved the root, the set of tables, or both out from under us, so the
+		// chunks we copied may not include everything which is now reachable.
+		nbs.upstream = updated
+		nbs.tables, err = nbs.tables.Rebase(ctx, updated.specs, nbs.stats)
+
+		if err != nil {
+			return err
+		}
+
+		return ErrConcurrentManifestUpdate
+	}
+
+	nbs.upstream = newContents
+	nbs.tables, err = nbs.tables.Rebase(ctx, newContents.specs, nbs.stats)
+
+	if err != nil {
+		return err
+	}
+
+	deleter, ok := nbs.p.(tableFileDeleter)
+
+	if !ok {
+		return nil
+	}
+
+	live := make(map[addr]bool, len(specs))
+	for _, spec := range specs {
+		live[spec.name] = true
+	}
+
+	var garbage []addr
+	for _, spec := range upstream.specs {
+		if !live[spec.name] {
+			garbage = append(garbage, spec.name)
+		}
+	}
+
+	return deleter.retireTableFiles(ctx, garbage, live, gcGracePeriod)
+}
+
+// copyReachableChunks walks the chunks reachable from |roots| breadth first, and writes each of them to new tables.
+// Returns the specs of the tables written. The caller must hold nbs.mu.
+func (nbs *NomsBlockStore) copyReachableChunks(ctx context.Context, nbf *types.NomsBinFormat, roots []hash.Hash) ([]tableSpec, error) {
+	var specs []tableSpec
+	mt := newMemTable(nbs.mtSize)
+
+	persist := func() error {
+		cs, err := nbs.p.Persist(ctx, mt, nil, nbs.stats)
+
+		if err != nil {
+			return err
+		}
+
+		cnt, err := cs.count()
+
+		if err != nil {
+			return err
+		}
+
+		if cnt > 0 {
+			h, err := cs.hash()
+
+			if err != nil {
+				return err
+			}
+
+			specs = append(specs, tableSpec{h, cnt})
+		}
+
+		return nil
+	}
+
+	visited := hash.HashSet{}
+	next := hash.HashSet{}
+	for _, h := range roots {
+		if !h.IsEmpty() {
+			next.Insert(h)
+		}
+	}
+
+	for len(next) > 0 {
+		for h := range next {
+			visited.Insert(h)
+		}
+
+		found, err := nbs.getManyFromTables(ctx, next)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found) != len(next) {
+			for _, c := range found {
+				next.Remove(c.Hash())
+			}
+
+			for h := range next {
+				return nil, fmt.Errorf("chunk %s is reachable but is missing from the store", h.String())
+			}
+		}
+
+		next = hash.HashSet{}
+		for _, c := range found {
+			if !mt.addChunk(addr(c.Hash()), c.Data()) {
+				err = persist()
+
+				if err != nil {
+					return nil, err
+				}
+
+				mt = newMemTable(nbs.mtSize)
+
+				if !mt.addChunk(addr(c.Hash()), c.Data()) {
+					// The chunk is larger than a whole memTable, so it gets a table of its own.
+					mt = newMemTable(uint64(len(c.Data())))
+					mt.addChunk(addr(c.Hash()), c.Data())
+				}
+			}
+
+			err = types.WalkRefs(c, nbf, func(r types.Ref) error {
+				if !visited.Has(r.TargetHash()) {
+					next.Insert(r.TargetHash())
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := persist(); err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+// getManyFromTables reads the chunks with the hashes given from the store's tables. Chunks which aren't found are
+// omitted from the result. The caller must hold nbs.mu.
+func (nbs *NomsBlockStore) getManyFromTables(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+	foundChunks := make(chan *chunks.Chunk, 32)
+	collected := make(chan []chunks.Chunk)
+	go func() {
+		var found []chunks.Chunk
+		for c := range foundChunks {
+			found = append(found, *c)
+		}
+		collected <- found
+	}()
+
+	ae := atomicerr.New()
+	wg := &sync.WaitGroup{}
+	nbs.tables.getMany(ctx, toGetRecords(hashes), foundChunks, wg, ae, nbs.stats)
+	wg.Wait()
+	close(foundChunks)
+	found := <-collected
+
+	if err := ae.Get(); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package nbs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/constants"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestMarkAndSweepChunks(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewLocalStore(ctx, constants.FormatDefaultString, dir, testMemTableSize)
+	require.NoError(t, err)
+	defer store.Close()
+
+	vs := types.NewValueStore(store)
+	writeValue := func(v types.Value) types.Ref {
+		r, err := vs.WriteValue(ctx, v)
+		require.NoError(t, err)
+		return r
+	}
+
+	var children []types.Value
+	for i := 0; i < 4; i++ {
+		children = append(children, writeValue(types.Uint(i)))
+	}
+
+	list, err := types.NewList(ctx, vs, children...)
+	require.NoError(t, err)
+	root, err := types.NewStruct(vs.Format(), "root", types.StructData{"children": writeValue(list)})
+	require.NoError(t, err)
+	rootRef := writeValue(root)
+	extraRef := writeValue(types.String("referenced from outside the store"))
+	garbageRef := writeValue(types.String("garbage"))
+
+	committed, err := vs.Commit(ctx, rootRef.TargetHash(), hash.Hash{})
+	require.NoError(t, err)
+	require.True(t, committed)
+
+	// Write some novel chunks, which must be committed before collecting garbage
+	require.NoError(t, store.Put(ctx, mustChunk(types.EncodeValue(types.String("novel"), vs.Format()))))
+	assert.Equal(t, ErrUncommittedChunks, store.MarkAndSweepChunks(ctx, nil))
+	committed, err = store.Commit(ctx, rootRef.TargetHash(), rootRef.TargetHash())
+	require.NoError(t, err)
+	require.True(t, committed)
+
+	oldSpecs := store.upstream.specs
+	require.NoError(t, store.MarkAndSweepChunks(ctx, []hash.Hash{extraRef.TargetHash()}))
+	assert.True(t, len(store.upstream.specs) < len(oldSpecs))
+
+	assertPresent := func(h hash.Hash, expected bool) {
+		ok, err := store.Has(ctx, h)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, h.String())
+	}
+
+	assertPresent(rootRef.TargetHash(), true)
+	assertPresent(extraRef.TargetHash(), true)
+	for _, child := range children {
+		assertPresent(child.(types.Ref).TargetHash(), true)
+	}
+	assertPresent(garbageRef.TargetHash(), false)
+
+	tableFiles := func() map[string]bool {
+		infos, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		names := map[string]bool{}
+		for _, info := range infos {
+			names[info.Name()] = true
+		}
+		for _, spec := range store.upstream.specs {
+			assert.True(t, names[spec.name.String()])
+			delete(names, spec.name.String())
+		}
+		for _, name := range []string{manifestFileName, lockFileName, retiredTableFilesName} {
+			delete(names, name)
+		}
+		return names
+	}
+
+	// Table files which are no longer referenced are kept for readers of the previous manifest
+	assert.NotEmpty(t, tableFiles())
+	for _, spec := range oldSpecs {
+		_, err := os.Stat(filepath.Join(dir, spec.name.String()))
+		assert.NoError(t, err)
+	}
+
+	// and are deleted by a later collection once the grace period has passed
+	defer func(gracePeriod time.Duration) { gcGracePeriod = gracePeriod }(gcGracePeriod)
+	gcGracePeriod = 0
+	require.NoError(t, store.MarkAndSweepChunks(ctx, []hash.Hash{extraRef.TargetHash()}))
+	assertPresent(rootRef.TargetHash(), true)
+	assert.Empty(t, tableFiles())
+
+	// A fresh store sees the collected state
+	reopened, err := NewLocalStore(ctx, constants.FormatDefaultString, dir, testMemTableSize)
+	require.NoError(t, err)
+	defer reopened.Close()
+	ok, err := reopened.Has(ctx, rootRef.TargetHash())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = reopened.Has(ctx, garbageRef.TargetHash())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
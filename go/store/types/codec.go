@@ -24,6 +24,8 @@ package types
 import (
 	"encoding/binary"
 	"math"
+	"math/big"
+	"time"
 
 	"github.com/liquidata-inc/dolt/go/store/chunks"
 	"github.com/liquidata-inc/dolt/go/store/d"
@@ -216,6 +218,39 @@ func (b *binaryNomsReader) skipUUID() {
 	b.offset += uuidNumBytes
 }
 
+func (b *binaryNomsReader) readTimestamp() Timestamp {
+	secs := b.readInt()
+	nanos := b.readUint()
+	return NewTimestamp(time.Unix(int64(secs), int64(nanos)))
+}
+
+func (b *binaryNomsReader) skipTimestamp() {
+	b.skipInt()
+	b.skipUint()
+}
+
+func (b *binaryNomsReader) readDecimal() Decimal {
+	scale := b.readInt()
+	neg := b.readBool()
+	size := uint32(b.readCount())
+
+	unscaled := new(big.Int).SetBytes(b.buff[b.offset : b.offset+size])
+	b.offset += size
+
+	if neg {
+		unscaled.Neg(unscaled)
+	}
+
+	return Decimal{unscaled, int32(scale)}
+}
+
+func (b *binaryNomsReader) skipDecimal() {
+	b.skipInt()
+	b.skipBool()
+	size := uint32(b.readCount())
+	b.offset += size
+}
+
 func (b *binaryNomsReader) readBool() bool {
 	return b.readUint8() == 1
 }
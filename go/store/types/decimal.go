@@ -0,0 +1,286 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+var bigTen = big.NewInt(10)
+
+// Decimal is a Noms Value holding an exact decimal number, represented as an arbitrary precision unscaled integer and a
+// scale, which is the number of digits after the decimal point. The value of the decimal is unscaled * 10^-scale.
+//
+// Two decimals are only equal if they have the same scale, so 1.5 and 1.50 are different values which sort next to
+// each other.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns the decimal unscaled * 10^-scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	d := Decimal{new(big.Int).Set(unscaled), scale}
+
+	if scale < 0 {
+		d = d.Round(0)
+	}
+
+	return d
+}
+
+// ParseDecimal parses a decimal number such as "-12.340" or "1.5e3". The scale of the result is the number of digits
+// after the decimal point, adjusted by the exponent if there is one.
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i != -1 {
+		var err error
+		exp, err = strconv.ParseInt(str[i+1:], 10, 32)
+
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+		}
+
+		str = str[:i]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i != -1 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+
+	digits := strings.TrimLeft(intPart, "+-")
+	if len(intPart)-len(digits) > 1 || (len(digits) == 0 && len(fracPart) == 0) {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+
+	for _, part := range []string{digits, fracPart} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+			}
+		}
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits+fracPart, 10)
+
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+
+	if strings.HasPrefix(intPart, "-") {
+		unscaled.Neg(unscaled)
+	}
+
+	return NewDecimal(unscaled, int32(int64(len(fracPart))-exp)), nil
+}
+
+// DecimalFromFloat returns the decimal with the fewest digits which converts back to the float given.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (v Decimal) unscaledVal() *big.Int {
+	if v.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return v.unscaled
+}
+
+// Scale returns the number of digits after the decimal point.
+func (v Decimal) Scale() int32 {
+	return v.scale
+}
+
+// Unscaled returns the decimal's value multiplied by 10^scale.
+func (v Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(v.unscaledVal())
+}
+
+// Precision returns the number of significant digits of the decimal, which includes the digits after the decimal
+// point.
+func (v Decimal) Precision() int {
+	digits := len(new(big.Int).Abs(v.unscaledVal()).String())
+
+	if digits < int(v.scale) {
+		return int(v.scale)
+	}
+
+	return digits
+}
+
+// Rat returns the decimal as a rational number.
+func (v Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(bigTen, big.NewInt(int64(v.scale)), nil)
+	return new(big.Rat).SetFrac(v.unscaledVal(), denom)
+}
+
+// Float64 returns the nearest float to the decimal.
+func (v Decimal) Float64() float64 {
+	f, _ := v.Rat().Float64()
+	return f
+}
+
+// Round returns the decimal rounded half away from zero to the scale given. Digits are added if the scale given is
+// larger than the decimal's scale.
+func (v Decimal) Round(scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+
+	unscaled := new(big.Int).Set(v.unscaledVal())
+
+	if scale >= v.scale {
+		mult := new(big.Int).Exp(bigTen, big.NewInt(int64(scale-v.scale)), nil)
+		return Decimal{unscaled.Mul(unscaled, mult), scale}
+	}
+
+	div := new(big.Int).Exp(bigTen, big.NewInt(int64(v.scale-scale)), nil)
+	quo, rem := new(big.Int).QuoRem(unscaled, div, new(big.Int))
+
+	// round away from zero if the remainder is at least half the divisor
+	if rem.Abs(rem).Mul(rem, big.NewInt(2)).Cmp(div) >= 0 {
+		if unscaled.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return Decimal{quo, scale}
+}
+
+// Cmp compares the values of two decimals regardless of their scales, returning -1, 0 or 1 if v is less than, equal to
+// or greater than other.
+func (v Decimal) Cmp(other Decimal) int {
+	if v.scale == other.scale {
+		return v.unscaledVal().Cmp(other.unscaledVal())
+	}
+
+	if v.scale < other.scale {
+		return v.Round(other.scale).unscaledVal().Cmp(other.unscaledVal())
+	}
+
+	return v.unscaledVal().Cmp(other.Round(v.scale).unscaledVal())
+}
+
+// Value interface
+func (v Decimal) Value(ctx context.Context) (Value, error) {
+	return v, nil
+}
+
+func (v Decimal) Equals(other Value) bool {
+	if v2, ok := other.(Decimal); ok {
+		return v.scale == v2.scale && v.unscaledVal().Cmp(v2.unscaledVal()) == 0
+	}
+
+	return false
+}
+
+func (v Decimal) Less(nbf *NomsBinFormat, other LesserValuable) (bool, error) {
+	if v2, ok := other.(Decimal); ok {
+		if c := v.Cmp(v2); c != 0 {
+			return c < 0, nil
+		}
+
+		return v.scale < v2.scale, nil
+	}
+
+	return DecimalKind < other.Kind(), nil
+}
+
+func (v Decimal) Hash(nbf *NomsBinFormat) (hash.Hash, error) {
+	return getHash(v, nbf)
+}
+
+func (v Decimal) WalkValues(ctx context.Context, cb ValueCallback) error {
+	return nil
+}
+
+func (v Decimal) WalkRefs(nbf *NomsBinFormat, cb RefCallback) error {
+	return nil
+}
+
+func (v Decimal) typeOf() (*Type, error) {
+	return DecimalType, nil
+}
+
+func (v Decimal) Kind() NomsKind {
+	return DecimalKind
+}
+
+func (v Decimal) valueReadWriter() ValueReadWriter {
+	return nil
+}
+
+func (v Decimal) writeTo(w nomsWriter, nbf *NomsBinFormat) error {
+	err := DecimalKind.writeTo(w, nbf)
+
+	if err != nil {
+		return err
+	}
+
+	unscaled := v.unscaledVal()
+	magnitude := new(big.Int).Abs(unscaled).Bytes()
+
+	w.writeInt(Int(v.scale))
+	w.writeBool(unscaled.Sign() < 0)
+	w.writeCount(uint64(len(magnitude)))
+	w.writeBytes(magnitude)
+
+	return nil
+}
+
+func (v Decimal) valueBytes(nbf *NomsBinFormat) ([]byte, error) {
+	w := newBinaryNomsWriter()
+	err := v.writeTo(&w, nbf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return w.data(), nil
+}
+
+// String returns the decimal in plain notation, with exactly scale digits after the decimal point.
+func (v Decimal) String() string {
+	unscaled := v.unscaledVal()
+	digits := new(big.Int).Abs(unscaled).String()
+
+	sign := ""
+	if unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if v.scale <= 0 {
+		return sign + digits
+	}
+
+	scale := int(v.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
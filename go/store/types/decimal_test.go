@@ -0,0 +1,135 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		str         string
+		expUnscaled int64
+		expScale    int32
+		expStr      string
+		expErr      bool
+	}{
+		{"0", 0, 0, "0", false},
+		{"12.50", 1250, 2, "12.50", false},
+		{"-0.05", -5, 2, "-0.05", false},
+		{"+3", 3, 0, "3", false},
+		{".5", 5, 1, "0.5", false},
+		{"7.", 7, 0, "7", false},
+		{" 42 ", 42, 0, "42", false},
+		{"1.5e3", 1500, 0, "1500", false},
+		{"1.5e-3", 15, 4, "0.0015", false},
+		{"", 0, 0, "", true},
+		{".", 0, 0, "", true},
+		{"--1", 0, 0, "", true},
+		{"1.2.3", 0, 0, "", true},
+		{"1e", 0, 0, "", true},
+		{"abc", 0, 0, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			d, err := ParseDecimal(test.str)
+
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(test.expUnscaled), d.Unscaled())
+			assert.Equal(t, test.expScale, d.Scale())
+			assert.Equal(t, test.expStr, d.String())
+		})
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		str    string
+		scale  int32
+		expStr string
+	}{
+		{"1.005", 2, "1.01"},
+		{"1.004", 2, "1.00"},
+		{"-1.005", 2, "-1.01"},
+		{"-1.004", 2, "-1.00"},
+		{"2.5", 0, "3"},
+		{"1.5", 3, "1.500"},
+		{"0.0001", 2, "0.00"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.str)
+		require.NoError(t, err)
+		assert.Equal(t, test.expStr, d.Round(test.scale).String(), "rounding %s to %d", test.str, test.scale)
+	}
+}
+
+func TestDecimalOrdering(t *testing.T) {
+	ordered := []string{"-10", "-1.5", "-1.50", "0", "0.001", "1.5", "1.50", "2", "100"}
+
+	for i := range ordered {
+		for j := range ordered {
+			di, err := ParseDecimal(ordered[i])
+			require.NoError(t, err)
+			dj, err := ParseDecimal(ordered[j])
+			require.NoError(t, err)
+
+			isLess, err := di.Less(Format_7_18, dj)
+			require.NoError(t, err)
+
+			assert.Equal(t, i < j, isLess, "%s < %s", ordered[i], ordered[j])
+			assert.Equal(t, i == j, di.Equals(dj), "%s == %s", ordered[i], ordered[j])
+		}
+	}
+
+	d1, _ := ParseDecimal("1.5")
+	d2, _ := ParseDecimal("1.50")
+	assert.Equal(t, 0, d1.Cmp(d2))
+}
+
+func TestDecimalMapKeys(t *testing.T) {
+	vs := newTestValueStore()
+	ctx := context.Background()
+
+	var kvs []Value
+	for _, str := range []string{"3.25", "-7", "0.5", "100.001"} {
+		d, err := ParseDecimal(str)
+		require.NoError(t, err)
+		kvs = append(kvs, d, String(str))
+	}
+
+	m, err := NewMap(ctx, vs, kvs...)
+	require.NoError(t, err)
+
+	var keys []string
+	err = m.IterAll(ctx, func(k, v Value) error {
+		keys = append(keys, k.(Decimal).String())
+		assert.Equal(t, String(k.(Decimal).String()), v)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-7", "0.5", "3.25", "100.001"}, keys)
+}
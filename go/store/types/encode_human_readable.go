@@ -372,6 +372,12 @@ func (w *hrsWriter) Write(ctx context.Context, v Value) error {
 	case NullKind:
 		w.write("null_value")
 
+	case TimestampKind:
+		w.write(v.(Timestamp).String())
+
+	case DecimalKind:
+		w.write(v.(Decimal).String())
+
 	default:
 		return ErrUnknownType
 	}
@@ -450,7 +456,7 @@ func (w *hrsWriter) writeSize(v Value) {
 
 func (w *hrsWriter) writeType(t *Type, seenStructs map[*Type]struct{}) {
 	switch t.TargetKind() {
-	case BlobKind, BoolKind, FloatKind, StringKind, TypeKind, ValueKind, UUIDKind, IntKind, UintKind, NullKind:
+	case BlobKind, BoolKind, FloatKind, StringKind, TypeKind, ValueKind, UUIDKind, IntKind, UintKind, NullKind, TimestampKind, DecimalKind:
 		w.write(t.TargetKind().String())
 	case ListKind, RefKind, SetKind, MapKind, TupleKind:
 		w.write(t.TargetKind().String())
@@ -25,9 +25,11 @@ import (
 	"bytes"
 	"context"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -128,6 +130,14 @@ func TestRoundTrips(t *testing.T) {
 	assertRoundTrips(String("AINT NO THANG"))
 	assertRoundTrips(String("💩"))
 
+	assertRoundTrips(NewTimestamp(time.Date(2019, 8, 1, 12, 30, 0, 0, time.UTC)))
+	assertRoundTrips(NewTimestamp(time.Date(1969, 7, 20, 20, 17, 40, 123456789, time.UTC)))
+
+	assertRoundTrips(NewDecimal(big.NewInt(0), 0))
+	assertRoundTrips(NewDecimal(big.NewInt(-1250), 2))
+	bigUnscaled, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	assertRoundTrips(NewDecimal(bigUnscaled, 10))
+
 	st, err := NewStruct(Format_7_18, "", StructData{"a": Bool(true), "b": String("foo"), "c": Float(2.3)})
 	assert.NoError(t, err)
 	assertRoundTrips(st)
@@ -49,6 +49,10 @@ func MakePrimitiveType(k NomsKind) (*Type, error) {
 		return ValueType, nil
 	case TypeKind:
 		return TypeType, nil
+	case TimestampKind:
+		return TimestampType, nil
+	case DecimalKind:
+		return DecimalType, nil
 	}
 
 	return nil, ErrUnknownType
@@ -136,6 +140,8 @@ var UUIDType = makePrimitiveType(UUIDKind)
 var IntType = makePrimitiveType(IntKind)
 var UintType = makePrimitiveType(UintKind)
 var NullType = makePrimitiveType(NullKind)
+var TimestampType = makePrimitiveType(TimestampKind)
+var DecimalType = makePrimitiveType(DecimalKind)
 
 func makeCompoundType(kind NomsKind, elemTypes ...*Type) (*Type, error) {
 	for _, el := range elemTypes {
@@ -0,0 +1,145 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// TimestampFormat is the layout used to print and parse timestamps without fractional seconds.
+const TimestampFormat = "2006-01-02 15:04:05"
+
+// timestampFractionalFormat is the layout used to print timestamps with fractional seconds.
+const timestampFractionalFormat = "2006-01-02 15:04:05.999999999"
+
+// timestampParseFormats are the layouts accepted by ParseTimestamp, in the order they're tried.
+var timestampParseFormats = []string{
+	timestampFractionalFormat,
+	"2006-01-02",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// Timestamp is a Noms Value wrapper around time.Time. Timestamps are stored in UTC with nanosecond precision.
+type Timestamp time.Time
+
+// NewTimestamp returns the Timestamp for the time given, converted to UTC.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp(t.UTC())
+}
+
+// ParseTimestamp parses a timestamp such as "2019-08-01 12:30:00", "2019-08-01" or "2019-08-01T12:30:00Z". Timestamps
+// without a time zone are taken to be in UTC.
+func ParseTimestamp(s string) (Timestamp, error) {
+	str := strings.TrimSpace(s)
+
+	for _, layout := range timestampParseFormats {
+		if t, err := time.Parse(layout, str); err == nil {
+			return NewTimestamp(t), nil
+		}
+	}
+
+	return Timestamp{}, fmt.Errorf("invalid timestamp '%s'", s)
+}
+
+// Value interface
+func (v Timestamp) Value(ctx context.Context) (Value, error) {
+	return v, nil
+}
+
+func (v Timestamp) Equals(other Value) bool {
+	if v2, ok := other.(Timestamp); ok {
+		return time.Time(v).Equal(time.Time(v2))
+	}
+
+	return false
+}
+
+func (v Timestamp) Less(nbf *NomsBinFormat, other LesserValuable) (bool, error) {
+	if v2, ok := other.(Timestamp); ok {
+		return time.Time(v).Before(time.Time(v2)), nil
+	}
+
+	return TimestampKind < other.Kind(), nil
+}
+
+func (v Timestamp) Hash(nbf *NomsBinFormat) (hash.Hash, error) {
+	return getHash(v, nbf)
+}
+
+func (v Timestamp) WalkValues(ctx context.Context, cb ValueCallback) error {
+	return nil
+}
+
+func (v Timestamp) WalkRefs(nbf *NomsBinFormat, cb RefCallback) error {
+	return nil
+}
+
+func (v Timestamp) typeOf() (*Type, error) {
+	return TimestampType, nil
+}
+
+func (v Timestamp) Kind() NomsKind {
+	return TimestampKind
+}
+
+func (v Timestamp) valueReadWriter() ValueReadWriter {
+	return nil
+}
+
+func (v Timestamp) writeTo(w nomsWriter, nbf *NomsBinFormat) error {
+	err := TimestampKind.writeTo(w, nbf)
+
+	if err != nil {
+		return err
+	}
+
+	t := time.Time(v)
+	w.writeInt(Int(t.Unix()))
+	w.writeUint(Uint(t.Nanosecond()))
+
+	return nil
+}
+
+func (v Timestamp) valueBytes(nbf *NomsBinFormat) ([]byte, error) {
+	// We know the size of the buffer here so allocate it once.
+	// TimestampKind, seconds (Varint), nanoseconds (UVarint)
+	buff := make([]byte, 1+2*binary.MaxVarintLen64)
+	w := binaryNomsWriter{buff, 0}
+	err := v.writeTo(&w, nbf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buff[:w.offset], nil
+}
+
+// String returns the timestamp in UTC, with fractional seconds only if it has any.
+func (v Timestamp) String() string {
+	t := time.Time(v).UTC()
+
+	if t.Nanosecond() == 0 {
+		return t.Format(TimestampFormat)
+	}
+
+	return t.Format(timestampFractionalFormat)
+}
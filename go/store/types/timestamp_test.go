@@ -0,0 +1,78 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		str    string
+		expStr string
+		expErr bool
+	}{
+		{"2019-08-01 12:30:00", "2019-08-01 12:30:00", false},
+		{"2019-08-01 12:30:00.5", "2019-08-01 12:30:00.5", false},
+		{"2019-08-01", "2019-08-01 00:00:00", false},
+		{"2019-08-01T12:30:00Z", "2019-08-01 12:30:00", false},
+		{"2019-08-01T12:30:00-07:00", "2019-08-01 19:30:00", false},
+		{"2019-08-01T12:30:00", "2019-08-01 12:30:00", false},
+		{"08/01/2019", "", true},
+		{"2019-13-01", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			ts, err := ParseTimestamp(test.str)
+
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expStr, ts.String())
+		})
+	}
+}
+
+func TestTimestampOrdering(t *testing.T) {
+	t1 := NewTimestamp(time.Date(2019, 8, 1, 12, 30, 0, 0, time.UTC))
+	t2 := NewTimestamp(time.Date(2019, 8, 1, 12, 30, 0, 1, time.UTC))
+	sameAsT1 := NewTimestamp(time.Date(2019, 8, 1, 5, 30, 0, 0, time.FixedZone("PDT", -7*60*60)))
+
+	assert.True(t, t1.Equals(sameAsT1))
+	assert.False(t, t1.Equals(t2))
+
+	isLess, err := t1.Less(Format_7_18, t2)
+	require.NoError(t, err)
+	assert.True(t, isLess)
+
+	isLess, err = t2.Less(Format_7_18, t1)
+	require.NoError(t, err)
+	assert.False(t, isLess)
+
+	h1, err := t1.Hash(Format_7_18)
+	require.NoError(t, err)
+	h2, err := sameAsT1.Hash(Format_7_18)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+}
@@ -47,6 +47,8 @@ type TypeDesc interface {
 // UUID
 // Int
 // Uint
+// Timestamp
+// Decimal
 type PrimitiveDesc NomsKind
 
 func (p PrimitiveDesc) Kind() NomsKind {
@@ -285,6 +285,12 @@ func (r *valueDecoder) readValue(nbf *NomsBinFormat) (Value, error) {
 	case UintKind:
 		r.skipKind()
 		return r.readUint(), nil
+	case TimestampKind:
+		r.skipKind()
+		return r.readTimestamp(), nil
+	case DecimalKind:
+		r.skipKind()
+		return r.readDecimal(), nil
 	case NullKind:
 		r.skipKind()
 		return NullValue, nil
@@ -357,6 +363,12 @@ func (r *valueDecoder) skipValue(nbf *NomsBinFormat) error {
 	case UintKind:
 		r.skipKind()
 		r.skipUint()
+	case TimestampKind:
+		r.skipKind()
+		r.skipTimestamp()
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
 	case StringKind:
 		r.skipKind()
 		r.skipString()
@@ -445,6 +457,14 @@ func (r *valueDecoder) readTypeOfValue(nbf *NomsBinFormat) (*Type, error) {
 		r.skipKind()
 		r.skipUint()
 		return UintType, nil
+	case TimestampKind:
+		r.skipKind()
+		r.skipTimestamp()
+		return TimestampType, nil
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
+		return DecimalType, nil
 	case NullKind:
 		r.skipKind()
 		return NullType, nil
@@ -502,7 +522,7 @@ func (r *valueDecoder) isValueSameTypeForSure(nbf *NomsBinFormat, t *Type) (bool
 	}
 
 	switch k {
-	case BlobKind, BoolKind, FloatKind, StringKind, UUIDKind, IntKind, UintKind, NullKind:
+	case BlobKind, BoolKind, FloatKind, StringKind, UUIDKind, IntKind, UintKind, NullKind, TimestampKind, DecimalKind:
 		err := r.skipValue(nbf)
 		if err != nil {
 			return false, err
@@ -191,6 +191,12 @@ func (r *refWalker) walkValue(nbf *NomsBinFormat, cb RefCallback) error {
 	case UUIDKind:
 		r.skipKind()
 		r.skipUUID()
+	case TimestampKind:
+		r.skipKind()
+		r.skipTimestamp()
+	case DecimalKind:
+		r.skipKind()
+		r.skipDecimal()
 	case NullKind:
 		r.skipKind()
 	case StringKind: